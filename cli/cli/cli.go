@@ -23,6 +23,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -37,6 +38,8 @@ import (
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/tasks"
 	tools "github.com/opencurve/curveadm/internal/tools/upgrade"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
 	"github.com/opencurve/curveadm/internal/utils"
@@ -181,6 +184,13 @@ func (curveadm *CurveAdm) init() error {
 		return errno.ERR_GET_MONITOR_FAILED.E(err)
 	}
 
+	// (9) Get hosts of playground which acting as current cluster
+	hosts.Data, err = getClusterHosts(s, cluster, hosts.Data)
+	if err != nil {
+		log.Error("Get playground hosts failed", log.Field("Error", err))
+		return err
+	}
+
 	curveadm.logpath = logpath
 	curveadm.config = config
 	curveadm.in = os.Stdin
@@ -199,6 +209,57 @@ func (curveadm *CurveAdm) init() error {
 	return nil
 }
 
+// the multi-node playground carries its own hosts, which override the committed hosts
+func getClusterHosts(s *storage.Storage, cluster storage.Cluster, hosts string) (string, error) {
+	items, err := s.GetPlaygroundHosts(cluster.Name)
+	if err != nil {
+		return "", errno.ERR_SELECT_PLAYGROUND_HOSTS_FAILED.E(err)
+	} else if len(items) > 0 {
+		return items[0].Data, nil
+	}
+	return hosts, nil
+}
+
+// SwitchCluster checks out the specified cluster and reloads its properties,
+// it's used by the command which adds and deploys a cluster at once.
+func (curveadm *CurveAdm) SwitchCluster(name string) error {
+	s := curveadm.storage
+	err := s.CheckoutCluster(name)
+	if err != nil {
+		return errno.ERR_CHECKOUT_CLUSTER_FAILED.E(err)
+	}
+
+	cluster, err := s.GetCurrentCluster()
+	if err != nil {
+		return errno.ERR_GET_CURRENT_CLUSTER_FAILED.E(err)
+	}
+	monitor, err := s.GetMonitor(cluster.Id)
+	if err != nil {
+		return errno.ERR_GET_MONITOR_FAILED.E(err)
+	}
+	hostses, err := s.GetHostses()
+	if err != nil {
+		return errno.ERR_GET_HOSTS_FAILED.E(err)
+	}
+	data := ""
+	if len(hostses) == 1 {
+		data = hostses[0].Data
+	}
+	data, err = getClusterHosts(s, cluster, data)
+	if err != nil {
+		return err
+	}
+
+	curveadm.hosts = data
+	curveadm.clusterId = cluster.Id
+	curveadm.clusterUUId = cluster.UUId
+	curveadm.clusterName = cluster.Name
+	curveadm.clusterTopologyData = cluster.Topology
+	curveadm.clusterPoolData = cluster.Pool
+	curveadm.monitor = monitor
+	return nil
+}
+
 func (curveadm *CurveAdm) detectVersion() {
 	latestVersion, err := tools.GetLatestVersion(Version)
 	if err != nil || len(latestVersion) == 0 {
@@ -278,6 +339,11 @@ func (curveadm *CurveAdm) ClusterTopologyData() string       { return curveadm.c
 func (curveadm *CurveAdm) ClusterPoolData() string           { return curveadm.clusterPoolData }
 func (curveadm *CurveAdm) Monitor() storage.Monitor          { return curveadm.monitor }
 
+// SetOut redirects the output, it's used to capture the output in tests
+func (curveadm *CurveAdm) SetOut(out io.Writer) {
+	curveadm.out = out
+}
+
 func (curveadm *CurveAdm) GetHost(name string) (*hosts.HostConfig, error) {
 	if len(curveadm.Hosts()) == 0 {
 		return nil, errno.ERR_HOST_NOT_FOUND.
@@ -500,10 +566,61 @@ func (curveadm *CurveAdm) PreAudit(now time.Time, args []string) int64 {
 	return id
 }
 
+// Timing returns the elapsed time of all playbook steps executed by current command
+func (curveadm *CurveAdm) Timing() []tasks.Timing {
+	v := curveadm.MemStorage().Get(comm.KEY_PLAYBOOK_TIMING)
+	if v == nil {
+		return []tasks.Timing{}
+	}
+	return v.([]tasks.Timing)
+}
+
+// the rendered commands may contain secrets (e.g. S3 secret key, password),
+// so only the name and elapsed time of steps are saved in audit log
+func stripCommands(timings []tasks.Timing) []tasks.Timing {
+	out := []tasks.Timing{}
+	for _, timing := range timings {
+		ts := []task.TaskTiming{}
+		for _, t := range timing.Tasks {
+			steps := []task.StepTiming{}
+			for _, step := range t.Steps {
+				step.Commands = nil
+				steps = append(steps, step)
+			}
+			t.Steps = steps
+			ts = append(ts, t)
+		}
+		timing.Tasks = ts
+		out = append(out, timing)
+	}
+	return out
+}
+
+func (curveadm *CurveAdm) saveAuditTiming(id int64) {
+	timings := curveadm.Timing()
+	if len(timings) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(stripCommands(timings))
+	if err != nil {
+		log.Error("Encode audit timing failed",
+			log.Field("Error", err))
+		return
+	}
+
+	err = curveadm.Storage().InsertAuditTiming(id, string(data))
+	if err != nil {
+		log.Error("Insert audit timing failed",
+			log.Field("Error", err))
+	}
+}
+
 func (curveadm *CurveAdm) PostAudit(id int64, ec error) {
 	if id < 0 {
 		return
 	}
+	curveadm.saveAuditTiming(id)
 
 	auditLogs, err := curveadm.Storage().GetAuditLog(id)
 	if err != nil {
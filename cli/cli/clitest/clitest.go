@@ -0,0 +1,225 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+/*
+ * Package clitest builds a CurveAdm for end-to-end tests of playbooks,
+ * which lives in a temporary $HOME and runs all commands against the
+ * in-memory executor:
+ *
+ *   env := clitest.New(t, HOSTS)
+ *   env.AddCluster("c1", TOPOLOGY)
+ *   err := runDeploy(env.CurveAdm, deployOptions{insecure: true})
+ *   env.Executor.Index(`docker create .* --name .*etcd`)
+ */
+package clitest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/google/uuid"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/task/step"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/pkg/module"
+	"github.com/opencurve/curveadm/pkg/module/moduletest"
+)
+
+const (
+	CURVEADM_CONFIG = `
+[defaults]
+log_level = error
+sudo_alias = "sudo"
+timeout = 180
+auto_upgrade = false
+
+[ssh_connections]
+retries = 1
+timeout = 3
+`
+)
+
+type Env struct {
+	t        testing.TB
+	Home     string
+	CurveAdm *cli.CurveAdm
+	Executor *moduletest.Executor
+}
+
+/*
+ * New prepares a temporary $HOME (with curveadm.cfg and SSH private key),
+ * commits the hosts and replaces the global executor with an in-memory
+ * one, everything will be restored when the test finished.
+ *
+ * NOTE: $HOME is changed for the whole process, so the tests which use
+ * it can't run in parallel.
+ */
+func New(t testing.TB, hosts string) *Env {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dirs := []string{
+		filepath.Join(home, ".ssh"),
+		filepath.Join(home, ".curveadm"),
+		filepath.Join(home, "tmp"),
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			t.Fatalf("create directory %s: %v", dir, err)
+		}
+	}
+	env := &Env{t: t, Home: home}
+	err := moduletest.GeneratePrivateKey(env.PrivateKeyFile())
+	if err != nil {
+		t.Fatalf("generate private key: %v", err)
+	}
+	err = os.WriteFile(filepath.Join(home, ".curveadm", "curveadm.cfg"),
+		[]byte(strings.TrimPrefix(CURVEADM_CONFIG, "\n")), 0644)
+	if err != nil {
+		t.Fatalf("write curveadm.cfg: %v", err)
+	}
+
+	// temporary files of curveadm are also under $HOME,
+	// the emulated commands can only touch the real files under it
+	tempDir := step.TEMP_DIR
+	step.TEMP_DIR = filepath.Join(home, "tmp")
+	t.Cleanup(func() { step.TEMP_DIR = tempDir })
+	executor := moduletest.NewExecutor().SetLocalRoot(home)
+	t.Cleanup(module.ReplaceGlobalExecutor(executor))
+
+	env.Executor = executor
+	env.Reload()
+	if len(hosts) > 0 {
+		env.SetHosts(hosts)
+	}
+	return env
+}
+
+func (env *Env) PrivateKeyFile() string {
+	return filepath.Join(env.Home, ".ssh", "id_rsa")
+}
+
+// Reload re-creates the CurveAdm, because the properties of
+// hosts and cluster are loaded only once when it created.
+func (env *Env) Reload() *cli.CurveAdm {
+	if env.CurveAdm != nil {
+		env.CurveAdm.Storage().Close()
+	}
+	curveadm, err := cli.NewCurveAdm()
+	if err != nil {
+		env.t.Fatalf("new curveadm: %v", err)
+	}
+	env.CurveAdm = curveadm
+	env.t.Cleanup(func() { curveadm.Storage().Close() })
+	return curveadm
+}
+
+// SetHosts commits the hosts, it's rendered as template before committed,
+// e.g. private_key_file: {{.PrivateKeyFile}}
+func (env *Env) SetHosts(hosts string) {
+	buffer := bytes.NewBufferString("")
+	tmpl, err := template.New("hosts").Parse(hosts)
+	if err == nil {
+		err = tmpl.Execute(buffer, map[string]string{
+			"PrivateKeyFile": env.PrivateKeyFile(),
+		})
+	}
+	if err != nil {
+		env.t.Fatalf("render hosts: %v", err)
+	}
+
+	err = env.CurveAdm.Storage().SetHosts(buffer.String())
+	if err != nil {
+		env.t.Fatalf("set hosts: %v", err)
+	}
+	env.Reload()
+}
+
+// AddCluster adds a cluster with specified topology and checkout it
+func (env *Env) AddCluster(name, topology string) {
+	s := env.CurveAdm.Storage()
+	err := s.InsertCluster(name, uuid.NewString(), "", topology)
+	if err == nil {
+		err = s.CheckoutCluster(name)
+	}
+	if err != nil {
+		env.t.Fatalf("add cluster %s: %v", name, err)
+	}
+	env.Reload()
+}
+
+// Answer answers the following prompts in order, e.g. "yes"
+func (env *Env) Answer(answers ...string) {
+	input := strings.Join(answers, "\n") + "\n"
+	env.t.Cleanup(tui.ReplaceStdin(strings.NewReader(input)))
+}
+
+// SetTopology commits a new topology for current cluster
+func (env *Env) SetTopology(topology string) {
+	err := env.CurveAdm.Storage().SetClusterTopology(env.CurveAdm.ClusterId(), topology)
+	if err != nil {
+		env.t.Fatalf("set topology: %v", err)
+	}
+	env.Reload()
+}
+
+// WriteFile writes a file in temporary $HOME, e.g. client.yaml
+func (env *Env) WriteFile(name, content string) string {
+	filename := filepath.Join(env.Home, name)
+	err := os.WriteFile(filename, []byte(content), 0644)
+	if err != nil {
+		env.t.Fatalf("write file %s: %v", filename, err)
+	}
+	return filename
+}
+
+// AssertOrder asserts the first commands which match the patterns are executed in order
+func (env *Env) AssertOrder(patterns ...string) {
+	env.t.Helper()
+	last := -1
+	for _, pattern := range patterns {
+		index := env.Executor.Index(pattern)
+		if index < 0 {
+			env.t.Errorf("no command matches %q", pattern)
+			return
+		} else if index <= last {
+			env.t.Errorf("command matches %q executed too early (index=%d, previous=%d)",
+				pattern, index, last)
+			return
+		}
+		last = index
+	}
+}
+
+// Dump returns all executed commands, it's useful for debugging failed tests
+func (env *Env) Dump() string {
+	lines := []string{}
+	for i, cmd := range env.Executor.Commands() {
+		lines = append(lines, fmt.Sprintf("%4d %-12s %s", i, cmd.Host, cmd.Command))
+	}
+	return strings.Join(lines, "\n")
+}
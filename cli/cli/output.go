@@ -0,0 +1,53 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package cli
+
+import (
+	"encoding/json"
+
+	"github.com/opencurve/curveadm/internal/errno"
+)
+
+// output format of list/info commands, e.g. curveadm volume ls --format json
+const (
+	FORMAT_TABLE = "table"
+	FORMAT_JSON  = "json"
+)
+
+func CheckOutputFormat(format string) error {
+	if format != FORMAT_TABLE && format != FORMAT_JSON {
+		return errno.ERR_UNSUPPORT_OUTPUT_FORMAT.
+			F("format: %s", format)
+	}
+	return nil
+}
+
+// WriteOutJSON writes v in indented json, code tells what failed to encode
+func (curveadm *CurveAdm) WriteOutJSON(v interface{}, code *errno.ErrorCode) error {
+	bytes, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return code.E(err)
+	}
+	curveadm.WriteOutln("%s", string(bytes))
+	return nil
+}
@@ -25,8 +25,12 @@
 package command
 
 import (
+	"encoding/json"
+	"strconv"
+
 	"github.com/opencurve/curveadm/cli/cli"
 	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/tasks"
 	"github.com/opencurve/curveadm/internal/tui"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
@@ -37,6 +41,11 @@ type auditOptions struct {
 	verbose bool
 }
 
+type auditShowOptions struct {
+	id     int64
+	timing bool
+}
+
 func NewAuditCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options auditOptions
 
@@ -54,6 +63,34 @@ func NewAuditCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags.IntVarP(&options.tail, "tail", "n", 20, "Number of lines to show from the end of the logs (0 means all)")
 	flags.BoolVarP(&options.verbose, "verbose", "v", false, "Verbose output for clusters")
 
+	cmd.AddCommand(NewAuditShowCommand(curveadm))
+	return cmd
+}
+
+func NewAuditShowCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options auditShowOptions
+
+	cmd := &cobra.Command{
+		Use:   "show ID [OPTIONS]",
+		Short: "Show specified audit log",
+		Args:  cliutil.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil || id <= 0 {
+				return errno.ERR_INVALID_AUDIT_LOG_ID.F("id: %s", args[0])
+			}
+			options.id = id
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAuditShow(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&options.timing, "timing", false, "Show elapsed time of each step and the slowest tasks")
+
 	return cmd
 }
 
@@ -71,3 +108,42 @@ func runAudit(curveadm *cli.CurveAdm, options auditOptions) error {
 	curveadm.WriteOut(output)
 	return nil
 }
+
+func getAuditTiming(curveadm *cli.CurveAdm, id int64) ([]tasks.Timing, error) {
+	items, err := curveadm.Storage().GetAuditTiming(id)
+	if err != nil {
+		return nil, errno.ERR_SELECT_AUDIT_TIMING_FAILED.E(err)
+	} else if len(items) == 0 {
+		return nil, errno.ERR_NO_TIMING_FOR_AUDIT_LOG.F("id: %d", id)
+	}
+
+	timings := []tasks.Timing{}
+	err = json.Unmarshal([]byte(items[0].Data), &timings)
+	if err != nil {
+		return nil, errno.ERR_DECODE_AUDIT_TIMING_FAILED.E(err)
+	}
+	return timings, nil
+}
+
+func runAuditShow(curveadm *cli.CurveAdm, options auditShowOptions) error {
+	// 1) get audit log by id
+	auditLogs, err := curveadm.Storage().GetAuditLog(options.id)
+	if err != nil {
+		return errno.ERR_GET_AUDIT_LOGS_FAILE.E(err)
+	} else if len(auditLogs) == 0 {
+		return errno.ERR_AUDIT_LOG_NOT_FOUND.F("id: %d", options.id)
+	}
+	curveadm.WriteOut(tui.FormatAuditLogs(auditLogs, true))
+	if !options.timing {
+		return nil
+	}
+
+	// 2) display timing which saved alongside the audit log
+	timings, err := getAuditTiming(curveadm, options.id)
+	if err != nil {
+		return err
+	}
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(tui.FormatTiming(timings))
+	return nil
+}
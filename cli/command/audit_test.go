@@ -0,0 +1,54 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli/clitest"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/tasks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditTiming_WithoutCommands(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	curveadm := env.CurveAdm
+
+	id := curveadm.PreAudit(time.Now(), []string{"client", "mount", "/s3_001", "/mnt"})
+	curveadm.MemStorage().Set(comm.KEY_PLAYBOOK_TIMING, []tasks.Timing{{
+		Name:    "Mount FileSystem",
+		Elapsed: 3 * time.Second,
+		Tasks: []task.TaskTiming{{
+			Name:    "Mount FileSystem",
+			Elapsed: 3 * time.Second,
+			Steps: []task.StepTiming{{
+				Name:    "step.CreateContainer",
+				Elapsed: 2 * time.Second,
+				Commands: []task.CommandTiming{{
+					Command: "docker create -e S3_SECRET_KEY=secret",
+					Elapsed: 2 * time.Second,
+				}},
+			}},
+		}},
+	}})
+	curveadm.PostAudit(id, nil)
+
+	// (1) name and elapsed time of steps are saved
+	timings, err := getAuditTiming(curveadm, id)
+	assert.Nil(err)
+	assert.Len(timings, 1)
+	steps := timings[0].Tasks[0].Steps
+	assert.Equal("step.CreateContainer", steps[0].Name)
+	assert.Equal(2*time.Second, steps[0].Elapsed)
+
+	// (2) the rendered commands are not saved
+	assert.Empty(steps[0].Commands)
+	items, err := curveadm.Storage().GetAuditTiming(id)
+	assert.Nil(err)
+	assert.NotContains(items[0].Data, "secret")
+
+	// (3) the commands in memory are kept for --timing report
+	assert.Len(curveadm.Timing()[0].Tasks[0].Steps[0].Commands, 1)
+}
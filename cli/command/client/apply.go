@@ -0,0 +1,596 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/monitor"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	"github.com/opencurve/curveadm/internal/task/task/fs"
+	tuiclient "github.com/opencurve/curveadm/internal/tui/client"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	APPLY_EXAMPLE = `Examples:
+  $ curveadm client apply -f clients.yaml            # Map volumes and mount filesystems which described in clients.yaml
+  $ curveadm client apply -f clients.yaml --dry-run  # Only show the apply plan
+  $ curveadm client apply -f clients.yaml --force    # Apply without prompt
+
+Client manifest (clients.yaml):
+  curvebs: client.yaml     # client configure for maps
+  curvefs: client-fs.yaml  # client configure for mounts
+  hosts:
+    - host: machine1
+      maps:
+        - volume: curve:/vol1
+          size: 20GiB
+          create: true
+          throttle: [ iops_total=1000 ]
+      mounts:
+        - fsname: /s3_001
+          mount_point: /mnt/s3_001
+          options: [ allow_root ]
+
+NOTE: the clients on the listed hosts which not described in manifest will be unmapped or umounted`
+)
+
+var (
+	// removals go first, so the NBD devices and mount points are released
+	APPLY_CLIENT_ACTIONS = []string{
+		task.CLIENT_APPLY_ACTION_UNMAP,
+		task.CLIENT_APPLY_ACTION_UMOUNT,
+		task.CLIENT_APPLY_ACTION_MAP,
+		task.CLIENT_APPLY_ACTION_MOUNT,
+	}
+)
+
+type applyOptions struct {
+	filename string
+	dryRun   bool
+	force    bool
+}
+
+func NewApplyCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options applyOptions
+
+	cmd := &cobra.Command{
+		Use:     "apply [OPTIONS]",
+		Short:   "Map volumes and mount filesystems described in manifest",
+		Args:    utils.NoArgs,
+		Example: APPLY_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runApply(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&options.filename, "file", "f", "clients.yaml", "Specify client manifest file")
+	flags.BoolVar(&options.dryRun, "dry-run", false, "Only show the apply plan")
+	flags.BoolVar(&options.force, "force", false, "Never prompt")
+
+	return cmd
+}
+
+func parseManifestConfig(filename, kind string) (*configure.ClientConfig, error) {
+	if len(filename) == 0 {
+		if kind == topology.KIND_CURVEBS {
+			return nil, errno.ERR_CLIENT_MANIFEST_REQUIRES_CURVEBS
+		}
+		return nil, errno.ERR_CLIENT_MANIFEST_REQUIRES_CURVEFS
+	} else if !utils.PathExist(filename) {
+		return nil, errno.ERR_CLIENT_CONFIGURE_FILE_NOT_EXIST.
+			F("file path: %s", utils.AbsPath(filename))
+	}
+
+	cc, err := configure.ParseClientConfig(filename)
+	if err != nil {
+		return nil, err
+	} else if cc.GetKind() != kind {
+		if kind == topology.KIND_CURVEBS {
+			return nil, errno.ERR_REQUIRE_CURVEBS_KIND_CLIENT_CONFIGURE_FILE.
+				F("kind: %s", cc.GetKind())
+		}
+		return nil, errno.ERR_REQUIRE_CURVEFS_KIND_CLIENT_CONFIGURE_FILE.
+			F("kind: %s", cc.GetKind())
+	}
+	return cc, nil
+}
+
+func newMapItem(curveadm *cli.CurveAdm, host string,
+	m configure.ClientManifestMap, cc *configure.ClientConfig) (task.ClientApplyItem, error) {
+	item := task.ClientApplyItem{Action: task.CLIENT_APPLY_ACTION_MAP, Config: cc}
+	user, volume, err := ParseImage(m.Volume)
+	if err != nil {
+		return item, err
+	}
+	size, err := ParseSize(m.Size)
+	if err != nil {
+		return item, err
+	} else if err := checkDevice(m.Device); err != nil {
+		return item, err
+	} else if m.Timeout < 0 {
+		return item, errno.ERR_INVALID_NBD_TIMEOUT.F("timeout: %d", m.Timeout)
+	}
+	throttles, err := ParseThrottles(m.Throttles)
+	if err != nil {
+		return item, err
+	}
+
+	item.Id = curveadm.GetVolumeId(host, user, volume)
+	item.MapOptions = bs.MapOptions{
+		Host:        host,
+		User:        user,
+		Volume:      volume,
+		Size:        size,
+		Create:      m.Create,
+		NoExclusive: m.NoExclusive,
+		Poolset:     m.Poolset,
+		Persist:     m.Persist,
+		Device:      m.Device,
+		Timeout:     m.Timeout,
+		ReadOnly:    m.ReadOnly,
+		Throttles:   throttles,
+	}
+	return item, nil
+}
+
+func newMountItem(curveadm *cli.CurveAdm, host string,
+	m configure.ClientManifestMount, cc *configure.ClientConfig) (task.ClientApplyItem, error) {
+	item := task.ClientApplyItem{Action: task.CLIENT_APPLY_ACTION_MOUNT, Config: cc}
+	if !strings.HasPrefix(m.MountPoint, "/") {
+		return item, errno.ERR_FS_MOUNTPOINT_REQUIRE_ABSOLUTE_PATH.
+			F("mount point: %s", m.MountPoint)
+	}
+	fuseOptions, err := parseFuseOptions(m.FuseOptions)
+	if err != nil {
+		return item, err
+	}
+
+	config := fs.MountConfig{ReadOnly: m.ReadOnly}
+	if len(fuseOptions) > 0 {
+		config.FuseOptions = fuseOptions
+	}
+	item.Id = curveadm.GetFilesystemId(host, m.MountPoint)
+	item.MountOptions = fs.MountOptions{
+		Host:        host,
+		MountFSName: m.FSName,
+		MountFSType: m.FSType,
+		MountPoint:  m.MountPoint,
+		Persist:     m.Persist,
+		Config:      config,
+	}
+	return item, nil
+}
+
+// get the maps and mounts which described in manifest
+func getDesiredItems(curveadm *cli.CurveAdm, manifest *configure.ClientManifest) ([]task.ClientApplyItem, error) {
+	var bcc, fcc *configure.ClientConfig
+	var err error
+	items := []task.ClientApplyItem{}
+	seen := map[string]bool{}
+	for _, host := range manifest.Hosts {
+		if _, err := curveadm.GetHost(host.Host); err != nil {
+			return nil, err
+		}
+
+		if len(host.Maps) > 0 && bcc == nil {
+			bcc, err = parseManifestConfig(manifest.CurveBS, topology.KIND_CURVEBS)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if len(host.Mounts) > 0 && fcc == nil {
+			fcc, err = parseManifestConfig(manifest.CurveFS, topology.KIND_CURVEFS)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, m := range host.Maps {
+			item, err := newMapItem(curveadm, host.Host, m, bcc)
+			if err != nil {
+				return nil, err
+			} else if seen[item.Id] {
+				return nil, errno.ERR_DUPLICATE_CLIENT_IN_CLIENT_MANIFEST.
+					F("host: %s, volume: %s", host.Host, m.Volume)
+			}
+			seen[item.Id] = true
+			items = append(items, item)
+		}
+		for _, m := range host.Mounts {
+			item, err := newMountItem(curveadm, host.Host, m, fcc)
+			if err != nil {
+				return nil, err
+			} else if seen[item.Id] {
+				return nil, errno.ERR_DUPLICATE_CLIENT_IN_CLIENT_MANIFEST.
+					F("host: %s, mount point: %s", host.Host, m.MountPoint)
+			}
+			seen[item.Id] = true
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// the client which recorded in database but not described in manifest
+func newRemoveItem(client storage.Client) (task.ClientApplyItem, error) {
+	item := task.ClientApplyItem{Id: client.Id}
+	switch client.Kind {
+	case topology.KIND_CURVEBS:
+		auxInfo, err := bs.DecodeAuxInfo(client)
+		if err != nil {
+			return item, err
+		}
+		item.Action = task.CLIENT_APPLY_ACTION_UNMAP
+		item.MapOptions = bs.MapOptions{
+			Host:   client.Host,
+			User:   auxInfo.User,
+			Volume: auxInfo.Volume,
+		}
+	case topology.KIND_CURVEFS:
+		auxInfo, err := fs.DecodeAuxInfo(client)
+		if err != nil {
+			return item, err
+		}
+		item.Action = task.CLIENT_APPLY_ACTION_UMOUNT
+		item.MountOptions = fs.MountOptions{
+			Host:        client.Host,
+			MountFSName: auxInfo.FSName,
+			MountPoint:  auxInfo.MountPoint,
+		}
+	default:
+		return item, errno.ERR_UNSUPPORT_CLIENT_KIND.F("kind: %s", client.Kind)
+	}
+	return item, nil
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalThrottles(a, b []bs.VolumeThrottle) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// the options in manifest which differ from the applied client, they are
+// named after the manifest keys
+func getOptionChanges(item task.ClientApplyItem, client storage.Client) ([]string, error) {
+	changes := []string{}
+	switch item.Action {
+	case task.CLIENT_APPLY_ACTION_MAP:
+		auxInfo, err := bs.DecodeAuxInfo(client)
+		if err != nil {
+			return nil, err
+		}
+		options := item.MapOptions
+		if options.NoExclusive != auxInfo.NoExclusive {
+			changes = append(changes, "no_exclusive")
+		}
+		if options.Persist != auxInfo.Persist {
+			changes = append(changes, "persist")
+		}
+		if options.Device != auxInfo.Device {
+			changes = append(changes, "device")
+		}
+		if options.Timeout != auxInfo.Timeout {
+			changes = append(changes, "timeout")
+		}
+		if options.ReadOnly != auxInfo.ReadOnly {
+			changes = append(changes, "read_only")
+		}
+		if !equalThrottles(options.Throttles, auxInfo.Throttles) {
+			changes = append(changes, "throttle")
+		}
+	case task.CLIENT_APPLY_ACTION_MOUNT:
+		auxInfo, err := fs.DecodeAuxInfo(client)
+		if err != nil {
+			return nil, err
+		}
+		options := item.MountOptions
+		config := fs.MountConfig{}
+		if auxInfo.Config != nil {
+			config = *auxInfo.Config
+		}
+		if options.Persist != auxInfo.Persist {
+			changes = append(changes, "persist")
+		}
+		if !equalStrings(options.Config.FuseOptions, config.FuseOptions) {
+			changes = append(changes, "options")
+		}
+		if options.Config.ReadOnly != config.ReadOnly {
+			changes = append(changes, "read_only")
+		}
+	}
+	return changes, nil
+}
+
+func newApplyPlan(item task.ClientApplyItem) tuiclient.ApplyPlan {
+	plan := tuiclient.ApplyPlan{
+		Id:     item.Id,
+		Action: item.Action,
+		Result: tuiclient.APPLY_RESULT_SKIPPED,
+	}
+	switch item.Action {
+	case task.CLIENT_APPLY_ACTION_MAP, task.CLIENT_APPLY_ACTION_UNMAP:
+		options := item.MapOptions
+		plan.Kind = topology.KIND_CURVEBS
+		plan.Host = options.Host
+		plan.Item = fmt.Sprintf("%s:%s", options.User, options.Volume)
+	default:
+		options := item.MountOptions
+		plan.Kind = topology.KIND_CURVEFS
+		plan.Host = options.Host
+		plan.Item = fmt.Sprintf("%s => %s", options.MountFSName, options.MountPoint)
+	}
+	return plan
+}
+
+// diff the manifest against the clients table, only the clients on hosts
+// which listed in manifest will be touched
+func genApplyPlans(curveadm *cli.CurveAdm,
+	manifest *configure.ClientManifest) ([]tuiclient.ApplyPlan, []task.ClientApplyItem, error) {
+	desired, err := getDesiredItems(curveadm, manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	clients, err := curveadm.Storage().GetClients()
+	if err != nil {
+		return nil, nil, errno.ERR_GET_ALL_CLIENTS_FAILED.E(err)
+	}
+
+	existed := map[string]storage.Client{}
+	for _, client := range clients {
+		existed[client.Id] = client
+	}
+	plans := []tuiclient.ApplyPlan{}
+	items := []task.ClientApplyItem{}
+	wanted := map[string]bool{}
+	for _, item := range desired {
+		wanted[item.Id] = true
+		plan := newApplyPlan(item)
+		if client, ok := existed[item.Id]; ok {
+			plan.Action = tuiclient.APPLY_ACTION_NONE
+			plan.Changes, err = getOptionChanges(item, client)
+			if err != nil {
+				return nil, nil, err
+			}
+		} else {
+			items = append(items, item)
+		}
+		plans = append(plans, plan)
+	}
+
+	hosts := map[string]bool{}
+	for _, host := range manifest.Hosts {
+		hosts[host.Host] = true
+	}
+	for _, client := range clients {
+		if !hosts[client.Host] || wanted[client.Id] {
+			continue
+		}
+		item, err := newRemoveItem(client)
+		if err != nil {
+			return nil, nil, err
+		}
+		plans = append(plans, newApplyPlan(item))
+		items = append(items, item)
+	}
+	return plans, items, nil
+}
+
+// the same checkers which map and mount run before, the kernel module
+// is checked once for each host
+func addApplyCheckSteps(pb *playbook.Playbook, items []task.ClientApplyItem) {
+	var steps []int
+	var module string
+	switch items[0].Action {
+	case task.CLIENT_APPLY_ACTION_MAP:
+		steps, module = MAP_PLAYBOOK_STEPS, comm.KERNERL_MODULE_NBD
+	case task.CLIENT_APPLY_ACTION_MOUNT:
+		steps, module = MOUNT_PLAYBOOK_STEPS, comm.KERNERL_MODULE_FUSE
+	default:
+		return
+	}
+
+	ccs := []*configure.ClientConfig{items[0].Config}
+	for _, step := range steps {
+		switch step {
+		case playbook.CHECK_KERNEL_MODULE:
+			seen := map[string]bool{}
+			for _, item := range items {
+				host := item.MapOptions.Host
+				if item.Action == task.CLIENT_APPLY_ACTION_MOUNT {
+					host = item.MountOptions.Host
+				}
+				if seen[host] {
+					continue
+				}
+				seen[host] = true
+				pb.AddStep(&playbook.PlaybookStep{
+					Type:    step,
+					Configs: ccs,
+					Options: map[string]interface{}{
+						comm.KEY_CLIENT_HOST:              host, // for checker
+						comm.KEY_CHECK_KERNEL_MODULE_NAME: module,
+					},
+				})
+			}
+		case playbook.CHECK_CLIENT_S3:
+			pb.AddStep(&playbook.PlaybookStep{
+				Type:    step,
+				Configs: ccs,
+				ExecOptions: playbook.ExecOptions{
+					SilentSubBar: true,
+				},
+			})
+		}
+	}
+}
+
+func genApplyPlaybook(curveadm *cli.CurveAdm, items []task.ClientApplyItem) *playbook.Playbook {
+	config := []interface{}{}
+	for _, item := range items {
+		config = append(config, item)
+	}
+
+	pb := playbook.NewPlaybook(curveadm)
+	addApplyCheckSteps(pb, items)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.APPLY_CLIENT,
+		Configs: config,
+		ExecOptions: playbook.ExecOptions{
+			SkipError: true,
+		},
+	})
+	return pb
+}
+
+// run the playbooks action by action, the items of each action
+// are applied concurrently and a failed item won't stop the others
+func applyItems(curveadm *cli.CurveAdm, items []task.ClientApplyItem) error {
+	var applyErr error
+	for _, action := range APPLY_CLIENT_ACTIONS {
+		actionItems := []task.ClientApplyItem{}
+		for _, item := range items {
+			if item.Action == action {
+				actionItems = append(actionItems, item)
+			}
+		}
+		if len(actionItems) == 0 {
+			continue
+		}
+
+		err := genApplyPlaybook(curveadm, actionItems).Run()
+		curveadm.WriteOutln("")
+		if err != nil && applyErr == nil {
+			applyErr = err
+		}
+	}
+
+	// refresh scrape targets once all items applied
+	monitor.SyncTarget(curveadm)
+	return applyErr
+}
+
+func setApplyResults(curveadm *cli.CurveAdm, plans []tuiclient.ApplyPlan) int {
+	nfailed := 0
+	results := task.GetApplyResults(curveadm)
+	for i, plan := range plans {
+		if plan.Action == tuiclient.APPLY_ACTION_NONE {
+			continue
+		} else if results[plan.Id] {
+			plans[i].Result = tuiclient.APPLY_RESULT_SUCCESS
+		} else {
+			plans[i].Result = tuiclient.APPLY_RESULT_FAILED
+			nfailed++
+		}
+	}
+	return nfailed
+}
+
+func countChangedPlans(plans []tuiclient.ApplyPlan) int {
+	n := 0
+	for _, plan := range plans {
+		if len(plan.Changes) > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func runApply(curveadm *cli.CurveAdm, options applyOptions) error {
+	// 1) parse client manifest
+	manifest, err := configure.ParseClientManifest(options.filename)
+	if err != nil {
+		return err
+	}
+
+	// 2) display apply plan
+	plans, items, err := genApplyPlans(curveadm, manifest)
+	if err != nil {
+		return err
+	}
+	curveadm.WriteOut(tuiclient.FormatApplyPlan(plans, false))
+	if nchanged := countChangedPlans(plans); nchanged > 0 {
+		curveadm.WriteOutln(color.YellowString("WARNING: options of %d client(s) changed, "+
+			"unmap or umount them and apply again to take effect", nchanged))
+	}
+	if options.dryRun {
+		return nil
+	} else if len(items) == 0 {
+		curveadm.WriteOutln(color.GreenString("All clients already applied"))
+		return nil
+	}
+
+	// 3) confirm by user
+	if !options.force {
+		if pass := tui.ConfirmYes(tui.DEFAULT_CONFIRM_PROMPT); !pass {
+			curveadm.WriteOut(tui.PromptCancelOpetation("apply client"))
+			return errno.ERR_CANCEL_OPERATION
+		}
+	}
+
+	// 4) run playbooks
+	err = applyItems(curveadm, items)
+
+	// 5) print result of each item
+	nfailed := setApplyResults(curveadm, plans)
+	curveadm.WriteOut(tuiclient.FormatApplyPlan(plans, true))
+	if err != nil {
+		return err
+	}
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Apply %d client(s) success ^_^"), len(items)-nfailed)
+	return nil
+}
@@ -0,0 +1,874 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/cli/cli/clitest"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/scripts"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tuiclient "github.com/opencurve/curveadm/internal/tui/client"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module/moduletest"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	HOSTS = `
+global:
+  user: curve
+  ssh_port: 22
+  private_key_file: {{.PrivateKeyFile}}
+hosts:
+  - host: client-host
+    hostname: 10.0.1.4
+`
+
+	CLIENT_HOST = "10.0.1.4"
+
+	BS_CLIENT_CONFIG = `
+kind: curvebs
+container_image: opencurvedocker/curvebs:v1.2
+mds.listen.addr: 10.0.1.1:6700,10.0.1.2:6700,10.0.1.3:6700
+log_dir: /home/curve/curvebs/logs/client
+`
+
+	FS_CLIENT_CONFIG = `
+kind: curvefs
+container_image: opencurvedocker/curvefs:v2.7
+mdsOpt.rpcRetryOpt.addrs: 10.0.1.1:6700,10.0.1.2:6700,10.0.1.3:6700
+log_dir: /home/curve/curvefs/logs/client
+s3.ak: ak
+s3.sk: sk
+s3.endpoint: 10.0.1.5:9000
+s3.bucket_name: curvefs
+`
+)
+
+func TestMap(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	filename := env.WriteFile("client.yaml", BS_CLIENT_CONFIG)
+	env.Executor.On(`create\.sh curve /vol1`, moduletest.Reply("SUCCESS"))
+	env.Executor.On(`ss .*--listening`, moduletest.Reply(strings.Join([]string{
+		`LISTEN 0 128 127.0.0.1:6001 0.0.0.0:* users:(("nebd-server",pid=7,fd=5))`,
+		`LISTEN 0 128 0.0.0.0:9000 0.0.0.0:* users:(("node_exporter",pid=9,fd=3))`,
+		`LISTEN 0 128 0.0.0.0:9001 0.0.0.0:* users:(("nebd-server",pid=7,fd=9))`,
+	}, "\n")))
+
+	err := runMap(env.CurveAdm, mapOptions{
+		image:    "curve:/vol1",
+		host:     "client-host",
+		size:     "10GiB",
+		create:   true,
+		filename: filename,
+		poolset:  "default",
+	})
+	assert.Nil(err, env.Dump())
+
+	// (1) command sequence
+	env.AssertOrder(
+		`modinfo nbd`,
+		`docker create .*--name curvebs-volume-.* --role nebd`,
+		`docker start`,
+		`create\.sh curve /vol1 10 default`,
+		`docker exec .*map\.sh curve /vol1`,
+	)
+
+	// (2) client is recorded
+	clients, err := env.CurveAdm.Storage().GetClients()
+	assert.Nil(err)
+	assert.Len(clients, 1)
+	assert.Equal("curvebs", clients[0].Kind)
+	assert.Equal("client-host", clients[0].Host)
+	_, ok := env.Executor.Container(CLIENT_HOST, clients[0].ContainerId)
+	assert.True(ok)
+
+	// (3) the metric port which client actually listens on is recorded
+	assert.Contains(clients[0].AuxInfo, `"metric_port":9001`)
+}
+
+func TestMount(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	filename := env.WriteFile("client.yaml", FS_CLIENT_CONFIG)
+
+	err := runMount(env.CurveAdm, mountOptions{
+		host:        "client-host",
+		mountFSName: "/fs1",
+		mountFSType: "s3",
+		mountPoint:  "/mnt/fs1",
+		filename:    filename,
+		insecure:    true,
+	})
+	assert.Nil(err, env.Dump())
+
+	// (1) command sequence
+	env.AssertOrder(
+		`docker pull .*opencurvedocker/curvefs:v2.7`,
+		`docker create .*--name curvefs-filesystem-.* /client\.sh /fs1 s3`,
+		`docker cp .*:/client\.sh`,
+		`docker start`,
+	)
+
+	// (2) client is recorded
+	clients, err := env.CurveAdm.Storage().GetClients()
+	assert.Nil(err)
+	assert.Len(clients, 1)
+	assert.Equal("curvefs", clients[0].Kind)
+	container, ok := env.Executor.Container(CLIENT_HOST, clients[0].ContainerId)
+	assert.True(ok)
+	assert.Equal("running", container.Status)
+	assert.Contains(container.Options["--mount"][0], "source=/mnt/fs1")
+}
+
+func TestMount_Options(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	filename := env.WriteFile("client.yaml", FS_CLIENT_CONFIG)
+	options := mountOptions{
+		host:        "client-host",
+		mountFSName: "/fs1",
+		mountFSType: "s3",
+		mountPoint:  "/mnt/fs1",
+		filename:    filename,
+		insecure:    true,
+	}
+
+	// (1) invalid options
+	options.fuseOptions = []string{"fsname=/fs2"}
+	err := runMount(env.CurveAdm, options)
+	assert.Equal(errno.ERR_INVALID_MOUNT_OPTION.GetCode(), err.(*errno.ErrorCode).GetCode())
+	options.fuseOptions = []string{}
+	options.sets = []string{"container_image=opencurvedocker/curvefs:v2.8"}
+	err = runMount(env.CurveAdm, options)
+	assert.Equal(errno.ERR_UNSUPPORT_CLIENT_CONFIGURE_OVERRIDE.GetCode(), err.(*errno.ErrorCode).GetCode())
+
+	// (2) fuse options, overrides and read-only
+	options.fuseOptions = []string{"allow_root", "user=curvefs2"}
+	options.sets = []string{"s3.bucket_name=bucket2"}
+	options.readOnly = true
+	err = runMount(env.CurveAdm, options)
+	assert.Nil(err, env.Dump())
+	env.AssertOrder(
+		`docker create .*-o fstype=s3 -o user=curvefs2 -o conf=\S+ -o ro -o allow_root /curvefs/client/mnt/mnt/fs1'`,
+	)
+
+	// (3) options are recorded and reused by upgrade
+	clients, _ := env.CurveAdm.Storage().GetClients()
+	assert.Contains(clients[0].AuxInfo, `"fuse_options":["allow_root","user=curvefs2"]`)
+	assert.Contains(clients[0].AuxInfo, `"overrides":{"s3.bucket_name":"bucket2"}`)
+	assert.Contains(clients[0].AuxInfo, `"read_only":true`)
+	env.Executor.Reset()
+	err = runUpgrade(env.CurveAdm, upgradeOptions{
+		id:    clients[0].Id,
+		image: "opencurvedocker/curvefs:v2.8",
+		force: true,
+	})
+	assert.Nil(err, env.Dump())
+	env.AssertOrder(
+		`docker create .*-o user=curvefs2 .*-o ro -o allow_root .*`,
+	)
+}
+
+func getClientStatus(t *testing.T, env *clitest.Env) task.ClientStatus {
+	err := runStatus(env.CurveAdm, statusOptions{})
+	assert.Nil(t, err, env.Dump())
+	statuses := env.CurveAdm.MemStorage().Get(comm.KEY_ALL_CLIENT_STATUS).(map[string]task.ClientStatus)
+	assert.Len(t, statuses, 1)
+	for _, status := range statuses {
+		return status
+	}
+	return task.ClientStatus{}
+}
+
+func TestMap_Persist(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	filename := env.WriteFile("client.yaml", BS_CLIENT_CONFIG)
+
+	err := runMap(env.CurveAdm, mapOptions{
+		image:    "curve:/vol1",
+		host:     "client-host",
+		size:     "10GiB",
+		filename: filename,
+		poolset:  "default",
+		persist:  true,
+	})
+	assert.Nil(err, env.Dump())
+
+	// (1) unit installed and enabled
+	volumeId := env.CurveAdm.GetVolumeId("client-host", "curve", "/vol1")
+	unit := "curveadm-client-" + volumeId + ".service"
+	content, ok := env.Executor.ReadFile(CLIENT_HOST, "/etc/systemd/system/"+unit)
+	assert.True(ok)
+	assert.Regexp(`ExecStartPre=-/usr/bin/env modprobe nbd nbds_max=64`, content)
+	assert.Regexp(`ExecStart=/usr/bin/env docker start curvebs-volume-\w+`, content)
+	assert.Regexp(`ExecStartPost=/usr/bin/env docker exec curvebs-volume-\w+ /bin/bash .*map\.sh curve /vol1`, content)
+	env.AssertOrder(
+		`docker exec .*map\.sh curve /vol1`,
+		`systemctl +daemon-reload`,
+		`systemctl +enable `+unit,
+	)
+	clients, _ := env.CurveAdm.Storage().GetClients()
+	assert.Contains(clients[0].AuxInfo, `"persist":true`)
+
+	// (2) unit removed after unmap
+	err = runUnmap(env.CurveAdm, unmapOptions{image: "curve:/vol1", host: "client-host"})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`systemctl +disable `+unit), 1)
+	_, ok = env.Executor.ReadFile(CLIENT_HOST, "/etc/systemd/system/"+unit)
+	assert.False(ok)
+}
+
+func TestMap_Options(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	filename := env.WriteFile("client.yaml", BS_CLIENT_CONFIG)
+	options := mapOptions{
+		image:     "curve:/vol1",
+		host:      "client-host",
+		size:      "10GiB",
+		filename:  filename,
+		poolset:   "default",
+		device:    "/dev/nbd3",
+		timeout:   60,
+		readOnly:  true,
+		throttles: []string{"iops_total=1000", "bps_write=100MiB"},
+	}
+	assert.Nil(checkMapOptions(env.CurveAdm, options))
+
+	err := runMap(env.CurveAdm, options)
+	assert.Nil(err, env.Dump())
+
+	// (1) throttles applied before map, and map with NBD options
+	env.AssertOrder(
+		`curve bs update throttle --path /vol1 --user curve --type iops_total --limit 1000$`,
+		`curve bs update throttle --path /vol1 --user curve --type bps_write --limit 104857600$`,
+		`map\.sh curve /vol1 --device /dev/nbd3 --timeout 60 --read-only$`,
+	)
+
+	// (2) options are recorded and reused by re-map
+	clients, _ := env.CurveAdm.Storage().GetClients()
+	auxInfo := clients[0].AuxInfo
+	assert.Contains(auxInfo, `"device":"/dev/nbd3","timeout":60,"read_only":true`)
+	assert.Contains(auxInfo, `"throttles":[{"type":"iops_total","limit":1000},{"type":"bps_write","limit":104857600}]`)
+	env.Executor.SetContainerStatus(CLIENT_HOST, clients[0].ContainerId, "exited")
+	env.Executor.Reset()
+	err = runRepair(env.CurveAdm, repairOptions{ids: []string{clients[0].Id}})
+	assert.Nil(err, env.Dump())
+	env.AssertOrder(
+		`docker start`,
+		`map\.sh curve /vol1 --device /dev/nbd3 --timeout 60 --read-only$`,
+	)
+
+	// (3) invalid options
+	code := func(options mapOptions) int {
+		return checkMapOptions(env.CurveAdm, options).(*errno.ErrorCode).GetCode()
+	}
+	invalid := options
+	invalid.device = "/dev/sda"
+	assert.Equal(errno.ERR_INVALID_NBD_DEVICE.GetCode(), code(invalid))
+	invalid.device = "/dev/nbd64" // exceed nbds_max
+	assert.Equal(errno.ERR_INVALID_NBD_DEVICE.GetCode(), code(invalid))
+	invalid.device = "/dev/nbd63"
+	assert.Nil(checkMapOptions(env.CurveAdm, invalid))
+	assert.Contains(scripts.MAP, fmt.Sprintf("g_nbds_max=%d ", comm.NBDS_MAX))
+	invalid = options
+	invalid.timeout = -1
+	assert.Equal(errno.ERR_INVALID_NBD_TIMEOUT.GetCode(), code(invalid))
+	for _, throttle := range []string{"iops=1000", "iops_total=0", "iops_total=1k", "bps_read"} {
+		invalid = options
+		invalid.throttles = []string{throttle}
+		assert.Equal(errno.ERR_INVALID_VOLUME_THROTTLE.GetCode(), code(invalid), throttle)
+	}
+}
+
+func TestStatus_Drift(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	filename := env.WriteFile("client.yaml", BS_CLIENT_CONFIG)
+	err := runMap(env.CurveAdm, mapOptions{
+		image:    "curve:/vol1",
+		host:     "client-host",
+		size:     "10GiB",
+		filename: filename,
+		poolset:  "default",
+	})
+	assert.Nil(err, env.Dump())
+
+	// (1) mapped
+	env.Executor.On(`curve-nbd list-mapped`, moduletest.Reply("1 cbd:pool//vol1_curve_ /dev/nbd0"))
+	status := getClientStatus(t, env)
+	assert.Equal(comm.CLIENT_DRIFT_NONE, status.Drift)
+
+	// (2) nbd device is gone, e.g. host rebooted
+	env.Executor.On(`curve-nbd list-mapped`, moduletest.Reply(""))
+	status = getClientStatus(t, env)
+	assert.Equal(comm.CLIENT_DRIFT_NOT_MAPPED, status.Drift)
+
+	// (3) container is not running
+	env.Executor.SetContainerStatus(CLIENT_HOST, status.ContainerId, "exited")
+	status = getClientStatus(t, env)
+	assert.Equal(comm.CLIENT_DRIFT_CONTAINER_DOWN, status.Drift)
+}
+
+func TestRepair(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	filename := env.WriteFile("client.yaml", FS_CLIENT_CONFIG)
+	err := runMount(env.CurveAdm, mountOptions{
+		host:        "client-host",
+		mountFSName: "/fs1",
+		mountFSType: "s3",
+		mountPoint:  "/mnt/fs1",
+		filename:    filename,
+		insecure:    true,
+		persist:     true,
+	})
+	assert.Nil(err, env.Dump())
+	clients, _ := env.CurveAdm.Storage().GetClients()
+	containerId := clients[0].ContainerId
+	unit := "curveadm-client-" + clients[0].Id + ".service"
+
+	// (1) nothing to repair
+	env.Executor.WriteFile(CLIENT_HOST, "/proc/mounts", "curvefs /mnt/fs1 fuse rw 0 0\n")
+	env.Executor.Reset()
+	err = runRepair(env.CurveAdm, repairOptions{})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`docker (start|restart)`), 0)
+
+	// (2) container exited and unit disabled after host rebooted
+	env.Executor.WriteFile(CLIENT_HOST, "/proc/mounts", "")
+	env.Executor.SetContainerStatus(CLIENT_HOST, containerId, "exited")
+	env.Executor.On(`systemctl +is-enabled`, moduletest.Fail("disabled"))
+	status := getClientStatus(t, env)
+	assert.Equal(comm.CLIENT_DRIFT_CONTAINER_DOWN, status.Drift)
+	env.Executor.Reset()
+	err = runRepair(env.CurveAdm, repairOptions{ids: []string{clients[0].Id}})
+	assert.Nil(err, env.Dump())
+	env.AssertOrder(
+		`docker start +`+containerId,
+		`systemctl +enable `+unit,
+	)
+	container, _ := env.Executor.Container(CLIENT_HOST, containerId)
+	assert.Equal("running", container.Status)
+
+	// (3) unknown client
+	err = runRepair(env.CurveAdm, repairOptions{ids: []string{"unknown"}})
+	assert.Equal(errno.ERR_CLIENT_ID_NOT_FOUND.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
+
+func TestUpgrade_CurveBS(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	filename := env.WriteFile("client.yaml", BS_CLIENT_CONFIG)
+	err := runMap(env.CurveAdm, mapOptions{
+		image:    "curve:/vol1",
+		host:     "client-host",
+		size:     "10GiB",
+		filename: filename,
+		poolset:  "default",
+	})
+	assert.Nil(err, env.Dump())
+	clients, _ := env.CurveAdm.Storage().GetClients()
+	oldContainerId := clients[0].ContainerId
+
+	// (1) upgrade nebd and keep the NBD device
+	env.Executor.On(`curve-nbd list-mapped`,
+		moduletest.Reply("id    image                   device\n1234  cbd:pool//vol1_curve_  /dev/nbd3\n"))
+	env.Executor.Reset()
+	err = runUpgrade(env.CurveAdm, upgradeOptions{
+		id:    clients[0].Id,
+		image: "opencurvedocker/curvebs:v1.3",
+		force: true,
+	})
+	assert.Nil(err, env.Dump())
+	env.AssertOrder(
+		`docker pull .*opencurvedocker/curvebs:v1\.3`,
+		`curve-nbd list-mapped`,
+		`curve-nbd unmap cbd:pool//vol1_curve_`,
+		`docker stop +`+oldContainerId,
+		`docker rename +`+oldContainerId+` curvebs-volume-[0-9a-f]+-backup`,
+		`docker create .*--name curvebs-volume-.*opencurvedocker/curvebs:v1\.3`,
+		`docker start`,
+		`map\.sh curve /vol1 +--device /dev/nbd3`,
+		`docker rm +`+oldContainerId,
+	)
+
+	// (2) new container and image are recorded
+	clients, _ = env.CurveAdm.Storage().GetClients()
+	assert.NotEqual(oldContainerId, clients[0].ContainerId)
+	assert.Contains(clients[0].AuxInfo, `"image":"opencurvedocker/curvebs:v1.3"`)
+	_, ok := env.Executor.Container(CLIENT_HOST, oldContainerId)
+	assert.False(ok)
+
+	// (3) skip the client which already upgraded
+	env.Executor.Reset()
+	err = runUpgrade(env.CurveAdm, upgradeOptions{
+		host:  "client-host",
+		image: "opencurvedocker/curvebs:v1.3",
+		force: true,
+	})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`docker`), 0)
+}
+
+func TestUpgrade_CurveBSRestartInPlace(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	filename := env.WriteFile("client.yaml", BS_CLIENT_CONFIG)
+	err := runMap(env.CurveAdm, mapOptions{
+		image:    "curve:/vol1",
+		host:     "client-host",
+		size:     "10GiB",
+		filename: filename,
+		poolset:  "default",
+	})
+	assert.Nil(err, env.Dump())
+	clients, _ := env.CurveAdm.Storage().GetClients()
+	oldContainerId := clients[0].ContainerId
+
+	// nebd-server (pid 100) is respawned (pid 200) by the main process (pid 1) of container
+	killed := false
+	env.Executor.On(`curve-nbd list-mapped`,
+		moduletest.Reply("id    image                   device\n1234  cbd:pool//vol1_curve_  /dev/nbd3\n"))
+	env.Executor.On(`docker inspect .*State\.Pid`, moduletest.Reply("1"))
+	env.Executor.On(`kill 100`, func(cmd moduletest.Command) (string, error) {
+		killed = true
+		return "", nil
+	})
+	env.Executor.On(`pgrep -x nebd-server`, func(cmd moduletest.Command) (string, error) {
+		return utils.Choose(killed, "200", "100"), nil
+	})
+	env.Executor.Reset()
+	err = runUpgrade(env.CurveAdm, upgradeOptions{
+		id:    clients[0].Id,
+		image: "opencurvedocker/curvebs:v1.3",
+		force: true,
+	})
+	assert.Nil(err, env.Dump())
+
+	// (1) the new nebd-server replaced in the running container, device kept open
+	shortId := oldContainerId[:12]
+	env.AssertOrder(
+		`docker pull .*opencurvedocker/curvebs:v1\.3`,
+		`docker exec +`+shortId+` cp -fp /curvebs/nebd/sbin/nebd-server /curvebs/nebd/sbin/nebd-server\.old`,
+		`docker create .*--name curvebs-nebd-staging-\w+ opencurvedocker/curvebs:v1\.3`,
+		`docker cp +curvebs-nebd-staging-\w+:/curvebs/nebd/sbin/nebd-server \S*/tmp/\w+`,
+		`docker cp +\S*/tmp/\w+ `+shortId+`:/curvebs/nebd/sbin/nebd-server\.new`,
+		`docker exec +`+shortId+` mv -f /curvebs/nebd/sbin/nebd-server\.new /curvebs/nebd/sbin/nebd-server`,
+		`docker rm +curvebs-nebd-staging-\w+`,
+		`docker exec +`+shortId+` kill 100`,
+		`docker exec +`+shortId+` rm -f /curvebs/nebd/sbin/nebd-server\.old`,
+	)
+	assert.Len(env.Executor.Grep(`curve-nbd list-mapped`), 2) // mapped before and after restart
+	assert.Len(env.Executor.Grep(`curve-nbd unmap`), 0)
+	assert.Len(env.Executor.Grep(`docker stop`), 0)
+
+	// (2) same container, the new image is recorded for nebd-server only
+	clients, _ = env.CurveAdm.Storage().GetClients()
+	assert.Equal(oldContainerId, clients[0].ContainerId)
+	assert.Contains(clients[0].AuxInfo, `"image":"opencurvedocker/curvebs:v1.2"`)
+	assert.Contains(clients[0].AuxInfo, `"nebd_image":"opencurvedocker/curvebs:v1.3"`)
+	container, ok := env.Executor.Container(CLIENT_HOST, oldContainerId)
+	assert.True(ok)
+	assert.Equal("running", container.Status)
+
+	// (3) skip the client which nebd-server already upgraded
+	env.Executor.Reset()
+	err = runUpgrade(env.CurveAdm, upgradeOptions{
+		id:    clients[0].Id,
+		image: "opencurvedocker/curvebs:v1.3",
+		force: true,
+	})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`docker`), 0)
+}
+
+func TestUpgrade_CurveBSRestartFallback(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	filename := env.WriteFile("client.yaml", BS_CLIENT_CONFIG)
+	err := runMap(env.CurveAdm, mapOptions{
+		image:    "curve:/vol1",
+		host:     "client-host",
+		size:     "10GiB",
+		filename: filename,
+		poolset:  "default",
+	})
+	assert.Nil(err, env.Dump())
+	clients, _ := env.CurveAdm.Storage().GetClients()
+	oldContainerId := clients[0].ContainerId
+
+	// nebd-server (pid 100) never respawned after killed
+	wait := bs.WAIT_NEBD_SERVER_RESPAWN_SECONDS
+	bs.WAIT_NEBD_SERVER_RESPAWN_SECONDS = 0
+	defer func() { bs.WAIT_NEBD_SERVER_RESPAWN_SECONDS = wait }()
+	env.Executor.On(`curve-nbd list-mapped`,
+		moduletest.Reply("id    image                   device\n1234  cbd:pool//vol1_curve_  /dev/nbd3\n"))
+	env.Executor.On(`docker inspect .*State\.Pid`, moduletest.Reply("1"))
+	env.Executor.On(`pgrep -x nebd-server`, moduletest.Reply("100"))
+	env.Executor.Reset()
+	err = runUpgrade(env.CurveAdm, upgradeOptions{
+		id:    clients[0].Id,
+		image: "opencurvedocker/curvebs:v1.3",
+		force: true,
+	})
+	assert.Nil(err, env.Dump())
+
+	// (1) old binary restored, then remap with new container
+	shortId := oldContainerId[:12]
+	env.AssertOrder(
+		`docker exec +`+shortId+` mv -f /curvebs/nebd/sbin/nebd-server\.new /curvebs/nebd/sbin/nebd-server`,
+		`docker exec +`+shortId+` kill 100`,
+		`docker exec +`+shortId+` mv -f /curvebs/nebd/sbin/nebd-server\.old /curvebs/nebd/sbin/nebd-server`,
+		`curve-nbd unmap cbd:pool//vol1_curve_`,
+		`docker create .*--name curvebs-volume-.*opencurvedocker/curvebs:v1\.3`,
+		`map\.sh curve /vol1 +--device /dev/nbd3`,
+		`docker rm +`+oldContainerId,
+	)
+
+	// (2) new container and image are recorded
+	clients, _ = env.CurveAdm.Storage().GetClients()
+	assert.NotEqual(oldContainerId, clients[0].ContainerId)
+	assert.Contains(clients[0].AuxInfo, `"image":"opencurvedocker/curvebs:v1.3"`)
+	assert.NotContains(clients[0].AuxInfo, `nebd_image`)
+}
+
+func TestUpgrade_Rollback(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	filename := env.WriteFile("client.yaml", BS_CLIENT_CONFIG)
+	err := runMap(env.CurveAdm, mapOptions{
+		image:    "curve:/vol1",
+		host:     "client-host",
+		size:     "10GiB",
+		filename: filename,
+		poolset:  "default",
+	})
+	assert.Nil(err, env.Dump())
+	clients, _ := env.CurveAdm.Storage().GetClients()
+	oldContainerId := clients[0].ContainerId
+
+	// map failed in the new container
+	env.Executor.On(`bash .*map\.sh`, moduletest.Fail("map failed"))
+	env.Executor.Reset()
+	err = runUpgrade(env.CurveAdm, upgradeOptions{
+		id:    clients[0].Id,
+		image: "opencurvedocker/curvebs:v1.3",
+		force: true,
+	})
+	assert.Equal(errno.ERR_MAP_VOLUME_FAILED.GetCode(), err.(*errno.ErrorCode).GetCode(), env.Dump())
+	env.AssertOrder(
+		`docker rename +`+oldContainerId+` curvebs-volume-[0-9a-f]+-backup`,
+		`map\.sh curve /vol1`,
+		`docker rm `,
+		`docker rename +`+oldContainerId+` curvebs-volume-[0-9a-f]+$`,
+		`docker start +`+oldContainerId,
+	)
+
+	// the old container is back, and nothing changed in database
+	container, ok := env.Executor.Container(CLIENT_HOST, oldContainerId)
+	assert.True(ok)
+	assert.Equal("running", container.Status)
+	assert.Len(env.Executor.Containers(CLIENT_HOST), 1)
+	clients, _ = env.CurveAdm.Storage().GetClients()
+	assert.Equal(oldContainerId, clients[0].ContainerId)
+	assert.NotContains(clients[0].AuxInfo, "v1.3")
+}
+
+func TestUpgrade_CurveFS(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	filename := env.WriteFile("client.yaml", FS_CLIENT_CONFIG)
+	err := runMount(env.CurveAdm, mountOptions{
+		host:        "client-host",
+		mountFSName: "/fs1",
+		mountFSType: "s3",
+		mountPoint:  "/mnt/fs1",
+		filename:    filename,
+		insecure:    true,
+	})
+	assert.Nil(err, env.Dump())
+	clients, _ := env.CurveAdm.Storage().GetClients()
+	oldContainerId := clients[0].ContainerId
+	options := upgradeOptions{
+		host:  "client-host",
+		image: "opencurvedocker/curvefs:v2.8",
+		force: true,
+	}
+
+	// (1) dry run
+	env.Executor.Reset()
+	options.dryRun = true
+	err = runUpgrade(env.CurveAdm, options)
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Commands(), 0)
+
+	// (2) mount point is still busy after drain wait
+	options.dryRun = false
+	env.Executor.On(`fuser -m /mnt/fs1`, moduletest.Reply("/mnt/fs1:  1234c"))
+	err = runUpgrade(env.CurveAdm, options)
+	assert.Equal(errno.ERR_FS_MOUNT_POINT_BUSY.GetCode(), err.(*errno.ErrorCode).GetCode())
+	assert.Len(env.Executor.Grep(`umount`), 0)
+	container, _ := env.Executor.Container(CLIENT_HOST, oldContainerId)
+	assert.Equal("running", container.Status)
+
+	// (3) fuser failed for other reasons, e.g. command not found
+	env.Executor.On(`fuser -m /mnt/fs1`, func(cmd moduletest.Command) (string, error) {
+		return "fuser: command not found", &moduletest.ExitError{Status: 127}
+	})
+	err = runUpgrade(env.CurveAdm, options)
+	assert.Equal(errno.ERR_CHECK_MOUNT_POINT_BUSY_FAILED.GetCode(), err.(*errno.ErrorCode).GetCode())
+	assert.Len(env.Executor.Grep(`umount`), 0)
+	container, _ = env.Executor.Container(CLIENT_HOST, oldContainerId)
+	assert.Equal("running", container.Status)
+
+	// (4) remount with new container
+	env.Executor.On(`fuser -m /mnt/fs1`, moduletest.Fail(""))
+	env.Executor.Reset()
+	err = runUpgrade(env.CurveAdm, options)
+	assert.Nil(err, env.Dump())
+	env.AssertOrder(
+		`fuser -m /mnt/fs1`,
+		`docker exec +`+oldContainerId+` umount`,
+		`docker wait +`+oldContainerId,
+		`docker rename +`+oldContainerId+` curvefs-filesystem-[0-9a-f]+-backup`,
+		`docker create .*--name curvefs-filesystem-.*opencurvedocker/curvefs:v2\.8`,
+		`docker start`,
+		`docker rm +`+oldContainerId,
+	)
+	clients, _ = env.CurveAdm.Storage().GetClients()
+	assert.NotEqual(oldContainerId, clients[0].ContainerId)
+	assert.Contains(clients[0].AuxInfo, `"image":"opencurvedocker/curvefs:v2.8"`)
+}
+
+func TestApply(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS+`
+  - host: client-host2
+    hostname: 10.0.1.5
+`)
+	filename := env.WriteFile("client.yaml", BS_CLIENT_CONFIG)
+	env.WriteFile("client-fs.yaml", FS_CLIENT_CONFIG)
+	err := runMap(env.CurveAdm, mapOptions{
+		image:    "curve:/old",
+		host:     "client-host",
+		size:     "10GiB",
+		filename: filename,
+		poolset:  "default",
+	})
+	assert.Nil(err, env.Dump())
+	oldId := env.CurveAdm.GetVolumeId("client-host", "curve", "/old")
+
+	// (1) map, mount and unmap the volume which not described
+	manifest := env.WriteFile("clients.yaml", `
+curvebs: client.yaml
+curvefs: client-fs.yaml
+hosts:
+  - host: client-host
+    maps:
+      - volume: curve:/vol1
+        create: true
+    mounts:
+      - fsname: /fs1
+        mount_point: /mnt/fs1/
+  - host: client-host2
+    maps:
+      - volume: curve:/vol2
+        throttle: [ iops_total=1000 ]
+`)
+	env.Executor.On(`create\.sh curve /vol1`, moduletest.Reply("EXIST"))
+	env.Executor.Reset()
+	err = runApply(env.CurveAdm, applyOptions{filename: manifest, force: true})
+	assert.Nil(err, env.Dump())
+	env.AssertOrder(
+		`curve-nbd unmap`,
+		`create\.sh curve /vol1 10 default`,
+		`docker create .*--name curvefs-filesystem-.* /client\.sh /fs1 s3`,
+	)
+	maps := env.Executor.Grep(`map\.sh curve /vol2`)
+	assert.Len(maps, 1)
+	assert.Equal("10.0.1.5", maps[0].Host)
+	assert.Len(env.Executor.Grep(`update throttle .*--limit 1000`), 1)
+	assert.Len(env.Executor.Grep(`modinfo nbd`), 2) // once for each host
+	assert.Len(env.Executor.Grep(`modinfo fuse`), 1)
+
+	clients, err := env.CurveAdm.Storage().GetClients()
+	assert.Nil(err)
+	assert.Len(clients, 3)
+	for _, client := range clients {
+		assert.NotEqual(oldId, client.Id)
+	}
+
+	// (2) nothing changed
+	env.Executor.Reset()
+	err = runApply(env.CurveAdm, applyOptions{filename: manifest, force: true})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`docker (create|exec)`), 0)
+	plans, _, err := genApplyPlans(env.CurveAdm, loadManifest(t, manifest))
+	assert.Nil(err)
+	for _, plan := range plans {
+		assert.Equal(tuiclient.APPLY_ACTION_NONE, plan.Action)
+		assert.Empty(plan.Changes)
+	}
+
+	// (3) changed options are reported but not applied
+	manifest = env.WriteFile("clients.yaml", `
+curvebs: client.yaml
+curvefs: client-fs.yaml
+hosts:
+  - host: client-host
+    maps:
+      - volume: curve:/vol1
+        create: true
+        persist: true
+    mounts:
+      - fsname: /fs1
+        mount_point: /mnt/fs1/
+        options: [ allow_root ]
+        read_only: true
+  - host: client-host2
+    maps:
+      - volume: curve:/vol2
+        throttle: [ iops_total=2000 ]
+`)
+	plans, items, err := genApplyPlans(env.CurveAdm, loadManifest(t, manifest))
+	assert.Nil(err)
+	assert.Len(items, 0)
+	changes := map[string][]string{}
+	for _, plan := range plans {
+		assert.Equal(tuiclient.APPLY_ACTION_NONE, plan.Action)
+		changes[plan.Item] = plan.Changes
+	}
+	assert.Equal([]string{"persist"}, changes["curve:/vol1"])
+	assert.Equal([]string{"throttle"}, changes["curve:/vol2"])
+	assert.Equal([]string{"options", "read_only"}, changes["/fs1 => /mnt/fs1"])
+	assert.Contains(tuiclient.FormatApplyPlan(plans, false), "none (options changed: options, read_only)")
+
+	// (4) failed item is reported and the others still applied
+	manifest = env.WriteFile("clients.yaml", `
+curvebs: client.yaml
+hosts:
+  - host: client-host2
+    maps:
+      - volume: curve:/vol2
+      - volume: curve:/vol3
+      - volume: curve:/vol4
+      - volume: curve:/vol5
+      - volume: curve:/vol6
+      - volume: curve:/vol7
+      - volume: curve:/vol8
+`)
+	env.Executor.On(`map\.sh curve /vol3`, moduletest.Fail("map failed"))
+	env.Executor.Reset()
+	err = runApply(env.CurveAdm, applyOptions{filename: manifest, force: true})
+	assert.NotNil(err)
+	vol3Id := env.CurveAdm.GetVolumeId("client-host2", "curve", "/vol3")
+	results := task.GetApplyResults(env.CurveAdm)
+	assert.False(results[vol3Id])
+	for _, volume := range []string{"/vol4", "/vol5", "/vol6", "/vol7", "/vol8"} {
+		assert.True(results[env.CurveAdm.GetVolumeId("client-host2", "curve", volume)], volume)
+	}
+	clients, _ = env.CurveAdm.Storage().GetClients()
+	assert.Len(clients, 8) // vol3 isn't recorded
+	for _, client := range clients {
+		assert.NotEqual(vol3Id, client.Id)
+	}
+	assert.Len(env.Executor.Grep(`docker rm`), 1) // NEBD container of vol3
+
+	// re-apply the failed item
+	env.Executor.On(`map\.sh curve /vol3`, moduletest.Reply(""))
+	env.Executor.Reset()
+	plans, items, err = genApplyPlans(env.CurveAdm, loadManifest(t, manifest))
+	assert.Nil(err)
+	assert.Len(items, 1)
+	assert.Equal(vol3Id, items[0].Id)
+	err = runApply(env.CurveAdm, applyOptions{filename: manifest, force: true})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`map\.sh curve /vol3`), 1)
+	clients, _ = env.CurveAdm.Storage().GetClients()
+	assert.Len(clients, 9)
+
+	// (5) invalid manifest
+	manifest = env.WriteFile("clients.yaml", `
+hosts:
+  - host: client-host
+    mounts:
+      - fsname: /fs1
+        mount_point: /mnt/fs1
+`)
+	err = runApply(env.CurveAdm, applyOptions{filename: manifest, dryRun: true})
+	assert.Equal(errno.ERR_CLIENT_MANIFEST_REQUIRES_CURVEFS.GetCode(), err.(*errno.ErrorCode).GetCode())
+	manifest = env.WriteFile("clients.yaml", `
+curvebs: client.yaml
+hosts:
+  - host: client-host
+    maps:
+      - volume: curve:/vol1
+      - volume: curve:/vol1
+`)
+	err = runApply(env.CurveAdm, applyOptions{filename: manifest, dryRun: true})
+	assert.Equal(errno.ERR_DUPLICATE_CLIENT_IN_CLIENT_MANIFEST.GetCode(), err.(*errno.ErrorCode).GetCode())
+
+	// (6) volume won't be mapped if kernel module check failed
+	manifest = env.WriteFile("clients.yaml", `
+curvebs: client.yaml
+hosts:
+  - host: client-host2
+    maps:
+      - volume: curve:/vol2
+      - volume: curve:/vol3
+      - volume: curve:/vol9
+`)
+	env.Executor.OnHost("10.0.1.5", `modinfo nbd`, moduletest.Fail("module nbd not found"))
+	env.Executor.Reset()
+	err = runApply(env.CurveAdm, applyOptions{filename: manifest, force: true})
+	assert.Equal(errno.ERR_KERNEL_NBD_MODULE_NOT_LOADED.GetCode(), err.(*errno.ErrorCode).GetCode())
+	assert.Len(env.Executor.Grep(`map\.sh curve /vol9`), 0)
+	assert.False(task.GetApplyResults(env.CurveAdm)[env.CurveAdm.GetVolumeId("client-host2", "curve", "/vol9")])
+}
+
+func loadManifest(t *testing.T, filename string) *configure.ClientManifest {
+	manifest, err := configure.ParseClientManifest(filename)
+	if err != nil {
+		t.Fatalf("parse client manifest: %v", err)
+	}
+	return manifest
+}
+
+func TestLogs(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	filename := env.WriteFile("client.yaml", BS_CLIENT_CONFIG)
+	err := runMap(env.CurveAdm, mapOptions{
+		image:    "curve:/vol1",
+		host:     "client-host",
+		size:     "10GiB",
+		filename: filename,
+		poolset:  "default",
+	})
+	assert.Nil(err, env.Dump())
+	clients, err := env.CurveAdm.Storage().GetClients()
+	assert.Nil(err)
+	env.Executor.On(`docker logs .*`+clients[0].ContainerId, moduletest.Reply("nebd started\n"))
+	env.Executor.On(`tail .*/home/curve/curvebs/logs/client/\*\.log\*`, moduletest.Reply("I map /vol1\n"))
+
+	out := &bytes.Buffer{}
+	env.CurveAdm.SetOut(out)
+	err = runLogs(env.CurveAdm, logsOptions{id: clients[0].Id, tail: 100})
+	assert.Nil(err, env.Dump())
+	assert.Contains(out.String(), clients[0].Id+" | nebd started\n")
+	assert.Contains(out.String(), clients[0].Id+" | I map /vol1\n")
+
+	// (1) client not found
+	err = runLogs(env.CurveAdm, logsOptions{id: "unknown"})
+	assert.ErrorIs(err, errno.ERR_NO_CLIENT_MATCHED)
+}
@@ -42,7 +42,11 @@ func NewClientCommand(curveadm *cli.CurveAdm) *cobra.Command {
 		NewMountCommand(curveadm),
 		NewUmountCommand(curveadm),
 		NewStatusCommand(curveadm),
+		NewRepairCommand(curveadm),
+		NewUpgradeCommand(curveadm),
+		NewApplyCommand(curveadm),
 		NewEnterCommand(curveadm),
+		NewLogsCommand(curveadm),
 		// NewInstallCommand(curveadm),
 		// NewUninstallCommand(curveadm),
 	)
@@ -0,0 +1,98 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package client
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/tools"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type logsOptions struct {
+	id     string
+	follow bool
+	since  string
+	tail   int
+	grep   string
+}
+
+func NewLogsCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options logsOptions
+
+	cmd := &cobra.Command{
+		Use:   "logs ID [OPTIONS]",
+		Short: "Fetch logs of client",
+		Args:  utils.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.id = args[0]
+			return runLogs(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&options.follow, "follow", "f", false, "Follow log output")
+	flags.StringVar(&options.since, "since", "", "Show container output since timestamp or relative (e.g. 42m)")
+	flags.IntVarP(&options.tail, "tail", "n", 100, "Number of lines to show from the end of each log, -1 for all")
+	flags.StringVar(&options.grep, "grep", "", "Only show lines which match the regular expression")
+
+	return cmd
+}
+
+func runLogs(curveadm *cli.CurveAdm, options logsOptions) error {
+	// 1) get client
+	clients, err := curveadm.Storage().GetClient(options.id)
+	if err != nil {
+		return errno.ERR_GET_CLIENT_BY_ID_FAILED.E(err)
+	} else if len(clients) != 1 {
+		return errno.ERR_NO_CLIENT_MATCHED
+	}
+	client := clients[0]
+
+	// 2) the log directory is recorded in client configure
+	logDir := ""
+	cfgs, err := curveadm.Storage().GetClientConfig(client.Id)
+	if err == nil && len(cfgs) > 0 {
+		if cc, err := configure.ParseClientCfg(cfgs[0].Data); err == nil {
+			logDir = cc.GetLogDir()
+		}
+	}
+
+	// 3) fetch logs
+	return tools.FetchLogs(curveadm, []tools.LogSource{
+		{
+			Prefix:      client.Id,
+			Host:        client.Host,
+			ContainerId: client.ContainerId,
+			LogDir:      logDir,
+		},
+	}, tools.LogOptions{
+		Follow: options.follow,
+		Since:  options.since,
+		Tail:   options.tail,
+		Grep:   options.grep,
+	})
+}
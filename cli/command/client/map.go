@@ -23,12 +23,14 @@
 package client
 
 import (
+	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/monitor"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/configure/topology"
@@ -44,7 +46,11 @@ const (
   $ curveadm map user:/volume --host machine1 --create                  # Map volume which created by automatic
   $ curveadm map user:/volume --host machine1 --size=10GiB --create     # Map volume which size is 10GiB and created by automatic
   $ curveadm map user:/volume --host machine1 --create --poolset ssd    # Map volume created by automatic in poolset 'ssd'
-  $ curveadm map user:/volume --host machine1 -c /path/to/client.yaml   # Map volume with specified configure file`
+  $ curveadm map user:/volume --host machine1 -c /path/to/client.yaml   # Map volume with specified configure file
+  $ curveadm map user:/volume --host machine1 --persist                 # Map volume and map it again after host reboot
+  $ curveadm map user:/volume --host machine1 --device /dev/nbd3        # Map volume to specified NBD device
+  $ curveadm map user:/volume --host machine1 --read-only --timeout 60  # Map volume read-only with 60 seconds NBD timeout
+  $ curveadm map user:/volume --host machine1 --throttle iops_total=1000,bps_write=100MiB  # Limit IOPS and write bandwidth`
 )
 
 var (
@@ -66,6 +72,11 @@ type mapOptions struct {
 	filename    string
 	noExclusive bool
 	poolset     string
+	persist     bool
+	device      string
+	timeout     int
+	readOnly    bool
+	throttles   []string
 }
 
 func ParseImage(image string) (user, name string, err error) {
@@ -125,11 +136,58 @@ func ParseBlockSize(blocksize string) (uint64, error) {
 	}
 	return m, nil
 }
+
+// e.g. /dev/nbd0, the index should be less than nbds_max of nbd module
+func checkDevice(device string) error {
+	if len(device) == 0 {
+		return nil
+	}
+
+	mu := regexp.MustCompile(`^/dev/nbd([0-9]+)$`).FindStringSubmatch(device)
+	if len(mu) == 0 {
+		return errno.ERR_INVALID_NBD_DEVICE.F("device: %s", device)
+	} else if index, err := strconv.Atoi(mu[1]); err != nil || index >= comm.NBDS_MAX {
+		return errno.ERR_INVALID_NBD_DEVICE.F("device: %s, nbds_max: %d", device, comm.NBDS_MAX)
+	}
+	return nil
+}
+
+// e.g. iops_total=1000, bps_write=100MiB
+func ParseThrottles(throttles []string) ([]bs.VolumeThrottle, error) {
+	out := []bs.VolumeThrottle{}
+	types := utils.Slice2Map(bs.THROTTLE_TYPES)
+	for _, throttle := range throttles {
+		items := strings.SplitN(throttle, "=", 2)
+		if len(items) != 2 || !types[items[0]] {
+			return nil, errno.ERR_INVALID_VOLUME_THROTTLE.F("throttle: %s", throttle)
+		}
+
+		var limit uint64
+		var err error
+		if strings.HasPrefix(items[0], "bps_") {
+			limit, err = humanize.ParseBytes(items[1])
+		} else {
+			limit, err = strconv.ParseUint(items[1], 10, 64)
+		}
+		if err != nil || limit == 0 {
+			return nil, errno.ERR_INVALID_VOLUME_THROTTLE.F("throttle: %s", throttle)
+		}
+		out = append(out, bs.VolumeThrottle{Type: items[0], Limit: limit})
+	}
+	return out, nil
+}
+
 func checkMapOptions(curveadm *cli.CurveAdm, options mapOptions) error {
 	if _, _, err := ParseImage(options.image); err != nil {
 		return err
 	} else if _, err = ParseSize(options.size); err != nil {
 		return err
+	} else if err := checkDevice(options.device); err != nil {
+		return err
+	} else if options.timeout < 0 {
+		return errno.ERR_INVALID_NBD_TIMEOUT.F("timeout: %d", options.timeout)
+	} else if _, err := ParseThrottles(options.throttles); err != nil {
+		return err
 	} else if !utils.PathExist(options.filename) {
 		return errno.ERR_CLIENT_CONFIGURE_FILE_NOT_EXIST.
 			F("file path: %s", utils.AbsPath(options.filename))
@@ -162,6 +220,11 @@ func NewMapCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags.StringVar(&options.size, "size", "10GiB", "Specify volume size")
 	flags.StringVarP(&options.filename, "conf", "c", "client.yaml", "Specify client configuration file")
 	flags.StringVar(&options.poolset, "poolset", "default", "Specify the poolset name")
+	flags.BoolVar(&options.persist, "persist", false, "Install systemd unit to map volume again after host reboot")
+	flags.StringVar(&options.device, "device", "", "Specify NBD device, e.g. /dev/nbd0")
+	flags.IntVar(&options.timeout, "timeout", 0, "Specify NBD request timeout in seconds")
+	flags.BoolVar(&options.readOnly, "read-only", false, "Map volume read-only")
+	flags.StringSliceVar(&options.throttles, "throttle", []string{}, "Specify volume throttle (iops_total/iops_read/iops_write/bps_total/bps_read/bps_write), it is kept after unmap")
 	return cmd
 }
 
@@ -170,6 +233,10 @@ func genMapPlaybook(curveadm *cli.CurveAdm,
 	options mapOptions) (*playbook.Playbook, error) {
 	user, name, _ := ParseImage(options.image)
 	size, _ := ParseSize(options.size)
+	throttles, err := ParseThrottles(options.throttles)
+	if err != nil {
+		return nil, err
+	}
 	steps := MAP_PLAYBOOK_STEPS
 	pb := playbook.NewPlaybook(curveadm)
 	for _, step := range steps {
@@ -185,6 +252,11 @@ func genMapPlaybook(curveadm *cli.CurveAdm,
 					Create:      options.create,
 					NoExclusive: options.noExclusive,
 					Poolset:     options.poolset,
+					Persist:     options.persist,
+					Device:      options.device,
+					Timeout:     options.timeout,
+					ReadOnly:    options.readOnly,
+					Throttles:   throttles,
 				},
 				comm.KEY_CLIENT_HOST:              options.host, // for checker
 				comm.KEY_CHECK_KERNEL_MODULE_NAME: comm.KERNERL_MODULE_NBD,
@@ -216,6 +288,7 @@ func runMap(curveadm *cli.CurveAdm, options mapOptions) error {
 	if err != nil {
 		return err
 	}
+	monitor.SyncTarget(curveadm)
 
 	// 4) print success prompt
 	curveadm.WriteOutln("")
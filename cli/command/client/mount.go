@@ -27,6 +27,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/monitor"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/configure/topology"
@@ -39,8 +40,11 @@ import (
 
 const (
 	MOUNT_EXAMPLE = `Examples:
-  $ curveadm mount /s3_001     /path/to/mount --host machine -c client.yaml [--fstype s3]    # Mount a s3 CurveFS '/s3_001' to '/path/to/mount'
-  $ curveadm mount /volume_001 /path/to/mount --host machine -c client.yaml --fstype volume  # Mount a volume CurveFS '/volume_001' to '/path/to/mount'`
+  $ curveadm mount /s3_001     /path/to/mount --host machine -c client.yaml [--fstype s3]                 # Mount a s3 CurveFS '/s3_001' to '/path/to/mount'
+  $ curveadm mount /volume_001 /path/to/mount --host machine -c client.yaml --fstype volume               # Mount a volume CurveFS '/volume_001' to '/path/to/mount'
+  $ curveadm mount /s3_001     /path/to/mount --host machine -c client.yaml --persist                     # Mount CurveFS and mount it again after host reboot
+  $ curveadm mount /s3_001     /path/to/mount --host machine -c client.yaml --opt allow_root --read-only  # Mount with FUSE options
+  $ curveadm mount /s3_001     /path/to/mount --host machine -c client.yaml --set s3.bucket_name=bucket2  # Override client.yaml item for this mount`
 )
 
 var (
@@ -59,6 +63,59 @@ type mountOptions struct {
 	mountPoint  string
 	filename    string
 	insecure    bool
+	persist     bool
+	fuseOptions []string
+	sets        []string
+	readOnly    bool
+}
+
+// e.g. --opt max_read=131072 --opt allow_root
+func parseFuseOptions(options []string) ([]string, error) {
+	out := []string{}
+	for _, option := range options {
+		key := strings.SplitN(option, "=", 2)[0]
+		if len(key) == 0 || strings.ContainsAny(option, " '") {
+			return nil, errno.ERR_INVALID_MOUNT_OPTION.F("--opt %s", option)
+		} else if fs.RESERVED_FUSE_OPTIONS[key] {
+			return nil, errno.ERR_INVALID_MOUNT_OPTION.
+				F("--opt %s: %s is reserved", option, key)
+		}
+		out = append(out, option)
+	}
+	return out, nil
+}
+
+// e.g. --set s3.bucket_name=curvefs2
+func parseOverrides(sets []string) (map[string]string, error) {
+	overrides := map[string]string{}
+	for _, set := range sets {
+		items := strings.SplitN(set, "=", 2)
+		if len(items) != 2 || len(items[0]) == 0 {
+			return nil, errno.ERR_INVALID_CLIENT_CONFIGURE_OVERRIDE.F("--set %s", set)
+		}
+		overrides[items[0]] = items[1]
+	}
+	return overrides, nil
+}
+
+func getMountConfig(options mountOptions) (fs.MountConfig, error) {
+	config := fs.MountConfig{ReadOnly: options.readOnly}
+	fuseOptions, err := parseFuseOptions(options.fuseOptions)
+	if err != nil {
+		return config, err
+	}
+	overrides, err := parseOverrides(options.sets)
+	if err != nil {
+		return config, err
+	}
+
+	if len(fuseOptions) > 0 {
+		config.FuseOptions = fuseOptions
+	}
+	if len(overrides) > 0 {
+		config.Overrides = overrides
+	}
+	return config, nil
 }
 
 func checkMountOptions(curveadm *cli.CurveAdm, options mountOptions) error {
@@ -66,7 +123,8 @@ func checkMountOptions(curveadm *cli.CurveAdm, options mountOptions) error {
 		return errno.ERR_FS_MOUNTPOINT_REQUIRE_ABSOLUTE_PATH.
 			F("mount point: %s", options.mountPoint)
 	}
-	return nil
+	_, err := getMountConfig(options)
+	return err
 }
 
 func NewMountCommand(curveadm *cli.CurveAdm) *cobra.Command {
@@ -95,6 +153,10 @@ func NewMountCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags.StringVarP(&options.filename, "conf", "c", "client.yaml", "Specify client configuration file")
 	flags.StringVar(&options.mountFSType, "fstype", "s3", "Specify fs data backend")
 	flags.BoolVarP(&options.insecure, "insecure", "k", false, "Mount without precheck")
+	flags.BoolVar(&options.persist, "persist", false, "Install systemd unit to mount filesystem again after host reboot")
+	flags.StringArrayVar(&options.fuseOptions, "opt", []string{}, "Specify FUSE mount option (key or key=value)")
+	flags.StringArrayVar(&options.sets, "set", []string{}, "Override client configure item for this mount (key=value)")
+	flags.BoolVar(&options.readOnly, "read-only", false, "Mount filesystem as read-only")
 
 	return cmd
 }
@@ -102,6 +164,11 @@ func NewMountCommand(curveadm *cli.CurveAdm) *cobra.Command {
 func genMountPlaybook(curveadm *cli.CurveAdm,
 	ccs []*configure.ClientConfig,
 	options mountOptions) (*playbook.Playbook, error) {
+	config, err := getMountConfig(options)
+	if err != nil {
+		return nil, err
+	}
+
 	steps := MOUNT_PLAYBOOK_STEPS
 	pb := playbook.NewPlaybook(curveadm)
 	for _, step := range steps {
@@ -119,6 +186,8 @@ func genMountPlaybook(curveadm *cli.CurveAdm,
 					MountFSName: options.mountFSName,
 					MountFSType: options.mountFSType,
 					MountPoint:  utils.TrimSuffixRepeat(options.mountPoint, "/"),
+					Persist:     options.persist,
+					Config:      config,
 				},
 				comm.KEY_CLIENT_HOST:              options.host, // for checker
 				comm.KEY_CHECK_KERNEL_MODULE_NAME: comm.KERNERL_MODULE_FUSE,
@@ -140,6 +209,12 @@ func runMount(curveadm *cli.CurveAdm, options mountOptions) error {
 		return errno.ERR_REQUIRE_CURVEFS_KIND_CLIENT_CONFIGURE_FILE.
 			F("kind: %s", cc.GetKind())
 	}
+	overrides, err := parseOverrides(options.sets)
+	if err != nil {
+		return err
+	} else if err = cc.Override(overrides); err != nil {
+		return err
+	}
 
 	// 2) generate mount playbook
 	pb, err := genMountPlaybook(curveadm, []*configure.ClientConfig{cc}, options)
@@ -152,6 +227,7 @@ func runMount(curveadm *cli.CurveAdm, options mountOptions) error {
 	if err != nil {
 		return err
 	}
+	monitor.SyncTarget(curveadm)
 
 	// 4) print success prompt
 	curveadm.WriteOutln("")
@@ -0,0 +1,179 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package client
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/storage"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	"github.com/spf13/cobra"
+)
+
+const (
+	REPAIR_EXAMPLE = `Examples:
+  $ curveadm client repair            # Repair all drifted clients
+  $ curveadm client repair ID1 ID2    # Repair specified clients`
+)
+
+var (
+	REPAIR_PLAYBOOK_STEPS = []int{
+		playbook.REPAIR_CLIENT,
+	}
+)
+
+type repairOptions struct {
+	ids []string
+}
+
+func NewRepairCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options repairOptions
+
+	cmd := &cobra.Command{
+		Use:     "repair [ID...]",
+		Short:   "Map volume or mount filesystem again for drifted clients",
+		Example: REPAIR_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.ids = args
+			return runRepair(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+func filterClients(clients []storage.Client, ids []string) ([]storage.Client, error) {
+	if len(ids) == 0 {
+		return clients, nil
+	}
+
+	m := map[string]storage.Client{}
+	for _, client := range clients {
+		m[client.Id] = client
+	}
+	out := []storage.Client{}
+	for _, id := range ids {
+		client, ok := m[id]
+		if !ok {
+			return nil, errno.ERR_CLIENT_ID_NOT_FOUND.F("id: %s", id)
+		}
+		out = append(out, client)
+	}
+	return out, nil
+}
+
+// get clients which actual state differs from the clients table
+func getDriftedClients(curveadm *cli.CurveAdm, clients []storage.Client) ([]storage.Client, error) {
+	config := []interface{}{}
+	for _, client := range clients {
+		config = append(config, client)
+	}
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range GET_STATUS_PLAYBOOK_STEPS {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: config,
+			ExecOptions: playbook.ExecOptions{
+				SilentSubBar:  true,
+				SilentMainBar: true,
+				SkipError:     true,
+			},
+		})
+	}
+	if err := pb.Run(); err != nil {
+		return nil, err
+	}
+
+	statuses := map[string]task.ClientStatus{}
+	v := curveadm.MemStorage().Get(comm.KEY_ALL_CLIENT_STATUS)
+	if v != nil {
+		statuses = v.(map[string]task.ClientStatus)
+	}
+	drifted := []storage.Client{}
+	for _, client := range clients {
+		status, ok := statuses[client.Id]
+		if !ok || status.Drift != comm.CLIENT_DRIFT_NONE {
+			drifted = append(drifted, client)
+		}
+	}
+	return drifted, nil
+}
+
+func genRepairPlaybook(curveadm *cli.CurveAdm,
+	clients []storage.Client,
+	options repairOptions) (*playbook.Playbook, error) {
+	config := []interface{}{}
+	for _, client := range clients {
+		config = append(config, client)
+	}
+
+	steps := REPAIR_PLAYBOOK_STEPS
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range steps {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: config,
+		})
+	}
+	return pb, nil
+}
+
+func runRepair(curveadm *cli.CurveAdm, options repairOptions) error {
+	// 1) get clients which need to repair
+	clients, err := curveadm.Storage().GetClients()
+	if err != nil {
+		return errno.ERR_GET_ALL_CLIENTS_FAILED.E(err)
+	}
+	clients, err = filterClients(clients, options.ids)
+	if err != nil {
+		return err
+	}
+	clients, err = getDriftedClients(curveadm, clients)
+	if err != nil {
+		return err
+	} else if len(clients) == 0 {
+		curveadm.WriteOutln(color.GreenString("No drifted client found"))
+		return nil
+	}
+
+	// 2) generate repair playbook
+	pb, err := genRepairPlaybook(curveadm, clients, options)
+	if err != nil {
+		return err
+	}
+
+	// 3) run playground
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+
+	// 4) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Repair %d client(s) success ^_^"), len(clients))
+	return nil
+}
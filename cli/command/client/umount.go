@@ -26,6 +26,7 @@ import (
 	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/monitor"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/errno"
@@ -107,5 +108,10 @@ func runUmount(curveadm *cli.CurveAdm, options umountOptions) error {
 	}
 
 	// 2) run playground
-	return pb.Run()
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+	monitor.SyncTarget(curveadm)
+	return nil
 }
@@ -24,6 +24,7 @@ package client
 
 import (
 	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/monitor"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/playbook"
@@ -108,5 +109,10 @@ func runUnmap(curveadm *cli.CurveAdm, options unmapOptions) error {
 	}
 
 	// 2) run playground
-	return pb.Run()
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+	monitor.SyncTarget(curveadm)
+	return nil
 }
@@ -0,0 +1,224 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/storage"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tuiclient "github.com/opencurve/curveadm/internal/tui/client"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	UPGRADE_EXAMPLE = `Examples:
+  $ curveadm client upgrade --id ID --image opencurvedocker/curvebs:v1.2.7            # Upgrade specified client
+  $ curveadm client upgrade --host client-host --image opencurvedocker/curvefs:v2.7  # Upgrade all clients on host
+  $ curveadm client upgrade --host client-host --image IMAGE --drain-wait 1m         # Wait mount point idle for 1 minute
+  $ curveadm client upgrade --host client-host --image IMAGE --dry-run               # Only show the upgrade plan`
+)
+
+var (
+	UPGRADE_CLIENT_PLAYBOOK_STEPS = []int{
+		playbook.UPGRADE_CLIENT,
+	}
+)
+
+type upgradeOptions struct {
+	id        string
+	host      string
+	image     string
+	drainWait time.Duration
+	dryRun    bool
+	force     bool
+}
+
+func checkUpgradeOptions(options upgradeOptions) error {
+	if len(options.id) == 0 && len(options.host) == 0 {
+		return errno.ERR_REQUIRE_CLIENT_ID_OR_HOST
+	}
+	return nil
+}
+
+func NewUpgradeCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options upgradeOptions
+
+	cmd := &cobra.Command{
+		Use:     "upgrade [OPTIONS]",
+		Short:   "Upgrade client",
+		Args:    cliutil.NoArgs,
+		Example: UPGRADE_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkUpgradeOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpgrade(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.id, "id", "", "Specify client id")
+	flags.StringVar(&options.host, "host", "", "Specify client host")
+	flags.StringVar(&options.image, "image", "", "Specify new container image")
+	flags.DurationVar(&options.drainWait, "drain-wait", 30*time.Second, "Specify how long to wait for mount point idle (curvefs)")
+	flags.BoolVar(&options.dryRun, "dry-run", false, "Only show the upgrade plan")
+	flags.BoolVarP(&options.force, "force", "f", false, "Never prompt")
+	cmd.MarkFlagRequired("image")
+
+	return cmd
+}
+
+func matchUpgradeClients(clients []storage.Client, options upgradeOptions) []storage.Client {
+	out := []storage.Client{}
+	for _, client := range clients {
+		if len(options.id) > 0 && client.Id != options.id {
+			continue
+		} else if len(options.host) > 0 && client.Host != options.host {
+			continue
+		}
+		out = append(out, client)
+	}
+	return out
+}
+
+func getUpgradeAction(client storage.Client, options upgradeOptions) string {
+	if client.Kind == topology.KIND_CURVEBS {
+		return "restart nebd-server in place (fallback: remap with new container)"
+	}
+	return fmt.Sprintf("drain (%s), umount, mount with new container", options.drainWait)
+}
+
+// plan the upgrade for each client, the client which already runs
+// with the new image will be skipped
+func genUpgradePlans(curveadm *cli.CurveAdm,
+	clients []storage.Client,
+	options upgradeOptions) ([]tuiclient.UpgradePlan, []storage.Client, error) {
+	plans := []tuiclient.UpgradePlan{}
+	upgrades := []storage.Client{}
+	for _, client := range clients {
+		image, err := task.GetClientImage(curveadm, client)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		plan := tuiclient.UpgradePlan{
+			Id:       client.Id,
+			Kind:     client.Kind,
+			Host:     client.Host,
+			OldImage: image,
+			NewImage: options.image,
+			Action:   getUpgradeAction(client, options),
+		}
+		if image == options.image {
+			plan.Action = tuiclient.UPGRADE_ACTION_SKIP
+		} else {
+			upgrades = append(upgrades, client)
+		}
+		plans = append(plans, plan)
+	}
+	return plans, upgrades, nil
+}
+
+func genUpgradeClientPlaybook(curveadm *cli.CurveAdm,
+	clients []storage.Client,
+	options upgradeOptions) (*playbook.Playbook, error) {
+	config := []interface{}{}
+	for _, client := range clients {
+		config = append(config, client)
+	}
+
+	steps := UPGRADE_CLIENT_PLAYBOOK_STEPS
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range steps {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: config,
+			Options: map[string]interface{}{
+				comm.KEY_CLIENT_UPGRADE_IMAGE: options.image,
+				comm.KEY_CLIENT_DRAIN_WAIT:    options.drainWait,
+			},
+		})
+	}
+	return pb, nil
+}
+
+func runUpgrade(curveadm *cli.CurveAdm, options upgradeOptions) error {
+	// 1) get clients which need to upgrade
+	clients, err := curveadm.Storage().GetClients()
+	if err != nil {
+		return errno.ERR_GET_ALL_CLIENTS_FAILED.E(err)
+	}
+	clients = matchUpgradeClients(clients, options)
+	if len(clients) == 0 {
+		return errno.ERR_NO_CLIENT_MATCHED
+	}
+
+	// 2) display upgrade plan
+	plans, clients, err := genUpgradePlans(curveadm, clients, options)
+	if err != nil {
+		return err
+	}
+	curveadm.WriteOut(tuiclient.FormatUpgradePlan(plans))
+	if options.dryRun {
+		return nil
+	} else if len(clients) == 0 {
+		curveadm.WriteOutln(color.GreenString("All clients already upgraded"))
+		return nil
+	}
+
+	// 3) confirm by user
+	if !options.force {
+		if pass := tui.ConfirmYes(tui.DEFAULT_CONFIRM_PROMPT); !pass {
+			curveadm.WriteOut(tui.PromptCancelOpetation("upgrade client"))
+			return errno.ERR_CANCEL_OPERATION
+		}
+	}
+
+	// 4) generate upgrade playbook
+	pb, err := genUpgradeClientPlaybook(curveadm, clients, options)
+	if err != nil {
+		return err
+	}
+
+	// 5) run playground
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+
+	// 6) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Upgrade %d client(s) success :)"), len(clients))
+	return nil
+}
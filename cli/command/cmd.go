@@ -32,11 +32,14 @@ import (
 	"github.com/opencurve/curveadm/cli/command/cluster"
 	"github.com/opencurve/curveadm/cli/command/config"
 	copycmd "github.com/opencurve/curveadm/cli/command/copy"
+	"github.com/opencurve/curveadm/cli/command/fs"
 	"github.com/opencurve/curveadm/cli/command/hosts"
 	"github.com/opencurve/curveadm/cli/command/monitor"
 	"github.com/opencurve/curveadm/cli/command/pfs"
 	"github.com/opencurve/curveadm/cli/command/playground"
+	"github.com/opencurve/curveadm/cli/command/snapshot"
 	"github.com/opencurve/curveadm/cli/command/target"
+	"github.com/opencurve/curveadm/cli/command/volume"
 	"github.com/opencurve/curveadm/internal/errno"
 	tools "github.com/opencurve/curveadm/internal/tools/upgrade"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
@@ -55,9 +58,11 @@ var curveadmExample = `Examples:
 type rootOptions struct {
 	debug   bool
 	upgrade bool
+	timing  bool
 }
 
 func addSubCommands(cmd *cobra.Command, curveadm *cli.CurveAdm) {
+	playground.RegisterDeployFunc(deployPlayground)
 	cmd.AddCommand(
 		client.NewClientCommand(curveadm),         // curveadm client
 		cluster.NewClusterCommand(curveadm),       // curveadm cluster ...
@@ -68,6 +73,10 @@ func addSubCommands(cmd *cobra.Command, curveadm *cli.CurveAdm) {
 		pfs.NewPFSCommand(curveadm),               // curveadm pfs ...
 		monitor.NewMonitorCommand(curveadm),       // curveadm monitor ...
 		copycmd.NewCopyCommand(curveadm),          // curveadm copy ...
+		volume.NewVolumeCommand(curveadm),         // curveadm volume ...
+		snapshot.NewSnapshotCommand(curveadm),     // curveadm snapshot ...
+		snapshot.NewCloneCommand(curveadm),        // curveadm clone ...
+		fs.NewFSCommand(curveadm),                 // curveadm fs ...
 
 		NewAuditCommand(curveadm),      // curveadm audit
 		NewCleanCommand(curveadm),      // curveadm clean
@@ -76,6 +85,8 @@ func addSubCommands(cmd *cobra.Command, curveadm *cli.CurveAdm) {
 		NewEnterCommand(curveadm),      // curveadm enter
 		NewExecCommand(curveadm),       // curveadm exec
 		NewFormatCommand(curveadm),     // curveadm format
+		NewHealthCommand(curveadm),     // curveadm health
+		NewLogsCommand(curveadm),       // curveadm logs
 		NewMigrateCommand(curveadm),    // curveadm migrate
 		NewPrecheckCommand(curveadm),   // curveadm precheck
 		NewReloadCommand(curveadm),     // curveadm reload
@@ -85,6 +96,7 @@ func addSubCommands(cmd *cobra.Command, curveadm *cli.CurveAdm) {
 		NewStatusCommand(curveadm),     // curveadm status
 		NewStopCommand(curveadm),       // curveadm stop
 		NewSupportCommand(curveadm),    // curveadm support
+		NewTopCommand(curveadm),        // curveadm top
 		NewUpgradeCommand(curveadm),    // curveadm upgrade
 		// commonly used shorthands
 		hosts.NewSSHCommand(curveadm),      // curveadm ssh
@@ -132,6 +144,7 @@ func NewCurveAdmCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	cmd.PersistentFlags().BoolP("help", "h", false, "Print usage")
 	cmd.Flags().BoolVarP(&options.debug, "debug", "d", false, "Print debug information")
 	cmd.Flags().BoolVarP(&options.upgrade, "upgrade", "u", false, "Upgrade curveadm itself to the latest version")
+	cmd.PersistentFlags().BoolVar(&options.timing, "timing", false, "Print elapsed time of each step and the slowest tasks")
 
 	addSubCommands(cmd, curveadm)
 	setupRootCommand(cmd, curveadm)
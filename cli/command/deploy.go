@@ -211,6 +211,15 @@ func precheckBeforeDeploy(curveadm *cli.CurveAdm,
 	return nil
 }
 
+// deploy the cluster of multi-node playground, the nodes are created just now
+func deployPlayground(curveadm *cli.CurveAdm) error {
+	return runDeploy(curveadm, deployOptions{
+		insecure:        true,
+		poolset:         "default",
+		poolsetDiskType: "ssd",
+	})
+}
+
 func calcNumOfChunkserver(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) int {
 	services := curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_CHUNKSERVER)
 	return len(services)
@@ -0,0 +1,148 @@
+package command
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/cli/clitest"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	HOSTS = `
+global:
+  user: curve
+  ssh_port: 22
+  private_key_file: {{.PrivateKeyFile}}
+hosts:
+  - host: server-host1
+    hostname: 10.0.1.1
+  - host: server-host2
+    hostname: 10.0.1.2
+  - host: server-host3
+    hostname: 10.0.1.3
+  - host: client-host
+    hostname: 10.0.1.4
+`
+
+	HOST1 = "10.0.1.1"
+	IMAGE = "opencurvedocker/curvebs:v1.2"
+
+	// container image, chunkserver instances
+	TOPOLOGY = `
+kind: curvebs
+global:
+  container_image: %[1]s
+  log_dir: ${home}/logs/${service_role}${service_host_sequence}
+  data_dir: ${home}/data/${service_role}${service_host_sequence}
+  variable:
+    home: /tmp
+    machine1: server-host1
+    machine2: server-host2
+    machine3: server-host3
+
+etcd_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 2380
+    listen.client_port: 2379
+  deploy:
+    - host: ${machine1}
+    - host: ${machine2}
+    - host: ${machine3}
+
+mds_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 6700
+    listen.dummy_port: 7700
+  deploy:
+    - host: ${machine1}
+    - host: ${machine2}
+    - host: ${machine3}
+
+chunkserver_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 82${format_instances_sequence}
+    data_dir: /data/chunkserver${service_instances_sequence}
+    copysets: 100
+  deploy:
+    - host: ${machine1}
+      instances: %[2]d
+    - host: ${machine2}
+      instances: %[2]d
+    - host: ${machine3}
+      instances: %[2]d
+`
+)
+
+// pattern matches the start command of the first service with specified role
+func startPattern(t *testing.T, curveadm *cli.CurveAdm, role string) string {
+	dcs, err := curveadm.ParseTopology()
+	assert.Nil(t, err)
+	dcs = curveadm.FilterDeployConfigByRole(dcs, role)
+	containerId, err := curveadm.GetContainerId(curveadm.GetServiceId(dcs[0].GetId()))
+	assert.Nil(t, err)
+	return fmt.Sprintf("docker start .*%s", containerId)
+}
+
+func newDeployedEnv(t *testing.T) *clitest.Env {
+	env := clitest.New(t, HOSTS)
+	env.AddCluster("c1", fmt.Sprintf(TOPOLOGY, IMAGE, 1))
+	err := runDeploy(env.CurveAdm, deployOptions{insecure: true, poolset: "default", poolsetDiskType: "ssd"})
+	if err != nil {
+		t.Fatalf("deploy cluster: %v\n%s", err, env.Dump())
+	}
+	env.Executor.Reset()
+	return env
+}
+
+func TestDeploy(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	env.AddCluster("c1", fmt.Sprintf(TOPOLOGY, IMAGE, 1))
+	curveadm := env.CurveAdm
+
+	err := runDeploy(curveadm, deployOptions{insecure: true, poolset: "default", poolsetDiskType: "ssd"})
+	assert.Nil(err, env.Dump())
+
+	// (1) command sequence
+	env.AssertOrder(
+		`docker pull .*curvebs:v1.2`,
+		`docker create .*--role etcd`,
+		`docker start`,
+		`create_physicalpool`,
+		`wait_chunkservers\.sh 3`,
+		`create_logicalpool`,
+	)
+	env.AssertOrder(
+		startPattern(t, curveadm, "etcd"),
+		startPattern(t, curveadm, "mds"),
+		`create_physicalpool`,
+		startPattern(t, curveadm, "chunkserver"),
+	)
+	assert.Len(env.Executor.Grep(`docker create`), 9)
+	assert.Len(env.Executor.Grep(`create_physicalpool`), 1)
+
+	// (2) every service has a running container
+	services, err := curveadm.Storage().GetServices(curveadm.ClusterId())
+	assert.Nil(err)
+	assert.Len(services, 9)
+	dcs, err := curveadm.ParseTopology()
+	assert.Nil(err)
+	for _, dc := range dcs {
+		hc, err := curveadm.GetHost(dc.GetHost())
+		assert.Nil(err)
+		containerId, err := curveadm.GetContainerId(curveadm.GetServiceId(dc.GetId()))
+		assert.Nil(err)
+		container, ok := env.Executor.Container(hc.GetHostname(), containerId)
+		assert.True(ok, "container of %s not found", dc.GetId())
+		assert.Equal("running", container.Status)
+	}
+
+	// (3) cluster pool is recorded after logical pool created
+	env.Reload()
+	assert.NotEmpty(env.CurveAdm.ClusterPoolData())
+}
@@ -0,0 +1,125 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package fs
+
+import (
+	"encoding/json"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/fs"
+)
+
+// all filesystem operations are executed in the leader mds container,
+// or a random one if the leader can't be determined
+func getLeaderMDS(curveadm *cli.CurveAdm) (*topology.DeployConfig, error) {
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return nil, err
+	} else if len(dcs) == 0 || dcs[0].GetKind() != topology.KIND_CURVEFS {
+		return nil, errno.ERR_REQUIRE_CURVEFS_CLUSTER
+	}
+
+	dcs = curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_MDS)
+	return playbook.AttachLeaderOrRandom(curveadm, dcs)
+}
+
+// filesystem name => hosts which the filesystem mounted on
+func getMountedHosts(curveadm *cli.CurveAdm) (map[string][]string, error) {
+	clients, err := curveadm.Storage().GetClients()
+	if err != nil {
+		return nil, errno.ERR_GET_ALL_CLIENTS_FAILED.E(err)
+	}
+
+	m := map[string][]string{}
+	for _, client := range clients {
+		if client.Kind != topology.KIND_CURVEFS {
+			continue
+		}
+		auxInfo := &task.AuxInfo{}
+		if err := json.Unmarshal([]byte(client.AuxInfo), auxInfo); err != nil {
+			continue
+		}
+		m[auxInfo.FSName] = append(m[auxInfo.FSName], client.Host)
+	}
+	return m, nil
+}
+
+func runFSOperation(curveadm *cli.CurveAdm, options task.FSOptions, silent bool) error {
+	// 1) find the leader mds
+	dc, err := getLeaderMDS(curveadm)
+	if err != nil {
+		return err
+	}
+
+	// 2) generate and run filesystem playbook
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.OPERATE_FILESYSTEM,
+		Configs: []*topology.DeployConfig{dc},
+		Options: map[string]interface{}{
+			comm.KEY_FS_OPTIONS: options,
+		},
+		ExecOptions: playbook.ExecOptions{
+			SilentSubBar:  silent,
+			SilentMainBar: silent,
+		},
+	})
+	return pb.Run()
+}
+
+// list or query filesystems and attach the hosts which they mounted on
+func getFilesystems(curveadm *cli.CurveAdm, options task.FSOptions, silent bool) ([]task.Filesystem, error) {
+	err := runFSOperation(curveadm, options, silent)
+	if err != nil {
+		return nil, err
+	}
+
+	filesystems := []task.Filesystem{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_FILESYSTEMS); v != nil {
+		filesystems = v.([]task.Filesystem)
+	}
+	m, err := getMountedHosts(curveadm)
+	if err != nil {
+		return nil, err
+	}
+	for i, filesystem := range filesystems {
+		filesystems[i].MountedBy = m[filesystem.Name]
+	}
+	return filesystems, nil
+}
+
+func getQuota(curveadm *cli.CurveAdm, fsname, path string, silent bool) (task.Quota, error) {
+	err := runFSOperation(curveadm, task.FSOptions{
+		Op:        task.FS_OP_GET_QUOTA,
+		FSName:    fsname,
+		QuotaPath: path,
+	}, silent)
+	if err != nil {
+		return task.Quota{}, err
+	}
+	return curveadm.MemStorage().Get(comm.KEY_FS_QUOTA).(task.Quota), nil
+}
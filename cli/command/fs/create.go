@@ -0,0 +1,109 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package fs
+
+import (
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/client"
+	"github.com/opencurve/curveadm/internal/errno"
+	task "github.com/opencurve/curveadm/internal/task/task/fs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type createOptions struct {
+	fsname     string
+	fstype     string
+	volume     string
+	volumeSize string
+}
+
+func checkCreateOptions(options createOptions) error {
+	switch options.fstype {
+	case task.FSTYPE_S3:
+		return nil
+	case task.FSTYPE_VOLUME, task.FSTYPE_HYBRID:
+		if len(options.volume) == 0 {
+			return errno.ERR_FILESYSTEM_REQUIRE_VOLUME.
+				F("fstype: %s", options.fstype)
+		} else if _, _, err := client.ParseImage(options.volume); err != nil {
+			return err
+		} else if _, err := humanize.ParseBytes(options.volumeSize); err != nil {
+			return errno.ERR_VOLUME_SIZE_REQUIRES_POSITIVE_INTEGER.
+				F("volume size: %s", options.volumeSize)
+		}
+		return nil
+	}
+	return errno.ERR_UNSUPPORT_FILESYSTEM_TYPE.
+		F("fstype: %s", options.fstype)
+}
+
+func NewCreateCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options createOptions
+
+	cmd := &cobra.Command{
+		Use:   "create NAME [OPTIONS]",
+		Short: "Create filesystem",
+		Args:  cliutil.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkCreateOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.fsname = args[0]
+			return runCreate(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.fstype, "fstype", task.FSTYPE_S3, "Specify fs data backend (s3/volume/hybrid)")
+	flags.StringVar(&options.volume, "volume", "", "Specify CurveBS volume for volume or hybrid backend, like curve:/fs1")
+	flags.StringVar(&options.volumeSize, "volume-size", "10GiB", "Specify CurveBS volume size")
+
+	return cmd
+}
+
+func runCreate(curveadm *cli.CurveAdm, options createOptions) error {
+	// 1) create filesystem
+	fsOptions := task.FSOptions{
+		Op:     task.FS_OP_CREATE,
+		FSName: options.fsname,
+		FSType: options.fstype,
+	}
+	if options.fstype != task.FSTYPE_S3 {
+		fsOptions.VolumeUser, fsOptions.VolumeName, _ = client.ParseImage(options.volume)
+		fsOptions.VolumeSize, _ = humanize.ParseBytes(options.volumeSize)
+	}
+	err := runFSOperation(curveadm, fsOptions, false)
+	if err != nil {
+		return err
+	}
+
+	// 2) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Create filesystem %s (%s) success ^_^"),
+		options.fsname, options.fstype)
+	return nil
+}
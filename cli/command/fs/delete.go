@@ -0,0 +1,105 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package fs
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	task "github.com/opencurve/curveadm/internal/task/task/fs"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type deleteOptions struct {
+	fsname string
+}
+
+func NewDeleteCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options deleteOptions
+
+	cmd := &cobra.Command{
+		Use:     "delete NAME",
+		Aliases: []string{"rm"},
+		Short:   "Delete filesystem",
+		Args:    cliutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.fsname = args[0]
+			return runDelete(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+// refuse to delete the filesystem which mounted by any client
+func checkFSNotMounted(curveadm *cli.CurveAdm, fsname string) error {
+	filesystems, err := getFilesystems(curveadm, task.FSOptions{
+		Op:     task.FS_OP_INFO,
+		FSName: fsname,
+	}, true)
+	if err != nil {
+		return err
+	}
+
+	for _, filesystem := range filesystems {
+		if len(filesystem.MountedBy) > 0 {
+			return errno.ERR_FS_IS_MOUNTED.
+				F("fsname=%s hosts=%v", fsname, filesystem.MountedBy)
+		} else if filesystem.MountNum > 0 {
+			return errno.ERR_FS_IS_MOUNTED.
+				F("fsname=%s mountNum=%d", fsname, filesystem.MountNum)
+		}
+	}
+	return nil
+}
+
+func runDelete(curveadm *cli.CurveAdm, options deleteOptions) error {
+	// 1) check whether filesystem is mounted
+	err := checkFSNotMounted(curveadm, options.fsname)
+	if err != nil {
+		return err
+	}
+
+	// 2) confirm by user
+	if pass := tui.ConfirmYes(tui.PromptDeleteFilesystem(options.fsname)); !pass {
+		curveadm.WriteOut(tui.PromptCancelOpetation("delete filesystem"))
+		return errno.ERR_CANCEL_OPERATION
+	}
+
+	// 3) delete filesystem
+	err = runFSOperation(curveadm, task.FSOptions{
+		Op:     task.FS_OP_DELETE,
+		FSName: options.fsname,
+	}, false)
+	if err != nil {
+		return err
+	}
+
+	// 4) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Delete filesystem %s success ^_^"), options.fsname)
+	return nil
+}
@@ -0,0 +1,190 @@
+package fs
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/cli/clitest"
+	"github.com/opencurve/curveadm/internal/errno"
+	task "github.com/opencurve/curveadm/internal/task/task/fs"
+	"github.com/opencurve/curveadm/pkg/module/moduletest"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	HOSTS = `
+global:
+  user: curve
+  ssh_port: 22
+  private_key_file: {{.PrivateKeyFile}}
+hosts:
+  - host: server-host1
+    hostname: 10.0.1.1
+  - host: server-host2
+    hostname: 10.0.1.2
+  - host: client-host
+    hostname: 10.0.1.4
+`
+
+	TOPOLOGY = `
+kind: curvefs
+global:
+  container_image: opencurvedocker/curvefs:v2.4
+  log_dir: /tmp/logs/${service_role}
+  data_dir: /tmp/data/${service_role}
+
+mds_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 6700
+    listen.dummy_port: 7700
+  deploy:
+    - host: server-host1
+    - host: server-host2
+`
+
+	LEADER_HOST = "10.0.1.2"
+
+	LIST_FS_OUTPUT = `{"error": [{"code": 0, "message": "success"}], "result": {"fsInfo": [
+  {"fsId": 1, "fsName": "fs1", "fsType": "TYPE_S3", "status": "INITED", "blockSize": "1048576", "capacity": "18446744073709551615", "mountNum": 1},
+  {"fsId": 2, "fsName": "fs2", "fsType": "TYPE_VOLUME", "status": "INITED", "blockSize": "4096", "capacity": "107374182400", "mountNum": 0}
+]}}`
+	QUERY_FS_OUTPUT = `{"error": [{"code": 0, "message": "success"}], "result": {"fsInfo":
+  {"fsId": 2, "fsName": "fs2", "fsType": "TYPE_VOLUME", "status": "INITED", "mountNum": 0}
+}}`
+	GET_QUOTA_OUTPUT = `{"error": [{"code": 0, "message": "success"}], "result": {"quota":
+  {"maxBytes": "10737418240", "maxInodes": "0", "usedBytes": "1073741824", "usedInodes": "42"}
+}}`
+	SUCCESS_OUTPUT = `{"error": [{"code": 0, "message": "success"}]}`
+)
+
+// mds containers are running and the one on LEADER_HOST is leader
+func newFSEnv(t *testing.T) *clitest.Env {
+	env := clitest.New(t, HOSTS)
+	env.AddCluster("c1", TOPOLOGY)
+
+	curveadm := env.CurveAdm
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		t.Fatalf("parse topology: %v", err)
+	}
+	for _, dc := range dcs {
+		host := dc.GetListenIp()
+		out, _ := env.Executor.Run(host, "docker run --name mds opencurvedocker/curvefs:v2.4")
+		serviceId := curveadm.GetServiceId(dc.GetId())
+		err := curveadm.Storage().InsertService(curveadm.ClusterId(), serviceId, strings.TrimSpace(out))
+		if err != nil {
+			t.Fatalf("insert service: %v", err)
+		}
+	}
+	env.Executor.OnHost(LEADER_HOST, `curl .*curvefs_mds_status`, moduletest.Reply("leader"))
+	env.Executor.On(`curve fs list fs`, moduletest.Reply(LIST_FS_OUTPUT))
+	env.Executor.On(`curve fs query fs`, moduletest.Reply(QUERY_FS_OUTPUT))
+	env.Executor.On(`curve fs quota`, moduletest.Reply(GET_QUOTA_OUTPUT))
+	env.Executor.On(`curve fs (create|delete) fs`, moduletest.Reply(SUCCESS_OUTPUT))
+	env.Executor.Reset()
+	return env
+}
+
+func mountFS(t *testing.T, env *clitest.Env, fsname string) {
+	auxInfo, _ := json.Marshal(task.AuxInfo{FSName: fsname, MountPoint: "/mnt/" + fsname})
+	err := env.CurveAdm.Storage().InsertClient(fsname, "curvefs", "client-host", "id", string(auxInfo))
+	if err != nil {
+		t.Fatalf("insert client: %v", err)
+	}
+}
+
+func TestFS_List(t *testing.T) {
+	assert := assert.New(t)
+	env := newFSEnv(t)
+	mountFS(t, env, "fs1")
+
+	filesystems, err := getFilesystems(env.CurveAdm, task.FSOptions{Op: task.FS_OP_LIST}, true)
+	assert.Nil(err, env.Dump())
+	cmds := env.Executor.Grep(`curve fs list fs --format json`)
+	assert.Len(cmds, 1)
+	assert.Equal(LEADER_HOST, cmds[0].Host)
+
+	assert.Len(filesystems, 2)
+	assert.Equal("s3", filesystems[0].Type)
+	assert.Equal(uint64(1), filesystems[0].MountNum)
+	assert.Equal([]string{"client-host"}, filesystems[0].MountedBy)
+	assert.Equal("volume", filesystems[1].Type)
+	assert.Equal(uint64(107374182400), filesystems[1].Capacity)
+	assert.Len(filesystems[1].MountedBy, 0)
+}
+
+func TestFS_Create(t *testing.T) {
+	assert := assert.New(t)
+	env := newFSEnv(t)
+
+	err := checkCreateOptions(createOptions{fstype: "nfs"})
+	assert.Equal(errno.ERR_UNSUPPORT_FILESYSTEM_TYPE.GetCode(), err.(*errno.ErrorCode).GetCode())
+	err = checkCreateOptions(createOptions{fstype: "hybrid"})
+	assert.Equal(errno.ERR_FILESYSTEM_REQUIRE_VOLUME.GetCode(), err.(*errno.ErrorCode).GetCode())
+
+	options := createOptions{fsname: "fs3", fstype: "hybrid", volume: "curve:/fs3", volumeSize: "10GiB"}
+	assert.Nil(checkCreateOptions(options))
+	err = runCreate(env.CurveAdm, options)
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`curve fs create fs --fsname fs3 --fstype hybrid `+
+		`--volume.user curve --volume.name /fs3 --volume.size 10737418240 --format json`), 1)
+
+	env.Executor.On(`curve fs create fs`, moduletest.Fail(
+		`{"error": [{"code": 9, "message": "fs exist"}]}`))
+	err = runCreate(env.CurveAdm, createOptions{fsname: "fs1", fstype: "s3"})
+	assert.Equal(errno.ERR_CREATE_FILESYSTEM_FAILED.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
+
+func TestFS_Delete(t *testing.T) {
+	assert := assert.New(t)
+	env := newFSEnv(t)
+	env.Answer("yes")
+
+	// mounted by client which recorded in clients table
+	mountFS(t, env, "fs2")
+	err := runDelete(env.CurveAdm, deleteOptions{fsname: "fs2"})
+	assert.Equal(errno.ERR_FS_IS_MOUNTED.GetCode(), err.(*errno.ErrorCode).GetCode())
+	assert.Len(env.Executor.Grep(`curve fs delete fs`), 0)
+
+	env.CurveAdm.Storage().DeleteClient("fs2")
+	err = runDelete(env.CurveAdm, deleteOptions{fsname: "fs2"})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`curve fs query fs --fsname fs2`), 2)
+	assert.Len(env.Executor.Grep(`curve fs delete fs --fsname fs2 --noconfirm --format json`), 1)
+}
+
+func TestFS_Quota(t *testing.T) {
+	assert := assert.New(t)
+	env := newFSEnv(t)
+
+	err := runSetQuota(env.CurveAdm, setQuotaOptions{fsname: "fs1", capacity: 10})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`curve fs quota setfs --fsname fs1 --capacity 10 --format json`), 1)
+
+	err = runSetQuota(env.CurveAdm, setQuotaOptions{fsname: "fs1", path: "/dir", inodes: 1000})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`curve fs quota set --fsname fs1 --path /dir --inodes 1000 --format json`), 1)
+
+	quota, err := getQuota(env.CurveAdm, "fs1", "", true)
+	assert.Nil(err, env.Dump())
+	assert.Equal(task.Quota{
+		FSName:     "fs1",
+		Path:       "/",
+		Capacity:   10737418240,
+		UsedBytes:  1073741824,
+		UsedInodes: 42,
+	}, quota)
+}
+
+func TestFS_Usage(t *testing.T) {
+	assert := assert.New(t)
+	env := newFSEnv(t)
+
+	err := runUsage(env.CurveAdm, usageOptions{format: cli.FORMAT_JSON})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`curve fs quota getfs --fsname fs1`), 1)
+	assert.Len(env.Executor.Grep(`curve fs quota getfs --fsname fs2`), 1)
+}
@@ -0,0 +1,72 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package fs
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	task "github.com/opencurve/curveadm/internal/task/task/fs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type infoOptions struct {
+	fsname string
+	format string
+}
+
+func NewInfoCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options infoOptions
+
+	cmd := &cobra.Command{
+		Use:   "info NAME [OPTIONS]",
+		Short: "Display filesystem information",
+		Args:  cliutil.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return cli.CheckOutputFormat(options.format)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.fsname = args[0]
+			return runInfo(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.format, "format", cli.FORMAT_TABLE, "Output format (table/json)")
+
+	return cmd
+}
+
+func runInfo(curveadm *cli.CurveAdm, options infoOptions) error {
+	// 1) get filesystem info
+	filesystems, err := getFilesystems(curveadm, task.FSOptions{
+		Op:     task.FS_OP_INFO,
+		FSName: options.fsname,
+	}, options.format == cli.FORMAT_JSON)
+	if err != nil {
+		return err
+	}
+
+	// 2) display filesystem
+	return displayFilesystems(curveadm, filesystems, options.format)
+}
@@ -0,0 +1,81 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package fs
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	task "github.com/opencurve/curveadm/internal/task/task/fs"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	format string
+}
+
+func NewListCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options listOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List filesystems",
+		Args:    cliutil.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return cli.CheckOutputFormat(options.format)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.format, "format", cli.FORMAT_TABLE, "Output format (table/json)")
+
+	return cmd
+}
+
+func displayFilesystems(curveadm *cli.CurveAdm, filesystems []task.Filesystem, format string) error {
+	if format == cli.FORMAT_JSON {
+		return curveadm.WriteOutJSON(filesystems, errno.ERR_ENCODE_FS_INFO_TO_JSON_FAILED)
+	}
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(tui.FormatFilesystems(filesystems))
+	return nil
+}
+
+func runList(curveadm *cli.CurveAdm, options listOptions) error {
+	// 1) list filesystems
+	filesystems, err := getFilesystems(curveadm, task.FSOptions{
+		Op: task.FS_OP_LIST,
+	}, options.format == cli.FORMAT_JSON)
+	if err != nil {
+		return err
+	}
+
+	// 2) display filesystems
+	return displayFilesystems(curveadm, filesystems, options.format)
+}
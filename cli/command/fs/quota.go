@@ -0,0 +1,154 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package fs
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	task "github.com/opencurve/curveadm/internal/task/task/fs"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type (
+	setQuotaOptions struct {
+		fsname   string
+		path     string
+		capacity uint64
+		inodes   uint64
+	}
+
+	getQuotaOptions struct {
+		fsname string
+		path   string
+		format string
+	}
+)
+
+func NewQuotaCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "quota",
+		Short: "Manage quota of filesystem or directory",
+		Args:  cliutil.NoArgs,
+		RunE:  cliutil.ShowHelp(curveadm.Err()),
+	}
+
+	cmd.AddCommand(
+		NewSetQuotaCommand(curveadm),
+		NewGetQuotaCommand(curveadm),
+	)
+	return cmd
+}
+
+func NewSetQuotaCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options setQuotaOptions
+
+	cmd := &cobra.Command{
+		Use:   "set NAME [OPTIONS]",
+		Short: "Set quota of filesystem or directory",
+		Args:  cliutil.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if options.capacity == 0 && options.inodes == 0 {
+				return errno.ERR_INVALID_FILESYSTEM_QUOTA
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.fsname = args[0]
+			return runSetQuota(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.path, "path", "", "Specify directory in filesystem, the whole filesystem if not specified")
+	flags.Uint64Var(&options.capacity, "capacity", 0, "Specify capacity quota (GiB)")
+	flags.Uint64Var(&options.inodes, "inodes", 0, "Specify inodes quota")
+
+	return cmd
+}
+
+func runSetQuota(curveadm *cli.CurveAdm, options setQuotaOptions) error {
+	// 1) set quota
+	err := runFSOperation(curveadm, task.FSOptions{
+		Op:        task.FS_OP_SET_QUOTA,
+		FSName:    options.fsname,
+		QuotaPath: options.path,
+		Capacity:  options.capacity,
+		Inodes:    options.inodes,
+	}, false)
+	if err != nil {
+		return err
+	}
+
+	// 2) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Set quota of filesystem %s success ^_^"), options.fsname)
+	return nil
+}
+
+func NewGetQuotaCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options getQuotaOptions
+
+	cmd := &cobra.Command{
+		Use:   "get NAME [OPTIONS]",
+		Short: "Get quota of filesystem or directory",
+		Args:  cliutil.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return cli.CheckOutputFormat(options.format)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.fsname = args[0]
+			return runGetQuota(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.path, "path", "", "Specify directory in filesystem, the whole filesystem if not specified")
+	flags.StringVar(&options.format, "format", cli.FORMAT_TABLE, "Output format (table/json)")
+
+	return cmd
+}
+
+func displayQuotas(curveadm *cli.CurveAdm, quotas []task.Quota, format string) error {
+	if format == cli.FORMAT_JSON {
+		return curveadm.WriteOutJSON(quotas, errno.ERR_ENCODE_FS_INFO_TO_JSON_FAILED)
+	}
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(tui.FormatQuotas(quotas))
+	return nil
+}
+
+func runGetQuota(curveadm *cli.CurveAdm, options getQuotaOptions) error {
+	// 1) get quota
+	quota, err := getQuota(curveadm, options.fsname, options.path, options.format == cli.FORMAT_JSON)
+	if err != nil {
+		return err
+	}
+
+	// 2) display quota
+	return displayQuotas(curveadm, []task.Quota{quota}, options.format)
+}
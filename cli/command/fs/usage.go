@@ -0,0 +1,90 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package fs
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	task "github.com/opencurve/curveadm/internal/task/task/fs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type usageOptions struct {
+	fsname string
+	format string
+}
+
+func NewUsageCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options usageOptions
+
+	cmd := &cobra.Command{
+		Use:   "usage [NAME] [OPTIONS]",
+		Short: "Display space and inode usage of filesystems",
+		Args:  cliutil.RequiresMaxArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return cli.CheckOutputFormat(options.format)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				options.fsname = args[0]
+			}
+			return runUsage(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.format, "format", cli.FORMAT_TABLE, "Output format (table/json)")
+
+	return cmd
+}
+
+func runUsage(curveadm *cli.CurveAdm, options usageOptions) error {
+	// 1) list filesystems if not specified
+	fsnames := []string{options.fsname}
+	if len(options.fsname) == 0 {
+		filesystems, err := getFilesystems(curveadm, task.FSOptions{
+			Op: task.FS_OP_LIST,
+		}, true)
+		if err != nil {
+			return err
+		}
+		fsnames = []string{}
+		for _, filesystem := range filesystems {
+			fsnames = append(fsnames, filesystem.Name)
+		}
+	}
+
+	// 2) get usage of each filesystem
+	quotas := []task.Quota{}
+	for _, fsname := range fsnames {
+		quota, err := getQuota(curveadm, fsname, "", true)
+		if err != nil {
+			return err
+		}
+		quotas = append(quotas, quota)
+	}
+
+	// 3) display usage
+	return displayQuotas(curveadm, quotas, options.format)
+}
@@ -0,0 +1,150 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package command
+
+import (
+	"encoding/json"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/internal/tui"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	HEALTH_EXAMPLE = `Examples:
+  $ curveadm health                 # Display health of current cluster
+  $ curveadm health --format json   # Display health in json format
+  $ curveadm health || alert        # Exit code is non-zero if cluster is unhealthy`
+
+	HEALTH_FORMAT_TABLE = "table"
+	HEALTH_FORMAT_JSON  = "json"
+)
+
+type healthOptions struct {
+	format string
+}
+
+func checkHealthOptions(options healthOptions) error {
+	if options.format != HEALTH_FORMAT_TABLE && options.format != HEALTH_FORMAT_JSON {
+		return errno.ERR_UNSUPPORT_OUTPUT_FORMAT.
+			F("format: %s", options.format)
+	}
+	return nil
+}
+
+func NewHealthCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options healthOptions
+
+	cmd := &cobra.Command{
+		Use:     "health [OPTIONS]",
+		Short:   "Display cluster health",
+		Args:    cliutil.NoArgs,
+		Example: HEALTH_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkHealthOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHealth(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.format, "format", HEALTH_FORMAT_TABLE, "Output format (table/json)")
+
+	return cmd
+}
+
+func genHealthPlaybook(curveadm *cli.CurveAdm,
+	dc *topology.DeployConfig,
+	options healthOptions) (*playbook.Playbook, error) {
+	silent := options.format == HEALTH_FORMAT_JSON
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.GET_CLUSTER_HEALTH,
+		Configs: []*topology.DeployConfig{dc},
+		ExecOptions: playbook.ExecOptions{
+			SilentSubBar:  silent,
+			SilentMainBar: silent,
+		},
+	})
+	return pb, nil
+}
+
+func displayHealth(curveadm *cli.CurveAdm, health bs.ClusterHealth, options healthOptions) error {
+	if options.format == HEALTH_FORMAT_JSON {
+		bytes, err := json.MarshalIndent(health, "", "  ")
+		if err != nil {
+			return errno.ERR_ENCODE_CLUSTER_HEALTH_TO_JSON_FAILED.E(err)
+		}
+		curveadm.WriteOutln("%s", string(bytes))
+		return nil
+	}
+
+	curveadm.WriteOutln("")
+	curveadm.WriteOut("%s", tui.FormatClusterHealth(health))
+	return nil
+}
+
+func runHealth(curveadm *cli.CurveAdm, options healthOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	} else if len(dcs) == 0 || dcs[0].GetKind() != topology.KIND_CURVEBS {
+		return errno.ERR_REQUIRE_CURVEBS_CLUSTER
+	}
+
+	// 2) collect health from the leader mds
+	dc, err := playbook.AttachLeaderOrRandom(curveadm,
+		curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_MDS))
+	if err != nil {
+		return err
+	}
+
+	// 3) generate and run health playbook
+	pb, err := genHealthPlaybook(curveadm, dc, options)
+	if err != nil {
+		return err
+	}
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+
+	// 4) display cluster health
+	health := curveadm.MemStorage().Get(comm.KEY_CLUSTER_HEALTH).(bs.ClusterHealth)
+	err = displayHealth(curveadm, health, options)
+	if err != nil {
+		return err
+	} else if health.Verdict == bs.HEALTH_VERDICT_UNHEALTHY {
+		return errno.ERR_CLUSTER_UNHEALTHY.F("problems: %v", health.Problems)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/pkg/module/moduletest"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ETCD_STATUS = `leader: 10.0.1.1:2379
+online: 10.0.1.1:2379, 10.0.1.2:2379, 10.0.1.3:2379
+offline: `
+	MDS_STATUS = `current MDS: 10.0.1.1:6700
+online mds list: 10.0.1.1:6700, 10.0.1.2:6700, 10.0.1.3:6700
+offline mds list: `
+)
+
+func TestHealth(t *testing.T) {
+	assert := assert.New(t)
+	env := newDeployedEnv(t)
+	curveadm := env.CurveAdm
+	env.Executor.On(`curve_ops_tool copysets-status`,
+		moduletest.Reply("total copysets: 100, unhealthy copysets: 0, unhealthy_ratio: 0%"))
+	env.Executor.On(`curve_ops_tool chunkserver-status`,
+		moduletest.Reply("chunkserver: total num = 3, online = 3, offline = 0(recoveringout = 0)"))
+	env.Executor.On(`curve_ops_tool space`,
+		moduletest.Reply("logical: total = 100GB, used = 10GB(10.00%, can be recycled = 0GB(0.00%))"))
+	env.Executor.On(`curve_ops_tool etcd-status`, moduletest.Reply(ETCD_STATUS))
+	env.Executor.On(`curve_ops_tool mds-status`, moduletest.Reply(MDS_STATUS))
+
+	// (1) healthy, collected from one mds container
+	out := &bytes.Buffer{}
+	curveadm.SetOut(out)
+	err := runHealth(curveadm, healthOptions{format: HEALTH_FORMAT_JSON})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`docker exec .* curve_ops_tool`), 5)
+	assert.Len(env.Executor.Grep(`curve_ops_tool snapshot-clone-status`), 0)
+	health := bs.ClusterHealth{}
+	assert.Nil(json.Unmarshal(out.Bytes(), &health), out.String())
+	assert.Equal(bs.HEALTH_VERDICT_HEALTHY, health.Verdict)
+	assert.Equal(100, health.Copysets.Total)
+	assert.Equal("10.0.1.1:6700", health.MDS.Leader)
+
+	// (2) unhealthy copysets exits with error
+	env.Executor.On(`curve_ops_tool copysets-status`, moduletest.Fail(
+		"Copysets not healthy!\ntotal copysets: 100, unhealthy copysets: 2, unhealthy_ratio: 2%"))
+	out.Reset()
+	err = runHealth(curveadm, healthOptions{format: HEALTH_FORMAT_TABLE})
+	assert.NotNil(err)
+	assert.Equal(errno.ERR_CLUSTER_UNHEALTHY.GetCode(), err.(*errno.ErrorCode).GetCode())
+	assert.Contains(out.String(), "2/100 copysets are unhealthy")
+	assert.Contains(out.String(), "total=100 unhealthy=2")
+}
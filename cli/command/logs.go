@@ -0,0 +1,128 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package command
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/tools"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	LOGS_EXAMPLE = `Examples:
+  $ curveadm logs --role mds                      # Display the last 100 lines of all mds logs
+  $ curveadm logs --id 6ff561598c6f -f            # Follow logs of specified service
+  $ curveadm logs --host server-host1 --since 1h  # Display container output since 1 hour ago
+  $ curveadm logs --role chunkserver --grep ERROR # Display lines which contain 'ERROR'`
+)
+
+type logsOptions struct {
+	id     string
+	role   string
+	host   string
+	follow bool
+	since  string
+	tail   int
+	grep   string
+}
+
+func NewLogsCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options logsOptions
+
+	cmd := &cobra.Command{
+		Use:     "logs [OPTIONS]",
+		Short:   "Fetch logs of service",
+		Args:    cliutil.NoArgs,
+		Example: LOGS_EXAMPLE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLogs(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.id, "id", "*", "Specify service id")
+	flags.StringVar(&options.role, "role", "*", "Specify service role")
+	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.BoolVarP(&options.follow, "follow", "f", false, "Follow log output")
+	flags.StringVar(&options.since, "since", "", "Show container output since timestamp or relative (e.g. 42m)")
+	flags.IntVarP(&options.tail, "tail", "n", 100, "Number of lines to show from the end of each log, -1 for all")
+	flags.StringVar(&options.grep, "grep", "", "Only show lines which match the regular expression")
+
+	return cmd
+}
+
+func genLogSources(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig) ([]tools.LogSource, error) {
+	sources := []tools.LogSource{}
+	for _, dc := range dcs {
+		serviceId := curveadm.GetServiceId(dc.GetId())
+		containerId, err := curveadm.GetContainerId(serviceId)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, tools.LogSource{
+			Prefix:      serviceId,
+			Host:        dc.GetHost(),
+			ContainerId: containerId,
+			LogDir:      dc.GetLogDir(),
+		})
+	}
+	return sources, nil
+}
+
+// logs:
+//  1. parse cluster topology
+//  2. filter service
+//  3. fetch logs from all services concurrently
+func runLogs(curveadm *cli.CurveAdm, options logsOptions) error {
+	// 1) parse cluster topology
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return err
+	}
+
+	// 2) filter service
+	dcs = curveadm.FilterDeployConfig(dcs, topology.FilterOption{
+		Id:   options.id,
+		Role: options.role,
+		Host: options.host,
+	})
+	if len(dcs) == 0 {
+		return errno.ERR_NO_SERVICES_MATCHED
+	}
+
+	// 3) fetch logs
+	sources, err := genLogSources(curveadm, dcs)
+	if err != nil {
+		return err
+	}
+	return tools.FetchLogs(curveadm, sources, tools.LogOptions{
+		Follow: options.follow,
+		Since:  options.since,
+		Tail:   options.tail,
+		Grep:   options.grep,
+	})
+}
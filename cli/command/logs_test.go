@@ -0,0 +1,49 @@
+package command
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/pkg/module/moduletest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogs(t *testing.T) {
+	assert := assert.New(t)
+	env := newDeployedEnv(t)
+	curveadm := env.CurveAdm
+	dcs, err := curveadm.ParseTopology()
+	assert.Nil(err)
+	dcs = curveadm.FilterDeployConfigByRole(dcs, ROLE_MDS)
+	env.Executor.On(`docker logs .*`, moduletest.Reply("mds started\nmds is leader\n"))
+	env.Executor.On(`tail .*/tmp/logs/mds\d/\*\.log\*`, moduletest.Reply("E 0102 write failed\nI 0102 heartbeat"))
+
+	out := &bytes.Buffer{}
+	curveadm.SetOut(out)
+	err = runLogs(curveadm, logsOptions{
+		id: "*", role: ROLE_MDS, host: "*",
+		follow: true, since: "1h", tail: 10, grep: "leader|failed",
+	})
+	assert.Nil(err, env.Dump())
+
+	// (1) container output and log files of each mds are fetched
+	assert.Len(env.Executor.Grep(`docker logs --follow --since 1h --tail 10 `), 3)
+	assert.Len(env.Executor.Grep(`tail --quiet --follow=name --retry --lines=10 /tmp/logs/mds\d/\*\.log\*`), 3)
+
+	// (2) each line is prefixed with service id and filtered
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	assert.Len(lines, 6, out.String())
+	for _, dc := range dcs {
+		serviceId := curveadm.GetServiceId(dc.GetId())
+		assert.Contains(out.String(), serviceId+" | mds is leader")
+		assert.Contains(out.String(), serviceId+" | E 0102 write failed")
+	}
+	assert.NotContains(out.String(), "heartbeat")
+
+	// (3) container logs failed
+	env.Executor.On(`docker logs .*`, moduletest.Fail("Error: No such container"))
+	err = runLogs(curveadm, logsOptions{id: "*", role: ROLE_MDS, host: "*", tail: -1})
+	assert.NotNil(err)
+	assert.Len(env.Executor.Grep(`tail --quiet --lines=\+1 `), 3)
+}
@@ -25,6 +25,7 @@ package command
 import (
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/monitor"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/configure/topology"
@@ -292,6 +293,7 @@ func runMigrate(curveadm *cli.CurveAdm, options migrateOptions) error {
 	if err != nil {
 		return err
 	}
+	monitor.SyncTarget(curveadm)
 
 	// 9) print success prompt
 	curveadm.WriteOutln("")
@@ -57,13 +57,14 @@ type deployOptions struct {
 
 /*
  * Deploy Steps:
- *   1) pull images(curvebs, node_exporter, prometheus, grafana)
+ *   1) pull images(curvebs, node_exporter, prometheus, grafana, alertmanager)
  *   2) create container
  *   3) sync config
  *   4) start container
  *     4.1) start node_exporter container
  *     4.2) start prometheus container
  *     4.3) start grafana container
+ *     4.4) start alertmanager container
  */
 func NewDeployCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options deployOptions
@@ -108,37 +109,31 @@ func genDeployPlaybook(curveadm *cli.CurveAdm,
 	return pb, nil
 }
 
-func parseTopology(curveadm *cli.CurveAdm) ([]string, []string, []*topology.DeployConfig, error) {
+func parseTopology(curveadm *cli.CurveAdm) ([]string, []*topology.DeployConfig, error) {
 	dcs, err := curveadm.ParseTopology()
 	if err != nil || len(dcs) == 0 {
-		return nil, nil, nil, err
+		return nil, nil, err
 	}
 	hosts := []string{}
-	hostIps := []string{}
 	thostMap := make(map[string]bool)
-	thostIpMap := make(map[string]bool)
 	for _, dc := range dcs {
+		if !thostMap[dc.GetHost()] {
+			hosts = append(hosts, dc.GetHost())
+		}
 		thostMap[dc.GetHost()] = true
-		thostIpMap[dc.GetListenIp()] = true
-	}
-	for key := range thostMap {
-		hosts = append(hosts, key)
-	}
-	for key := range thostIpMap {
-		hostIps = append(hostIps, key)
 	}
-	return hosts, hostIps, dcs, nil
+	return hosts, dcs, nil
 }
 
 func runDeploy(curveadm *cli.CurveAdm, options deployOptions) error {
 	// 1) parse cluster topology and get services' hosts
-	hosts, hostIps, dcs, err := parseTopology(curveadm)
+	hosts, dcs, err := parseTopology(curveadm)
 	if err != nil {
 		return err
 	}
 
 	// 2) parse monitor configure
-	mcs, err := configure.ParseMonitorConfig(curveadm, options.filename, "", hosts, hostIps, dcs)
+	mcs, err := configure.ParseMonitorConfig(curveadm, options.filename, "", hosts, dcs)
 	if err != nil {
 		return err
 	}
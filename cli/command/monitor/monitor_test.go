@@ -0,0 +1,471 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/cli/cli/clitest"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/task/monitor"
+	"github.com/opencurve/curveadm/pkg/module/moduletest"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	HOSTS = `
+global:
+  user: curve
+  ssh_port: 22
+  private_key_file: {{.PrivateKeyFile}}
+hosts:
+  - host: server-host1
+    hostname: 10.0.1.1
+  - host: server-host2
+    hostname: 10.0.1.2
+  - host: server-host3
+    hostname: 10.0.1.3
+`
+
+	MONITOR_HOST = "10.0.1.1"
+
+	TOPOLOGY = `
+kind: curvebs
+global:
+  container_image: opencurvedocker/curvebs:v1.2
+  log_dir: /tmp/logs/${service_role}${service_host_sequence}
+  data_dir: /tmp/data/${service_role}${service_host_sequence}
+
+etcd_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 2380
+    listen.client_port: 2379
+  deploy:
+    - host: server-host1
+    - host: server-host2
+    - host: server-host3
+
+mds_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 6700
+    listen.dummy_port: 7700
+  deploy:
+    - host: server-host1
+    - host: server-host2
+    - host: server-host3
+
+chunkserver_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 8200
+    data_dir: /data/chunkserver0
+    copysets: 100
+  deploy:
+    - host: server-host1
+    - host: server-host2
+    - host: server-host3
+`
+
+	// disk usage threshold
+	MONITOR = `
+host: server-host1
+
+node_exporter:
+  container_image: prom/node-exporter:latest
+  listen_port: 9100
+
+prometheus:
+  container_image: prom/prometheus:latest
+  data_dir: /tmp/monitor/prometheus
+  listen_port: 9090
+  retention.time: 7d
+  retention.size: 256GB
+  alert.disk_usage: %d
+
+grafana:
+  container_image: grafana/grafana:latest
+  data_dir: /tmp/monitor/grafana
+  listen_port: 3000
+  username: admin
+  password: curve
+
+alertmanager:
+  container_image: prom/alertmanager:latest
+  data_dir: /tmp/monitor/alertmanager
+  listen_port: 9093
+  webhook_url: http://127.0.0.1:5001/alerts
+`
+
+	// prometheus replicas, node_exporter port for server-host3
+	MONITOR_HA = `
+host: server-host1
+
+node_exporter:
+  container_image: prom/node-exporter:latest
+  listen_port: 9100
+  deploy:
+    - host: server-host1
+    - host: server-host2
+    - host: server-host3
+      listen_port: 9200
+
+prometheus:
+  container_image: prom/prometheus:latest
+  data_dir: /tmp/monitor/prometheus
+  listen_port: 9090
+  retention.time: 7d
+  retention.size: 256GB
+  deploy:
+    - host: server-host1
+    - host: server-host2
+      listen_port: 9091
+  remote_write:
+    - url: http://10.0.1.5:9201/write
+      username: curve
+      password: curve
+
+grafana:
+  host: server-host3
+  container_image: grafana/grafana:latest
+  data_dir: /tmp/monitor/grafana
+  listen_port: 3000
+  username: admin
+  password: curve
+`
+)
+
+var (
+	HOST_IPS = map[string]string{
+		"server-host1": "10.0.1.1",
+		"server-host2": "10.0.1.2",
+		"server-host3": "10.0.1.3",
+	}
+)
+
+func getContainerId(t *testing.T, env *clitest.Env, role string) string {
+	curveadm := env.CurveAdm
+	containerId, err := curveadm.GetContainerId(
+		curveadm.GetServiceId(fmt.Sprintf("%s_server-host1", role)))
+	assert.Nil(t, err)
+	return containerId
+}
+
+func readContainerFile(t *testing.T, env *clitest.Env, role, path string) string {
+	content, ok := env.Executor.ReadContainerFile(MONITOR_HOST, getContainerId(t, env, role), path)
+	assert.True(t, ok, "%s not found in %s container", path, role)
+	return content
+}
+
+func newMonitorEnv(t *testing.T) *clitest.Env {
+	env := clitest.New(t, HOSTS)
+	env.AddCluster("test", TOPOLOGY)
+	err := runDeploy(env.CurveAdm, deployOptions{
+		filename: env.WriteFile("monitor.yaml", fmt.Sprintf(MONITOR, 85)),
+	})
+	assert.Nil(t, err, env.Dump())
+	return env
+}
+
+func TestDeployAlertmanager(t *testing.T) {
+	assert := assert.New(t)
+	env := newMonitorEnv(t)
+
+	// (1) alertmanager is running
+	container, ok := env.Executor.Container(MONITOR_HOST, getContainerId(t, env, configure.ROLE_ALERTMANAGER))
+	assert.True(ok)
+	assert.Equal("running", container.Status)
+	env.AssertOrder(`docker create .*--config\.file=/etc/alertmanager/alertmanager\.yml`)
+
+	// (2) prometheus loads rules and sends alerts to alertmanager
+	prometheus := readContainerFile(t, env, configure.ROLE_PROMETHEUS, "/etc/prometheus/prometheus.yml")
+	assert.Contains(prometheus, "rule_files: ['curve_rules.yml']")
+	assert.Contains(prometheus, "targets: ['10.0.1.1:9093']")
+	rules := readContainerFile(t, env, configure.ROLE_PROMETHEUS, "/etc/prometheus/curve_rules.yml")
+	for _, alert := range []string{"EtcdNoLeader", "MDSDown", "ChunkserverOffline",
+		"CopysetUnhealthy", "DiskNearlyFull", "ChunkserverHighLatency"} {
+		assert.Contains(rules, "alert: "+alert)
+	}
+	assert.Contains(rules, "* 100 > 85")
+	assert.Contains(rules, "% full")
+
+	// (3) receivers
+	alertmanager := readContainerFile(t, env, configure.ROLE_ALERTMANAGER, "/etc/alertmanager/alertmanager.yml")
+	assert.Contains(alertmanager, "url: 'http://127.0.0.1:5001/alerts'")
+	assert.Contains(alertmanager, "smtp_smarthost: 'localhost:25'")
+	assert.NotContains(alertmanager, "email_configs")
+}
+
+func TestReloadWithoutRestart(t *testing.T) {
+	assert := assert.New(t)
+	env := newMonitorEnv(t)
+	env.Executor.Reset()
+	env.Answer("yes")
+
+	err := runReload(env.CurveAdm, reloadOptions{
+		id:       "*",
+		role:     "*",
+		host:     "*",
+		filename: env.WriteFile("monitor.yaml", fmt.Sprintf(MONITOR, 70)),
+	})
+	assert.Nil(err, env.Dump())
+
+	// (1) prometheus and alertmanager reload by SIGHUP
+	prometheusId := getContainerId(t, env, configure.ROLE_PROMETHEUS)
+	alertmanagerId := getContainerId(t, env, configure.ROLE_ALERTMANAGER)
+	assert.Len(env.Executor.Grep("docker kill --signal HUP "+prometheusId), 1)
+	assert.Len(env.Executor.Grep("docker kill --signal HUP "+alertmanagerId), 1)
+	assert.Len(env.Executor.Grep("docker restart .*"+prometheusId), 0)
+	assert.Len(env.Executor.Grep("docker restart .*"+alertmanagerId), 0)
+	container, ok := env.Executor.Container(MONITOR_HOST, prometheusId)
+	assert.True(ok)
+	assert.Equal("running", container.Status)
+
+	// (2) rules re-rendered with new threshold
+	rules := readContainerFile(t, env, configure.ROLE_PROMETHEUS, "/etc/prometheus/curve_rules.yml")
+	assert.Contains(rules, "* 100 > 70")
+
+	// (3) new configure is stored
+	assert.Contains(env.Reload().Monitor().Monitor, "alert.disk_usage: 70")
+}
+
+func TestDeployPrometheusReplicas(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	env.AddCluster("test", TOPOLOGY)
+	err := runDeploy(env.CurveAdm, deployOptions{
+		filename: env.WriteFile("monitor.yaml", MONITOR_HA),
+	})
+	assert.Nil(err, env.Dump())
+
+	// (1) prometheus replicas
+	for _, host := range []string{"server-host1", "server-host2"} {
+		curveadm := env.CurveAdm
+		containerId, err := curveadm.GetContainerId(
+			curveadm.GetServiceId(fmt.Sprintf("%s_%s", configure.ROLE_PROMETHEUS, host)))
+		assert.Nil(err)
+		prometheus, ok := env.Executor.ReadContainerFile(HOST_IPS[host], containerId,
+			"/etc/prometheus/prometheus.yml")
+		assert.True(ok)
+		assert.Contains(prometheus, fmt.Sprintf("replica: '%s'", host))
+		assert.Contains(prometheus, "'10.0.1.1:9100','10.0.1.2:9100','10.0.1.3:9200'")
+		assert.Contains(prometheus, "- url: 'http://10.0.1.5:9201/write'")
+		assert.Contains(prometheus, "username: 'curve'")
+	}
+
+	// (2) grafana is placed on server-host3, with datasource for each replica
+	curveadm := env.CurveAdm
+	containerId, err := curveadm.GetContainerId(
+		curveadm.GetServiceId(fmt.Sprintf("%s_server-host3", configure.ROLE_GRAFANA)))
+	assert.Nil(err)
+	datasource, ok := env.Executor.ReadContainerFile(HOST_IPS["server-host3"], containerId,
+		"/etc/grafana/provisioning/datasources/all.yml")
+	assert.True(ok)
+	assert.Contains(datasource, "url: 'http://10.0.1.1:9090'")
+	assert.Contains(datasource, "url: 'http://10.0.1.2:9091'")
+}
+
+func TestDeployInvalidMonitorConfig(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	env.AddCluster("test", TOPOLOGY)
+
+	// (1) multiple grafana
+	err := runDeploy(env.CurveAdm, deployOptions{
+		filename: env.WriteFile("monitor.yaml", MONITOR_HA+`
+  deploy:
+    - host: server-host1
+    - host: server-host2
+`),
+	})
+	assert.ErrorIs(err, errno.ERR_MONITOR_ROLE_REQUIRES_SINGLE_SERVICE)
+
+	// (2) duplicate prometheus
+	err = runDeploy(env.CurveAdm, deployOptions{
+		filename: env.WriteFile("monitor.yaml", strings.Replace(MONITOR_HA,
+			"server-host2\n      listen_port: 9091", "server-host1", 1)),
+	})
+	assert.ErrorIs(err, errno.ERR_DUPLICATE_MONITOR_SERVICE_HOST)
+}
+
+func TestStatusScrapeHealth(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	env.AddCluster("test", TOPOLOGY)
+	err := runDeploy(env.CurveAdm, deployOptions{
+		filename: env.WriteFile("monitor.yaml", MONITOR_HA),
+	})
+	assert.Nil(err, env.Dump())
+	env.Executor.OnHost("10.0.1.1", `wget .*127\.0\.0\.1:9090/api/v1/targets`, moduletest.Reply(
+		`{"status":"success","data":{"activeTargets":[{"health":"up"},{"health":"down"},{"health":"up"}]}}`))
+	env.Executor.OnHost("10.0.1.2", `wget .*127\.0\.0\.1:9091/api/v1/targets`, moduletest.Fail(""))
+
+	err = runStatus(env.Reload(), statusOptions{id: "*", role: "*", host: "*"})
+	assert.Nil(err, env.Dump())
+
+	statuses := env.CurveAdm.MemStorage().Get(comm.KEY_MONITOR_STATUS).(map[string]monitor.MonitorStatus)
+	scrapes := map[string]string{}
+	for _, status := range statuses {
+		if status.Role == configure.ROLE_PROMETHEUS {
+			scrapes[status.Host] = status.Scrape
+		}
+	}
+	assert.Equal(map[string]string{
+		"server-host1": "2/3 up",
+		"server-host2": comm.SERVICE_STATUS_UNKNOWN,
+	}, scrapes)
+}
+
+func TestSyncTargetWithClient(t *testing.T) {
+	assert := assert.New(t)
+	env := newMonitorEnv(t)
+	curveadm := env.CurveAdm
+	clientConfig := "kind: curvebs\nmds.listen.addr: %s\n"
+	for _, client := range []struct{ id, host, mdsAddr string }{
+		{"c0ffee", "server-host2", "10.0.1.1:6700,10.0.1.2:6700,10.0.1.3:6700"},
+		{"deadbeef", "server-host3", "10.0.9.1:6700,10.0.9.2:6700,10.0.9.3:6700"}, // other cluster
+	} {
+		err := curveadm.Storage().InsertClient(client.id, "curvebs", client.host,
+			client.id+"123456", `{"metric_port":9001}`)
+		assert.Nil(err)
+		err = curveadm.Storage().InsertClientConfig(client.id, fmt.Sprintf(clientConfig, client.mdsAddr))
+		assert.Nil(err)
+	}
+
+	// (1) refresh scrape targets
+	step := NewSyncTargetStep(env.Reload())
+	assert.NotNil(step)
+	pb := playbook.NewPlaybook(env.CurveAdm)
+	pb.AddStep(step)
+	assert.Nil(pb.Run(), env.Dump())
+
+	// (2) every target labeled with cluster, role, host and instance id
+	target := readContainerFile(t, env, configure.ROLE_PROMETHEUS, "/etc/prometheus/target.json")
+	items := []struct {
+		Targets []string          `json:"targets"`
+		Labels  map[string]string `json:"labels"`
+	}{}
+	assert.Nil(json.Unmarshal([]byte(target), &items))
+	roles := map[string]int{}
+	for _, item := range items {
+		roles[item.Labels["role"]]++
+		assert.Equal("test", item.Labels["cluster"])
+		assert.NotEmpty(item.Labels["host"])
+		assert.NotEmpty(item.Labels["instance_id"])
+	}
+	assert.Equal(map[string]int{"etcd": 3, "mds": 3, "chunkserver": 3, "client": 1}, roles)
+
+	// (3) client target with its metric port, client of other cluster excluded
+	assert.Contains(target, `"10.0.1.2:9001"`)
+	assert.NotContains(target, "deadbeef")
+
+	// (4) sync is best effort if prometheus host unreachable
+	env.Executor.Unreachable(MONITOR_HOST)
+	env.Executor.Reset()
+	SyncTarget(env.Reload())
+	assert.Len(env.Executor.Grep(`target\.json`), 0)
+}
+
+func TestProvisionDashboards(t *testing.T) {
+	assert := assert.New(t)
+	env := newMonitorEnv(t)
+
+	// (1) dashboards matched to cluster kind and version
+	provider := readContainerFile(t, env, configure.ROLE_GRAFANA, "/etc/grafana/provisioning/dashboards/curve.yml")
+	assert.Contains(provider, "folder: 'CurveBS v1.2'")
+	assert.Contains(provider, "path: '/etc/grafana/provisioning/dashboards/curve'")
+	for _, name := range []string{"overview", "chunkserver", "client", "etcd"} {
+		dashboard := readContainerFile(t, env, configure.ROLE_GRAFANA,
+			fmt.Sprintf("/etc/grafana/provisioning/dashboards/curve/%s.json", name))
+		assert.Contains(dashboard, fmt.Sprintf(`"uid": "curvebs-%s"`, name))
+	}
+
+	// (2) dashboards are updated on reload
+	grafanaId := getContainerId(t, env, configure.ROLE_GRAFANA)
+	env.Executor.Reset()
+	curveadm := env.Reload()
+	env.Answer("yes")
+	err := runReload(curveadm, reloadOptions{id: "*", role: configure.ROLE_GRAFANA, host: "*"})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`docker cp .* `+grafanaId+`:/etc/grafana/provisioning/dashboards/curve/`), 4)
+}
+
+func TestDeployLogPipeline(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	env.AddCluster("test", TOPOLOGY)
+	err := runDeploy(env.CurveAdm, deployOptions{
+		filename: env.WriteFile("monitor.yaml", fmt.Sprintf(MONITOR, 85)+`
+loki:
+  container_image: grafana/loki:latest
+  data_dir: /tmp/monitor/loki
+  listen_port: 3100
+
+promtail:
+  container_image: grafana/promtail:latest
+  listen_port: 9080
+`),
+	})
+	assert.Nil(err, env.Dump())
+
+	// (1) log store with retention
+	loki := readContainerFile(t, env, configure.ROLE_LOKI, "/etc/loki/loki.yml")
+	assert.Contains(loki, "http_listen_port: 3100")
+	assert.Contains(loki, "retention_period: 7d")
+
+	// (2) log shipper on every service host, tails log_dir of services
+	curveadm := env.CurveAdm
+	for host, ip := range HOST_IPS {
+		containerId, err := curveadm.GetContainerId(
+			curveadm.GetServiceId(fmt.Sprintf("%s_%s", configure.ROLE_PROMTAIL, host)))
+		assert.Nil(err)
+		promtail, ok := env.Executor.ReadContainerFile(ip, containerId, "/etc/promtail/promtail.yml")
+		assert.True(ok)
+		assert.Contains(promtail, "url: 'http://10.0.1.1:3100/loki/api/v1/push'")
+		assert.Contains(promtail, fmt.Sprintf("host: '%s'", host))
+		assert.Equal(3, strings.Count(promtail, "host: '"), "only services on %s", host)
+		for _, role := range []string{"etcd", "mds", "chunkserver"} {
+			assert.Contains(promtail, fmt.Sprintf("role: '%s'", role))
+			assert.Regexp(fmt.Sprintf(`__path__: '/host/tmp/logs/%s\d/\*\*/\*\.log\*'`, role), promtail)
+		}
+	}
+	env.AssertOrder(`docker create .*--volume /:/host:ro,rslave .*grafana/promtail:latest`)
+
+	// (3) grafana datasource
+	datasource := readContainerFile(t, env, configure.ROLE_GRAFANA, "/etc/grafana/provisioning/datasources/all.yml")
+	assert.Contains(datasource, "type: 'loki'")
+	assert.Contains(datasource, "url: 'http://10.0.1.1:3100'")
+
+	// (4) log targets are refreshed with the latest topology
+	err = curveadm.Storage().SetClusterTopology(curveadm.ClusterId(),
+		strings.ReplaceAll(TOPOLOGY, "log_dir: /tmp/logs", "log_dir: /data/logs"))
+	assert.Nil(err)
+	env.Executor.Reset()
+	SyncTarget(env.Reload())
+	curveadm = env.CurveAdm
+	for host, ip := range HOST_IPS {
+		containerId, err := curveadm.GetContainerId(
+			curveadm.GetServiceId(fmt.Sprintf("%s_%s", configure.ROLE_PROMTAIL, host)))
+		assert.Nil(err)
+		promtail, ok := env.Executor.ReadContainerFile(ip, containerId, "/etc/promtail/promtail.yml")
+		assert.True(ok)
+		assert.Regexp(`__path__: '/host/data/logs/etcd\d/\*\*/\*\.log\*'`, promtail)
+		assert.NotContains(promtail, "/host/tmp/logs/")
+		assert.Len(env.Executor.Grep(`docker restart +`+containerId), 1)
+	}
+
+	// (5) loki and promtail should be configured together
+	err = runDeploy(env.CurveAdm, deployOptions{
+		filename: env.WriteFile("monitor.yaml", fmt.Sprintf(MONITOR, 85)+`
+loki:
+  container_image: grafana/loki:latest
+  listen_port: 3100
+`),
+	})
+	assert.ErrorIs(err, errno.ERR_INCOMPLETE_MONITOR_LOG_PIPELINE)
+}
@@ -27,33 +27,44 @@ import (
 	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/storage"
 	"github.com/opencurve/curveadm/internal/tasks"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
 )
 
+const (
+	RELOAD_EXAMPLE = `Examples:
+	$ curveadm monitor reload                    # Reload all monitor services
+	$ curveadm monitor reload -c monitor.yaml    # Replace monitor configure and reload
+	$ curveadm monitor reload --role prometheus  # Re-render rules and reload prometheus`
+)
+
 var (
 	MONITOR_RELOAD_STEPS = []int{
 		playbook.CREATE_MONITOR_CONTAINER,
 		playbook.SYNC_MONITOR_CONFIG,
 		playbook.CLEAN_CONFIG_CONTAINER,
-		playbook.RESTART_MONITOR_SERVICE,
+		playbook.RELOAD_MONITOR_SERVICE,
 	}
 )
 
 type reloadOptions struct {
-	id   string
-	role string
-	host string
+	id       string
+	role     string
+	host     string
+	filename string
 }
 
 func NewReloadCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options reloadOptions
 	cmd := &cobra.Command{
-		Use:   "reload [OPTIONS]",
-		Short: "Reload monitor service",
-		Args:  cliutil.NoArgs,
+		Use:     "reload [OPTIONS]",
+		Short:   "Reload monitor service",
+		Args:    cliutil.NoArgs,
+		Example: RELOAD_EXAMPLE,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runReload(curveadm, options)
 		},
@@ -64,18 +75,41 @@ func NewReloadCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags.StringVar(&options.id, "id", "*", "Specify monitor service id")
 	flags.StringVar(&options.role, "role", "*", "Specify monitor service role")
 	flags.StringVar(&options.host, "host", "*", "Specify monitor service host")
+	flags.StringVarP(&options.filename, "conf", "c", "", "Specify new monitor configuration file")
 
 	return cmd
 }
 
+// grafana's configure is synced from the monitor_conf container,
+// so it should be reloaded together with grafana
+func withMonitorConf(all, mcs []*configure.MonitorConfig) []*configure.MonitorConfig {
+	selected := map[string]bool{}
+	for _, mc := range mcs {
+		selected[mc.GetId()] = true
+	}
+	for _, mc := range mcs {
+		if mc.GetRole() != configure.ROLE_GRAFANA {
+			continue
+		}
+		for _, conf := range all {
+			if conf.GetRole() == configure.ROLE_MONITOR_CONF &&
+				conf.GetHost() == mc.GetHost() && !selected[conf.GetId()] {
+				mcs = append(mcs, conf)
+				selected[conf.GetId()] = true
+			}
+		}
+	}
+	return mcs
+}
+
 func genReloadPlaybook(curveadm *cli.CurveAdm,
 	mcs []*configure.MonitorConfig,
 	options reloadOptions) (*playbook.Playbook, error) {
-	mcs = configure.FilterMonitorConfig(curveadm, mcs, configure.FilterMonitorOption{
+	mcs = withMonitorConf(mcs, configure.FilterMonitorConfig(curveadm, mcs, configure.FilterMonitorOption{
 		Id:   options.id,
 		Role: options.role,
 		Host: options.host,
-	})
+	}))
 	if len(mcs) == 0 {
 		return nil, errno.ERR_NO_SERVICES_MATCHED
 	}
@@ -102,9 +136,34 @@ func genReloadPlaybook(curveadm *cli.CurveAdm,
 	return pb, nil
 }
 
+// the new configure will replace the stored one only if it is valid
+func parseReloadConfig(curveadm *cli.CurveAdm, options reloadOptions) (
+	[]*configure.MonitorConfig, string, error) {
+	if len(options.filename) == 0 {
+		mcs, err := parseMonitorConfig(curveadm)
+		return mcs, "", err
+	} else if curveadm.ClusterId() == -1 {
+		return nil, "", errno.ERR_NO_CLUSTER_SPECIFIED
+	}
+
+	hosts, dcs, err := parseTopology(curveadm)
+	if err != nil {
+		return nil, "", err
+	}
+	mcs, err := configure.ParseMonitorConfig(curveadm, options.filename, "", hosts, dcs)
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := utils.ReadFile(options.filename)
+	if err != nil {
+		return nil, "", errno.ERR_READ_MONITOR_FILE_FAILED.E(err)
+	}
+	return mcs, data, nil
+}
+
 func runReload(curveadm *cli.CurveAdm, options reloadOptions) error {
 	// 1) parse monitor configure
-	mcs, err := parseMonitorConfig(curveadm)
+	mcs, data, err := parseReloadConfig(curveadm, options)
 	if err != nil {
 		return err
 	}
@@ -121,6 +180,17 @@ func runReload(curveadm *cli.CurveAdm, options reloadOptions) error {
 		return errno.ERR_CANCEL_OPERATION
 	}
 
-	// 4) run playground
+	// 4) save new monitor data
+	if len(data) > 0 {
+		err = curveadm.Storage().ReplaceMonitor(storage.Monitor{
+			ClusterId: curveadm.ClusterId(),
+			Monitor:   data,
+		})
+		if err != nil {
+			return errno.ERR_REPLACE_MONITOR_FAILED.E(err)
+		}
+	}
+
+	// 5) run playground
 	return pb.Run()
 }
@@ -72,13 +72,13 @@ func parseMonitorConfig(curveadm *cli.CurveAdm) ([]*configure.MonitorConfig, err
 	if curveadm.ClusterId() == -1 {
 		return nil, errno.ERR_NO_CLUSTER_SPECIFIED
 	}
-	hosts, hostIps, dcs, err := parseTopology(curveadm)
+	hosts, dcs, err := parseTopology(curveadm)
 	if err != nil {
 		return nil, err
 	}
 
 	monitor := curveadm.Monitor()
-	return configure.ParseMonitorConfig(curveadm, "", monitor.Monitor, hosts, hostIps, dcs)
+	return configure.ParseMonitorConfig(curveadm, "", monitor.Monitor, hosts, dcs)
 }
 
 func genStatusPlaybook(curveadm *cli.CurveAdm,
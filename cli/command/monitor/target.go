@@ -0,0 +1,89 @@
+/*
+*  Copyright (c) 2026 NetEase Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+ */
+
+/*
+* Project: Curveadm
+* Created Date: 2026-10-19
+* Author: Jingli Chen (Wine93)
+ */
+
+package monitor
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/playbook"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
+)
+
+// NewSyncTargetStep returns the step which refreshes scrape targets of
+// prometheus and log targets of promtail. It returns nil if there is no
+// monitor deployed for current cluster.
+func NewSyncTargetStep(curveadm *cli.CurveAdm) *playbook.PlaybookStep {
+	data := curveadm.Monitor().Monitor
+	if curveadm.ClusterId() == -1 || len(data) == 0 || data == comm.CLEANED_MONITOR_CONF {
+		return nil
+	}
+
+	// the broken monitor configure shouldn't block the cluster operation
+	mcs, err := parseMonitorConfig(curveadm)
+	if err != nil {
+		log.Warn("Parse monitor configure failed, skip sync target",
+			log.Field("Error", err))
+		return nil
+	}
+	configs := []*configure.MonitorConfig{}
+	for _, role := range []string{configure.ROLE_PROMETHEUS, configure.ROLE_PROMTAIL} {
+		configs = append(configs, configure.FilterMonitorConfig(curveadm, mcs, configure.FilterMonitorOption{
+			Id:   "*",
+			Role: role,
+			Host: "*",
+		})...)
+	}
+	if len(configs) == 0 {
+		return nil
+	}
+
+	return &playbook.PlaybookStep{
+		Type:    playbook.SYNC_MONITOR_TARGET,
+		Configs: configs,
+		ExecOptions: playbook.ExecOptions{
+			SilentSubBar: true,
+		},
+	}
+}
+
+// SyncTarget refreshes scrape and log targets after the operation which changes
+// services or clients (e.g. scale-out, migrate, map) succeeded. It's best
+// effort: the operation has been done, so the failure only warns user
+// instead of failing the whole command.
+func SyncTarget(curveadm *cli.CurveAdm) {
+	step := NewSyncTargetStep(curveadm)
+	if step == nil {
+		return
+	}
+
+	pb := playbook.NewPlaybook(curveadm)
+	pb.AddStep(step)
+	if err := pb.Run(); err != nil {
+		log.Warn("Sync monitor target failed",
+			log.Field("Error", err))
+		curveadm.WriteOutln(color.YellowString("WARNING: sync monitor target failed, " +
+			"please run 'curveadm monitor reload' later"))
+	}
+}
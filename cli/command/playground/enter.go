@@ -23,7 +23,10 @@
 package playground
 
 import (
+	"fmt"
+
 	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/tools"
 	"github.com/opencurve/curveadm/internal/utils"
@@ -62,6 +65,15 @@ func runEnter(curveadm *cli.CurveAdm, options enterOptions) error {
 			F("id=%s", id)
 	}
 
-	// 2) attch local container
-	return tools.AttachLocalContainer(curveadm, playgrounds[0].Name)
+	// 2) enter the first node for multi-node playground
+	name := playgrounds[0].Name
+	items, err := curveadm.Storage().GetPlaygroundHosts(name)
+	if err != nil {
+		return errno.ERR_SELECT_PLAYGROUND_HOSTS_FAILED.E(err)
+	} else if len(items) > 0 {
+		name = fmt.Sprintf(configure.FORMAT_PLAYGROUND_NODE_NAME, name, 1)
+	}
+
+	// 3) attch local container
+	return tools.AttachLocalContainer(curveadm, name)
 }
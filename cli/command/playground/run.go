@@ -23,12 +23,16 @@
 package playground
 
 import (
+	"bytes"
 	"fmt"
 	"path/filepath"
+	"text/template"
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/google/uuid"
 	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
@@ -44,6 +48,11 @@ const (
 	KIND_CURVEFS = topology.KIND_CURVEFS
 
 	FORMAT_PLAYGROUND_NAME = "playground-%s-%d" // playground-curvebs-1656035415
+
+	RUN_EXAMPLE = `Examples:
+  $ curveadm playground run --kind curvebs             # Run an all-in-one CurveBS playground
+  $ curveadm playground run --kind curvebs --nodes 3   # Run a CurveBS cluster on 3 local nodes
+  $ curveadm playground run --kind curvebs -c topology.yaml --client client.yaml  # Run playground with specified topology and client configure`
 )
 
 var (
@@ -57,21 +66,52 @@ var (
 		playbook.INIT_PLAYGROUND,
 		playbook.START_PLAYGROUND,
 	}
+
+	RUN_MULTI_NODE_PLAYGROUND_PLAYBOOK_STEPS = []int{
+		playbook.CREATE_PLAYGROUND_NODES,
+	}
+
+	// registered by command package which we can't import (import cycle)
+	deployFunc DeployFunc
 )
 
-type runOptions struct {
-	name           string
-	kind           string
-	mountPoint     string
-	containerImage string
-}
+type (
+	// DeployFunc deploys the current cluster, it's the normal deploy command
+	DeployFunc func(curveadm *cli.CurveAdm) error
+
+	runOptions struct {
+		name           string
+		kind           string
+		mountPoint     string
+		containerImage string
+		nodes          int
+		nodeImage      string
+		filename       string
+		clientFilename string
+	}
+
+	playgroundNode struct {
+		Name    string
+		Address string
+	}
+)
 
 func checkRunOptions(curveadm *cli.CurveAdm, options runOptions) error {
 	kind := options.kind
 	mountPoint := options.mountPoint
-	if !supportKind[kind] {
+	if options.nodes > 1 && kind != KIND_CURVEBS {
+		// TODO(P1): support curvefs, which requires its nodes topology
+		return errno.ERR_MULTI_NODES_PLAYGROUND_ONLY_SUPPORT_CURVEBS.
+			F("kind=%s nodes=%d", kind, options.nodes)
+	} else if !supportKind[kind] {
 		return errno.ERR_UNSUPPORT_PLAYGROUND_KIND.
 			F("kind=%s", kind)
+	} else if options.nodes < 1 || options.nodes == 2 {
+		return errno.ERR_INVALID_PLAYGROUND_NODES.
+			F("nodes=%d", options.nodes)
+	} else if options.nodes > 1 && (len(options.filename) > 0 || len(options.clientFilename) > 0) {
+		return errno.ERR_CUSTOM_CONFIGURE_REQUIRE_ALL_IN_ONE_PLAYGROUND.
+			F("nodes=%d", options.nodes)
 	}
 
 	if kind == KIND_CURVEBS {
@@ -91,6 +131,11 @@ func checkRunOptions(curveadm *cli.CurveAdm, options runOptions) error {
 	return nil
 }
 
+// RegisterDeployFunc registers the function which deploys multi-node playground
+func RegisterDeployFunc(deploy DeployFunc) {
+	deployFunc = deploy
+}
+
 func NewRunCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options runOptions
 
@@ -99,11 +144,15 @@ func NewRunCommand(curveadm *cli.CurveAdm) *cobra.Command {
 		Aliases: []string{"create"},
 		Short:   "Run playground",
 		Args:    cliutil.NoArgs,
+		Example: RUN_EXAMPLE,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return checkRunOptions(curveadm, options)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			options.name = fmt.Sprintf(FORMAT_PLAYGROUND_NAME, options.kind, time.Now().Unix())
+			if options.nodes > 1 {
+				return runMultiNode(curveadm, options)
+			}
 			return runRun(curveadm, options)
 		},
 		DisableFlagsInUseLine: true,
@@ -113,6 +162,10 @@ func NewRunCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags.StringVarP(&options.kind, "kind", "k", "curvefs", "Specify the type of playground (curvebs/curvefs)")
 	flags.StringVar(&options.mountPoint, "mountpoint", "p", "Specify the mountpoint for CurveFS playground")
 	flags.StringVarP(&options.containerImage, "container_image", "i", "opencurvedocker/curvebs:playground", "Specify the playground container image")
+	flags.IntVar(&options.nodes, "nodes", 1, "Specify the number of nodes, each node acts as a host of cluster")
+	flags.StringVar(&options.nodeImage, "node-image", configure.DEFAULT_PLAYGROUND_NODE_IMAGE, "Specify the node container image")
+	flags.StringVarP(&options.filename, "topology", "c", "", "Specify the path of topology file, all services should be deployed on 'localhost'")
+	flags.StringVar(&options.clientFilename, "client", "", "Specify the path of client configure file")
 
 	return cmd
 }
@@ -134,6 +187,9 @@ func genRunPlaybook(curveadm *cli.CurveAdm,
 				DeployConfigs:  dcs,
 				ClientConfig:   cc,
 			},
+			Options: map[string]interface{}{
+				comm.KEY_POOLSET: configure.Poolset{Name: "default", Type: "ssd"},
+			},
 			ExecOptions: playbook.ExecOptions{
 				SilentSubBar: true,
 			},
@@ -142,20 +198,80 @@ func genRunPlaybook(curveadm *cli.CurveAdm,
 	return pb, nil
 }
 
+// the topology is embedded one unless user specified
+func parsePlaygroundTopology(options runOptions) ([]*topology.DeployConfig, error) {
+	data := script.TOPOLOGY
+	if len(options.filename) > 0 {
+		if !utils.PathExist(options.filename) {
+			return nil, errno.ERR_TOPOLOGY_FILE_NOT_FOUND.
+				F("%s: no such file", utils.AbsPath(options.filename))
+		}
+		content, err := utils.ReadFile(options.filename)
+		if err != nil {
+			return nil, errno.ERR_READ_TOPOLOGY_FILE_FAILED.E(err)
+		}
+		data = content
+	}
+
+	ctx := topology.NewContext()
+	ctx.Add("localhost", "127.0.0.1")
+	dcs, err := topology.ParseTopology(data, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// all services run in one container, so they can't share the same prefix,
+	// and no host directory mounted for logs and data of them
+	prefixes := map[string]string{}
+	for _, dc := range dcs {
+		if dc.GetKind() != options.kind {
+			return nil, errno.ERR_PLAYGROUND_KIND_MISMATCH.
+				F("playground kind=%s, topology kind=%s", options.kind, dc.GetKind())
+		} else if len(options.filename) > 0 && (len(dc.GetLogDir()) > 0 || len(dc.GetDataDir()) > 0) {
+			return nil, errno.ERR_PLAYGROUND_UNSUPPORT_LOG_OR_DATA_DIR.
+				F("%s: log_dir=%s, data_dir=%s", dc.GetId(), dc.GetLogDir(), dc.GetDataDir())
+		}
+		prefix := dc.GetProjectLayout().ServiceRootDir
+		if id, ok := prefixes[prefix]; ok {
+			return nil, errno.ERR_PLAYGROUND_SERVICES_SHARE_PREFIX.
+				F("%s and %s: prefix=%s", id, dc.GetId(), prefix)
+		}
+		prefixes[prefix] = dc.GetId()
+	}
+	return dcs, nil
+}
+
+// the client configure is embedded one unless user specified
+func parsePlaygroundClientConfig(options runOptions) (*configure.ClientConfig, error) {
+	if len(options.clientFilename) == 0 {
+		return configure.ParseClientCfg(script.CLIENT)
+	} else if !utils.PathExist(options.clientFilename) {
+		return nil, errno.ERR_CLIENT_CONFIGURE_FILE_NOT_EXIST.
+			F("file path: %s", utils.AbsPath(options.clientFilename))
+	}
+
+	cc, err := configure.ParseClientConfig(options.clientFilename)
+	if err != nil {
+		return nil, err
+	} else if cc.GetKind() != options.kind {
+		return nil, errno.ERR_PLAYGROUND_KIND_MISMATCH.
+			F("playground kind=%s, client configure kind=%s", options.kind, cc.GetKind())
+	}
+	return cc, nil
+}
+
 func runRun(curveadm *cli.CurveAdm, options runOptions) error {
 	// 1) print prompt
 	curveadm.WriteOutln(color.GreenString("Start to run playground '%s', it will takes 1~2 minutes\n"), options.name)
 
 	// 2) parse topology
-	ctx := topology.NewContext()
-	ctx.Add("localhost", "127.0.0.1")
-	dcs, err := topology.ParseTopology(script.TOPOLOGY, ctx)
+	dcs, err := parsePlaygroundTopology(options)
 	if err != nil {
 		return err
 	}
 
 	// 3) parse client configure
-	cc, err := configure.ParseClientCfg(script.CLIENT)
+	cc, err := parsePlaygroundClientConfig(options)
 	if err != nil {
 		return err
 	}
@@ -178,3 +294,120 @@ func runRun(curveadm *cli.CurveAdm, options runOptions) error {
 		options.name))
 	return nil
 }
+
+func genRunMultiNodePlaybook(curveadm *cli.CurveAdm, options runOptions) (*playbook.Playbook, error) {
+	steps := RUN_MULTI_NODE_PLAYGROUND_PLAYBOOK_STEPS
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range steps {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type: step,
+			Configs: &configure.PlaygroundConfig{
+				Kind:      options.kind,
+				Name:      options.name,
+				Nodes:     options.nodes,
+				NodeImage: options.nodeImage,
+			},
+			ExecOptions: playbook.ExecOptions{
+				SilentSubBar: true,
+			},
+		})
+	}
+	return pb, nil
+}
+
+func getPlaygroundNodes(curveadm *cli.CurveAdm, options runOptions) []playgroundNode {
+	addresses := map[string]string{}
+	if v := curveadm.MemStorage().Get(comm.KEY_PLAYGROUND_NODES); v != nil {
+		addresses = v.(map[string]string)
+	}
+
+	nodes := []playgroundNode{}
+	cfg := &configure.PlaygroundConfig{Name: options.name}
+	for i := 1; i <= options.nodes; i++ {
+		name := cfg.GetNodeName(i)
+		nodes = append(nodes, playgroundNode{Name: name, Address: addresses[name]})
+	}
+	return nodes
+}
+
+func renderNodesTemplate(text string, nodes []playgroundNode) (string, error) {
+	tmpl, err := template.New("playground").Parse(text)
+	if err != nil {
+		return "", errno.ERR_BUILD_TEMPLATE_FAILED.E(err)
+	}
+	buffer := bytes.NewBufferString("")
+	err = tmpl.Execute(buffer, map[string]interface{}{"Nodes": nodes})
+	if err != nil {
+		return "", errno.ERR_BUILD_TEMPLATE_FAILED.E(err)
+	}
+	return buffer.String(), nil
+}
+
+// add cluster named by playground, which carries the hosts of nodes
+func addPlaygroundCluster(curveadm *cli.CurveAdm, options runOptions) error {
+	nodes := getPlaygroundNodes(curveadm, options)
+	hosts, err := renderNodesTemplate(script.NODES_HOSTS, nodes)
+	if err != nil {
+		return err
+	}
+	data, err := renderNodesTemplate(script.NODES_TOPOLOGY, nodes)
+	if err != nil {
+		return err
+	}
+
+	storage := curveadm.Storage()
+	name := options.name
+	err = storage.InsertCluster(name, uuid.NewString(), "multi-node playground", data)
+	if err != nil {
+		return errno.ERR_INSERT_CLUSTER_FAILED.E(err)
+	}
+	err = storage.InsertPlaygroundHosts(name, hosts)
+	if err != nil {
+		return errno.ERR_INSERT_PLAYGROUND_HOSTS_FAILED.E(err)
+	}
+	return curveadm.SwitchCluster(name)
+}
+
+/*
+ * Multi-Node Playground:
+ *   1) create network and nodes (privileged container which runs a docker daemon)
+ *   2) add cluster with generated topology and hosts, and switch to it
+ *   3) deploy cluster on nodes by the normal deploy command
+ */
+func runMultiNode(curveadm *cli.CurveAdm, options runOptions) error {
+	// 1) print prompt
+	curveadm.WriteOutln(color.GreenString("Start to run playground '%s' with %d nodes\n"),
+		options.name, options.nodes)
+
+	// 2) create nodes
+	pb, err := genRunMultiNodePlaybook(curveadm, options)
+	if err != nil {
+		return err
+	}
+	err = pb.Run()
+	if err != nil {
+		return err
+	}
+
+	// 3) add and switch cluster
+	err = addPlaygroundCluster(curveadm, options)
+	if err != nil {
+		return err
+	}
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln("Switched to cluster '%s'", options.name)
+	curveadm.WriteOutln("")
+
+	// 4) deploy cluster
+	err = deployFunc(curveadm)
+	if err != nil {
+		return err
+	}
+
+	// 5) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Playground '%s' successfully deployed ^_^"), options.name)
+	curveadm.WriteOutln("Stop a node to test failover, e.g. docker stop %s",
+		(&configure.PlaygroundConfig{Name: options.name}).GetNodeName(options.nodes))
+	return nil
+}
@@ -0,0 +1,179 @@
+package command
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/cli/clitest"
+	"github.com/opencurve/curveadm/cli/command/playground"
+	"github.com/opencurve/curveadm/internal/errno"
+	pgtask "github.com/opencurve/curveadm/internal/task/task/playground"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module/moduletest"
+	"github.com/stretchr/testify/assert"
+)
+
+func runPlaygroundCommand(curveadm *cli.CurveAdm, args ...string) error {
+	playground.RegisterDeployFunc(deployPlayground)
+	cmd := playground.NewPlaygroundCommand(curveadm)
+	cmd.SetArgs(args)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	return cmd.Execute()
+}
+
+func TestPlaygroundMultiNode(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	curveadm := env.CurveAdm
+	curveadm.SetOut(&bytes.Buffer{})
+
+	// (1) 2 nodes can't tolerate any failure
+	err := runPlaygroundCommand(curveadm, "run", "--kind", "curvebs", "--nodes", "2")
+	assert.NotNil(err)
+	assert.Len(env.Executor.Commands(), 0)
+
+	// (2) only curvebs ships the nodes topology, and curvefs is the default kind
+	err = runPlaygroundCommand(curveadm, "run", "--nodes", "3", "--mountpoint", t.TempDir())
+	assert.Equal(errno.ERR_MULTI_NODES_PLAYGROUND_ONLY_SUPPORT_CURVEBS.GetCode(),
+		err.(*errno.ErrorCode).GetCode())
+	assert.Len(env.Executor.Commands(), 0)
+
+	// (3) create network and nodes in local, then deploy cluster on nodes
+	err = runPlaygroundCommand(curveadm, "run", "--kind", "curvebs", "--nodes", "3", "--node-image", "node:test")
+	assert.Nil(err, env.Dump())
+	nodes := env.Executor.Containers(moduletest.LOCAL_HOST)
+	assert.Len(nodes, 3)
+	assert.Len(env.Executor.Grep(`docker network create --driver bridge playground-curvebs-`), 1)
+	env.AssertOrder(
+		`docker pull .*node:test`,
+		`docker create .*--privileged .*node:test`,
+		`docker exec .*-node1 docker info`,
+		`docker pull .*curvebs`,
+		`create_logicalpool`,
+	)
+
+	name := curveadm.ClusterName()
+	assert.Regexp(`^playground-curvebs-[0-9]+$`, name)
+	services, err := curveadm.Storage().GetServices(curveadm.ClusterId())
+	assert.Nil(err)
+	assert.Len(services, 9) // etcd*3, mds*3, chunkserver*3
+	for _, node := range nodes {
+		hc, err := curveadm.GetHost(node.Name)
+		assert.Nil(err)
+		assert.Equal(node.Name, hc.GetLocalContainer())
+		assert.Equal(node.Address, hc.GetHostname())
+		assert.Len(env.Executor.Containers(node.Address), 3, node.Name)
+	}
+
+	// (4) playground hosts are kept for the following commands
+	curveadm = env.Reload()
+	assert.Equal(name, curveadm.ClusterName())
+	_, err = curveadm.GetHost(nodes[0].Name)
+	assert.Nil(err)
+
+	// (5) remove nodes, network and cluster
+	playgrounds, err := curveadm.Storage().GetPlaygrounds(name)
+	assert.Nil(err)
+	assert.Len(playgrounds, 1)
+	curveadm.SetOut(&bytes.Buffer{})
+	err = runPlaygroundCommand(curveadm, "rm", utils.Atoa(playgrounds[0].Id))
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Containers(moduletest.LOCAL_HOST), 0)
+	assert.Len(env.Executor.Grep(`docker network rm .*`+name), 1)
+	clusters, err := curveadm.Storage().GetClusters(name)
+	assert.Nil(err)
+	assert.Len(clusters, 0)
+	items, err := curveadm.Storage().GetPlaygroundHosts(name)
+	assert.Nil(err)
+	assert.Len(items, 0)
+}
+
+const PLAYGROUND_TOPOLOGY = `
+kind: curvebs
+global:
+  prefix: /curvebs/playground/${service_role}${service_host_sequence}
+  variable:
+    target: localhost
+
+etcd_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 2380${service_host_sequence}
+    listen.client_port: 2379${service_host_sequence}
+  deploy:
+    - host: ${target}
+    - host: ${target}
+    - host: ${target}
+
+mds_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 670${service_host_sequence}
+    listen.dummy_port: 770${service_host_sequence}
+  deploy:
+    - host: ${target}
+    - host: ${target}
+    - host: ${target}
+
+chunkserver_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 830${service_host_sequence}
+    copysets: 100
+  deploy:
+    - host: ${target}
+    - host: ${target}
+    - host: ${target}
+    - host: ${target}
+`
+
+const PLAYGROUND_CLIENT = `
+kind: curvebs
+mds.listen.addr: 127.0.0.1:6700,127.0.0.1:6701,127.0.0.1:6702
+global.logPath: /tmp/playground
+`
+
+func TestPlaygroundCustomTopology(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	curveadm := env.CurveAdm
+	curveadm.SetOut(&bytes.Buffer{})
+	filename := env.WriteFile("topology.yaml", PLAYGROUND_TOPOLOGY)
+	clientFilename := env.WriteFile("client.yaml", PLAYGROUND_CLIENT)
+	defer func(seconds int) { pgtask.WAIT_PLAYGROUND_START_SECONDS = seconds }(pgtask.WAIT_PLAYGROUND_START_SECONDS)
+	pgtask.WAIT_PLAYGROUND_START_SECONDS = 0
+
+	// (1) custom configure is only for all-in-one playground
+	err := runPlaygroundCommand(curveadm, "run", "--kind", "curvebs", "--nodes", "3", "-c", filename)
+	assert.NotNil(err)
+
+	// (2) services share the same prefix
+	data := strings.Replace(PLAYGROUND_TOPOLOGY, "${service_role}${service_host_sequence}", "${service_role}", 1)
+	err = runPlaygroundCommand(curveadm, "run", "--kind", "curvebs", "-c", env.WriteFile("shared.yaml", data))
+	assert.NotNil(err)
+
+	// (3) services must be deployed on localhost
+	data = strings.Replace(PLAYGROUND_TOPOLOGY, "target: localhost", "target: host1", 1)
+	err = runPlaygroundCommand(curveadm, "run", "--kind", "curvebs", "-c", env.WriteFile("remote.yaml", data))
+	assert.NotNil(err)
+
+	// (4) logs and data are kept in container, no host directory for them
+	data = strings.Replace(PLAYGROUND_TOPOLOGY, "  variable:", "  log_dir: /tmp/logs/${service_role}${service_host_sequence}\n  variable:", 1)
+	err = runPlaygroundCommand(curveadm, "run", "--kind", "curvebs", "-c", env.WriteFile("logdir.yaml", data))
+	assert.ErrorIs(err, errno.ERR_PLAYGROUND_UNSUPPORT_LOG_OR_DATA_DIR)
+	assert.Len(env.Executor.Commands(), 0)
+
+	// (5) run playground with custom topology and client configure
+	err = runPlaygroundCommand(curveadm, "run", "--kind", "curvebs", "-c", filename, "--client", clientFilename)
+	assert.Nil(err, env.Dump())
+	containers := env.Executor.Containers(moduletest.LOCAL_HOST)
+	assert.Len(containers, 1)
+	services, ok := env.Executor.ReadContainerFile(moduletest.LOCAL_HOST, containers[0].Name, "/curvebs/playground/services")
+	assert.True(ok, env.Dump())
+	assert.Equal(10, strings.Count(services, "\n")) // etcd*3, mds*3, chunkserver*4
+	assert.Contains(services, "chunkserver /curvebs/playground/chunkserver3 8303\n")
+}
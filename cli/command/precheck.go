@@ -29,9 +29,11 @@ import (
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/task/checker"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
 	utils "github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
@@ -41,7 +43,8 @@ const (
 	PRECHECK_EXAMPLE = `Examples:
   $ curveadm precheck                         # Check all items
   $ curveadm precheck --skip topology         # Check all items except topology
-  $ curveadm precheck --skip topology,kernel  # Check all items except topology and kernel`
+  $ curveadm precheck --skip topology,kernel  # Check all items except topology and kernel
+  $ curveadm precheck -f format.yaml          # Check capacity of chunkfile pool which formatted by format.yaml`
 )
 
 const (
@@ -52,6 +55,8 @@ const (
 	CHECK_ITEM_NERWORK    = "network"
 	CHECK_ITEM_DATE       = "date"
 	CHECK_ITEM_SERVICE    = "service"
+	CHECK_ITEM_DISK       = "disk"
+	CHECK_ITEM_RESOURCE   = "resource"
 )
 
 var (
@@ -65,10 +70,15 @@ var (
 		playbook.CHECK_DESTINATION_REACHABLE,
 		playbook.START_HTTP_SERVER,
 		playbook.CHECK_NETWORK_FIREWALL,
+		playbook.MEASURE_NETWORK_QUALITY,
+		playbook.CHECK_NETWORK_QUALITY,
 		playbook.GET_HOST_DATE, // date
 		playbook.CHECK_HOST_DATE,
 		playbook.CHECK_CHUNKFILE_POOL, // service
 		//playbook.CHECK_S3,
+		playbook.CHECK_DISK, // disk
+		playbook.CHECK_SHARED_DISK,
+		playbook.CHECK_HOST_RESOURCE, // resource
 	}
 
 	CURVEFS_PRECHECK_STEPS = []int{
@@ -80,8 +90,12 @@ var (
 		playbook.START_HTTP_SERVER,
 		playbook.CHECK_DESTINATION_REACHABLE,
 		playbook.CHECK_NETWORK_FIREWALL,
+		playbook.MEASURE_NETWORK_QUALITY,
+		playbook.CHECK_NETWORK_QUALITY,
 		playbook.GET_HOST_DATE, // date
 		playbook.CHECK_HOST_DATE,
+		playbook.CHECK_DISK,          // disk
+		playbook.CHECK_HOST_RESOURCE, // resource
 	}
 
 	PRECHECK_POST_STEPS = []int{
@@ -96,10 +110,15 @@ var (
 		playbook.CHECK_PORT_IN_USE:           CHECK_ITEM_NERWORK,
 		playbook.CHECK_DESTINATION_REACHABLE: CHECK_ITEM_NERWORK,
 		playbook.CHECK_NETWORK_FIREWALL:      CHECK_ITEM_NERWORK,
+		playbook.MEASURE_NETWORK_QUALITY:     CHECK_ITEM_NERWORK,
+		playbook.CHECK_NETWORK_QUALITY:       CHECK_ITEM_NERWORK,
 		playbook.GET_HOST_DATE:               CHECK_ITEM_DATE,
 		playbook.CHECK_HOST_DATE:             CHECK_ITEM_DATE,
 		playbook.CHECK_CHUNKFILE_POOL:        CHECK_ITEM_SERVICE,
 		playbook.CHECK_S3:                    CHECK_ITEM_SERVICE,
+		playbook.CHECK_DISK:                  CHECK_ITEM_DISK,
+		playbook.CHECK_SHARED_DISK:           CHECK_ITEM_DISK,
+		playbook.CHECK_HOST_RESOURCE:         CHECK_ITEM_RESOURCE,
 	}
 
 	CHECK_ITEMS = []string{
@@ -110,12 +129,15 @@ var (
 		CHECK_ITEM_NERWORK,
 		CHECK_ITEM_DATE,
 		CHECK_ITEM_SERVICE,
+		CHECK_ITEM_DISK,
+		CHECK_ITEM_RESOURCE,
 	}
 )
 
 type precheckOptions struct {
 	skipSnapshotClone bool
 	skip              []string
+	formatting        string
 	//only              []string
 }
 
@@ -149,6 +171,7 @@ func NewPrecheckCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags := cmd.Flags()
 	usage := fmt.Sprintf("Specify skipped check item (%s)", strings.Join(CHECK_ITEMS, ","))
 	flags.StringSliceVar(&options.skip, "skip", []string{}, usage)
+	flags.StringVarP(&options.formatting, "formatting", "f", "", "Specify the configure file for formatting chunkfile pool")
 	//flags.StringSliceVar(&options.only, "only", CHECK_ITEMS, usage)
 
 	return cmd
@@ -169,6 +192,15 @@ func skipPrecheckSteps(precheckSteps []int, options precheckOptions) []int {
 func genPrecheckPlaybook(curveadm *cli.CurveAdm,
 	dcs []*topology.DeployConfig,
 	options precheckOptions) (*playbook.Playbook, error) {
+	var fcs []*configure.FormatConfig
+	if len(options.formatting) > 0 {
+		var err error
+		fcs, err = configure.ParseFormat(options.formatting)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	kind := dcs[0].GetKind()
 	steps := CURVEFS_PRECHECK_STEPS
 	if kind == topology.KIND_CURVEBS {
@@ -186,12 +218,20 @@ func genPrecheckPlaybook(curveadm *cli.CurveAdm,
 		case playbook.CHECK_KERNEL_VERSION:
 			// TODO:
 			configs = curveadm.FilterDeployConfigByRole(dcs, ROLE_CHUNKSERVER)
-		case playbook.CHECK_HOST_DATE:
+		case playbook.CHECK_HOST_DATE,
+			playbook.CHECK_NETWORK_QUALITY,
+			playbook.CHECK_SHARED_DISK:
 			configs = configs[:1]
 		case playbook.CHECK_CHUNKFILE_POOL:
 			configs = curveadm.FilterDeployConfigByRole(dcs, ROLE_CHUNKSERVER)
 		}
 
+		// measure one host at a time, otherwise the throughput tests compete for bandwidth
+		var concurrency uint
+		if step == playbook.MEASURE_NETWORK_QUALITY {
+			concurrency = 1
+		}
+
 		pb.AddStep(&playbook.PlaybookStep{
 			Type:    step,
 			Configs: configs,
@@ -199,9 +239,13 @@ func genPrecheckPlaybook(curveadm *cli.CurveAdm,
 				comm.KEY_ALL_DEPLOY_CONFIGS:       dcs,
 				comm.KEY_CHECK_WITH_WEAK:          false,
 				comm.KEY_CHECK_SKIP_SNAPSHOECLONE: options.skipSnapshotClone,
+				comm.KEY_CHECK_FORMAT_CONFIGS:     fcs,
 			},
 			ExecOptions: playbook.ExecOptions{
-				SilentSubBar: step == playbook.CHECK_HOST_DATE,
+				Concurrency: concurrency,
+				SilentSubBar: step == playbook.CHECK_HOST_DATE ||
+					step == playbook.CHECK_NETWORK_QUALITY ||
+					step == playbook.CHECK_SHARED_DISK,
 			},
 		})
 	}
@@ -240,8 +284,24 @@ func runPrecheck(curveadm *cli.CurveAdm, options precheckOptions) error {
 		return err
 	}
 
-	// 4) print success prompt
+	// 4) print warnings and success prompt
 	curveadm.WriteOutln("")
+	warnings := checker.GetWarnings(curveadm)
+	for _, w := range warnings {
+		source := fmt.Sprintf("host=%s", w.Host)
+		if len(w.Role) > 0 {
+			source = fmt.Sprintf("%s role=%s", source, w.Role)
+		}
+		curveadm.WriteOutln("%s", color.YellowString("WARNING: %s: %s", source, w.Message))
+		if len(w.Hint) > 0 {
+			curveadm.WriteOutln("  hint: %s", w.Hint)
+		}
+	}
+	if len(warnings) > 0 {
+		curveadm.WriteOutln("")
+		curveadm.WriteOutln(color.GreenString("All precheck passed with %d warning(s)", len(warnings)))
+		return nil
+	}
 	curveadm.WriteOutln(color.GreenString("Congratulations!!! all precheck passed :)"))
 	return nil
 }
@@ -0,0 +1,133 @@
+package command
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/cli/cli/clitest"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/task/checker"
+	"github.com/opencurve/curveadm/pkg/module/moduletest"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	// size=200GiB avail=150GiB
+	DISK_FREE = `Filesystem     Type  1K-blocks     Avail   Inodes    IFree Mounted on
+/dev/sda1      ext4  209715200 157286400 13107200 13000000 /`
+	MEMINFO = `MemTotal:       16318480 kB
+MemFree:         8159240 kB
+SwapTotal:             0 kB
+`
+)
+
+var (
+	// only check disk and resource
+	precheckSkipped = []string{
+		CHECK_ITEM_TOPOLOGY,
+		CHECK_ITEM_SSH,
+		CHECK_ITEM_PERMISSION,
+		CHECK_ITEM_KERNEL,
+		CHECK_ITEM_NERWORK,
+		CHECK_ITEM_DATE,
+		CHECK_ITEM_SERVICE,
+	}
+)
+
+func newPrecheckEnv(t *testing.T, instances int) *clitest.Env {
+	env := clitest.New(t, HOSTS)
+	env.AddCluster("c1", fmt.Sprintf(TOPOLOGY, IMAGE, instances))
+
+	e := env.Executor
+	e.On(`df --output=`, moduletest.Reply(DISK_FREE))
+	e.On(`lsblk --output=PKNAME,TYPE`, moduletest.Reply("sda part"))
+	e.On(`nproc`, moduletest.Reply("8\n"))
+	e.On(`ulimit -n`, moduletest.Reply("1048576\n"))
+	for _, host := range []string{"10.0.1.1", "10.0.1.2", "10.0.1.3"} {
+		e.WriteFile(host, "/proc/mounts", "/dev/sda1 / ext4 rw,relatime 0 0\n")
+		e.WriteFile(host, "/proc/meminfo", MEMINFO)
+		e.WriteFile(host, "/proc/sys/vm/swappiness", "60\n")
+		e.WriteFile(host, "/proc/sys/fs/file-max", "9223372036854775807\n")
+		e.WriteFile(host, "/sys/kernel/mm/transparent_hugepage/enabled", "always [madvise] never\n")
+	}
+	return env
+}
+
+func TestPrecheck_DiskAndResource(t *testing.T) {
+	assert := assert.New(t)
+	env := newPrecheckEnv(t, 1)
+	env.Executor.WriteFile("10.0.1.2", "/sys/kernel/mm/transparent_hugepage/enabled",
+		"[always] madvise never\n")
+
+	err := runPrecheck(env.CurveAdm, precheckOptions{skip: precheckSkipped})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`nproc`), 3) // once per host
+
+	warnings := checker.GetWarnings(env.CurveAdm)
+	assert.Len(warnings, 1)
+	assert.Equal("server-host2", warnings[0].Host)
+	assert.Contains(warnings[0].Message, "transparent hugepage")
+}
+
+func TestPrecheck_NoEnoughMemory(t *testing.T) {
+	assert := assert.New(t)
+	env := newPrecheckEnv(t, 1)
+	env.Executor.WriteFile("10.0.1.3", "/proc/meminfo", "MemTotal: 4194304 kB\n")
+
+	err := runPrecheck(env.CurveAdm, precheckOptions{skip: precheckSkipped})
+	assert.NotNil(err)
+	assert.Equal(errno.ERR_HOST_NO_ENOUGH_MEMORY.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
+
+func TestPrecheck_SharedDisk(t *testing.T) {
+	assert := assert.New(t)
+	env := newPrecheckEnv(t, 2)
+
+	// (1) data directories not mounted, they resolve to the root filesystem
+	err := runPrecheck(env.CurveAdm, precheckOptions{skip: precheckSkipped})
+	assert.Nil(err, env.Dump())
+
+	// (2) data directories mounted from partitions of the same disk
+	env.Executor.On(`df --output=.* /data/chunkserver\d+`, func(cmd moduletest.Command) (string, error) {
+		dir := regexp.MustCompile(`/data/chunkserver\d+`).FindString(cmd.Command)
+		part := map[string]string{"/data/chunkserver0": "/dev/sda2", "/data/chunkserver1": "/dev/sda3"}[dir]
+		return strings.NewReplacer("/dev/sda1", part, " /", " "+dir).Replace(DISK_FREE), nil
+	})
+	err = runPrecheck(env.CurveAdm, precheckOptions{skip: precheckSkipped})
+	assert.NotNil(err)
+	assert.Equal(errno.ERR_CHUNKSERVER_INSTANCES_SHARE_DISK.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
+
+const (
+	PING_OUTPUT = `5 packets transmitted, 5 received, 0% packet loss, time 803ms
+rtt min/avg/max/mdev = 0.045/0.060/0.081/0.013 ms`
+)
+
+func TestPrecheck_NetworkQuality(t *testing.T) {
+	assert := assert.New(t)
+	env := newPrecheckEnv(t, 1)
+	skip := []string{}
+	for _, item := range precheckSkipped {
+		if item != CHECK_ITEM_NERWORK {
+			skip = append(skip, item)
+		}
+	}
+	skip = append(skip, CHECK_ITEM_DISK, CHECK_ITEM_RESOURCE)
+
+	e := env.Executor
+	e.On(`ping `, moduletest.Reply(PING_OUTPUT))
+	e.On(`ss `, moduletest.Reply(""))
+	e.On(`ip -o addr show to`, moduletest.Reply("2: eth0    inet 10.0.1.1/24 scope global eth0"))
+	e.On(`curl `, moduletest.Reply("125000000")) // 1000Mbit/s
+	for _, host := range []string{"10.0.1.1", "10.0.1.2", "10.0.1.3"} {
+		e.WriteFile(host, "/sys/class/net/eth0/mtu", "1500\n")
+	}
+
+	err := runPrecheck(env.CurveAdm, precheckOptions{skip: skip})
+	assert.Nil(err, env.Dump())
+	// throughput is measured by curl in curve image, 3 hosts * 2 peers
+	assert.Len(e.Grep(`docker exec .*curl .*/payload`), 6, env.Dump())
+	assert.Len(e.Grep(`^curl .*/payload`), 0)
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/monitor"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/configure/topology"
@@ -420,6 +421,7 @@ func runScaleOut(curveadm *cli.CurveAdm, options scaleOutOptions) error {
 	if err = pb.Run(); err != nil {
 		return err
 	}
+	monitor.SyncTarget(curveadm)
 
 	// 9) print success prompt
 	curveadm.WriteOutln("")
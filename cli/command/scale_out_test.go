@@ -0,0 +1,45 @@
+package command
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaleOut(t *testing.T) {
+	assert := assert.New(t)
+	env := newDeployedEnv(t)
+	filename := env.WriteFile("topology.yaml", fmt.Sprintf(TOPOLOGY, IMAGE, 2))
+
+	env.Answer("yes")
+	err := runScaleOut(env.CurveAdm, scaleOutOptions{
+		insecure:        true,
+		filename:        filename,
+		poolset:         "default",
+		poolsetDiskType: "ssd",
+	})
+	assert.Nil(err, env.Dump())
+
+	// (1) command sequence: only new chunkservers are created
+	env.AssertOrder(
+		`etcdctl .*snapshot save`,
+		`docker create .*--role chunkserver`,
+		`create_physicalpool`,
+		`docker start`,
+		`create_logicalpool`,
+	)
+	assert.Len(env.Executor.Grep(`docker create`), 3)
+	assert.Len(env.Executor.Grep(`--role (etcd|mds)`), 0)
+
+	// (2) new services are recorded and the topology is updated
+	env.Reload()
+	curveadm := env.CurveAdm
+	services, err := curveadm.Storage().GetServices(curveadm.ClusterId())
+	assert.Nil(err)
+	assert.Len(services, 12)
+	dcs, err := curveadm.ParseTopology()
+	assert.Nil(err)
+	assert.Len(curveadm.FilterDeployConfigByRole(dcs, ROLE_CHUNKSERVER), 6)
+	assert.NotEmpty(curveadm.ClusterPoolData())
+}
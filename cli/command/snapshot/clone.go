@@ -0,0 +1,94 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package snapshot
+
+import (
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/client"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type cloneOptions struct {
+	source  string
+	image   string
+	lazy    bool
+	noWait  bool
+	timeout time.Duration
+}
+
+func NewCloneCreateCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options cloneOptions
+
+	cmd := &cobra.Command{
+		Use:   "create SOURCE USER:VOLUME [OPTIONS]",
+		Short: "Clone volume from snapshot (UUID) or another volume",
+		Args:  cliutil.ExactArgs(2),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			_, _, err := client.ParseImage(args[1])
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.source = args[0]
+			options.image = args[1]
+			return runClone(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&options.lazy, "lazy", false, "Lazy clone, the volume is available once its metadata installed")
+	flags.BoolVar(&options.noWait, "no-wait", false, "Return immediately without waiting for the clone to be done")
+	flags.DurationVar(&options.timeout, "timeout", DEFAULT_WAIT_TIMEOUT, "Specify how long to wait for the clone to be done, 0 means no limit")
+
+	return cmd
+}
+
+func runClone(curveadm *cli.CurveAdm, options cloneOptions) error {
+	// 1) clone volume
+	user, volume, _ := client.ParseImage(options.image)
+	uuid, err := runSnapshotOperation(curveadm, bs.SnapshotOptions{
+		Action:  bs.SNAPSHOT_ACTION_CLONE,
+		User:    user,
+		Volume:  volume,
+		Source:  options.source,
+		Lazy:    options.lazy,
+		Timeout: options.timeout,
+	}, !options.noWait, false)
+	if err != nil {
+		return err
+	}
+
+	// 2) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Clone volume %s from %s (uuid=%s) success ^_^"),
+		options.image, options.source, uuid)
+	if options.lazy {
+		curveadm.WriteOutln("Run 'curveadm clone flatten %s' to copy all data from source", options.image)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package snapshot
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type cloneListOptions struct {
+	user   string
+	volume string
+	format string
+}
+
+func NewCloneListCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options cloneListOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls USER [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List clone tasks",
+		Args:    cliutil.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return cli.CheckOutputFormat(options.format)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.user = args[0]
+			return runCloneList(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.volume, "volume", "", "Only list clone tasks of the destination volume")
+	flags.StringVar(&options.format, "format", cli.FORMAT_TABLE, "Output format (table/json)")
+
+	return cmd
+}
+
+func runCloneList(curveadm *cli.CurveAdm, options cloneListOptions) error {
+	// 1) list clone tasks
+	_, err := runSnapshotOperation(curveadm, bs.SnapshotOptions{
+		Action: bs.SNAPSHOT_ACTION_LIST_CLONES,
+		User:   options.user,
+		Volume: options.volume,
+	}, false, options.format == cli.FORMAT_JSON)
+	if err != nil {
+		return err
+	}
+
+	// 2) display clone tasks
+	tasks := []bs.CloneTask{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_CLONE_TASKS); v != nil {
+		tasks = v.([]bs.CloneTask)
+	}
+	return displayCloneTasks(curveadm, tasks, options.format)
+}
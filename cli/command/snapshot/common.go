@@ -0,0 +1,129 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package snapshot
+
+import (
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/internal/tui"
+)
+
+const (
+	DEFAULT_WAIT_TIMEOUT = time.Hour
+)
+
+// requests are sent to the nginx proxy in a running snapshotclone container,
+// which forwards them to the leader snapshotclone server
+func getSnapshotCloneService(curveadm *cli.CurveAdm) (*topology.DeployConfig, error) {
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return nil, err
+	} else if len(dcs) == 0 || dcs[0].GetKind() != topology.KIND_CURVEBS {
+		return nil, errno.ERR_REQUIRE_CURVEBS_CLUSTER
+	}
+
+	services := []*topology.DeployConfig{}
+	for _, dc := range curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_SNAPSHOTCLONE) {
+		if !curveadm.IsSkip(dc) {
+			services = append(services, dc)
+		}
+	}
+	if len(services) == 0 {
+		return nil, errno.ERR_NO_SNAPSHOTCLONE_SERVICE
+	}
+	return playbook.AttachLeaderOrRandom(curveadm, services)
+}
+
+func genSnapshotPlaybook(curveadm *cli.CurveAdm,
+	dc *topology.DeployConfig,
+	options bs.SnapshotOptions,
+	wait, silent bool) *playbook.Playbook {
+	steps := []int{playbook.REQUEST_SNAPSHOTCLONE}
+	if wait {
+		steps = append(steps, playbook.WAIT_SNAPSHOTCLONE_TASK)
+	}
+
+	pb := playbook.NewPlaybook(curveadm)
+	for _, step := range steps {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: []*topology.DeployConfig{dc},
+			Options: map[string]interface{}{
+				comm.KEY_SNAPSHOT_OPTIONS: options,
+			},
+			ExecOptions: playbook.ExecOptions{
+				SilentSubBar:  silent,
+				SilentMainBar: silent,
+			},
+		})
+	}
+	return pb
+}
+
+// returns the uuid of snapshot or clone task
+func runSnapshotOperation(curveadm *cli.CurveAdm,
+	options bs.SnapshotOptions, wait, silent bool) (string, error) {
+	// 1) find the snapshotclone service
+	dc, err := getSnapshotCloneService(curveadm)
+	if err != nil {
+		return "", err
+	}
+
+	// 2) generate and run snapshot playbook
+	pb := genSnapshotPlaybook(curveadm, dc, options, wait, silent)
+	err = pb.Run()
+	if err != nil {
+		return "", err
+	}
+
+	// 3) return uuid of snapshot or clone task
+	uuid := ""
+	if v := curveadm.MemStorage().Get(comm.KEY_SNAPSHOT_TASK_UUID); v != nil {
+		uuid = v.(string)
+	}
+	return uuid, nil
+}
+
+func displaySnapshots(curveadm *cli.CurveAdm, snapshots []bs.Snapshot, format string) error {
+	if format == cli.FORMAT_JSON {
+		return curveadm.WriteOutJSON(snapshots, errno.ERR_ENCODE_SNAPSHOT_TO_JSON_FAILED)
+	}
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(tui.FormatSnapshots(snapshots))
+	return nil
+}
+
+func displayCloneTasks(curveadm *cli.CurveAdm, tasks []bs.CloneTask, format string) error {
+	if format == cli.FORMAT_JSON {
+		return curveadm.WriteOutJSON(tasks, errno.ERR_ENCODE_SNAPSHOT_TO_JSON_FAILED)
+	}
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(tui.FormatCloneTasks(tasks))
+	return nil
+}
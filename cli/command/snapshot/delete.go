@@ -0,0 +1,88 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package snapshot
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/client"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type deleteOptions struct {
+	image string
+	uuid  string
+}
+
+func NewDeleteCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options deleteOptions
+
+	cmd := &cobra.Command{
+		Use:     "delete USER:VOLUME UUID",
+		Aliases: []string{"rm"},
+		Short:   "Delete snapshot",
+		Args:    cliutil.ExactArgs(2),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			_, _, err := client.ParseImage(args[0])
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			options.uuid = args[1]
+			return runDelete(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+func runDelete(curveadm *cli.CurveAdm, options deleteOptions) error {
+	// 1) confirm by user
+	if pass := tui.ConfirmYes(tui.PromptDeleteSnapshot(options.image, options.uuid)); !pass {
+		curveadm.WriteOut(tui.PromptCancelOpetation("delete snapshot"))
+		return errno.ERR_CANCEL_OPERATION
+	}
+
+	// 2) delete snapshot
+	user, volume, _ := client.ParseImage(options.image)
+	_, err := runSnapshotOperation(curveadm, bs.SnapshotOptions{
+		Action: bs.SNAPSHOT_ACTION_DELETE,
+		User:   user,
+		Volume: volume,
+		UUID:   options.uuid,
+	}, false, false)
+	if err != nil {
+		return err
+	}
+
+	// 3) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Delete snapshot %s of volume %s success ^_^"),
+		options.uuid, options.image)
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package snapshot
+
+import (
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/client"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type flattenOptions struct {
+	image   string
+	noWait  bool
+	timeout time.Duration
+}
+
+func NewFlattenCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options flattenOptions
+
+	cmd := &cobra.Command{
+		Use:   "flatten USER:VOLUME [OPTIONS]",
+		Short: "Flatten lazy cloned volume",
+		Args:  cliutil.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			_, _, err := client.ParseImage(args[0])
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			return runFlatten(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&options.noWait, "no-wait", false, "Return immediately without waiting for the flatten to be done")
+	flags.DurationVar(&options.timeout, "timeout", DEFAULT_WAIT_TIMEOUT, "Specify how long to wait for the flatten to be done, 0 means no limit")
+
+	return cmd
+}
+
+func runFlatten(curveadm *cli.CurveAdm, options flattenOptions) error {
+	// 1) flatten volume
+	user, volume, _ := client.ParseImage(options.image)
+	_, err := runSnapshotOperation(curveadm, bs.SnapshotOptions{
+		Action:  bs.SNAPSHOT_ACTION_FLATTEN,
+		User:    user,
+		Volume:  volume,
+		Timeout: options.timeout,
+	}, !options.noWait, false)
+	if err != nil {
+		return err
+	}
+
+	// 2) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Flatten volume %s success ^_^"), options.image)
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package snapshot
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	user   string
+	volume string
+	format string
+}
+
+func NewListCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options listOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls USER [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List snapshots",
+		Args:    cliutil.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return cli.CheckOutputFormat(options.format)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.user = args[0]
+			return runList(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.volume, "volume", "", "Only list snapshots of the volume")
+	flags.StringVar(&options.format, "format", cli.FORMAT_TABLE, "Output format (table/json)")
+
+	return cmd
+}
+
+func runList(curveadm *cli.CurveAdm, options listOptions) error {
+	// 1) list snapshots
+	_, err := runSnapshotOperation(curveadm, bs.SnapshotOptions{
+		Action: bs.SNAPSHOT_ACTION_LIST,
+		User:   options.user,
+		Volume: options.volume,
+	}, false, options.format == cli.FORMAT_JSON)
+	if err != nil {
+		return err
+	}
+
+	// 2) display snapshots
+	snapshots := []bs.Snapshot{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_SNAPSHOTS); v != nil {
+		snapshots = v.([]bs.Snapshot)
+	}
+	return displaySnapshots(curveadm, snapshots, options.format)
+}
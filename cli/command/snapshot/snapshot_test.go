@@ -0,0 +1,207 @@
+package snapshot
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/cli/clitest"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/pkg/module/moduletest"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	HOSTS = `
+global:
+  user: curve
+  ssh_port: 22
+  private_key_file: {{.PrivateKeyFile}}
+hosts:
+  - host: server-host1
+    hostname: 10.0.1.1
+  - host: server-host2
+    hostname: 10.0.1.2
+`
+
+	TOPOLOGY = `
+kind: curvebs
+global:
+  container_image: opencurvedocker/curvebs:v1.2
+  log_dir: /tmp/logs/${service_role}
+  data_dir: /tmp/data/${service_role}
+
+mds_services:
+  deploy:
+    - host: server-host1
+
+snapshotclone_services:
+  config:
+    listen.proxy_port: 8080
+  deploy:
+    - host: server-host2
+`
+
+	SNAPSHOTCLONE_HOST = "10.0.1.2"
+
+	SUCCESS_OUTPUT   = `{"Code": "0", "Message": "Exec success.", "UUID": "%s"}`
+	SNAPSHOTS_OUTPUT = `{"Code": "0", "Message": "Exec success.", "TotalCount": 2, "Snapshots": [
+  {"UUID": "snap1", "User": "curve", "File": "/vol1", "Name": "s1", "FileLength": 10737418240, "Status": 0, "Progress": 100, "Time": 1700000000000000},
+  {"UUID": "snap2", "User": "curve", "File": "/vol1", "Name": "s2", "FileLength": 10737418240, "Status": 1, "Progress": 30, "Time": 1700000001000000}
+]}`
+	CLONE_TASKS_OUTPUT = `{"Code": "0", "Message": "Exec success.", "TotalCount": 1, "TaskInfos": [
+  {"UUID": "clone1", "User": "curve", "File": "/vol2", "Src": "snap1", "IsLazy": true, "TaskType": 0, "TaskStatus": %d, "Progress": 50, "Time": 1700000002000000}
+]}`
+)
+
+func reply(format string, a ...interface{}) moduletest.Handler {
+	return moduletest.Reply(fmt.Sprintf(format, a...))
+}
+
+// all services are running
+func newSnapshotEnv(t *testing.T) *clitest.Env {
+	env := clitest.New(t, HOSTS)
+	env.AddCluster("c1", TOPOLOGY)
+
+	curveadm := env.CurveAdm
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		t.Fatalf("parse topology: %v", err)
+	}
+	for _, dc := range dcs {
+		out, _ := env.Executor.Run(dc.GetListenIp(), "docker run --name "+dc.GetRole()+" opencurvedocker/curvebs:v1.2")
+		serviceId := curveadm.GetServiceId(dc.GetId())
+		err := curveadm.Storage().InsertService(curveadm.ClusterId(), serviceId, strings.TrimSpace(out))
+		if err != nil {
+			t.Fatalf("insert service: %v", err)
+		}
+	}
+	env.Executor.On(`Action=GetFileSnapshotList`, reply(SNAPSHOTS_OUTPUT))
+	env.Executor.Reset()
+	return env
+}
+
+func TestSnapshot_Create(t *testing.T) {
+	assert := assert.New(t)
+	env := newSnapshotEnv(t)
+	env.Executor.On(`Action=CreateSnapshot`, reply(SUCCESS_OUTPUT, "snap1"))
+
+	err := runCreate(env.CurveAdm, createOptions{image: "curve:/vol1", name: "s1"})
+	assert.Nil(err, env.Dump())
+	cmds := env.Executor.Grep(`curl .*http://10.0.1.2:8080/SnapshotCloneService\?Action=CreateSnapshot&File=%2Fvol1&Name=s1&User=curve&Version=0.0.6`)
+	assert.Len(cmds, 1)
+	assert.Equal(SNAPSHOTCLONE_HOST, cmds[0].Host)
+	assert.True(env.Executor.Index(`Action=CreateSnapshot`) < env.Executor.Index(`Action=GetFileSnapshotList.*UUID=snap1`))
+}
+
+func TestSnapshot_CreateFailed(t *testing.T) {
+	assert := assert.New(t)
+	env := newSnapshotEnv(t)
+
+	env.Executor.On(`Action=CreateSnapshot`,
+		moduletest.Reply(`{"Code": "-8", "Message": "File not exist."}`))
+	err := runCreate(env.CurveAdm, createOptions{image: "curve:/vol3", name: "s1"})
+	assert.Equal(errno.ERR_CREATE_SNAPSHOT_FAILED.GetCode(), err.(*errno.ErrorCode).GetCode())
+
+	// snapshot task turns into error
+	env.Executor.On(`Action=CreateSnapshot`, reply(SUCCESS_OUTPUT, "snap3"))
+	env.Executor.On(`Action=GetFileSnapshotList`, moduletest.Reply(
+		`{"Code": "0", "TotalCount": 1, "Snapshots": [{"UUID": "snap3", "Status": 5}]}`))
+	err = runCreate(env.CurveAdm, createOptions{image: "curve:/vol1", name: "s3"})
+	assert.Equal(errno.ERR_SNAPSHOT_TASK_FAILED.GetCode(), err.(*errno.ErrorCode).GetCode())
+
+	// no wait
+	env.Executor.Reset()
+	err = runCreate(env.CurveAdm, createOptions{image: "curve:/vol1", name: "s3", noWait: true})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`Action=GetFileSnapshotList`), 0)
+}
+
+func TestSnapshot_List(t *testing.T) {
+	assert := assert.New(t)
+	env := newSnapshotEnv(t)
+
+	err := runList(env.CurveAdm, listOptions{user: "curve", volume: "/vol1", format: cli.FORMAT_JSON})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`Action=GetFileSnapshotList&File=%2Fvol1&Limit=100&Offset=0&User=curve`), 1)
+
+	snapshots := env.CurveAdm.MemStorage().Get(comm.KEY_ALL_SNAPSHOTS).([]bs.Snapshot)
+	assert.Len(snapshots, 2)
+	assert.Equal("done", snapshots[0].Status)
+	assert.Equal("pending", snapshots[1].Status)
+	assert.Equal(30, snapshots[1].Progress)
+}
+
+func TestSnapshot_Delete(t *testing.T) {
+	assert := assert.New(t)
+	env := newSnapshotEnv(t)
+	env.Executor.On(`Action=DeleteSnapshot`, reply(SUCCESS_OUTPUT, ""))
+
+	env.Answer("no")
+	err := runDelete(env.CurveAdm, deleteOptions{image: "curve:/vol1", uuid: "snap1"})
+	assert.Equal(errno.ERR_CANCEL_OPERATION, err)
+	assert.Len(env.Executor.Grep(`Action=DeleteSnapshot`), 0)
+
+	env.Answer("yes")
+	err = runDelete(env.CurveAdm, deleteOptions{image: "curve:/vol1", uuid: "snap1"})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`Action=DeleteSnapshot&File=%2Fvol1&UUID=snap1&User=curve`), 1)
+}
+
+func TestClone_LazyAndFlatten(t *testing.T) {
+	assert := assert.New(t)
+	env := newSnapshotEnv(t)
+	env.Executor.On(`Action=Clone`, reply(SUCCESS_OUTPUT, "clone1"))
+	env.Executor.On(`Action=Flatten`, reply(SUCCESS_OUTPUT, ""))
+
+	// lazy clone is done once its metadata installed
+	env.Executor.On(`Action=GetCloneTaskList`, reply(CLONE_TASKS_OUTPUT, bs.CLONE_STATUS_META_INSTALLED))
+	err := runClone(env.CurveAdm, cloneOptions{source: "snap1", image: "curve:/vol2", lazy: true})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`Action=Clone&Destination=%2Fvol2&Lazy=true&Source=snap1&User=curve`), 1)
+
+	// flatten the clone task of volume
+	env.Executor.Reset()
+	env.Executor.On(`Action=GetCloneTaskList.*UUID=clone1`, reply(CLONE_TASKS_OUTPUT, bs.CLONE_STATUS_DONE))
+	err = runFlatten(env.CurveAdm, flattenOptions{image: "curve:/vol2"})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`Action=GetCloneTaskList&File=%2Fvol2`), 1)
+	assert.Len(env.Executor.Grep(`Action=Flatten&UUID=clone1&User=curve`), 1)
+
+	// clone task failed
+	env.Executor.On(`Action=GetCloneTaskList`, reply(CLONE_TASKS_OUTPUT, bs.CLONE_STATUS_ERROR))
+	err = runClone(env.CurveAdm, cloneOptions{source: "snap1", image: "curve:/vol2"})
+	assert.Equal(errno.ERR_CLONE_TASK_FAILED.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
+
+func TestSnapshot_NoSnapshotCloneService(t *testing.T) {
+	assert := assert.New(t)
+	env := clitest.New(t, HOSTS)
+	env.AddCluster("c1", TOPOLOGY)
+
+	// snapshotclone service skipped while deploying
+	curveadm := env.CurveAdm
+	dcs, _ := curveadm.ParseTopology()
+	for _, dc := range dcs {
+		curveadm.Storage().InsertService(curveadm.ClusterId(), curveadm.GetServiceId(dc.GetId()), "")
+	}
+	err := runList(curveadm, listOptions{user: "curve", format: cli.FORMAT_TABLE})
+	assert.Equal(errno.ERR_NO_SNAPSHOTCLONE_SERVICE, err)
+}
+
+func TestSnapshot_WaitTimeout(t *testing.T) {
+	assert := assert.New(t)
+	env := newSnapshotEnv(t)
+	env.Executor.On(`Action=CreateSnapshot`, reply(SUCCESS_OUTPUT, "snap2"))
+
+	// snap2 is still pending with 30% progress
+	err := runCreate(env.CurveAdm, createOptions{image: "curve:/vol1", name: "s2", timeout: time.Nanosecond})
+	assert.NotNil(err)
+	assert.Equal(errno.ERR_WAIT_SNAPSHOT_TASK_TIMEOUT.GetCode(), err.(*errno.ErrorCode).GetCode())
+	assert.Contains(err.(*errno.ErrorCode).GetClue(), "progress=30%")
+	assert.Len(env.Executor.Grep(`Action=GetFileSnapshotList.*UUID=snap2`), 1)
+}
@@ -26,7 +26,12 @@ package command
 
 import (
 	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
@@ -40,6 +45,17 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const (
+	STATUS_EXAMPLE = `Examples:
+  $ curveadm status                # Display service status
+  $ curveadm status --watch        # Refresh service status every 2 seconds
+  $ curveadm status --watch 10s    # Refresh service status every 10 seconds`
+
+	DEFAULT_WATCH_INTERVAL = "2s"
+	// move cursor to top-left and clear screen
+	ANSI_CLEAR_SCREEN = "\033[H\033[2J"
+)
+
 var (
 	GET_STATUS_PLAYBOOK_STEPS = []int{
 		playbook.INIT_SERVIE_STATUS,
@@ -53,15 +69,46 @@ type statusOptions struct {
 	host          string
 	verbose       bool
 	showInstances bool
+	watch         string
+	interval      time.Duration
+}
+
+// interval can be a duration (5s, 1m) or seconds (5)
+func parseRefreshInterval(interval string) (time.Duration, error) {
+	if n, err := strconv.Atoi(interval); err == nil {
+		interval = fmt.Sprintf("%ds", n)
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil || d <= 0 {
+		return 0, errno.ERR_INVALID_REFRESH_INTERVAL.
+			F("interval: %s", interval)
+	}
+	return d, nil
+}
+
+func checkStatusOptions(cmd *cobra.Command, args []string, options *statusOptions) error {
+	if len(options.watch) == 0 { // status [OPTIONS]
+		return cliutil.NoArgs(cmd, args)
+	} else if len(args) > 0 { // status --watch INTERVAL
+		options.watch = args[0]
+	}
+
+	interval, err := parseRefreshInterval(options.watch)
+	options.interval = interval
+	return err
 }
 
 func NewStatusCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options statusOptions
 
 	cmd := &cobra.Command{
-		Use:   "status [OPTIONS]",
-		Short: "Display service status",
-		Args:  cliutil.NoArgs,
+		Use:     "status [OPTIONS] [--watch [INTERVAL]]",
+		Short:   "Display service status",
+		Args:    cliutil.RequiresMaxArgs(1),
+		Example: STATUS_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkStatusOptions(cmd, args, &options)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runStatus(curveadm, options)
 		},
@@ -74,6 +121,8 @@ func NewStatusCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	flags.StringVar(&options.host, "host", "*", "Specify service host")
 	flags.BoolVarP(&options.verbose, "verbose", "v", false, "Verbose output for status")
 	flags.BoolVarP(&options.showInstances, "show-instances", "s", false, "Display service num")
+	flags.StringVarP(&options.watch, "watch", "w", "", "Refresh status periodically until interrupted")
+	flags.Lookup("watch").NoOptDefVal = DEFAULT_WATCH_INTERVAL
 
 	return cmd
 }
@@ -144,7 +193,7 @@ func genStatusPlaybook(curveadm *cli.CurveAdm,
 			ExecOptions: playbook.ExecOptions{
 				//Concurrency:   10,
 				SilentSubBar:  true,
-				SilentMainBar: step == playbook.INIT_SERVIE_STATUS,
+				SilentMainBar: step == playbook.INIT_SERVIE_STATUS || options.interval > 0,
 				SkipError:     true,
 			},
 		})
@@ -152,6 +201,33 @@ func genStatusPlaybook(curveadm *cli.CurveAdm,
 	return pb, nil
 }
 
+// refresh status in place until interrupted
+func watchStatus(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig,
+	options statusOptions,
+	signals <-chan os.Signal) error {
+	ticker := time.NewTicker(options.interval)
+	defer ticker.Stop()
+	for {
+		pb, err := genStatusPlaybook(curveadm, dcs, options)
+		if err != nil {
+			return err
+		}
+
+		pb.Run() // services which failed to get status will be displayed as unknown
+		curveadm.WriteOut(ANSI_CLEAR_SCREEN)
+		curveadm.WriteOutln("Every %s: curveadm status    %s",
+			options.interval, time.Now().Format("2006-01-02 15:04:05"))
+		displayStatus(curveadm, dcs, options)
+
+		select {
+		case <-signals:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
 func runStatus(curveadm *cli.CurveAdm, options statusOptions) error {
 	// 1) parse cluster topology
 	dcs, err := curveadm.ParseTopology()
@@ -159,16 +235,24 @@ func runStatus(curveadm *cli.CurveAdm, options statusOptions) error {
 		return err
 	}
 
-	// 2) generate get status playbook
+	// 2) refresh status until interrupted if watch enabled
+	if options.interval > 0 {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(signals)
+		return watchStatus(curveadm, dcs, options, signals)
+	}
+
+	// 3) generate get status playbook
 	pb, err := genStatusPlaybook(curveadm, dcs, options)
 	if err != nil {
 		return err
 	}
 
-	// 3) run playground
+	// 4) run playground
 	err = pb.Run()
 
-	// 4) display service status
+	// 5) display service status
 	displayStatus(curveadm, dcs, options)
 	return err
 }
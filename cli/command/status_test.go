@@ -0,0 +1,61 @@
+package command
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRefreshInterval(t *testing.T) {
+	assert := assert.New(t)
+	for interval, expect := range map[string]time.Duration{
+		"5":   5 * time.Second,
+		"2s":  2 * time.Second,
+		"1m":  time.Minute,
+		"1.5": 0,
+		"0":   0,
+		"-1s": 0,
+		"abc": 0,
+	} {
+		d, err := parseRefreshInterval(interval)
+		if expect == 0 {
+			assert.Equal(errno.ERR_INVALID_REFRESH_INTERVAL.GetCode(), err.(*errno.ErrorCode).GetCode(), interval)
+		} else {
+			assert.Nil(err, interval)
+			assert.Equal(expect, d, interval)
+		}
+	}
+}
+
+func TestStatusWatch(t *testing.T) {
+	assert := assert.New(t)
+	env := newDeployedEnv(t)
+	curveadm := env.CurveAdm
+	dcs, err := curveadm.ParseTopology()
+	assert.Nil(err)
+
+	// (1) --watch INTERVAL
+	cmd := NewStatusCommand(curveadm)
+	options := statusOptions{watch: DEFAULT_WATCH_INTERVAL}
+	assert.Nil(checkStatusOptions(cmd, []string{"10"}, &options))
+	assert.Equal(10*time.Second, options.interval)
+	assert.NotNil(checkStatusOptions(cmd, []string{"10"}, &statusOptions{}))
+
+	// (2) refresh in place until interrupted
+	out := &bytes.Buffer{}
+	curveadm.SetOut(out)
+	signals := make(chan os.Signal, 1)
+	signals <- os.Interrupt
+	options = statusOptions{id: "*", role: "*", host: "*", interval: time.Second}
+	err = watchStatus(curveadm, dcs, options, signals)
+	assert.Nil(err, env.Dump())
+	assert.True(strings.HasPrefix(out.String(), ANSI_CLEAR_SCREEN))
+	assert.Contains(out.String(), "Every 1s: curveadm status")
+	assert.Contains(out.String(), "cluster name      : c1")
+	assert.Equal(9, strings.Count(out.String(), "Up 1 minute"), out.String())
+}
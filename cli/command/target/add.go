@@ -23,11 +23,15 @@
 package target
 
 import (
+	"net"
+	"strings"
+
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
 	"github.com/opencurve/curveadm/cli/command/client"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
@@ -35,6 +39,22 @@ import (
 	cliutil "github.com/opencurve/curveadm/internal/utils"
 	utils "github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	ADD_EXAMPLE = `Examples:
+  $ curveadm target add curve:/vol1 --host machine -c client.yaml                          # Add a target which allows all initiators
+  $ curveadm target add curve:/vol1 curve:/vol2 --host machine -c client.yaml              # Add a target with 2 LUNs
+  $ curveadm target add curve:/vol1 --host machine1,machine2 --no-exclusive -c client.yaml # Export target on 2 gateways for multipath
+  $ curveadm target add curve:/vol1 --host machine -c client.yaml --initiator 10.0.0.0/24  # Only allow initiators in 10.0.0.0/24
+  $ curveadm target add curve:/vol1 --host machine -c client.yaml --chap-file chap.yaml    # Require CHAP authentication
+
+CHAP credentials file (chap.yaml, requires 600 permissions):
+  user: initiator-user
+  password: initiator-secret
+  mutual_user: target-user          # optional, for mutual CHAP
+  mutual_password: target-secret    # optional, for mutual CHAP`
 )
 
 var (
@@ -45,16 +65,106 @@ var (
 )
 
 type addOptions struct {
-	image     string
-	host      string
-	size      string
-	create    bool
-	filename  string
-	blocksize string
+	images      []string
+	hosts       []string
+	size        string
+	create      bool
+	filename    string
+	blocksize   string
+	noExclusive bool
+	initiators  []string
+	chapFile    string
+}
+
+func parseLuns(images []string) ([]bs.TargetLun, error) {
+	luns := []bs.TargetLun{}
+	exist := map[string]bool{}
+	for _, image := range images {
+		user, name, err := client.ParseImage(image)
+		if err != nil {
+			return nil, err
+		} else if exist[user+":"+name] {
+			return nil, errno.ERR_DUPLICATE_TARGET_LUN.F("volume: %s", image)
+		}
+		exist[user+":"+name] = true
+		luns = append(luns, bs.TargetLun{User: user, Volume: name})
+	}
+	return luns, nil
+}
+
+func uniqueHosts(hosts []string) []string {
+	out := []string{}
+	exist := map[string]bool{}
+	for _, host := range hosts {
+		if !exist[host] {
+			exist[host] = true
+			out = append(out, host)
+		}
+	}
+	return out
+}
+
+// every tgtd opens the volumes, so they can't be opened exclusively
+// when target exported on multiple gateways
+func checkGateways(hosts []string, noExclusive bool) error {
+	if len(hosts) > 1 && !noExclusive {
+		return errno.ERR_MULTI_GATEWAY_TARGET_REQUIRE_NO_EXCLUSIVE.
+			F("hosts: %s", strings.Join(hosts, ","))
+	}
+	return nil
+}
+
+func checkInitiators(initiators []string) error {
+	for _, initiator := range initiators {
+		if bs.IsInitiatorName(initiator) || initiator == "ALL" {
+			continue
+		} else if net.ParseIP(initiator) != nil {
+			continue
+		} else if _, _, err := net.ParseCIDR(initiator); err == nil {
+			continue
+		}
+		return errno.ERR_INVALID_TARGET_INITIATOR.F("initiator: %s", initiator)
+	}
+	return nil
+}
+
+// credentials are sourced from file which only readable by owner,
+// rather than specified in command line
+func parseChapFile(filename string) (*bs.TargetChap, error) {
+	if len(filename) == 0 {
+		return nil, nil
+	} else if !utils.PathExist(filename) {
+		return nil, errno.ERR_CHAP_FILE_NOT_EXIST.
+			F("file path: %s", utils.AbsPath(filename))
+	} else if utils.GetFilePermissions(filename) != hosts.PERMISSIONS_600 {
+		return nil, errno.ERR_CHAP_FILE_REQUIRE_600_PERMISSIONS.
+			F("%s: mode (%d)", filename, utils.GetFilePermissions(filename))
+	}
+
+	parser := viper.New()
+	parser.SetConfigFile(filename)
+	parser.SetConfigType("yaml")
+	err := parser.ReadInConfig()
+	if err != nil {
+		return nil, errno.ERR_INVALID_CHAP_CREDENTIALS.E(err)
+	}
+	chap := &bs.TargetChap{}
+	err = parser.Unmarshal(chap)
+	if err != nil {
+		return nil, errno.ERR_INVALID_CHAP_CREDENTIALS.E(err)
+	} else if len(chap.User) == 0 || len(chap.Password) == 0 {
+		return nil, errno.ERR_INVALID_CHAP_CREDENTIALS.F("file path: %s", filename)
+	} else if (len(chap.MutualUser) == 0) != (len(chap.MutualPassword) == 0) {
+		return nil, errno.ERR_INVALID_CHAP_CREDENTIALS.
+			F("file path: %s: mutual user and password must be specified together", filename)
+	}
+	return chap, nil
 }
 
 func checkAddOptions(curveadm *cli.CurveAdm, options addOptions) error {
-	if _, _, err := client.ParseImage(options.image); err != nil {
+	if _, err := parseLuns(options.images); err != nil {
+		return err
+	} else if err := checkGateways(uniqueHosts(options.hosts), options.noExclusive); err != nil {
 		return err
 	} else if _, err = client.ParseSize(options.size); err != nil {
 		return err
@@ -63,6 +173,10 @@ func checkAddOptions(curveadm *cli.CurveAdm, options addOptions) error {
 	} else if !utils.PathExist(options.filename) {
 		return errno.ERR_CLIENT_CONFIGURE_FILE_NOT_EXIST.
 			F("file path: %s", utils.AbsPath(options.filename))
+	} else if err := checkInitiators(options.initiators); err != nil {
+		return err
+	} else if _, err := parseChapFile(options.chapFile); err != nil {
+		return err
 	}
 	return nil
 }
@@ -71,49 +185,73 @@ func NewAddCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options addOptions
 
 	cmd := &cobra.Command{
-		Use:   "add USER:VOLUME [OPTIONS]",
-		Short: "Add a target of CurveBS",
-		Args:  cliutil.ExactArgs(1),
+		Use:     "add USER:VOLUME [USER:VOLUME...] [OPTIONS]",
+		Short:   "Add a target of CurveBS",
+		Args:    cliutil.RequiresMinArgs(1),
+		Example: ADD_EXAMPLE,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			options.image = args[0]
+			options.images = args
 			return checkAddOptions(curveadm, options)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			options.image = args[0]
+			options.images = args
 			return runAdd(curveadm, options)
 		},
 		DisableFlagsInUseLine: true,
 	}
 
 	flags := cmd.Flags()
-	flags.StringVar(&options.host, "host", "localhost", "Specify target host")
+	flags.StringSliceVar(&options.hosts, "host", []string{"localhost"}, "Specify target hosts, the target will be exported on all of them")
 	flags.BoolVar(&options.create, "create", false, "Create volume iff not exist")
 	flags.StringVar(&options.size, "size", "10GiB", "Specify volume size")
 	flags.StringVarP(&options.filename, "conf", "c", "client.yaml", "Specify client configuration file")
 	flags.StringVar(&options.blocksize, "blocksize", "4096B", "Specify volume blocksize")
+	flags.BoolVar(&options.noExclusive, "no-exclusive", false, "Open volumes non exclusive, required by multiple hosts")
+	flags.StringSliceVar(&options.initiators, "initiator", []string{}, "Specify initiators allowed to login (IP address, CIDR or iSCSI name)")
+	flags.StringVar(&options.chapFile, "chap-file", "", "Specify CHAP credentials file")
 	return cmd
 }
 
 func genAddPlaybook(curveadm *cli.CurveAdm,
-	ccs []*configure.ClientConfig,
+	cc *configure.ClientConfig,
 	options addOptions) (*playbook.Playbook, error) {
-	user, name, _ := client.ParseImage(options.image)
+	luns, err := parseLuns(options.images)
+	if err != nil {
+		return nil, err
+	}
 	size, _ := client.ParseSize(options.size)
 	blocksize, _ := client.ParseBlockSize(options.blocksize)
+	chap, err := parseChapFile(options.chapFile)
+	if err != nil {
+		return nil, err
+	}
+
+	gateways := []interface{}{}
+	for _, host := range options.hosts {
+		gateways = append(gateways, bs.TargetGateway{
+			Host:         host,
+			ClientConfig: cc,
+		})
+	}
+
 	steps := ADD_PLAYBOOK_STEPS
 	pb := playbook.NewPlaybook(curveadm)
 	for _, step := range steps {
 		pb.AddStep(&playbook.PlaybookStep{
 			Type:    step,
-			Configs: ccs,
+			Configs: gateways,
 			Options: map[string]interface{}{
 				comm.KEY_TARGET_OPTIONS: bs.TargetOption{
-					Host:      options.host,
-					User:      user,
-					Volume:    name,
-					Size:      size,
-					Blocksize: blocksize,
-					Create:    options.create,
+					User:        luns[0].User,
+					Volume:      luns[0].Volume,
+					Luns:        luns[1:],
+					Name:        bs.GetTargetName(curveadm.ClusterUUId(), luns),
+					Size:        size,
+					Blocksize:   blocksize,
+					Create:      options.create,
+					NoExclusive: options.noExclusive,
+					Initiators:  options.initiators,
+					Chap:        chap,
 				},
 			},
 		})
@@ -121,6 +259,25 @@ func genAddPlaybook(curveadm *cli.CurveAdm,
 	return pb, nil
 }
 
+func checkTargetExist(curveadm *cli.CurveAdm, options addOptions) error {
+	luns, err := parseLuns(options.images)
+	if err != nil {
+		return err
+	}
+	name := bs.GetTargetName(curveadm.ClusterUUId(), luns)
+	targets, err := curveadm.Storage().GetTarget(curveadm.ClusterId(), name)
+	if err != nil {
+		return errno.ERR_GET_TARGET_FAILED.E(err)
+	}
+	for _, target := range targets {
+		if utils.Slice2Map(options.hosts)[target.Host] {
+			return errno.ERR_TARGET_ALREADY_EXIST.
+				F("host=%s target=%s", target.Host, name)
+		}
+	}
+	return nil
+}
+
 func runAdd(curveadm *cli.CurveAdm, options addOptions) error {
 	// 1) parse client configure
 	cc, err := configure.ParseClientConfig(options.filename)
@@ -131,21 +288,29 @@ func runAdd(curveadm *cli.CurveAdm, options addOptions) error {
 			F("kind: %s", cc.GetKind())
 	}
 
-	// 2) generate map playbook
-	pb, err := genAddPlaybook(curveadm, []*configure.ClientConfig{cc}, options)
+	// 2) check whether target already exist
+	options.hosts = uniqueHosts(options.hosts)
+	err = checkTargetExist(curveadm, options)
+	if err != nil {
+		return err
+	}
+
+	// 3) generate add playbook
+	pb, err := genAddPlaybook(curveadm, cc, options)
 	if err != nil {
 		return err
 	}
 
-	// 3) run playground
+	// 4) run playground
 	err = pb.Run()
 	if err != nil {
 		return err
 	}
 
-	// 4) print success prompt
+	// 5) print success prompt
+	luns, _ := parseLuns(options.images)
 	curveadm.WriteOutln("")
 	curveadm.WriteOutln(color.GreenString("Add target (%s) to %s success ^_^"),
-		options.image, options.host)
+		bs.GetTargetName(curveadm.ClusterUUId(), luns), strings.Join(options.hosts, ","))
 	return nil
 }
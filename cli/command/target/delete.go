@@ -23,15 +23,24 @@
 package target
 
 import (
+	"strconv"
+
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/cli/cli"
-	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
-	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/internal/storage"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/cobra"
 )
 
+const (
+	DELETE_EXAMPLE = `Examples:
+  $ curveadm target rm iqn.2022-02.com.opencurve:curve.0d7f3b                  # Delete target on all hosts
+  $ curveadm target rm iqn.2022-02.com.opencurve:curve.0d7f3b --host machine1  # Delete target on specified host
+  $ curveadm target rm 1 --host machine                                         # Delete target by tid which not recorded`
+)
+
 var (
 	DELETE_PLAYBOOK_STEPS = []int{
 		playbook.DELETE_TARGET,
@@ -39,65 +48,93 @@ var (
 )
 
 type deleteOptions struct {
-	host string
-	tid  string
+	host   string
+	target string
 }
 
 func NewDeleteCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	var options deleteOptions
 
 	cmd := &cobra.Command{
-		Use:     "rm TID [OPTIONS]",
+		Use:     "rm TARGET [OPTIONS]",
 		Aliases: []string{"delete"},
 		Short:   "Delete a target of CurveBS",
 		Args:    cliutil.ExactArgs(1),
+		Example: DELETE_EXAMPLE,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			options.tid = args[0]
+			options.target = args[0]
 			return runDelete(curveadm, options)
 		},
 		DisableFlagsInUseLine: true,
 	}
 
 	flags := cmd.Flags()
-	flags.StringVar(&options.host, "host", "localhost", "Specify target host")
+	flags.StringVar(&options.host, "host", "", "Specify target host")
 
 	return cmd
 }
 
-func genDeletePlaybook(curveadm *cli.CurveAdm, options deleteOptions) (*playbook.Playbook, error) {
+// TARGET is the target name, or tid for target which not recorded in database
+func getDeleteTargets(curveadm *cli.CurveAdm, options deleteOptions) ([]interface{}, error) {
+	records, err := curveadm.Storage().GetTarget(curveadm.ClusterId(), options.target)
+	if err != nil {
+		return nil, errno.ERR_GET_TARGET_FAILED.E(err)
+	}
+
+	targets := []interface{}{}
+	for _, record := range records {
+		if len(options.host) == 0 || record.Host == options.host {
+			targets = append(targets, record)
+		}
+	}
+	if len(targets) > 0 {
+		return targets, nil
+	}
+
+	host := options.host
+	if len(host) == 0 {
+		host = "localhost"
+	}
+	if _, err := strconv.Atoi(options.target); err != nil {
+		return nil, errno.ERR_TARGET_NOT_FOUND.F("target=%s", options.target)
+	}
+	targets = append(targets, storage.Target{Host: host, Tid: options.target})
+	return targets, nil
+}
+
+func genDeletePlaybook(curveadm *cli.CurveAdm, targets []interface{}) (*playbook.Playbook, error) {
 	steps := DELETE_PLAYBOOK_STEPS
 	pb := playbook.NewPlaybook(curveadm)
 	for _, step := range steps {
 		pb.AddStep(&playbook.PlaybookStep{
 			Type:    step,
-			Configs: nil,
-			Options: map[string]interface{}{
-				comm.KEY_TARGET_OPTIONS: bs.TargetOption{
-					Host: options.host,
-					Tid:  options.tid,
-				},
-			},
+			Configs: targets,
 		})
 	}
 	return pb, nil
 }
 
 func runDelete(curveadm *cli.CurveAdm, options deleteOptions) error {
-	// 1) generate list playbook
-	pb, err := genDeletePlaybook(curveadm, options)
+	// 1) get targets on gateways
+	targets, err := getDeleteTargets(curveadm, options)
+	if err != nil {
+		return err
+	}
+
+	// 2) generate delete playbook
+	pb, err := genDeletePlaybook(curveadm, targets)
 	if err != nil {
 		return err
 	}
 
-	// 2) run playground
+	// 3) run playground
 	err = pb.Run()
 	if err != nil {
 		return err
 	}
 
-	// 3) print targets
+	// 4) print success prompt
 	curveadm.WriteOutln("")
-	curveadm.WriteOutln(color.GreenString("Delete target (tid=%s) on %s success ^_^"),
-		options.tid, options.host)
+	curveadm.WriteOutln(color.GreenString("Delete target (%s) success ^_^"), options.target)
 	return nil
 }
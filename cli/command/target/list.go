@@ -23,9 +23,13 @@
 package target
 
 import (
+	"fmt"
+
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/storage"
 	"github.com/opencurve/curveadm/internal/task/task/bs"
 	"github.com/opencurve/curveadm/internal/tui"
 	cliutil "github.com/opencurve/curveadm/internal/utils"
@@ -57,57 +61,129 @@ func NewListCommand(curveadm *cli.CurveAdm) *cobra.Command {
 	}
 
 	flags := cmd.Flags()
-	flags.StringVar(&options.host, "host", "localhost", "Specify target host")
+	flags.StringVar(&options.host, "host", "", "Specify target host")
 
 	return cmd
 }
 
-func genListPlaybook(curveadm *cli.CurveAdm, options listOptions) (*playbook.Playbook, error) {
+// we list targets on all gateways which recorded in database,
+// and the specified host for targets which not recorded, the localhost
+// is listed if nothing recorded, which is the default host of old version
+func getListHosts(records []storage.Target, options listOptions) []interface{} {
+	hosts := []interface{}{}
+	exist := map[string]bool{}
+	if len(options.host) > 0 {
+		hosts = append(hosts, options.host)
+		exist[options.host] = true
+	}
+	for _, record := range records {
+		if len(options.host) > 0 || exist[record.Host] {
+			continue
+		}
+		exist[record.Host] = true
+		hosts = append(hosts, record.Host)
+	}
+	if len(hosts) == 0 {
+		hosts = append(hosts, "localhost")
+	}
+	return hosts
+}
+
+func genListPlaybook(curveadm *cli.CurveAdm, hosts []interface{}) (*playbook.Playbook, error) {
 	steps := LIST_PLAYBOOK_STEPS
 	pb := playbook.NewPlaybook(curveadm)
 	for _, step := range steps {
 		pb.AddStep(&playbook.PlaybookStep{
 			Type:    step,
-			Configs: nil,
-			Options: map[string]interface{}{
-				comm.KEY_TARGET_OPTIONS: bs.TargetOption{
-					Host: options.host,
-				},
-			},
+			Configs: hosts,
 		})
 	}
 	return pb, nil
 }
 
-func displayTargets(curveadm *cli.CurveAdm) {
-	targets := []bs.Target{}
+// merge the recorded targets with the actual ones on gateways:
+//
+//	Online: target recorded and exported
+//	Lost: target recorded but not exported, e.g. target daemon restarted
+//	Unrecorded: target exported but not recorded, e.g. added by old version
+func mergeTargets(curveadm *cli.CurveAdm, records []storage.Target, options listOptions) ([]bs.Target, error) {
+	actual := map[string]*bs.Target{}
 	value := curveadm.MemStorage().Get(comm.KEY_ALL_TARGETS)
 	if value != nil {
-		m := value.(map[string]*bs.Target)
-		for _, target := range m {
+		actual = value.(map[string]*bs.Target)
+	}
+
+	targets := []bs.Target{}
+	merged := map[string]bool{}
+	for _, record := range records {
+		if len(options.host) > 0 && record.Host != options.host {
+			continue
+		}
+
+		id := bs.FormatTargetId(record.Host, record.Name)
+		if target, ok := actual[id]; ok {
+			target.Status = comm.TARGET_STATUS_ONLINE
 			targets = append(targets, *target)
+			merged[id] = true
+			continue
 		}
-	}
 
-	output := tui.FormatTargets(targets)
-	curveadm.WriteOutln("")
-	curveadm.WriteOut(output)
+		info, err := bs.DecodeTargetInfo(record)
+		if err != nil {
+			return nil, err
+		}
+		hc, err := curveadm.GetHost(record.Host)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, bs.Target{
+			Host:     record.Host,
+			Tid:      "-",
+			Name:     record.Name,
+			Stores:   info.Luns,
+			Portal:   fmt.Sprintf("%s:%d", hc.GetHostname(), bs.DEFAULT_TGTD_LISTEN_PORT),
+			Status:   comm.TARGET_STATUS_LOST,
+			ACLs:     info.Initiators,
+			Accounts: []string{},
+			Sessions: []string{},
+		})
+	}
+	for id, target := range actual {
+		if merged[id] {
+			continue
+		}
+		target.Status = comm.TARGET_STATUS_UNRECORDED
+		targets = append(targets, *target)
+	}
+	return targets, nil
 }
 
 func runList(curveadm *cli.CurveAdm, options listOptions) error {
-	// 1) generate list playbook
-	pb, err := genListPlaybook(curveadm, options)
+	// 1) get recorded targets
+	records, err := curveadm.Storage().GetTargets(curveadm.ClusterId())
 	if err != nil {
-		return err
+		return errno.ERR_GET_ALL_TARGETS_FAILED.E(err)
 	}
 
-	// 2) run playground
-	err = pb.Run()
-	if err != nil {
-		return err
+	// 2) list actual targets on gateways
+	hosts := getListHosts(records, options)
+	if len(hosts) > 0 {
+		pb, err := genListPlaybook(curveadm, hosts)
+		if err != nil {
+			return err
+		}
+		err = pb.Run()
+		if err != nil {
+			return err
+		}
 	}
 
 	// 3) print targets
-	displayTargets(curveadm)
+	targets, err := mergeTargets(curveadm, records, options)
+	if err != nil {
+		return err
+	}
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(tui.FormatTargets(targets))
 	return nil
 }
@@ -0,0 +1,391 @@
+package target
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/cli/cli/clitest"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage/driver"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/internal/tui"
+	"github.com/opencurve/curveadm/pkg/module/moduletest"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	HOSTS = `
+global:
+  user: curve
+  ssh_port: 22
+  private_key_file: {{.PrivateKeyFile}}
+hosts:
+  - host: target-host
+    hostname: 10.0.1.4
+  - host: target-host2
+    hostname: 10.0.1.5
+`
+
+	TARGET_HOST  = "10.0.1.4"
+	TARGET_HOST2 = "10.0.1.5"
+
+	CLIENT_CONFIG = `
+kind: curvebs
+container_image: opencurvedocker/curvebs:v1.2
+mds.listen.addr: 10.0.1.1:6700,10.0.1.2:6700,10.0.1.3:6700
+`
+
+	CHAP_CONFIG = `
+user: initiator-user
+password: initiator-secret
+mutual_user: target-user
+mutual_password: target-secret
+`
+
+	TARGETS_OUTPUT = `Target 1: iqn.2026-10.com.opencurve:curve.0d7f3b
+    System information:
+        Driver: iscsi
+        State: ready
+    I_T nexus information:
+        I_T nexus: 1
+            Initiator: iqn.1994-05.com.redhat:client1 alias: client1
+            Connection: 0
+                IP Address: 10.0.0.5
+    LUN information:
+        LUN: 0
+            Type: controller
+        LUN: 1
+            Type: disk
+            Backing store path: cbd:pool//vol1_curve_
+    Account information:
+        initiator-user
+        target-user (outgoing)
+    ACL information:
+        10.0.0.0/24
+Target 2: iqn.2026-10.com.opencurve:curve.8e2a91
+    System information:
+        Driver: iscsi
+        State: ready
+    I_T nexus information:
+    LUN information:
+        LUN: 1
+            Backing store path: cbd:pool//vol2_curve_
+    Account information:
+    ACL information:
+        ALL
+`
+)
+
+// the target daemon is running on TARGET_HOST and TARGET_HOST2
+func newTargetEnv(t *testing.T) *clitest.Env {
+	env := clitest.New(t, HOSTS)
+	for _, host := range []string{TARGET_HOST, TARGET_HOST2} {
+		env.Executor.Run(host, "docker run --name "+bs.DEFAULT_TGTD_CONTAINER_NAME+" opencurvedocker/curvebs:v1.2")
+	}
+	env.Executor.On(`bash .*target\.sh`, moduletest.Reply("1"))
+	env.Executor.Reset()
+	return env
+}
+
+func writeChapFile(t *testing.T, env *clitest.Env, content string, mode os.FileMode) string {
+	filename := env.WriteFile("chap.yaml", content)
+	if err := os.Chmod(filename, mode); err != nil {
+		t.Fatalf("chmod %s: %v", filename, err)
+	}
+	return filename
+}
+
+func TestAdd_ACLAndChap(t *testing.T) {
+	assert := assert.New(t)
+	env := newTargetEnv(t)
+	options := addOptions{
+		images:     []string{"curve:/vol1"},
+		hosts:      []string{"target-host"},
+		size:       "10GiB",
+		blocksize:  "4096B",
+		filename:   env.WriteFile("client.yaml", CLIENT_CONFIG),
+		initiators: []string{"10.0.0.0/24", "iqn.1994-05.com.redhat:client1"},
+		chapFile:   writeChapFile(t, env, CHAP_CONFIG, 0600),
+	}
+	assert.Nil(checkAddOptions(env.CurveAdm, options))
+
+	err := runAdd(env.CurveAdm, options)
+	assert.Nil(err, env.Dump())
+
+	// (1) ACLs and CHAP credentials file are passed to target.sh
+	env.AssertOrder(
+		`docker cp +\S*/tmp/\w+ curvebs-target-daemon:\S*/tmp/\w+$`,
+		`target\.sh curve /vol1 false 10 4096 --targetname iqn\.2022-02\.com\.opencurve:curve\.\w+ `+
+			`--initiator-address 10.0.0.0/24 `+
+			`--initiator-name iqn.1994-05.com.redhat:client1 --chap \S*/tmp/\w+$`,
+	)
+
+	// (2) credentials never appear in command line, the file on host is only
+	// readable by owner and removed after it copied into container
+	for _, cmd := range env.Executor.Commands() {
+		assert.NotContains(cmd.Command, "secret")
+	}
+	cp := env.Executor.Grep(`docker cp +\S*/tmp/\w+ curvebs-target-daemon:/tmp/`)
+	assert.Len(cp, 1)
+	mu := regexp.MustCompile(`(\S*/tmp/\w+) curvebs-target-daemon:(\S*/tmp/\w+)`).FindStringSubmatch(cp[0].Command)
+	_, ok := env.Executor.ReadFile(TARGET_HOST, mu[1])
+	assert.False(ok)
+	mode, ok := env.Executor.UploadMode(TARGET_HOST, mu[1])
+	assert.True(ok)
+	assert.Equal(os.FileMode(0600), mode)
+	assert.Len(env.Executor.Grep(`chmod .*`+mu[1]), 0)
+	assert.Len(env.Executor.Grep(`mv .*`+mu[1]), 0)
+	content, ok := env.Executor.ReadContainerFile(TARGET_HOST, bs.DEFAULT_TGTD_CONTAINER_NAME, mu[2])
+	assert.True(ok)
+	assert.Contains(content, "g_chap_password='initiator-secret'")
+	assert.Contains(content, "g_chap_mutual_user='target-user'")
+
+	// (3) other files are installed with default mode, no extra chmod
+	cp = env.Executor.Grep(`docker cp +\S*/tmp/\w+ curvebs-target-daemon:/curvebs/tools/sbin/target\.sh$`)
+	assert.Len(cp, 1)
+	mu = regexp.MustCompile(`(\S*/tmp/\w+) curvebs-target-daemon:`).FindStringSubmatch(cp[0].Command)
+	mode, ok = env.Executor.UploadMode(TARGET_HOST, mu[1])
+	assert.True(ok)
+	assert.Equal(os.FileMode(0644), mode)
+	assert.Len(env.Executor.Grep(`chmod `), 0)
+}
+
+func TestAdd_InvalidOptions(t *testing.T) {
+	assert := assert.New(t)
+	env := newTargetEnv(t)
+	options := addOptions{
+		images:    []string{"curve:/vol1"},
+		hosts:     []string{"target-host"},
+		size:      "10GiB",
+		blocksize: "4096B",
+		filename:  env.WriteFile("client.yaml", CLIENT_CONFIG),
+	}
+	code := func(err error) int {
+		if err == nil {
+			return 0
+		}
+		return err.(*errno.ErrorCode).GetCode()
+	}
+
+	options.initiators = []string{"client1"}
+	assert.Equal(errno.ERR_INVALID_TARGET_INITIATOR.GetCode(), code(checkAddOptions(env.CurveAdm, options)))
+	options.initiators = []string{"ALL", "10.0.0.5", "eui.02004567A425678D"}
+	assert.Nil(checkAddOptions(env.CurveAdm, options))
+
+	options.chapFile = writeChapFile(t, env, CHAP_CONFIG, 0644)
+	assert.Equal(errno.ERR_CHAP_FILE_REQUIRE_600_PERMISSIONS.GetCode(), code(checkAddOptions(env.CurveAdm, options)))
+	options.chapFile = writeChapFile(t, env, "user: initiator-user\n", 0600)
+	assert.Equal(errno.ERR_INVALID_CHAP_CREDENTIALS.GetCode(), code(checkAddOptions(env.CurveAdm, options)))
+	options.chapFile = writeChapFile(t, env, "user: u\npassword: p\nmutual_user: m\n", 0600)
+	assert.Equal(errno.ERR_INVALID_CHAP_CREDENTIALS.GetCode(), code(checkAddOptions(env.CurveAdm, options)))
+	options.chapFile = env.Home + "/not-exist.yaml"
+	assert.Equal(errno.ERR_CHAP_FILE_NOT_EXIST.GetCode(), code(checkAddOptions(env.CurveAdm, options)))
+}
+
+func TestAdd_MultiLunAndGateway(t *testing.T) {
+	assert := assert.New(t)
+	env := newTargetEnv(t)
+	env.Executor.OnHost(TARGET_HOST2, `bash .*target\.sh`, moduletest.Reply("3"))
+	options := addOptions{
+		images:    []string{"curve:/vol1", "curve:/vol2"},
+		hosts:     []string{"target-host", "target-host2", "target-host"},
+		size:      "10GiB",
+		blocksize: "4096B",
+		filename:  env.WriteFile("client.yaml", CLIENT_CONFIG),
+	}
+	assert.Equal(errno.ERR_MULTI_GATEWAY_TARGET_REQUIRE_NO_EXCLUSIVE.GetCode(),
+		checkAddOptions(env.CurveAdm, options).(*errno.ErrorCode).GetCode())
+	options.noExclusive = true
+	assert.Nil(checkAddOptions(env.CurveAdm, options))
+
+	err := runAdd(env.CurveAdm, options)
+	assert.Nil(err, env.Dump())
+
+	// (1) target exported on both gateways with the same name and LUNs
+	name := bs.GetTargetName(env.CurveAdm.ClusterUUId(),
+		[]bs.TargetLun{{User: "curve", Volume: "/vol1"}, {User: "curve", Volume: "/vol2"}})
+	cmds := env.Executor.Grep(`bash .*target\.sh`)
+	assert.Len(cmds, 2)
+	hosts := []string{}
+	for _, cmd := range cmds {
+		hosts = append(hosts, cmd.Host)
+		assert.Contains(cmd.Command, "target.sh curve /vol1 false 10 4096 --lun curve:/vol2 "+
+			"--targetname "+name+" --no-exclusive")
+	}
+	assert.ElementsMatch([]string{TARGET_HOST, TARGET_HOST2}, hosts)
+
+	// (2) target recorded for each gateway
+	records, err := env.CurveAdm.Storage().GetTarget(env.CurveAdm.ClusterId(), name)
+	assert.Nil(err)
+	assert.Len(records, 2)
+	tids := map[string]string{}
+	for _, record := range records {
+		tids[record.Host] = record.Tid
+		info, err := bs.DecodeTargetInfo(record)
+		assert.Nil(err)
+		assert.Equal([]string{"cbd:pool//vol1_curve_", "cbd:pool//vol2_curve_"}, info.Luns)
+		assert.True(info.NoExclusive)
+	}
+	assert.Equal(map[string]string{"target-host": "1", "target-host2": "3"}, tids)
+
+	// (3) add again
+	err = runAdd(env.CurveAdm, options)
+	assert.Equal(errno.ERR_TARGET_ALREADY_EXIST.GetCode(), err.(*errno.ErrorCode).GetCode())
+
+	// (4) duplicate LUN
+	options.images = []string{"curve:/vol1", "curve:/vol1"}
+	assert.Equal(errno.ERR_DUPLICATE_TARGET_LUN.GetCode(),
+		checkAddOptions(env.CurveAdm, options).(*errno.ErrorCode).GetCode())
+}
+
+func TestAdd_ClusterScoped(t *testing.T) {
+	assert := assert.New(t)
+	env := newTargetEnv(t)
+	env.AddCluster("c1", "kind: curvebs\n")
+	options := addOptions{
+		images:    []string{"curve:/vol1"},
+		hosts:     []string{"target-host"},
+		size:      "10GiB",
+		blocksize: "4096B",
+		filename:  env.WriteFile("client.yaml", CLIENT_CONFIG),
+	}
+	err := runAdd(env.CurveAdm, options)
+	assert.Nil(err, env.Dump())
+	luns := []bs.TargetLun{{User: "curve", Volume: "/vol1"}}
+	c1 := bs.GetTargetName(env.CurveAdm.ClusterUUId(), luns)
+
+	// (1) the same volume of another cluster is exported with another name
+	env.AddCluster("c2", "kind: curvebs\n")
+	c2 := bs.GetTargetName(env.CurveAdm.ClusterUUId(), luns)
+	assert.NotEqual(c1, c2)
+	records, err := env.CurveAdm.Storage().GetTargets(env.CurveAdm.ClusterId())
+	assert.Nil(err)
+	assert.Len(records, 0)
+	err = runAdd(env.CurveAdm, options)
+	assert.Nil(err, env.Dump())
+	assert.Contains(env.Executor.Grep(`bash .*target\.sh`)[1].Command, "--targetname "+c2)
+
+	// (2) targets are recorded for each cluster
+	for _, name := range []string{"c1", "c2"} {
+		assert.Nil(env.CurveAdm.Storage().CheckoutCluster(name))
+		curveadm := env.Reload()
+		records, err := curveadm.Storage().GetTargets(curveadm.ClusterId())
+		assert.Nil(err)
+		assert.Len(records, 1)
+		assert.Equal(bs.GetTargetName(curveadm.ClusterUUId(), luns), records[0].Name)
+	}
+}
+
+func TestMigrateTargetsTable(t *testing.T) {
+	assert := assert.New(t)
+	env := newTargetEnv(t)
+	env.AddCluster("c1", "kind: curvebs\n")
+
+	// (1) targets table created by old version, without cluster_id column
+	db := driver.NewSQLiteDB()
+	assert.Nil(db.Open(filepath.Join(env.Home, ".curveadm", "data", "curveadm.db")))
+	for _, sql := range []string{
+		`DROP TABLE targets`,
+		`CREATE TABLE targets (name TEXT NOT NULL, host TEXT NOT NULL, tid TEXT NOT NULL, ` +
+			`aux_info TEXT NOT NULL, PRIMARY KEY (name, host))`,
+		`INSERT INTO targets VALUES('iqn.2022-02.com.opencurve:curve.0d7f3b', 'target-host', '1', '{}')`,
+	} {
+		_, err := db.Write(sql)
+		assert.Nil(err, sql)
+	}
+	assert.Nil(db.Close())
+
+	// (2) the recorded targets belong to the current cluster after migrated
+	curveadm := env.Reload()
+	records, err := curveadm.Storage().GetTargets(curveadm.ClusterId())
+	assert.Nil(err)
+	assert.Len(records, 1)
+	assert.Equal("iqn.2022-02.com.opencurve:curve.0d7f3b", records[0].Name)
+	assert.Equal("1", records[0].Tid)
+
+	// (3) migrate only once
+	curveadm = env.Reload()
+	records, err = curveadm.Storage().GetTargets(curveadm.ClusterId())
+	assert.Nil(err)
+	assert.Len(records, 1)
+}
+
+func TestList(t *testing.T) {
+	assert := assert.New(t)
+	env := newTargetEnv(t)
+	env.Executor.On(`tgtadm --lld iscsi --mode target --op show`, moduletest.Reply(TARGETS_OUTPUT))
+	env.Executor.OnHost(TARGET_HOST2, `tgtadm --lld iscsi --mode target --op show`, moduletest.Reply(""))
+	storage := env.CurveAdm.Storage()
+	auxInfo := `{"luns":["cbd:pool//vol1_curve_"]}`
+	assert.Nil(storage.InsertTarget(-1, "iqn.2026-10.com.opencurve:curve.0d7f3b", "target-host", "1", auxInfo))
+	assert.Nil(storage.InsertTarget(-1, "iqn.2026-10.com.opencurve:curve.0d7f3b", "target-host2", "1", auxInfo))
+
+	err := runList(env.CurveAdm, listOptions{})
+	assert.Nil(err, env.Dump())
+
+	m := env.CurveAdm.MemStorage().Get(comm.KEY_ALL_TARGETS).(map[string]*bs.Target)
+	assert.Len(m, 2)
+	t1 := m["target-host/iqn.2026-10.com.opencurve:curve.0d7f3b"]
+	t2 := m["target-host/iqn.2026-10.com.opencurve:curve.8e2a91"]
+	assert.Equal([]string{"cbd:pool//vol1_curve_"}, t1.Stores)
+	assert.Equal([]string{"10.0.0.0/24"}, t1.ACLs)
+	assert.Equal([]string{"initiator-user", "target-user (outgoing)"}, t1.Accounts)
+	assert.Equal([]string{"iqn.1994-05.com.redhat:client1@10.0.0.5"}, t1.Sessions)
+	assert.Equal([]string{"ALL"}, t2.ACLs)
+	assert.Len(t2.Accounts, 0)
+	assert.Len(t2.Sessions, 0)
+
+	// recorded target on target-host2 is lost, target 2 is not recorded
+	records, err := storage.GetTargets(-1)
+	assert.Nil(err)
+	targets, err := mergeTargets(env.CurveAdm, records, listOptions{})
+	assert.Nil(err)
+	output := tui.FormatTargets(targets)
+	lines := strings.Split(output, "\n")
+	assert.Len(targets, 3)
+	assert.Regexp(`curve\.0d7f3b +target-host +1 +cbd:pool//vol1_curve_ +10\.0\.1\.4:3260 +Online +mutual CHAP +10\.0\.0\.0/24 +`+
+		`iqn\.1994-05\.com\.redhat:client1@10\.0\.0\.5`, lines[2])
+	assert.Regexp(`curve\.0d7f3b +target-host2 +- +cbd:pool//vol1_curve_ +10\.0\.1\.5:3260 +Lost +- +- +-`, lines[3])
+	assert.Regexp(`curve\.8e2a91 +target-host +2 +cbd:pool//vol2_curve_ +10\.0\.1\.4:3260 +Unrecorded +- +ALL +-`, lines[4])
+
+	// targets added by old version are listed on localhost if nothing recorded
+	assert.Equal([]interface{}{"localhost"}, getListHosts(nil, listOptions{}))
+	assert.Equal([]interface{}{"target-host2"}, getListHosts(nil, listOptions{host: "target-host2"}))
+	assert.Equal([]interface{}{"target-host", "target-host2"}, getListHosts(records, listOptions{}))
+}
+
+func TestDelete(t *testing.T) {
+	assert := assert.New(t)
+	env := newTargetEnv(t)
+	env.Executor.On(`tgtadm --lld iscsi --mode target --op show`, moduletest.Reply(TARGETS_OUTPUT))
+	env.Executor.OnHost(TARGET_HOST2, `tgtadm --lld iscsi --mode target --op show`, moduletest.Reply(""))
+	storage := env.CurveAdm.Storage()
+	name := "iqn.2026-10.com.opencurve:curve.0d7f3b"
+	assert.Nil(storage.InsertTarget(-1, name, "target-host", "3", "{}"))
+	assert.Nil(storage.InsertTarget(-1, name, "target-host2", "1", "{}"))
+
+	// (1) delete by name on all gateways, the tid is found by name
+	err := runDelete(env.CurveAdm, deleteOptions{target: name})
+	assert.Nil(err, env.Dump())
+	cmds := env.Executor.Grep(`--op delete`)
+	assert.Len(cmds, 1)
+	assert.Equal(TARGET_HOST, cmds[0].Host)
+	assert.Contains(cmds[0].Command, "--op delete --tid 1")
+	records, err := storage.GetTargets(-1)
+	assert.Nil(err)
+	assert.Len(records, 0)
+
+	// (2) delete target which not recorded by tid
+	env.Executor.Reset()
+	err = runDelete(env.CurveAdm, deleteOptions{target: "2", host: "target-host"})
+	assert.Nil(err, env.Dump())
+	env.AssertOrder(`--op delete --tid 2`)
+
+	// (3) target not found
+	err = runDelete(env.CurveAdm, deleteOptions{target: name})
+	assert.Equal(errno.ERR_TARGET_NOT_FOUND.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
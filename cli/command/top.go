@@ -0,0 +1,409 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/moby/term"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tui "github.com/opencurve/curveadm/internal/tui/service"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	TOP_EXAMPLE = `Examples:
+  $ curveadm top                  # Display services of current cluster in full screen
+  $ curveadm top --role mds       # Only display mds services
+  $ curveadm top --interval 10s   # Refresh every 10 seconds`
+
+	DEFAULT_TOP_INTERVAL = "5s"
+	TOP_LOGS_TAIL        = 100
+
+	ANSI_ENTER_ALT_SCREEN = "\033[?1049h\033[?25l"
+	ANSI_LEAVE_ALT_SCREEN = "\033[?25h\033[?1049l"
+)
+
+const (
+	TOP_ACTION_NONE = iota
+	TOP_ACTION_QUIT
+	TOP_ACTION_RESTART
+	TOP_ACTION_ENTER
+	TOP_ACTION_LOGS
+)
+
+var (
+	GET_TOP_PLAYBOOK_STEPS = []int{
+		playbook.INIT_SERVIE_STATUS,
+		playbook.GET_SERVICE_STATUS,
+		playbook.GET_SERVICE_STATS,
+	}
+
+	TOP_HOTKEYS = map[string]int{
+		"q":    TOP_ACTION_QUIT,
+		"Q":    TOP_ACTION_QUIT,
+		"\x03": TOP_ACTION_QUIT, // Ctrl-C
+		"r":    TOP_ACTION_RESTART,
+		"e":    TOP_ACTION_ENTER,
+		"l":    TOP_ACTION_LOGS,
+	}
+)
+
+type (
+	topOptions struct {
+		id       string
+		role     string
+		host     string
+		interval string
+	}
+
+	topModel struct {
+		curveadm *cli.CurveAdm
+		dcs      []*topology.DeployConfig
+		options  topOptions
+		interval time.Duration
+		statuses []task.ServiceStatus
+		stats    map[string]task.ServiceStats
+		selected int
+		updated  time.Time
+		message  string
+	}
+)
+
+func NewTopCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options topOptions
+
+	cmd := &cobra.Command{
+		Use:     "top [OPTIONS]",
+		Short:   "Display and manage services in full screen",
+		Args:    cliutil.NoArgs,
+		Example: TOP_EXAMPLE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			_, err := parseRefreshInterval(options.interval)
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTop(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.id, "id", "*", "Specify service id")
+	flags.StringVar(&options.role, "role", "*", "Specify service role")
+	flags.StringVar(&options.host, "host", "*", "Specify service host")
+	flags.StringVar(&options.interval, "interval", DEFAULT_TOP_INTERVAL, "Specify refresh interval")
+
+	return cmd
+}
+
+func newTopModel(curveadm *cli.CurveAdm, options topOptions) (*topModel, error) {
+	interval, err := parseRefreshInterval(options.interval)
+	if err != nil {
+		return nil, err
+	}
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return nil, err
+	}
+	dcs = curveadm.FilterDeployConfig(dcs, topology.FilterOption{
+		Id:   options.id,
+		Role: options.role,
+		Host: options.host,
+	})
+	if len(dcs) == 0 {
+		return nil, errno.ERR_NO_SERVICES_MATCHED
+	}
+
+	return &topModel{
+		curveadm: curveadm,
+		dcs:      dcs,
+		options:  options,
+		interval: interval,
+		stats:    map[string]task.ServiceStats{},
+	}, nil
+}
+
+func (m *topModel) genPlaybook() *playbook.Playbook {
+	pb := playbook.NewPlaybook(m.curveadm)
+	for _, step := range GET_TOP_PLAYBOOK_STEPS {
+		pb.AddStep(&playbook.PlaybookStep{
+			Type:    step,
+			Configs: m.dcs,
+			ExecOptions: playbook.ExecOptions{
+				SilentSubBar:  true,
+				SilentMainBar: true,
+				SkipError:     true,
+			},
+		})
+	}
+	return pb
+}
+
+// refresh status and stats of services, keep the selected service unchanged
+func (m *topModel) refresh() {
+	id := ""
+	if service, ok := m.selectedService(); ok {
+		id = service.Id
+	}
+
+	m.genPlaybook().Run() // services which failed to get status will be displayed as unknown
+	memStorage := m.curveadm.MemStorage()
+	m.statuses = []task.ServiceStatus{}
+	if v := memStorage.Get(comm.KEY_ALL_SERVICE_STATUS); v != nil {
+		for _, status := range v.(map[string]task.ServiceStatus) {
+			m.statuses = append(m.statuses, status)
+		}
+	}
+	if v := memStorage.Get(comm.KEY_ALL_SERVICE_STATS); v != nil {
+		m.stats = v.(map[string]task.ServiceStats)
+	}
+	tui.SortStatus(m.statuses)
+	m.updated = time.Now()
+
+	m.selected = 0
+	for i, status := range m.statuses {
+		if status.Id == id {
+			m.selected = i
+		}
+	}
+}
+
+func (m *topModel) selectedService() (task.ServiceStatus, bool) {
+	if m.selected < 0 || m.selected >= len(m.statuses) {
+		return task.ServiceStatus{}, false
+	}
+	return m.statuses[m.selected], true
+}
+
+// move selection or return the action which bound to key
+func (m *topModel) handleKey(key string) int {
+	switch key {
+	case "k", "\033[A": // up
+		if m.selected > 0 {
+			m.selected--
+		}
+	case "j", "\033[B": // down
+		if m.selected < len(m.statuses)-1 {
+			m.selected++
+		}
+	default:
+		return TOP_HOTKEYS[key]
+	}
+	return TOP_ACTION_NONE
+}
+
+func (m *topModel) render(height int) string {
+	kind := "-"
+	if len(m.dcs) > 0 {
+		kind = m.dcs[0].GetKind()
+	}
+	return tui.FormatTop(m.statuses, m.stats, tui.TopView{
+		Cluster:  m.curveadm.ClusterName(),
+		Kind:     kind,
+		Interval: m.interval,
+		Updated:  m.updated,
+		Selected: m.selected,
+		Height:   height,
+		Message:  m.message,
+	})
+}
+
+// run action for selected service outside of full screen
+func (m *topModel) runAction(action int) error {
+	service, ok := m.selectedService()
+	if !ok {
+		return nil
+	}
+
+	switch action {
+	case TOP_ACTION_RESTART:
+		return runRestart(m.curveadm, restartOptions{id: service.Id, role: "*", host: "*"})
+	case TOP_ACTION_ENTER:
+		return runEnter(m.curveadm, enterOptions{id: service.Id})
+	case TOP_ACTION_LOGS:
+		return runLogs(m.curveadm, logsOptions{id: service.Id, role: "*", host: "*", tail: TOP_LOGS_TAIL})
+	}
+	return nil
+}
+
+func actionMessage(action int, id string, err error) string {
+	name := map[int]string{
+		TOP_ACTION_RESTART: "restart",
+		TOP_ACTION_ENTER:   "enter",
+		TOP_ACTION_LOGS:    "logs",
+	}[action]
+	if err == nil {
+		return fmt.Sprintf("%s %s: done", name, id)
+	} else if code, ok := err.(*errno.ErrorCode); ok {
+		if code.GetCode() == errno.ERR_CANCEL_OPERATION.GetCode() {
+			return fmt.Sprintf("%s %s: canceled", name, id)
+		}
+		return fmt.Sprintf("%s %s: %s", name, id, code.GetDescription())
+	}
+	return fmt.Sprintf("%s %s: %s", name, id, err)
+}
+
+/*
+ * terminal helper for full screen:
+ *   keys are read one by one on demand, so that stdin can be handed over to
+ *   restart confirmation or container shell while top is suspended.
+ */
+type topTerminal struct {
+	curveadm *cli.CurveAdm
+	fd       uintptr
+	state    *term.State
+	want     chan struct{}
+	keys     chan string
+}
+
+func newTopTerminal(curveadm *cli.CurveAdm) (*topTerminal, error) {
+	fd, isTerminal := term.GetFdInfo(os.Stdin)
+	if !isTerminal {
+		return nil, errno.ERR_REQUIRE_INTERACTIVE_TERMINAL
+	}
+
+	t := &topTerminal{
+		curveadm: curveadm,
+		fd:       fd,
+		want:     make(chan struct{}),
+		keys:     make(chan string),
+	}
+	go func() {
+		buffer := make([]byte, 16)
+		for range t.want {
+			n, err := os.Stdin.Read(buffer)
+			if err != nil {
+				close(t.keys)
+				return
+			}
+			t.keys <- string(buffer[:n])
+		}
+	}()
+	return t, t.enter()
+}
+
+func (t *topTerminal) enter() error {
+	state, err := term.MakeRaw(t.fd)
+	if err != nil {
+		return errno.ERR_REQUIRE_INTERACTIVE_TERMINAL.E(err)
+	}
+	t.state = state
+	t.curveadm.WriteOut(ANSI_ENTER_ALT_SCREEN)
+	return nil
+}
+
+func (t *topTerminal) leave() {
+	t.curveadm.WriteOut(ANSI_LEAVE_ALT_SCREEN)
+	if t.state != nil {
+		term.RestoreTerminal(t.fd, t.state)
+		t.state = nil
+	}
+}
+
+func (t *topTerminal) close() {
+	t.leave()
+	close(t.want)
+}
+
+func (t *topTerminal) height() int {
+	ws, err := term.GetWinsize(t.fd)
+	if err != nil {
+		return 0
+	}
+	return int(ws.Height)
+}
+
+// output in raw mode requires carriage return
+func (t *topTerminal) draw(output string) {
+	output = strings.ReplaceAll(output, "\n", "\r\n")
+	t.curveadm.WriteOut("%s%s", ANSI_CLEAR_SCREEN, output)
+}
+
+func (t *topTerminal) waitAnyKey() {
+	t.curveadm.WriteOut("\nPress any key to return to top...")
+	if state, err := term.MakeRaw(t.fd); err == nil {
+		t.want <- struct{}{}
+		<-t.keys
+		term.RestoreTerminal(t.fd, state)
+	}
+}
+
+func runTop(curveadm *cli.CurveAdm, options topOptions) error {
+	// 1) parse cluster topology and filter services
+	m, err := newTopModel(curveadm, options)
+	if err != nil {
+		return err
+	}
+
+	// 2) enter full screen
+	t, err := newTopTerminal(curveadm)
+	if err != nil {
+		return err
+	}
+	defer t.close()
+
+	// 3) refresh periodically and handle hotkeys
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	m.refresh()
+	t.want <- struct{}{}
+	for {
+		t.draw(m.render(t.height()))
+		select {
+		case <-ticker.C:
+			m.refresh()
+		case key, ok := <-t.keys:
+			if !ok {
+				return nil
+			}
+			action := m.handleKey(key)
+			switch action {
+			case TOP_ACTION_QUIT:
+				return nil
+			case TOP_ACTION_RESTART, TOP_ACTION_ENTER, TOP_ACTION_LOGS:
+				service, _ := m.selectedService()
+				t.leave()
+				err := m.runAction(action)
+				if action != TOP_ACTION_ENTER {
+					t.waitAnyKey()
+				}
+				m.message = actionMessage(action, service.Id, err)
+				if err := t.enter(); err != nil {
+					return err
+				}
+				m.refresh()
+			}
+			t.want <- struct{}{}
+		}
+	}
+}
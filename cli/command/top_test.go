@@ -0,0 +1,68 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/pkg/module/moduletest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTop(t *testing.T) {
+	assert := assert.New(t)
+	env := newDeployedEnv(t)
+	curveadm := env.CurveAdm
+	env.Executor.On(`ls -t /curvebs/mds/logs/\*\.ERROR\* .*grep -hsE`,
+		moduletest.Reply("E1019 10:00:00.000000 1 mds.cpp:10] connect etcd failed\n"))
+
+	m, err := newTopModel(curveadm, topOptions{id: "*", role: "*", host: "*", interval: "5s"})
+	assert.Nil(err)
+
+	// (1) status and stats of all services
+	m.refresh()
+	assert.Len(m.statuses, 9)
+	assert.Len(env.Executor.Grep(`docker stats --no-stream --format`), 9)
+	assert.Len(env.Executor.Grep(`\*\.log\*`), 0) // only the newest ERROR log is scanned
+	for _, status := range m.statuses {
+		stats := m.stats[status.Id]
+		assert.Equal("0.00%", stats.CPU)
+		assert.Equal("0B / 0B", stats.Memory)
+		assert.Equal(status.Role == topology.ROLE_MDS, len(stats.Errors) == 1)
+	}
+
+	// (2) services are grouped by role
+	output := m.render(0)
+	etcd, mds := strings.Index(output, "[etcd]"), strings.Index(output, "[mds]")
+	chunkserver := strings.Index(output, "[chunkserver]")
+	assert.True(etcd >= 0 && etcd < mds && mds < chunkserver, output)
+	assert.Equal(9, strings.Count(output, "1 minute"))
+
+	// (3) select service by hotkeys
+	assert.Equal(TOP_ACTION_NONE, m.handleKey("k"))
+	assert.Equal(0, m.selected)
+	for i := 0; i < 3; i++ {
+		assert.Equal(TOP_ACTION_NONE, m.handleKey("j"))
+	}
+	assert.Equal(TOP_ACTION_NONE, m.handleKey("\033[B"))
+	assert.Equal(TOP_ACTION_NONE, m.handleKey("\033[A"))
+	service, ok := m.selectedService()
+	assert.True(ok)
+	assert.Equal(topology.ROLE_MDS, service.Role)
+	assert.Contains(m.render(0), "connect etcd failed")
+	assert.Equal(TOP_ACTION_RESTART, m.handleKey("r"))
+	assert.Equal(TOP_ACTION_QUIT, m.handleKey("q"))
+	assert.Equal(TOP_ACTION_NONE, m.handleKey("x"))
+
+	// (4) selection kept after refresh
+	m.refresh()
+	assert.Equal(service.Id, m.statuses[m.selected].Id)
+
+	// (5) restart selected service
+	env.Executor.Reset()
+	env.Answer("yes")
+	err = m.runAction(TOP_ACTION_RESTART)
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`docker restart`), 1)
+	assert.Equal("restart "+service.Id+": done", actionMessage(TOP_ACTION_RESTART, service.Id, err))
+}
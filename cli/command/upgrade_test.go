@@ -0,0 +1,49 @@
+package command
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpgrade(t *testing.T) {
+	assert := assert.New(t)
+	env := newDeployedEnv(t)
+	curveadm := env.CurveAdm
+	dcs, err := curveadm.ParseTopology()
+	assert.Nil(err)
+	dcs = curveadm.FilterDeployConfigByRole(dcs, ROLE_MDS)
+	serviceId := curveadm.GetServiceId(dcs[0].GetId())
+	oldContainerId, err := curveadm.GetContainerId(serviceId)
+	assert.Nil(err)
+
+	// upgrade all mds services at once
+	newImage := "opencurvedocker/curvebs:v1.2.7"
+	env.SetTopology(fmt.Sprintf(TOPOLOGY, newImage, 1))
+	env.Answer("yes")
+	err = runUpgrade(env.CurveAdm, upgradeOptions{id: "*", role: ROLE_MDS, host: "*", force: true})
+	assert.Nil(err, env.Dump())
+
+	// (1) command sequence: pull new image, remove old container, start new one
+	env.AssertOrder(
+		fmt.Sprintf(`docker pull .*%s`, newImage),
+		fmt.Sprintf(`docker stop .*%s`, oldContainerId),
+		fmt.Sprintf(`docker rm .*%s`, oldContainerId),
+		fmt.Sprintf(`docker create .*%s .*--role mds`, newImage),
+		`docker start`,
+	)
+	assert.Len(env.Executor.Grep(`docker create`), 3)
+	assert.Len(env.Executor.Grep(`--role (etcd|chunkserver)`), 0)
+
+	// (2) container id in storage is replaced by the new one
+	newContainerId, err := env.CurveAdm.GetContainerId(serviceId)
+	assert.Nil(err)
+	assert.NotEqual(oldContainerId, newContainerId)
+	container, ok := env.Executor.Container(HOST1, newContainerId)
+	assert.True(ok)
+	assert.Equal(newImage, container.Image)
+	assert.Equal("running", container.Status)
+	_, ok = env.Executor.Container(HOST1, oldContainerId)
+	assert.False(ok)
+}
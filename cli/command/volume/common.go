@@ -0,0 +1,166 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package volume
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/playbook"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/internal/tui"
+)
+
+// all volume operations are executed in the leader mds container,
+// or a random one if the leader can't be determined
+func getLeaderMDS(curveadm *cli.CurveAdm) (*topology.DeployConfig, error) {
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return nil, err
+	} else if len(dcs) == 0 || dcs[0].GetKind() != topology.KIND_CURVEBS {
+		return nil, errno.ERR_REQUIRE_CURVEBS_CLUSTER
+	}
+
+	dcs = curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_MDS)
+	return playbook.AttachLeaderOrRandom(curveadm, dcs)
+}
+
+// volume (user:/name) => hosts which the volume mapped on
+func getMappedHosts(curveadm *cli.CurveAdm) (map[string][]string, error) {
+	clients, err := curveadm.Storage().GetClients()
+	if err != nil {
+		return nil, errno.ERR_GET_ALL_CLIENTS_FAILED.E(err)
+	}
+
+	m := map[string][]string{}
+	for _, client := range clients {
+		if client.Kind != topology.KIND_CURVEBS {
+			continue
+		}
+		auxInfo := &bs.AuxInfo{}
+		if err := json.Unmarshal([]byte(client.AuxInfo), auxInfo); err != nil {
+			continue // volume not created by curveadm
+		}
+		image := fmt.Sprintf("%s:%s", auxInfo.User, auxInfo.Volume)
+		m[image] = append(m[image], client.Host)
+	}
+	return m, nil
+}
+
+// refuse to delete or rename the volume which is in use
+func checkVolumeNotInUse(curveadm *cli.CurveAdm, options bs.VolumeOptions) error {
+	m, err := getMappedHosts(curveadm)
+	if err != nil {
+		return err
+	}
+	image := fmt.Sprintf("%s:%s", options.User, options.Volume)
+	if hosts, ok := m[image]; ok {
+		return errno.ERR_VOLUME_IS_MAPPED.
+			F("volume=%s hosts=%v", image, hosts)
+	}
+	return nil
+}
+
+func genVolumePlaybook(curveadm *cli.CurveAdm,
+	dc *topology.DeployConfig,
+	options bs.VolumeOptions,
+	silent bool) (*playbook.Playbook, error) {
+	pb := playbook.NewPlaybook(curveadm)
+	if options.Op == bs.VOLUME_OP_DELETE || options.Op == bs.VOLUME_OP_RENAME {
+		hcs, err := hosts.ParseHosts(curveadm.Hosts())
+		if err != nil {
+			return nil, err
+		}
+		if len(hcs) > 0 {
+			pb.AddStep(&playbook.PlaybookStep{
+				Type:    playbook.CHECK_VOLUME_TARGET,
+				Configs: hcs,
+				Options: map[string]interface{}{
+					comm.KEY_VOLUME_OPTIONS: options,
+				},
+			})
+		}
+	}
+
+	pb.AddStep(&playbook.PlaybookStep{
+		Type:    playbook.OPERATE_VOLUME,
+		Configs: []*topology.DeployConfig{dc},
+		Options: map[string]interface{}{
+			comm.KEY_VOLUME_OPTIONS: options,
+		},
+		ExecOptions: playbook.ExecOptions{
+			SilentSubBar:  silent,
+			SilentMainBar: silent,
+		},
+	})
+	return pb, nil
+}
+
+func runVolumeOperation(curveadm *cli.CurveAdm, options bs.VolumeOptions, silent bool) ([]bs.Volume, error) {
+	// 1) find the leader mds
+	dc, err := getLeaderMDS(curveadm)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2) generate volume playbook
+	pb, err := genVolumePlaybook(curveadm, dc, options, silent)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3) run playground
+	err = pb.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	// 4) attach the hosts which volume mapped on
+	volumes := []bs.Volume{}
+	if v := curveadm.MemStorage().Get(comm.KEY_ALL_VOLUMES); v != nil {
+		volumes = v.([]bs.Volume)
+	}
+	m, err := getMappedHosts(curveadm)
+	if err != nil {
+		return nil, err
+	}
+	for i, volume := range volumes {
+		volumes[i].MappedBy = m[fmt.Sprintf("%s:%s", volume.User, volume.Name)]
+	}
+	return volumes, nil
+}
+
+func displayVolumes(curveadm *cli.CurveAdm, volumes []bs.Volume, format string) error {
+	if format == cli.FORMAT_JSON {
+		return curveadm.WriteOutJSON(volumes, errno.ERR_ENCODE_VOLUME_INFO_TO_JSON_FAILED)
+	}
+
+	curveadm.WriteOutln("")
+	curveadm.WriteOut(tui.FormatVolumes(volumes))
+	return nil
+}
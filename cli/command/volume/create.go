@@ -0,0 +1,126 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package volume
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/client"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type createOptions struct {
+	image   string
+	size    string
+	poolset string
+	stripe  string
+}
+
+// 64KiB:32 => (65536, 32)
+func parseStripe(stripe string) (uint64, uint64, error) {
+	if len(stripe) == 0 {
+		return 0, 0, nil
+	}
+	items := strings.Split(stripe, ":")
+	if len(items) != 2 {
+		return 0, 0, errno.ERR_INVALID_VOLUME_STRIPE.F("stripe: %s", stripe)
+	}
+	unit, err := humanize.ParseBytes(items[0])
+	if err != nil || unit == 0 {
+		return 0, 0, errno.ERR_INVALID_VOLUME_STRIPE.F("stripe: %s", stripe)
+	}
+	count, err := strconv.ParseUint(items[1], 10, 64)
+	if err != nil || count == 0 {
+		return 0, 0, errno.ERR_INVALID_VOLUME_STRIPE.F("stripe: %s", stripe)
+	}
+	return unit, count, nil
+}
+
+func checkCreateOptions(options createOptions) error {
+	if _, _, err := client.ParseImage(options.image); err != nil {
+		return err
+	} else if _, err = client.ParseSize(options.size); err != nil {
+		return err
+	} else if _, _, err = parseStripe(options.stripe); err != nil {
+		return err
+	}
+	return nil
+}
+
+func NewCreateCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options createOptions
+
+	cmd := &cobra.Command{
+		Use:   "create USER:VOLUME [OPTIONS]",
+		Short: "Create volume",
+		Args:  cliutil.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			return checkCreateOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			return runCreate(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.size, "size", "10GiB", "Specify volume size")
+	flags.StringVar(&options.poolset, "poolset", "", "Specify the poolset of volume")
+	flags.StringVar(&options.stripe, "stripe", "", "Specify volume stripe, like 64KiB:32 (STRIPE_UNIT:STRIPE_COUNT)")
+
+	return cmd
+}
+
+func runCreate(curveadm *cli.CurveAdm, options createOptions) error {
+	// 1) create volume
+	user, volume, _ := client.ParseImage(options.image)
+	size, _ := client.ParseSize(options.size)
+	unit, count, _ := parseStripe(options.stripe)
+	_, err := runVolumeOperation(curveadm, bs.VolumeOptions{
+		Op:          bs.VOLUME_OP_CREATE,
+		User:        user,
+		Volume:      volume,
+		Size:        size,
+		Poolset:     options.poolset,
+		StripeUnit:  unit,
+		StripeCount: count,
+	}, false)
+	if err != nil {
+		return err
+	}
+
+	// 2) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Create volume %s (%s) success ^_^"),
+		options.image, options.size)
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package volume
+
+import (
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/client"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type extendOptions struct {
+	image string
+	size  string
+}
+
+func NewExtendCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options extendOptions
+
+	cmd := &cobra.Command{
+		Use:   "extend USER:VOLUME --size SIZE",
+		Short: "Extend volume to specified size",
+		Args:  cliutil.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			if _, _, err := client.ParseImage(options.image); err != nil {
+				return err
+			}
+			_, err := client.ParseSize(options.size)
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			return runExtend(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.size, "size", "", "Specify the new size of volume")
+	cmd.MarkFlagRequired("size")
+
+	return cmd
+}
+
+func runExtend(curveadm *cli.CurveAdm, options extendOptions) error {
+	// 1) extend volume
+	user, volume, _ := client.ParseImage(options.image)
+	size, _ := client.ParseSize(options.size)
+	_, err := runVolumeOperation(curveadm, bs.VolumeOptions{
+		Op:     bs.VOLUME_OP_EXTEND,
+		User:   user,
+		Volume: volume,
+		Size:   size,
+	}, false)
+	if err != nil {
+		return err
+	}
+
+	// 2) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Extend volume %s to %s success ^_^"),
+		options.image, options.size)
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package volume
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/cli/command/client"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	cliutil "github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type renameOptions struct {
+	image     string
+	newVolume string
+}
+
+func checkRenameOptions(options renameOptions) error {
+	user, _, err := client.ParseImage(options.image)
+	if err != nil {
+		return err
+	}
+	_, _, err = client.ParseImage(fmt.Sprintf("%s:%s", user, options.newVolume))
+	return err
+}
+
+func NewRenameCommand(curveadm *cli.CurveAdm) *cobra.Command {
+	var options renameOptions
+
+	cmd := &cobra.Command{
+		Use:   "rename USER:VOLUME NEW_VOLUME",
+		Short: "Rename volume",
+		Args:  cliutil.ExactArgs(2),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			options.newVolume = args[1]
+			return checkRenameOptions(options)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.image = args[0]
+			options.newVolume = args[1]
+			return runRename(curveadm, options)
+		},
+		DisableFlagsInUseLine: true,
+	}
+
+	return cmd
+}
+
+func runRename(curveadm *cli.CurveAdm, options renameOptions) error {
+	// 1) check whether volume is mapped
+	user, volume, _ := client.ParseImage(options.image)
+	volumeOptions := bs.VolumeOptions{
+		Op:        bs.VOLUME_OP_RENAME,
+		User:      user,
+		Volume:    volume,
+		NewVolume: options.newVolume,
+	}
+	err := checkVolumeNotInUse(curveadm, volumeOptions)
+	if err != nil {
+		return err
+	}
+
+	// 2) rename volume (refuse if it served by target)
+	_, err = runVolumeOperation(curveadm, volumeOptions, false)
+	if err != nil {
+		return err
+	}
+
+	// 3) print success prompt
+	curveadm.WriteOutln("")
+	curveadm.WriteOutln(color.GreenString("Rename volume %s to %s success ^_^"),
+		options.image, options.newVolume)
+	return nil
+}
@@ -0,0 +1,170 @@
+package volume
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curveadm/cli/cli/clitest"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/pkg/module/moduletest"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	HOSTS = `
+global:
+  user: curve
+  ssh_port: 22
+  private_key_file: {{.PrivateKeyFile}}
+hosts:
+  - host: server-host1
+    hostname: 10.0.1.1
+  - host: server-host2
+    hostname: 10.0.1.2
+  - host: client-host
+    hostname: 10.0.1.4
+`
+
+	TOPOLOGY = `
+kind: curvebs
+global:
+  container_image: opencurvedocker/curvebs:v1.2
+  log_dir: /tmp/logs/${service_role}
+  data_dir: /tmp/data/${service_role}
+
+mds_services:
+  config:
+    listen.ip: ${service_host}
+    listen.port: 6700
+    listen.dummy_port: 7700
+  deploy:
+    - host: server-host1
+    - host: server-host2
+`
+
+	LEADER_HOST = "10.0.1.2"
+
+	LIST_DIR_OUTPUT = `{"error": [{"code": 0, "message": "success"}], "result": [
+  {"fileName": "vol1", "fileType": "INODE_PAGEFILE", "owner": "curve", "length": "10737418240"},
+  {"fileName": "vol2", "fileType": "INODE_PAGEFILE", "owner": "wine93", "length": "21474836480"}
+]}`
+)
+
+// mds containers are running and the one on LEADER_HOST is leader
+func newVolumeEnv(t *testing.T) *clitest.Env {
+	env := clitest.New(t, HOSTS)
+	env.AddCluster("c1", TOPOLOGY)
+
+	curveadm := env.CurveAdm
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		t.Fatalf("parse topology: %v", err)
+	}
+	for _, dc := range dcs {
+		host := dc.GetListenIp()
+		out, _ := env.Executor.Run(host, "docker run --name mds opencurvedocker/curvebs:v1.2")
+		serviceId := curveadm.GetServiceId(dc.GetId())
+		err := curveadm.Storage().InsertService(curveadm.ClusterId(), serviceId, strings.TrimSpace(out))
+		if err != nil {
+			t.Fatalf("insert service: %v", err)
+		}
+	}
+	env.Executor.OnHost(LEADER_HOST, `curl .*mds_status`, moduletest.Reply("leader"))
+	env.Executor.On(`curve bs list dir`, moduletest.Reply(LIST_DIR_OUTPUT))
+	env.Executor.Reset()
+	return env
+}
+
+func mapVolume(t *testing.T, env *clitest.Env, user, volume string) {
+	auxInfo, _ := json.Marshal(bs.AuxInfo{User: user, Volume: volume})
+	err := env.CurveAdm.Storage().InsertClient("c1", "curvebs", "client-host", "id", string(auxInfo))
+	if err != nil {
+		t.Fatalf("insert client: %v", err)
+	}
+}
+
+func TestVolume_List(t *testing.T) {
+	assert := assert.New(t)
+	env := newVolumeEnv(t)
+	mapVolume(t, env, "curve", "/vol1")
+
+	volumes, err := runVolumeOperation(env.CurveAdm, bs.VolumeOptions{Op: bs.VOLUME_OP_LIST}, true)
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`curve bs list dir`), 1)
+	cmds := env.Executor.Grep(`curve bs list dir --path / --format json`)
+	assert.Equal(LEADER_HOST, cmds[0].Host)
+
+	assert.Len(volumes, 2)
+	assert.Equal("/vol1", volumes[0].Name)
+	assert.Equal([]string{"client-host"}, volumes[0].MappedBy)
+	assert.Len(filterVolumes(volumes, "wine93"), 1)
+}
+
+func TestVolume_Create(t *testing.T) {
+	assert := assert.New(t)
+	env := newVolumeEnv(t)
+
+	err := runCreate(env.CurveAdm, createOptions{
+		image:  "curve:/vol3",
+		size:   "20GiB",
+		stripe: "64KiB:32",
+	})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(
+		`curve bs create file --path /vol3 --user curve --size 20GiB --stripeunit 65536B --stripecount 32`), 1)
+
+	env.Executor.On(`curve bs create file`,
+		moduletest.Fail(`{"error": [{"code": 101, "message": "file already exist"}]}`))
+	err = runCreate(env.CurveAdm, createOptions{image: "curve:/vol3", size: "20GiB"})
+	assert.Equal(errno.ERR_CREATE_VOLUME_FAILED.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
+
+func TestVolume_DeleteMapped(t *testing.T) {
+	assert := assert.New(t)
+	env := newVolumeEnv(t)
+	mapVolume(t, env, "curve", "/vol1")
+
+	err := runDelete(env.CurveAdm, deleteOptions{image: "curve:/vol1"})
+	assert.Equal(errno.ERR_VOLUME_IS_MAPPED.GetCode(), err.(*errno.ErrorCode).GetCode())
+	assert.Len(env.Executor.Grep(`curve bs delete file`), 0)
+}
+
+func TestVolume_DeleteServedByTarget(t *testing.T) {
+	assert := assert.New(t)
+	env := newVolumeEnv(t)
+	env.Answer("yes")
+	env.Executor.Run("10.0.1.4", "docker run --name curvebs-target-daemon opencurvedocker/curvebs:v1.2")
+	env.Executor.On(`tgtadm --lld iscsi --mode target --op show`, moduletest.Reply(
+		"Target 1: iqn.2022-02.com.opencurve:curve.abc\n"+
+			"        Backing store path: cbd:pool//vol1_curve_\n"))
+
+	err := runDelete(env.CurveAdm, deleteOptions{image: "curve:/vol1"})
+	assert.Equal(errno.ERR_VOLUME_IS_SERVED_BY_TARGET.GetCode(), err.(*errno.ErrorCode).GetCode())
+	assert.Len(env.Executor.Grep(`curve bs delete file`), 0)
+}
+
+func TestVolume_Delete(t *testing.T) {
+	assert := assert.New(t)
+	env := newVolumeEnv(t)
+	env.Answer("yes")
+
+	err := runDelete(env.CurveAdm, deleteOptions{image: "curve:/vol1"})
+	assert.Nil(err, env.Dump())
+	assert.Len(env.Executor.Grep(`curve bs delete file --path /vol1 --user curve --force`), 1)
+}
+
+func TestParseStripe(t *testing.T) {
+	assert := assert.New(t)
+
+	unit, count, err := parseStripe("64KiB:32")
+	assert.Nil(err)
+	assert.Equal(uint64(65536), unit)
+	assert.Equal(uint64(32), count)
+
+	for _, stripe := range []string{"64KiB", "64KiB:0", "x:32"} {
+		_, _, err = parseStripe(stripe)
+		assert.NotNil(err, stripe)
+	}
+}
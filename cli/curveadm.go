@@ -29,6 +29,7 @@ import (
 
 	"github.com/opencurve/curveadm/cli/cli"
 	"github.com/opencurve/curveadm/cli/command"
+	"github.com/opencurve/curveadm/internal/tui"
 )
 
 func Execute() {
@@ -48,6 +49,11 @@ func Execute() {
 	id := curveadm.PreAudit(time.Now(), os.Args[1:])
 	cmd := command.NewCurveAdmCommand(curveadm)
 	err = cmd.Execute()
+	if timing, _ := cmd.PersistentFlags().GetBool("timing"); timing &&
+		len(curveadm.Timing()) > 0 {
+		curveadm.WriteOutln("")
+		curveadm.WriteOut(tui.FormatTiming(curveadm.Timing()))
+	}
 	curveadm.PostAudit(id, err)
 	if err != nil {
 		os.Exit(1)
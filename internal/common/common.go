@@ -59,6 +59,11 @@ const (
 	KEY_CHECK_KERNEL_MODULE_NAME = "CHECK_KERNEL_MODULE_NAME"
 	KEY_CHECK_SKIP_SNAPSHOECLONE = "CHECK_SKIP_SNAPSHOTCLONE"
 	KEY_ALL_HOST_DATE            = "ALL_HOST_DATE"
+	KEY_CHECK_FORMAT_CONFIGS     = "CHECK_FORMAT_CONFIGS"
+	KEY_ALL_SERVICE_DISKS        = "ALL_SERVICE_DISKS"
+	KEY_CHECK_WARNINGS           = "CHECK_WARNINGS"
+	KEY_ALL_NETWORK_PATHS        = "ALL_NETWORK_PATHS"
+	KEY_ALL_HOST_MTU             = "ALL_HOST_MTU"
 
 	// scale-out / migrate
 	KEY_SCALE_OUT_CLUSTER = "SCALE_OUT_CLUSTER"
@@ -67,10 +72,14 @@ const (
 
 	// status
 	KEY_ALL_SERVICE_STATUS = "ALL_SERVICE_STATUS"
+	KEY_ALL_SERVICE_STATS  = "ALL_SERVICE_STATS"
 	SERVICE_STATUS_CLEANED = "Cleaned"
 	SERVICE_STATUS_LOSED   = "Losed"
 	SERVICE_STATUS_UNKNOWN = "Unknown"
 
+	// health
+	KEY_CLUSTER_HEALTH = "CLUSTER_HEALTH"
+
 	// clean
 	KEY_CLEAN_ITEMS      = "CLEAN_ITEMS"
 	KEY_CLEAN_BY_RECYCLE = "CLEAN_BY_RECYCLE"
@@ -86,11 +95,24 @@ const (
 	KEY_CLIENT_STATUS_VERBOSE = "CLIENT_STATUS_VERBOSE"
 	KEY_MAP_OPTIONS           = "MAP_OPTIONS"
 	KEY_MOUNT_OPTIONS         = "MOUNT_OPTIONS"
+	KEY_CLIENT_UPGRADE_IMAGE  = "CLIENT_UPGRADE_IMAGE"
+	KEY_CLIENT_DRAIN_WAIT     = "CLIENT_DRAIN_WAIT"
+	KEY_CLIENT_APPLY_RESULTS  = "CLIENT_APPLY_RESULTS"
 	CLIENT_STATUS_LOSED       = "Losed"
 	CLIENT_STATUS_UNKNOWN     = "Unknown"
 	KERNERL_MODULE_NBD        = "nbd"
+	NBDS_MAX                  = 64 // nbds_max of nbd module, see also map.sh
 	KERNERL_MODULE_FUSE       = "fuse"
 
+	// client drift: the difference between clients table and the actual state of host
+	CLIENT_DRIFT_NONE            = "-"
+	CLIENT_DRIFT_UNKNOWN         = "unknown"
+	CLIENT_DRIFT_CONTAINER_LOSED = "container losed"
+	CLIENT_DRIFT_CONTAINER_DOWN  = "container not running"
+	CLIENT_DRIFT_NOT_MAPPED      = "volume not mapped"
+	CLIENT_DRIFT_NOT_MOUNTED     = "filesystem not mounted"
+	CLIENT_DRIFT_UNIT_DISABLED   = "unit not enabled"
+
 	// polarfs
 	KEY_POLARFS_HOST   = "POLARFS_HOST"
 	KEY_OS_RELEASE     = "OS_RELEASE"
@@ -105,11 +127,30 @@ const (
 	KEY_ALL_CLIENT_IDS            = "ALL_CLIENT_IDS"
 
 	// target
-	KEY_TARGET_OPTIONS = "TARGET_OPTIONS"
-	KEY_ALL_TARGETS    = "ALL_TARGETS"
+	KEY_TARGET_OPTIONS       = "TARGET_OPTIONS"
+	KEY_ALL_TARGETS          = "ALL_TARGETS"
+	TARGET_STATUS_ONLINE     = "Online"
+	TARGET_STATUS_LOST       = "Lost"
+	TARGET_STATUS_UNRECORDED = "Unrecorded"
+
+	// volume
+	KEY_VOLUME_OPTIONS = "VOLUME_OPTIONS"
+	KEY_ALL_VOLUMES    = "ALL_VOLUMES"
+
+	// snapshot
+	KEY_SNAPSHOT_OPTIONS   = "SNAPSHOT_OPTIONS"
+	KEY_SNAPSHOT_TASK_UUID = "SNAPSHOT_TASK_UUID"
+	KEY_ALL_SNAPSHOTS      = "ALL_SNAPSHOTS"
+	KEY_ALL_CLONE_TASKS    = "ALL_CLONE_TASKS"
+
+	// filesystem
+	KEY_FS_OPTIONS      = "FS_OPTIONS"
+	KEY_ALL_FILESYSTEMS = "ALL_FILESYSTEMS"
+	KEY_FS_QUOTA        = "FS_QUOTA"
 
 	// playground
 	KEY_ALL_PLAYGROUNDS_STATUS = "ALL_PLAYGROUNDS_STATUS"
+	KEY_PLAYGROUND_NODES       = "PLAYGROUND_NODES"
 	PLAYGROUDN_STATUS_LOSED    = "Losed"
 
 	// monitor
@@ -121,6 +162,9 @@ const (
 	// copy
 	KEY_COPY_PATH      = "COPY_PATH"
 	KEY_COPY_CONF_PATH = "COPY_CONF_PATH"
+
+	// timing
+	KEY_PLAYBOOK_TIMING = "PLAYBOOK_TIMING"
 )
 
 // others
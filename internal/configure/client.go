@@ -25,6 +25,7 @@ package configure
 import (
 	"bytes"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/opencurve/curveadm/internal/build"
@@ -52,7 +53,11 @@ const (
 	KEY_CLIENT_S3_ADDRESS     = "s3.endpoint"
 	KEY_CLIENT_S3_BUCKET_NAME = "s3.bucket_name"
 
-	DEFAULT_CORE_LOCATE_DIR = "/core"
+	KEY_CURVEBS_CLIENT_METRIC_PORT = "global.metricDummyServerStartPort"
+	KEY_CURVEFS_CLIENT_METRIC_PORT = "client.dummyServerStartPort"
+
+	DEFAULT_CORE_LOCATE_DIR    = "/core"
+	DEFAULT_CLIENT_METRIC_PORT = 9000
 )
 
 const (
@@ -140,7 +145,13 @@ func ParseClientCfg(data string) (*ClientConfig, error) {
 		return nil, errno.ERR_PARSE_CLIENT_CONFIGURE_FAILED.E(err)
 	}
 	build.DEBUG(build.DEBUG_CLIENT_CONFIGURE, config)
-	return NewClientConfig(config)
+	cfg, err := NewClientConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.data = data
+	return cfg, nil
 }
 
 func ParseClientConfig(filename string) (*ClientConfig, error) {
@@ -216,6 +227,36 @@ func (cc *ClientConfig) GetContainerImage() string {
 	return containerImage
 }
 
+// Override overrides the service configure items, e.g. per-mount configure
+func (cc *ClientConfig) Override(overrides map[string]string) error {
+	for k, v := range overrides {
+		key := strings.ToLower(k)
+		if excludeClientConfig[key] || key == KEY_KIND {
+			return errno.ERR_UNSUPPORT_CLIENT_CONFIGURE_OVERRIDE.F("%s", k)
+		}
+		cc.config[key] = v
+		cc.serviceConfig[key] = v
+	}
+	return nil
+}
+
+// GetMetricPort returns the port from which client finds an available one
+// for exposing metrics, the actual port is recorded in client's aux info
+func (cc *ClientConfig) GetMetricPort() int {
+	key := utils.Choose(cc.GetKind() == topology.KIND_CURVEBS,
+		KEY_CURVEBS_CLIENT_METRIC_PORT, KEY_CURVEFS_CLIENT_METRIC_PORT)
+	port, err := strconv.Atoi(cc.serviceConfig[strings.ToLower(key)])
+	if err != nil || port <= 0 {
+		return DEFAULT_CLIENT_METRIC_PORT
+	}
+	return port
+}
+
+// SetContainerImage overrides the container image, e.g. upgrade client
+func (cc *ClientConfig) SetContainerImage(image string) {
+	cc.config[strings.ToLower(KEY_CONTAINER_IMAGE)] = image
+}
+
 func (cc *ClientConfig) GetClusterMDSAddr() string {
 	if cc.GetKind() == topology.KIND_CURVEBS {
 		return cc.getString(KEY_CURVEBS_LISTEN_MDS_ADDRS)
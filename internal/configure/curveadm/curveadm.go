@@ -28,8 +28,10 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 
 	"github.com/opencurve/curveadm/internal/build"
+	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/utils"
 	"github.com/spf13/viper"
@@ -47,6 +49,11 @@ import (
  *
  * [database]
  * url = "sqlite:///home/curve/.curveadm/data/curveadm.db"
+ *
+ * [precheck]
+ * min_nofile = 65535
+ * chunkserver_min_cpu = 2
+ * chunkserver_min_memory = 4  # GiB
  */
 const (
 	KEY_LOG_LEVEL    = "log_level"
@@ -57,6 +64,11 @@ const (
 	KEY_SSH_RETRIES  = "retries"
 	KEY_SSH_TIMEOUT  = "timeout"
 	KEY_DB_URL       = "url"
+	KEY_MIN_NOFILE   = "min_nofile"
+
+	// <role>_min_cpu, <role>_min_memory
+	SUFFIX_MIN_CPU    = "_min_cpu"
+	SUFFIX_MIN_MEMORY = "_min_memory"
 
 	// rqlite://127.0.0.1:4000
 	// sqlite:///home/curve/.curveadm/data/curveadm.db
@@ -77,12 +89,16 @@ type (
 		SSHRetries  int
 		SSHTimeout  int
 		DBUrl       string
+		MinNofile   int
+		MinCPU      map[string]int // role: cores
+		MinMemory   map[string]int // role: GiB
 	}
 
 	CurveAdm struct {
 		Defaults       map[string]interface{} `mapstructure:"defaults"`
 		SSHConnections map[string]interface{} `mapstructure:"ssh_connections"`
 		DataBase       map[string]interface{} `mapstructure:"database"`
+		Precheck       map[string]interface{} `mapstructure:"precheck"`
 	}
 )
 
@@ -112,6 +128,21 @@ func newDefault() *CurveAdmConfig {
 		SSHRetries:  3,
 		SSHTimeout:  10,
 		DBUrl:       fmt.Sprintf("sqlite://%s/.curveadm/data/curveadm.db", home),
+		MinNofile:   65535,
+		MinCPU: map[string]int{
+			topology.ROLE_ETCD:          1,
+			topology.ROLE_MDS:           1,
+			topology.ROLE_CHUNKSERVER:   2,
+			topology.ROLE_SNAPSHOTCLONE: 1,
+			topology.ROLE_METASERVER:    2,
+		},
+		MinMemory: map[string]int{
+			topology.ROLE_ETCD:          2,
+			topology.ROLE_MDS:           2,
+			topology.ROLE_CHUNKSERVER:   4,
+			topology.ROLE_SNAPSHOTCLONE: 2,
+			topology.ROLE_METASERVER:    4,
+		},
 	}
 	return cfg
 }
@@ -244,6 +275,50 @@ func parseDatabaseSection(cfg *CurveAdmConfig, database map[string]interface{})
 	return nil
 }
 
+func parsePrecheckSection(cfg *CurveAdmConfig, precheck map[string]interface{}) error {
+	if precheck == nil {
+		return nil
+	}
+
+	for k, v := range precheck {
+		var m map[string]int
+		var role string
+		if strings.HasSuffix(k, SUFFIX_MIN_CPU) {
+			m, role = cfg.MinCPU, strings.TrimSuffix(k, SUFFIX_MIN_CPU)
+		} else if strings.HasSuffix(k, SUFFIX_MIN_MEMORY) {
+			m, role = cfg.MinMemory, strings.TrimSuffix(k, SUFFIX_MIN_MEMORY)
+		}
+
+		switch {
+		// min_nofile
+		case k == KEY_MIN_NOFILE:
+			num, err := requirePositiveInt(KEY_MIN_NOFILE, v)
+			if err != nil {
+				return err
+			}
+			cfg.MinNofile = num
+
+		// <role>_min_cpu, <role>_min_memory
+		case m != nil:
+			if _, ok := m[role]; !ok {
+				return errno.ERR_UNSUPPORT_CURVEADM_CONFIGURE_ITEM.
+					F("%s: %s", k, v)
+			}
+			num, err := requirePositiveInt(k, v)
+			if err != nil {
+				return err
+			}
+			m[role] = num
+
+		default:
+			return errno.ERR_UNSUPPORT_CURVEADM_CONFIGURE_ITEM.
+				F("%s: %s", k, v)
+		}
+	}
+
+	return nil
+}
+
 type sectionParser struct {
 	parser  func(*CurveAdmConfig, map[string]interface{}) error
 	section map[string]interface{}
@@ -275,6 +350,7 @@ func ParseCurveAdmConfig(filename string) (*CurveAdmConfig, error) {
 		{parseDefaultsSection, global.Defaults},
 		{parseConnectionSection, global.SSHConnections},
 		{parseDatabaseSection, global.DataBase},
+		{parsePrecheckSection, global.Precheck},
 	}
 	for _, item := range items {
 		err := item.parser(cfg, item.section)
@@ -293,6 +369,10 @@ func (cfg *CurveAdmConfig) GetAutoUpgrade() bool { return cfg.AutoUpgrade }
 func (cfg *CurveAdmConfig) GetSSHRetries() int   { return cfg.SSHRetries }
 func (cfg *CurveAdmConfig) GetSSHTimeout() int   { return cfg.SSHTimeout }
 func (cfg *CurveAdmConfig) GetEngine() string    { return cfg.Engine }
+func (cfg *CurveAdmConfig) GetMinNofile() int    { return cfg.MinNofile }
+
+func (cfg *CurveAdmConfig) GetMinCPU(role string) int    { return cfg.MinCPU[role] }
+func (cfg *CurveAdmConfig) GetMinMemory(role string) int { return cfg.MinMemory[role] }
 func (cfg *CurveAdmConfig) GetSudoAlias() string {
 	if len(cfg.SudoAlias) == 0 {
 		return WITHOUT_SUDO
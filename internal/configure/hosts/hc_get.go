@@ -67,6 +67,7 @@ func (hc *HostConfig) getBool(i *comm.Item) bool {
 	return v.(bool)
 }
 
+func (hc *HostConfig) GetHost() string           { return hc.getString(CONFIG_HOST) }
 func (hc *HostConfig) GetName() string           { return hc.getString(CONFIG_NAME) }
 func (hc *HostConfig) GetHostname() string       { return hc.getString(CONFIG_HOSTNAME) }
 func (hc *HostConfig) GetSSHHostname() string    { return hc.getString(CONFIG_SSH_HOSTNAME) }
@@ -74,6 +75,7 @@ func (hc *HostConfig) GetSSHPort() int           { return hc.getInt(CONFIG_SSH_P
 func (hc *HostConfig) GetPrivateKeyFile() string { return hc.getString(CONFIG_PRIVATE_CONFIG_FILE) }
 func (hc *HostConfig) GetForwardAgent() bool     { return hc.getBool(CONFIG_FORWARD_AGENT) }
 func (hc *HostConfig) GetBecomeUser() string     { return hc.getString(CONFIG_BECOME_USER) }
+func (hc *HostConfig) GetLocalContainer() string { return hc.getString(CONFIG_LOCAL_CONTAINER) }
 func (hc *HostConfig) GetEnvs() []string         { return hc.envs }
 
 func (hc *HostConfig) GetLabels() []string {
@@ -105,6 +107,7 @@ func (hc *HostConfig) GetSSHConfig() *module.SSHConfig {
 		BecomeMethod:      "sudo",
 		BecomeFlags:       "-iu",
 		BecomeUser:        hc.GetBecomeUser(),
+		LocalContainer:    hc.GetLocalContainer(),
 		ConnectTimeoutSec: curveadm.GlobalCurveAdmConfig.GetSSHTimeout(),
 		ConnectRetries:    curveadm.GlobalCurveAdmConfig.GetSSHRetries(),
 	}
@@ -106,4 +106,11 @@ var (
 		false,
 		nil,
 	)
+
+	CONFIG_LOCAL_CONTAINER = itemset.Insert(
+		"local_container",
+		comm.REQUIRE_STRING,
+		false,
+		nil,
+	)
 )
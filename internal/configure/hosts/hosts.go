@@ -158,7 +158,8 @@ func (hc *HostConfig) Build() error {
 			F("hosts[%d].private_key_file = %s", hc.sequence, privateKeyFile)
 	}
 
-	if hc.GetForwardAgent() == false {
+	// host which is a local container doesn't connect by SSH
+	if hc.GetForwardAgent() == false && len(hc.GetLocalContainer()) == 0 {
 		if !utils.PathExist(privateKeyFile) {
 			return errno.ERR_PRIVATE_KEY_FILE_NOT_EXIST.
 				F("%s: no such file", privateKeyFile)
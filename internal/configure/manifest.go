@@ -0,0 +1,155 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package configure
+
+import (
+	"path/filepath"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/spf13/viper"
+)
+
+const (
+	DEFAULT_MANIFEST_VOLUME_SIZE = "10GiB"
+	DEFAULT_MANIFEST_POOLSET     = "default"
+	DEFAULT_MANIFEST_FS_TYPE     = "s3"
+)
+
+/*
+ * curvebs: client.yaml     # client configure for maps
+ * curvefs: client-fs.yaml  # client configure for mounts
+ * hosts:
+ *   - host: machine1
+ *     maps:
+ *       - volume: curve:/vol1
+ *         size: 20GiB
+ *         create: true
+ *         throttle: [ iops_total=1000 ]
+ *     mounts:
+ *       - fsname: /s3_001
+ *         mount_point: /mnt/s3_001
+ *         options: [ allow_root ]
+ */
+type (
+	ClientManifest struct {
+		CurveBS string               `mapstructure:"curvebs"`
+		CurveFS string               `mapstructure:"curvefs"`
+		Hosts   []ClientManifestHost `mapstructure:"hosts"`
+	}
+
+	ClientManifestHost struct {
+		Host   string                `mapstructure:"host"`
+		Maps   []ClientManifestMap   `mapstructure:"maps"`
+		Mounts []ClientManifestMount `mapstructure:"mounts"`
+	}
+
+	ClientManifestMap struct {
+		Volume      string   `mapstructure:"volume"` // USER:VOLUME
+		Size        string   `mapstructure:"size"`
+		Create      bool     `mapstructure:"create"`
+		NoExclusive bool     `mapstructure:"no_exclusive"`
+		Poolset     string   `mapstructure:"poolset"`
+		Persist     bool     `mapstructure:"persist"`
+		Device      string   `mapstructure:"device"`
+		Timeout     int      `mapstructure:"timeout"`
+		ReadOnly    bool     `mapstructure:"read_only"`
+		Throttles   []string `mapstructure:"throttle"`
+	}
+
+	ClientManifestMount struct {
+		FSName      string   `mapstructure:"fsname"`
+		MountPoint  string   `mapstructure:"mount_point"`
+		FSType      string   `mapstructure:"fstype"`
+		Persist     bool     `mapstructure:"persist"`
+		FuseOptions []string `mapstructure:"options"`
+		ReadOnly    bool     `mapstructure:"read_only"`
+	}
+)
+
+// the client configure path is relative to the manifest file
+func resolveManifestPath(manifest, path string) string {
+	if len(path) == 0 || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(filepath.Dir(manifest), path)
+}
+
+func (manifest *ClientManifest) setDefault() {
+	for i := range manifest.Hosts {
+		host := &manifest.Hosts[i]
+		for j := range host.Maps {
+			m := &host.Maps[j]
+			if len(m.Size) == 0 {
+				m.Size = DEFAULT_MANIFEST_VOLUME_SIZE
+			}
+			if len(m.Poolset) == 0 {
+				m.Poolset = DEFAULT_MANIFEST_POOLSET
+			}
+		}
+		for j := range host.Mounts {
+			m := &host.Mounts[j]
+			if len(m.FSType) == 0 {
+				m.FSType = DEFAULT_MANIFEST_FS_TYPE
+			}
+			m.MountPoint = utils.TrimSuffixRepeat(m.MountPoint, "/")
+		}
+	}
+}
+
+func ParseClientManifest(filename string) (*ClientManifest, error) {
+	if !utils.PathExist(filename) {
+		return nil, errno.ERR_CLIENT_MANIFEST_FILE_NOT_EXIST.
+			F("filepath: %s", utils.AbsPath(filename))
+	}
+
+	parser := viper.New()
+	parser.SetConfigFile(filename)
+	parser.SetConfigType("yaml")
+	err := parser.ReadInConfig()
+	if err != nil {
+		return nil, errno.ERR_PARSE_CLIENT_MANIFEST_FAILED.E(err)
+	}
+
+	manifest := &ClientManifest{}
+	err = parser.Unmarshal(manifest)
+	if err != nil {
+		return nil, errno.ERR_PARSE_CLIENT_MANIFEST_FAILED.E(err)
+	}
+
+	seen := map[string]bool{}
+	for i, host := range manifest.Hosts {
+		if len(host.Host) == 0 {
+			return nil, errno.ERR_HOST_FIELD_MISSING.F("hosts[%d].host", i)
+		} else if seen[host.Host] {
+			return nil, errno.ERR_DUPLICATE_HOST_IN_CLIENT_MANIFEST.
+				F("host: %s", host.Host)
+		}
+		seen[host.Host] = true
+	}
+
+	manifest.CurveBS = resolveManifestPath(filename, manifest.CurveBS)
+	manifest.CurveFS = resolveManifestPath(filename, manifest.CurveFS)
+	manifest.setDefault()
+	return manifest, nil
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/opencurve/curveadm/internal/configure/hosts"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
 	"github.com/spf13/viper"
 )
 
@@ -40,7 +41,11 @@ const (
 	ROLE_NODE_EXPORTER = "node_exporter"
 	ROLE_PROMETHEUS    = "prometheus"
 	ROLE_GRAFANA       = "grafana"
+	ROLE_ALERTMANAGER  = "alertmanager"
+	ROLE_LOKI          = "loki"     // log store
+	ROLE_PROMTAIL      = "promtail" // log shipper
 	ROLE_MONITOR_CONF  = "monitor_conf"
+	ROLE_CLIENT        = "client"
 
 	KEY_HOST              = "host"
 	KEY_LISTEN_PORT       = "listen_port"
@@ -49,11 +54,37 @@ const (
 	KEY_PROMETHEUS_TARGET = "target"
 	KEY_GRAFANA_USER      = "username"
 	KEY_GRAFANA_PASSWORD  = "password"
-
-	KEY_NODE_IPS         = "node_ips"
-	KRY_NODE_LISTEN_PORT = "node_listen_port"
-	KEY_PROMETHEUS_IP    = "prometheus_listen_ip"
-	KEY_PROMETHEUS_PORT  = "prometheus_listen_port"
+	KEY_ALERT_DISK_USAGE  = "alert.disk_usage" // percent
+	KEY_ALERT_LATENCY     = "alert.latency"    // millisecond
+	KEY_WEBHOOK_URL       = "webhook_url"
+	KEY_EMAIL_TO          = "email_to"
+	KEY_SMTP_SMARTHOST    = "smtp_smarthost"
+	KEY_SMTP_FROM         = "smtp_from"
+	KEY_DEPLOY            = "deploy"
+	KEY_REMOTE_WRITE      = "remote_write"
+	KEY_REMOTE_WRITE_URL  = "url"
+	KEY_REMOTE_WRITE_USER = "username"
+	KEY_REMOTE_WRITE_PASS = "password"
+
+	KEY_NODE_ADDRS        = "node_addrs"
+	KEY_PROMETHEUS_ADDRS  = "prometheus_addrs"
+	KEY_ALERTMANAGER_ADDR = "alertmanager_addr"
+	KEY_CLUSTER_IMAGE     = "cluster_image"
+	KEY_LOKI_ADDR         = "loki_addr"
+	KEY_LOG_TARGETS       = "log_targets"
+
+	TARGET_LABEL_JOB         = "job"
+	TARGET_LABEL_CLUSTER     = "cluster"
+	TARGET_LABEL_ROLE        = "role"
+	TARGET_LABEL_HOST        = "host"
+	TARGET_LABEL_ZONE        = "zone"
+	TARGET_LABEL_INSTANCE_ID = "instance_id"
+
+	DEFAULT_ALERT_DISK_USAGE = 85
+	DEFAULT_ALERT_LATENCY    = 100
+	DEFAULT_SMTP_SMARTHOST   = "localhost:25"
+	DEFAULT_SMTP_FROM        = "alertmanager@localhost"
+	DEFAULT_LOG_RETENTION    = "7d"
 )
 
 type monitor struct {
@@ -61,6 +92,9 @@ type monitor struct {
 	NodeExporter map[string]interface{} `mapstructure:"node_exporter"`
 	Prometheus   map[string]interface{} `mapstructure:"prometheus"`
 	Grafana      map[string]interface{} `mapstructure:"grafana"`
+	Alertmanager map[string]interface{} `mapstructure:"alertmanager"`
+	Loki         map[string]interface{} `mapstructure:"loki"`
+	Promtail     map[string]interface{} `mapstructure:"promtail"`
 }
 
 type MonitorConfig struct {
@@ -72,6 +106,18 @@ type MonitorConfig struct {
 	ctx    *topology.Context
 }
 
+type RemoteWrite struct {
+	Url      string
+	Username string
+	Password string
+}
+
+// log files of service which shipped by promtail
+type LogTarget struct {
+	Path   string
+	Labels map[string]string
+}
+
 type serviceTarget struct {
 	Targets []string          `json:"targets"`
 	Labels  map[string]string `json:"labels"`
@@ -123,16 +169,43 @@ func (m *MonitorConfig) GetHost() string {
 	return m.host
 }
 
-func (m *MonitorConfig) GetNodeIps() []string {
-	return m.getStrings(&m.config, KEY_NODE_IPS)
+func (m *MonitorConfig) GetNodeAddrs() []string {
+	return m.getStrings(&m.config, KEY_NODE_ADDRS)
+}
+
+func (m *MonitorConfig) GetPrometheusAddrs() []string {
+	return m.getStrings(&m.config, KEY_PROMETHEUS_ADDRS)
+}
+
+func (m *MonitorConfig) GetClusterImage() string {
+	return m.getString(&m.config, KEY_CLUSTER_IMAGE)
 }
 
-func (m *MonitorConfig) GetNodeListenPort() int {
-	return m.getInt(&m.config, KRY_NODE_LISTEN_PORT)
+func (m *MonitorConfig) GetLokiAddr() string {
+	return m.getString(&m.config, KEY_LOKI_ADDR)
 }
 
-func (m *MonitorConfig) GetPrometheusListenPort() int {
-	return m.getInt(&m.config, KEY_PROMETHEUS_PORT)
+func (m *MonitorConfig) GetLokiRetentionTime() string {
+	if v := m.getString(&m.config, KEY_RETENTION_TIME); len(v) > 0 {
+		return v
+	}
+	return DEFAULT_LOG_RETENTION
+}
+
+func (m *MonitorConfig) GetLogTargets() []LogTarget {
+	v := m.config[KEY_LOG_TARGETS]
+	if v == nil {
+		return []LogTarget{}
+	}
+	return v.([]LogTarget)
+}
+
+func (m *MonitorConfig) GetRemoteWrite() []RemoteWrite {
+	v := m.config[KEY_REMOTE_WRITE]
+	if v == nil {
+		return []RemoteWrite{}
+	}
+	return v.([]RemoteWrite)
 }
 
 func (m *MonitorConfig) GetImage() string {
@@ -163,10 +236,6 @@ func (m *MonitorConfig) GetPrometheusTarget() string {
 	return m.getString(&m.config, KEY_PROMETHEUS_TARGET)
 }
 
-func (m *MonitorConfig) GetPrometheusIp() string {
-	return m.getString(&m.config, KEY_PROMETHEUS_IP)
-}
-
 func (m *MonitorConfig) GetGrafanaUser() string {
 	return m.getString(&m.config, KEY_GRAFANA_USER)
 }
@@ -175,31 +244,290 @@ func (m *MonitorConfig) GetGrafanaPassword() string {
 	return m.getString(&m.config, KEY_GRAFANA_PASSWORD)
 }
 
-func getHost(c *monitor, role string) string {
-	h := c.Host
-	switch role {
-	case ROLE_NODE_EXPORTER:
-		if _, ok := c.NodeExporter[KEY_HOST]; ok {
-			return c.NodeExporter[KEY_HOST].(string)
+func (m *MonitorConfig) GetAlertDiskUsage() int {
+	if v := m.getInt(&m.config, KEY_ALERT_DISK_USAGE); v > 0 {
+		return v
+	}
+	return DEFAULT_ALERT_DISK_USAGE
+}
+
+func (m *MonitorConfig) GetAlertLatency() int {
+	if v := m.getInt(&m.config, KEY_ALERT_LATENCY); v > 0 {
+		return v
+	}
+	return DEFAULT_ALERT_LATENCY
+}
+
+func (m *MonitorConfig) GetAlertmanagerAddr() string {
+	return m.getString(&m.config, KEY_ALERTMANAGER_ADDR)
+}
+
+func (m *MonitorConfig) GetWebhookUrl() string {
+	return m.getString(&m.config, KEY_WEBHOOK_URL)
+}
+
+func (m *MonitorConfig) GetEmailTo() string {
+	return m.getString(&m.config, KEY_EMAIL_TO)
+}
+
+func (m *MonitorConfig) GetSMTPSmarthost() string {
+	if v := m.getString(&m.config, KEY_SMTP_SMARTHOST); len(v) > 0 {
+		return v
+	}
+	return DEFAULT_SMTP_SMARTHOST
+}
+
+func (m *MonitorConfig) GetSMTPFrom() string {
+	if v := m.getString(&m.config, KEY_SMTP_FROM); len(v) > 0 {
+		return v
+	}
+	return DEFAULT_SMTP_FROM
+}
+
+func copyConfig(section map[string]interface{}) map[string]interface{} {
+	config := map[string]interface{}{}
+	for k, v := range section {
+		if k != KEY_DEPLOY {
+			config[k] = v
+		}
+	}
+	return config
+}
+
+/*
+ * deploy:
+ *   - host: server-host1
+ *     listen_port: 9090  # any item of the role can be overwritten
+ *   - host: server-host2
+ *
+ * the role will be deployed on default hosts if deploy section not specified
+ */
+func parseDeploy(role string, section map[string]interface{}, hosts []string) (
+	[]map[string]interface{}, error) {
+	items := []interface{}{}
+	if v, ok := section[KEY_DEPLOY]; ok {
+		deploy, ok := v.([]interface{})
+		if !ok || len(deploy) == 0 {
+			return nil, errno.ERR_INVALID_MONITOR_DEPLOY.F("%s.%s", role, KEY_DEPLOY)
+		}
+		items = deploy
+	} else {
+		for _, host := range hosts {
+			items = append(items, map[string]interface{}{KEY_HOST: host})
+		}
+	}
+
+	configs := []map[string]interface{}{}
+	exist := map[string]bool{}
+	for i, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, errno.ERR_INVALID_MONITOR_DEPLOY.F("%s.%s[%d]", role, KEY_DEPLOY, i)
+		}
+		host, ok := m[KEY_HOST].(string)
+		if !ok || len(host) == 0 {
+			return nil, errno.ERR_HOST_FIELD_MISSING.F("%s.%s[%d].host", role, KEY_DEPLOY, i)
+		} else if exist[host] {
+			return nil, errno.ERR_DUPLICATE_MONITOR_SERVICE_HOST.F("%s: %s", role, host)
+		}
+		exist[host] = true
+
+		config := copyConfig(section)
+		for k, v := range m {
+			config[strings.ToLower(k)] = v
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+// remote_write:
+//   - url: http://tsdb:8086/api/v1/prom/write
+//     username: curve
+//     password: curve
+func parseRemoteWrite(section map[string]interface{}) ([]RemoteWrite, error) {
+	v, ok := section[KEY_REMOTE_WRITE]
+	if !ok {
+		return []RemoteWrite{}, nil
+	}
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil, errno.ERR_INVALID_PROMETHEUS_REMOTE_WRITE.F("%s", KEY_REMOTE_WRITE)
+	}
+
+	rws := []RemoteWrite{}
+	for i, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, errno.ERR_INVALID_PROMETHEUS_REMOTE_WRITE.F("%s[%d]", KEY_REMOTE_WRITE, i)
+		}
+		rw := RemoteWrite{}
+		rw.Url, _ = m[KEY_REMOTE_WRITE_URL].(string)
+		rw.Username, _ = m[KEY_REMOTE_WRITE_USER].(string)
+		rw.Password, _ = m[KEY_REMOTE_WRITE_PASS].(string)
+		if len(rw.Url) == 0 {
+			return nil, errno.ERR_INVALID_PROMETHEUS_REMOTE_WRITE.
+				F("%s[%d].%s: missing", KEY_REMOTE_WRITE, i, KEY_REMOTE_WRITE_URL)
+		}
+		rws = append(rws, rw)
+	}
+	return rws, nil
+}
+
+// the host of role, e.g. prometheus.host > host
+func getRoleHosts(c *monitor, section map[string]interface{}) []string {
+	if host, ok := section[KEY_HOST].(string); ok && len(host) > 0 {
+		return []string{host}
+	}
+	return []string{c.Host}
+}
+
+// log directories of services on the host, labeled same as scrape targets
+func ParseLogTargets(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig, host string) []LogTarget {
+	targets := []LogTarget{}
+	for _, dc := range dcs {
+		if dc.GetHost() != host || len(dc.GetLogDir()) == 0 {
+			continue
+		}
+		targets = append(targets, LogTarget{
+			Path: dc.GetLogDir(),
+			Labels: map[string]string{
+				TARGET_LABEL_CLUSTER:     curveadm.ClusterName(),
+				TARGET_LABEL_ROLE:        dc.GetRole(),
+				TARGET_LABEL_HOST:        dc.GetHost(),
+				TARGET_LABEL_INSTANCE_ID: curveadm.GetServiceId(dc.GetId()),
+			},
+		})
+	}
+	return targets
+}
+
+// address of services, the listen ip of cluster service is preferred
+func getAddrs(configs []map[string]interface{}, ctx *topology.Context,
+	hostIps map[string]string) []string {
+	addrs := []string{}
+	for _, config := range configs {
+		host := config[KEY_HOST].(string)
+		ip, ok := hostIps[host]
+		if !ok {
+			ip = ctx.Lookup(host)
+		}
+		addrs = append(addrs, fmt.Sprintf("%s:%v", ip, config[KEY_LISTEN_PORT]))
+	}
+	return addrs
+}
+
+// zone of chunkserver/metaserver which recorded in cluster pool
+func getServiceZones(pool string) map[string]string {
+	zones := map[string]string{}
+	topo := CurveClusterTopo{}
+	if len(pool) == 0 || json.Unmarshal([]byte(pool), &topo) != nil {
+		return zones
+	}
+	for _, server := range topo.Servers {
+		zones[server.Name] = server.Zone
+	}
+	return zones
+}
+
+// the clients table has no cluster column, so the clients whose configure
+// shares MDS address with cluster are regarded as its clients, and the metric
+// port which client actually listens on is recorded in aux info
+func getClientTargets(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig,
+	ctx *topology.Context) ([]serviceTarget, error) {
+	clients, err := curveadm.Storage().GetClients()
+	if err != nil {
+		return nil, errno.ERR_GET_ALL_CLIENTS_FAILED.E(err)
+	}
+
+	kind := dcs[0].GetKind()
+	mdsAddrs := map[string]bool{}
+	for _, dc := range curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_MDS) {
+		mdsAddrs[fmt.Sprintf("%s:%d", dc.GetListenIp(), dc.GetListenPort())] = true
+	}
+
+	targets := []serviceTarget{}
+	for _, client := range clients {
+		if client.Kind != kind {
+			continue
 		}
-		c.NodeExporter[KEY_HOST] = h
-	case ROLE_PROMETHEUS:
-		if _, ok := c.Prometheus[KEY_HOST]; ok {
-			return c.Prometheus[KEY_HOST].(string)
+		belong, err := isClusterClient(curveadm, client, mdsAddrs)
+		if err != nil {
+			return nil, err
+		} else if !belong {
+			continue
 		}
-		c.Prometheus[KEY_HOST] = h
-	case ROLE_GRAFANA:
-		if _, ok := c.Grafana[KEY_HOST]; ok {
-			return c.Grafana[KEY_HOST].(string)
+
+		auxInfo := struct {
+			MetricPort int `json:"metric_port"`
+		}{}
+		json.Unmarshal([]byte(client.AuxInfo), &auxInfo)
+		port := auxInfo.MetricPort
+		if port <= 0 {
+			port = DEFAULT_CLIENT_METRIC_PORT
 		}
-		c.Grafana[KEY_HOST] = h
+		targets = append(targets, serviceTarget{
+			Targets: []string{fmt.Sprintf("%s:%d", ctx.Lookup(client.Host), port)},
+			Labels: map[string]string{
+				TARGET_LABEL_JOB:         ROLE_CLIENT,
+				TARGET_LABEL_CLUSTER:     curveadm.ClusterName(),
+				TARGET_LABEL_ROLE:        ROLE_CLIENT,
+				TARGET_LABEL_HOST:        client.Host,
+				TARGET_LABEL_INSTANCE_ID: client.Id,
+			},
+		})
 	}
-	return h
+	return targets, nil
 }
 
-func parsePrometheusTarget(dcs []*topology.DeployConfig) (string, error) {
+func isClusterClient(curveadm *cli.CurveAdm, client storage.Client, mdsAddrs map[string]bool) (bool, error) {
+	cfgs, err := curveadm.Storage().GetClientConfig(client.Id)
+	if err != nil {
+		return false, errno.ERR_SELECT_CLIENT_CONFIG_FAILED.E(err)
+	} else if len(cfgs) == 0 {
+		return false, nil
+	}
+
+	cc, err := ParseClientCfg(cfgs[0].Data)
+	if err != nil {
+		return false, err
+	}
+	for _, addr := range strings.Split(cc.GetClusterMDSAddr(), ",") {
+		if mdsAddrs[strings.TrimSpace(addr)] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+/*
+ * [
+ *   {
+ *     "targets": [ "10.0.1.1:8200" ],
+ *     "labels": {
+ *       "job": "chunkserver",
+ *       "cluster": "my-cluster",
+ *       "role": "chunkserver",
+ *       "host": "server-host1",
+ *       "zone": "zone1",
+ *       "instance_id": "c8d4a1f2b3e5"
+ *     }
+ *   },
+ *   ...
+ * ]
+ */
+func ParsePrometheusTarget(curveadm *cli.CurveAdm, dcs []*topology.DeployConfig, pool string) (string, error) {
+	hcs, err := hosts.ParseHosts(curveadm.Hosts())
+	if err != nil {
+		return "", err
+	}
+	ctx := topology.NewContext()
+	for _, hc := range hcs {
+		ctx.Add(hc.GetName(), hc.GetHostname())
+	}
+
 	targets := []serviceTarget{}
-	tMap := make(map[string]serviceTarget)
+	zones := getServiceZones(pool)
 	for _, dc := range dcs {
 		role := dc.GetRole()
 		ip := dc.GetListenIp()
@@ -214,20 +542,30 @@ func parsePrometheusTarget(dcs []*topology.DeployConfig) (string, error) {
 		case topology.ROLE_SNAPSHOTCLONE:
 			item = fmt.Sprintf("%s:%d", ip, dc.GetListenDummyPort())
 		}
-		if _, ok := tMap[role]; ok {
-			t := tMap[role]
-			t.Targets = append(t.Targets, item)
-			tMap[role] = t
-		} else {
-			tMap[role] = serviceTarget{
-				Labels:  map[string]string{"job": role},
-				Targets: []string{item},
-			}
+		labels := map[string]string{
+			TARGET_LABEL_JOB:         role,
+			TARGET_LABEL_CLUSTER:     curveadm.ClusterName(),
+			TARGET_LABEL_ROLE:        role,
+			TARGET_LABEL_HOST:        dc.GetHost(),
+			TARGET_LABEL_INSTANCE_ID: curveadm.GetServiceId(dc.GetId()),
 		}
+		if zone, ok := zones[formatName(dc)]; ok {
+			labels[TARGET_LABEL_ZONE] = zone
+		}
+		targets = append(targets, serviceTarget{
+			Targets: []string{item},
+			Labels:  labels,
+		})
 	}
-	for _, v := range tMap {
-		targets = append(targets, v)
+
+	if len(dcs) > 0 {
+		clients, err := getClientTargets(curveadm, dcs, ctx)
+		if err != nil {
+			return "", err
+		}
+		targets = append(targets, clients...)
 	}
+
 	target, err := json.Marshal(targets)
 	if err != nil {
 		return "", errno.ERR_PARSE_PROMETHEUS_TARGET_FAILED.E(err)
@@ -236,7 +574,7 @@ func parsePrometheusTarget(dcs []*topology.DeployConfig) (string, error) {
 }
 
 func ParseMonitorConfig(curveadm *cli.CurveAdm, filename string, data string, hs []string,
-	hostIps []string, dcs []*topology.DeployConfig) (
+	dcs []*topology.DeployConfig) (
 	[]*MonitorConfig, error) {
 	parser := viper.NewWithOptions(viper.KeyDelimiter("::"))
 	parser.SetConfigType("yaml")
@@ -281,59 +619,98 @@ func ParseMonitorConfig(curveadm *cli.CurveAdm, filename string, data string, hs
 	case config.Grafana != nil:
 		roles = append(roles, ROLE_GRAFANA)
 	}
+	if config.Alertmanager != nil {
+		roles = append(roles, ROLE_ALERTMANAGER)
+	}
+	if config.Loki != nil && config.Promtail != nil {
+		roles = append(roles, ROLE_LOKI, ROLE_PROMTAIL)
+	} else if config.Loki != nil || config.Promtail != nil {
+		return nil, errno.ERR_INCOMPLETE_MONITOR_LOG_PIPELINE
+	}
+	// role -> services' configure
+	sections := map[string]map[string]interface{}{
+		ROLE_NODE_EXPORTER: config.NodeExporter,
+		ROLE_PROMETHEUS:    config.Prometheus,
+		ROLE_GRAFANA:       config.Grafana,
+		ROLE_ALERTMANAGER:  config.Alertmanager,
+		ROLE_LOKI:          config.Loki,
+		ROLE_PROMTAIL:      config.Promtail,
+	}
+	services := map[string][]map[string]interface{}{}
+	for _, role := range roles {
+		// node_exporter and promtail are deployed on all hosts of cluster by default
+		hosts := hs
+		if role != ROLE_NODE_EXPORTER && role != ROLE_PROMTAIL {
+			hosts = getRoleHosts(&config, sections[role])
+		}
+		configs, err := parseDeploy(role, sections[role], hosts)
+		if err != nil {
+			return nil, err
+		} else if (role == ROLE_GRAFANA || role == ROLE_ALERTMANAGER || role == ROLE_LOKI) &&
+			len(configs) != 1 {
+			return nil, errno.ERR_MONITOR_ROLE_REQUIRES_SINGLE_SERVICE.
+				F("%s: %d services", role, len(configs))
+		}
+		services[role] = configs
+	}
+
+	hostIps := map[string]string{}
+	for _, dc := range dcs {
+		if _, ok := hostIps[dc.GetHost()]; !ok {
+			hostIps[dc.GetHost()] = dc.GetListenIp()
+		}
+	}
+
+	target, err := ParsePrometheusTarget(curveadm, dcs, curveadm.ClusterPoolData())
+	if err != nil {
+		return nil, err
+	}
+
 	ret := []*MonitorConfig{}
 	for _, role := range roles {
-		host := getHost(&config, role)
-		switch role {
-		case ROLE_PROMETHEUS:
-			target, err := parsePrometheusTarget(dcs)
-			if err != nil {
-				return nil, err
-			}
-			if config.NodeExporter != nil {
-				config.Prometheus[KEY_NODE_IPS] = hostIps
-				config.Prometheus[KRY_NODE_LISTEN_PORT] = config.NodeExporter[KEY_LISTEN_PORT]
-			}
-			config.Prometheus[KEY_PROMETHEUS_TARGET] = target
-			ret = append(ret, &MonitorConfig{
-				kind:   mkind,
-				id:     fmt.Sprintf("%s_%s", role, host),
-				role:   role,
-				host:   host,
-				config: config.Prometheus,
-				ctx:    ctx,
-			})
-		case ROLE_GRAFANA:
-			if config.Prometheus != nil {
-				config.Grafana[KEY_PROMETHEUS_PORT] = config.Prometheus[KEY_LISTEN_PORT]
-				config.Grafana[KEY_PROMETHEUS_IP] = ctx.Lookup(config.Prometheus[KEY_HOST].(string))
+		for _, cfg := range services[role] {
+			host := cfg[KEY_HOST].(string)
+			switch role {
+			case ROLE_PROMETHEUS:
+				rws, err := parseRemoteWrite(cfg)
+				if err != nil {
+					return nil, err
+				}
+				cfg[KEY_PROMETHEUS_TARGET] = target
+				cfg[KEY_REMOTE_WRITE] = rws
+				cfg[KEY_NODE_ADDRS] = getAddrs(services[ROLE_NODE_EXPORTER], ctx, hostIps)
+				if addrs := getAddrs(services[ROLE_ALERTMANAGER], ctx, nil); len(addrs) > 0 {
+					cfg[KEY_ALERTMANAGER_ADDR] = addrs[0]
+				}
+			case ROLE_GRAFANA:
+				cfg[KEY_PROMETHEUS_ADDRS] = getAddrs(services[ROLE_PROMETHEUS], ctx, nil)
+				cfg[KEY_CLUSTER_IMAGE] = mconfImage // for selecting dashboards
+				if addrs := getAddrs(services[ROLE_LOKI], ctx, nil); len(addrs) > 0 {
+					cfg[KEY_LOKI_ADDR] = addrs[0]
+				}
+			case ROLE_PROMTAIL:
+				cfg[KEY_LOKI_ADDR] = getAddrs(services[ROLE_LOKI], ctx, nil)[0]
+				cfg[KEY_LOG_TARGETS] = ParseLogTargets(curveadm, dcs, host)
 			}
+
 			ret = append(ret, &MonitorConfig{
 				kind:   mkind,
 				id:     fmt.Sprintf("%s_%s", role, host),
 				role:   role,
 				host:   host,
-				config: config.Grafana,
+				config: cfg,
 				ctx:    ctx,
-			}, &MonitorConfig{
-				kind: mkind,
-				id:   fmt.Sprintf("%s_%s", ROLE_MONITOR_CONF, host),
-				role: ROLE_MONITOR_CONF,
-				host: host,
-				config: map[string]interface{}{
-					KEY_CONTAINER_IMAGE: mconfImage,
-				},
-				ctx: ctx,
 			})
-		case ROLE_NODE_EXPORTER:
-			for _, h := range hs {
+			if role == ROLE_GRAFANA {
 				ret = append(ret, &MonitorConfig{
-					kind:   mkind,
-					id:     fmt.Sprintf("%s_%s", role, h),
-					role:   role,
-					host:   h,
-					config: config.NodeExporter,
-					ctx:    ctx,
+					kind: mkind,
+					id:   fmt.Sprintf("%s_%s", ROLE_MONITOR_CONF, host),
+					role: ROLE_MONITOR_CONF,
+					host: host,
+					config: map[string]interface{}{
+						KEY_CONTAINER_IMAGE: mconfImage,
+					},
+					ctx: ctx,
 				})
 			}
 		}
@@ -23,12 +23,18 @@
 package configure
 
 import (
+	"fmt"
+
 	"github.com/opencurve/curveadm/internal/configure/topology"
 )
 
 const (
 	DEFAULT_CURVEBS_CONTAINER_IMAGE = "opencurvedocker/curvebs-playground:v1.2"
 	DEFAULT_CURVEFS_CONTAINER_IMAGE = "opencurvedocker/curvefs-playground:v2.3"
+	// node of multi-node playground, which runs a docker daemon with bash and sudo installed
+	DEFAULT_PLAYGROUND_NODE_IMAGE = "opencurvedocker/playground-node:latest"
+
+	FORMAT_PLAYGROUND_NODE_NAME = "%s-node%d" // playground-curvebs-1656035415-node1
 )
 
 type (
@@ -37,6 +43,8 @@ type (
 		Name           string
 		ContainerImage string
 		Mountpoint     string
+		Nodes          int // number of nodes which acting as hosts, 1 means all-in-one
+		NodeImage      string
 
 		DeployConfigs []*topology.DeployConfig
 		ClientConfig  *ClientConfig
@@ -48,6 +56,20 @@ func (cfg *PlaygroundConfig) GetName() string                            { retur
 func (cfg *PlaygroundConfig) GetMointpoint() string                      { return cfg.Mountpoint }
 func (cfg *PlaygroundConfig) GetDeployConfigs() []*topology.DeployConfig { return cfg.DeployConfigs }
 func (cfg *PlaygroundConfig) GetClientConfig() *ClientConfig             { return cfg.ClientConfig }
+func (cfg *PlaygroundConfig) GetNodes() int                              { return cfg.Nodes }
+func (cfg *PlaygroundConfig) IsMultiNode() bool                          { return cfg.Nodes > 1 }
+
+func (cfg *PlaygroundConfig) GetNodeImage() string {
+	if len(cfg.NodeImage) > 0 {
+		return cfg.NodeImage
+	}
+	return DEFAULT_PLAYGROUND_NODE_IMAGE
+}
+
+// node name is also used as container name and host name, sequence starts from 1
+func (cfg *PlaygroundConfig) GetNodeName(sequence int) string {
+	return fmt.Sprintf(FORMAT_PLAYGROUND_NODE_NAME, cfg.Name, sequence)
+}
 
 func (cfg *PlaygroundConfig) GetContainIamge() string {
 	if len(cfg.ContainerImage) > 0 {
@@ -141,6 +141,8 @@ func (e *ErrorCode) Error() string {
  *   20*: hosts
  *   21*: cluster
  *   22*: client
+ *   23*: playground
+ *   24*: audit
  *
  * 3xx: configure (curveadm.cfg, hosts.yaml, topology.yaml, format.yaml...)
  *   300: common
@@ -181,6 +183,9 @@ func (e *ErrorCode) Error() string {
  *   55*: date
  *   56*: service
  *   57*: client
+ *   58*: host
+ *     580: disk
+ *     581: resource
  *   59*: others
  *
  * 6xx: execute task
@@ -226,21 +231,31 @@ var (
 	ERR_GET_ALL_CLIENTS_FAILED         = EC(113003, "execute SQL failed which get all clients")
 	ERR_DELETE_CLIENT_FAILED           = EC(113004, "execute SQL failed which delete client")
 	ERR_SET_CLIENT_AUX_INFO_FAILED     = EC(113005, "execute SQL failed which set client aux info")
+	ERR_SET_CLIENT_CONTAINER_ID_FAILED = EC(113006, "execute SQL failed which set client container id")
 	// 114: database/SQL (execute SQL statement: playground table)
 	ERR_INSERT_PLAYGROUND_FAILED      = EC(114000, "execute SQL failed which insert playground")
 	ERR_GET_ALL_PLAYGROUND_FAILED     = EC(114001, "execute SQL failed which get all playgrounds")
 	ERR_GET_PLAYGROUND_BY_NAME_FAILED = EC(114002, "execute SQL failed which get playground by name")
 	ERR_DELETE_PLAYGROUND_FAILED      = EC(114003, "execute SQL failed which delete playground")
 	// 115: database/SQL (execute SQL statement: audit table)
-	ERR_GET_AUDIT_LOGS_FAILE = EC(115000, "execute SQL failed which get audit logs")
+	ERR_GET_AUDIT_LOGS_FAILE       = EC(115000, "execute SQL failed which get audit logs")
+	ERR_SELECT_AUDIT_TIMING_FAILED = EC(115001, "execute SQL failed which select audit timing")
 	// 116: database/SQL (execute SQL statement: any table)
-	ERR_INSERT_CLIENT_CONFIG_FAILED = EC(116000, "execute SQL failed which insert client config")
-	ERR_SELECT_CLIENT_CONFIG_FAILED = EC(116001, "execute SQL failed which select client config")
-	ERR_DELETE_CLIENT_CONFIG_FAILED = EC(116002, "execute SQL failed which delete client config")
+	ERR_INSERT_CLIENT_CONFIG_FAILED    = EC(116000, "execute SQL failed which insert client config")
+	ERR_SELECT_CLIENT_CONFIG_FAILED    = EC(116001, "execute SQL failed which select client config")
+	ERR_DELETE_CLIENT_CONFIG_FAILED    = EC(116002, "execute SQL failed which delete client config")
+	ERR_INSERT_PLAYGROUND_HOSTS_FAILED = EC(116004, "execute SQL failed which insert playground hosts")
+	ERR_SELECT_PLAYGROUND_HOSTS_FAILED = EC(116005, "execute SQL failed which select playground hosts")
+	ERR_DELETE_PLAYGROUND_HOSTS_FAILED = EC(116006, "execute SQL failed which delete playground hosts")
 	// 117: database/SQL (execute SQL statement: monitor table)
 	ERR_GET_MONITOR_FAILED     = EC(117000, "execute SQL failed while get monitor")
 	ERR_REPLACE_MONITOR_FAILED = EC(117001, "execute SQL failed while replace monitor")
 	ERR_UPDATE_MONITOR_FAILED  = EC(117002, "execute SQL failed while update monitor")
+	// 118: database/SQL (execute SQL statement: targets table)
+	ERR_INSERT_TARGET_FAILED   = EC(118000, "execute SQL failed which insert target")
+	ERR_GET_TARGET_FAILED      = EC(118001, "execute SQL failed which get target")
+	ERR_GET_ALL_TARGETS_FAILED = EC(118002, "execute SQL failed which get all targets")
+	ERR_DELETE_TARGET_FAILED   = EC(118003, "execute SQL failed which delete target")
 
 	// 200: command options (hosts)
 
@@ -255,9 +270,15 @@ var (
 	// TODO: please check pool set disk type
 	ERR_INVALID_DISK_TYPE                   = EC(210007, "poolset disk type must be lowercase and can only be one of ssd, hdd and nvme")
 	ERR_NO_LEADER_OR_RANDOM_CONTAINER_FOUND = EC(210008, "no leader or random container found")
+	ERR_UNSUPPORT_OUTPUT_FORMAT             = EC(210009, "unsupport output format (table/json)")
+	ERR_REQUIRE_CURVEBS_CLUSTER             = EC(210010, "require curvebs cluster, please checkout a curvebs cluster first")
+	ERR_REQUIRE_CURVEFS_CLUSTER             = EC(210011, "require curvefs cluster, please checkout a curvefs cluster first")
+	ERR_INVALID_REFRESH_INTERVAL            = EC(210012, "refresh interval requires a positive duration, like 2s or 1m")
+	ERR_REQUIRE_INTERACTIVE_TERMINAL        = EC(210013, "require an interactive terminal")
 
 	// 220: commad options (client common)
-	ERR_UNSUPPORT_CLIENT_KIND = EC(220000, "unsupport client kind")
+	ERR_UNSUPPORT_CLIENT_KIND     = EC(220000, "unsupport client kind")
+	ERR_REQUIRE_CLIENT_ID_OR_HOST = EC(220001, "require client id or host, like --id ID or --host HOST")
 	// 221: command options (client/bs)
 	ERR_INVALID_VOLUME_FORMAT                      = EC(221000, "invalid volume format")
 	ERR_ROOT_VOLUME_USER_NOT_ALLOWED               = EC(221001, "root as volume user is not allowed")
@@ -271,14 +292,43 @@ var (
 	ERR_VOLUME_BLOCKSIZE_MUST_END_WITH_BYTE_SUFFIX = EC(201009, "volume block size must end with \"B\" suffix")
 	ERR_VOLUME_BLOCKSIZE_REQUIRES_POSITIVE_INTEGER = EC(221010, "volume block size requires a positive integer")
 	ERR_VOLUME_BLOCKSIZE_BE_MULTIPLE_OF_512        = EC(221011, "volume block size be a multiple of 512B, like 1KiB, 2KiB, 3KiB...")
+	ERR_INVALID_VOLUME_STRIPE                      = EC(221012, "invalid volume stripe, it should be like 64KiB:32 (STRIPE_UNIT:STRIPE_COUNT)")
+	ERR_INVALID_TARGET_INITIATOR                   = EC(221013, "invalid target initiator, it should be an IP address, CIDR or iSCSI name")
+	ERR_CHAP_FILE_NOT_EXIST                        = EC(221014, "CHAP credentials file not exist")
+	ERR_CHAP_FILE_REQUIRE_600_PERMISSIONS          = EC(221015, "CHAP credentials file require 600 permissions")
+	ERR_INVALID_CHAP_CREDENTIALS                   = EC(221016, "invalid CHAP credentials, user and password are required")
+	ERR_MULTI_GATEWAY_TARGET_REQUIRE_NO_EXCLUSIVE  = EC(221017, "target exported on multiple hosts requires non exclusive, please specify --no-exclusive")
+	ERR_DUPLICATE_TARGET_LUN                       = EC(221018, "duplicate volume in target LUNs")
+	ERR_TARGET_ALREADY_EXIST                       = EC(221019, "target already exist")
+	ERR_TARGET_NOT_FOUND                           = EC(221020, "target not found")
+	ERR_INVALID_NBD_DEVICE                         = EC(221021, "invalid NBD device, it should be like /dev/nbd0")
+	ERR_INVALID_NBD_TIMEOUT                        = EC(221022, "NBD timeout requires a positive integer (seconds)")
+	ERR_INVALID_VOLUME_THROTTLE                    = EC(221023, "invalid volume throttle, it should be like iops_total=1000 or bps_write=100MiB")
 	// 222: command options (client/fs)
 	ERR_FS_MOUNTPOINT_REQUIRE_ABSOLUTE_PATH = EC(222000, "mount point must be an absolute path")
+	ERR_UNSUPPORT_FILESYSTEM_TYPE           = EC(222001, "unsupport filesystem type (s3/volume/hybrid)")
+	ERR_FILESYSTEM_REQUIRE_VOLUME           = EC(222002, "filesystem with volume backend requires a volume, like --volume curve:/fs1")
+	ERR_INVALID_FILESYSTEM_QUOTA            = EC(222003, "filesystem quota requires capacity or inodes")
+	ERR_INVALID_MOUNT_OPTION                = EC(222004, "invalid mount option, it should be like key or key=value")
+	ERR_INVALID_CLIENT_CONFIGURE_OVERRIDE   = EC(222005, "invalid client configure override, it should be like key=value")
 
 	// 230: command options (playground)
 	ERR_UNSUPPORT_PLAYGROUND_KIND                      = EC(230000, "unsupport playground kind")
 	ERR_MUST_SPECIFY_MOUNTPOINT_FOR_CURVEFS_PLAYGROUND = EC(230001, "you must specify mountpoint for curvefs playground")
 	ERR_PLAYGROUND_MOUNTPOINT_REQUIRE_ABSOLUTE_PATH    = EC(230002, "mount point must be an absolute path")
 	ERR_PLAYGROUND_MOUNTPOINT_NOT_EXIST                = EC(230003, "mount point not exist")
+	ERR_INVALID_PLAYGROUND_NODES                       = EC(230004, "playground requires 1 node or at least 3 nodes")
+	ERR_PLAYGROUND_KIND_MISMATCH                       = EC(230005, "kind of topology or client configure mismatch with playground")
+	ERR_CUSTOM_CONFIGURE_REQUIRE_ALL_IN_ONE_PLAYGROUND = EC(230006, "custom topology and client configure only supported by all-in-one playground")
+	ERR_PLAYGROUND_SERVICES_SHARE_PREFIX               = EC(230007, "services in playground must have different prefix")
+	ERR_MULTI_NODES_PLAYGROUND_ONLY_SUPPORT_CURVEBS    = EC(230008, "playground with multiple nodes only supports curvebs")
+	ERR_PLAYGROUND_UNSUPPORT_LOG_OR_DATA_DIR           = EC(230009, "log_dir and data_dir are unsupported by playground, logs and data are kept under prefix in container")
+
+	// 240: command options (audit)
+	ERR_INVALID_AUDIT_LOG_ID       = EC(240000, "invalid audit log id")
+	ERR_AUDIT_LOG_NOT_FOUND        = EC(240001, "audit log not found")
+	ERR_DECODE_AUDIT_TIMING_FAILED = EC(240002, "decode audit timing failed")
+	ERR_NO_TIMING_FOR_AUDIT_LOG    = EC(240003, "no timing recorded for audit log")
 
 	// 301: configure (common: invalid configure value)
 	ERR_UNSUPPORT_CONFIGURE_VALUE_TYPE = EC(301000, "unsupport configure value type")
@@ -320,6 +370,12 @@ var (
 	ERR_PARSE_PROMETHEUS_TARGET_FAILED   = EC(322002, "parse prometheus targets failed")
 	ERR_PARSE_CURVE_MANAGER_CONF_FAILED  = EC(322003, "parse curve-manager configure failed")
 	ERR_UPDATE_CURVE_MANAGER_CONF_FAILED = EC(322004, "update curve-manager configure failed")
+	// 323: configure (monitor.yaml: invalid configure value)
+	ERR_INVALID_MONITOR_DEPLOY               = EC(323000, "invalid deploy section in monitor configure")
+	ERR_DUPLICATE_MONITOR_SERVICE_HOST       = EC(323001, "monitor service host is duplicate")
+	ERR_MONITOR_ROLE_REQUIRES_SINGLE_SERVICE = EC(323002, "monitor role requires exactly one service")
+	ERR_INVALID_PROMETHEUS_REMOTE_WRITE      = EC(323003, "invalid remote_write in prometheus configure")
+	ERR_INCOMPLETE_MONITOR_LOG_PIPELINE      = EC(323004, "loki and promtail should be configured together")
 
 	// 330: configure (topology.yaml: parse failed)
 	ERR_TOPOLOGY_FILE_NOT_FOUND         = EC(330000, "topology file not found")
@@ -371,6 +427,16 @@ var (
 	ERR_REQUIRE_CURVEBS_KIND_CLIENT_CONFIGURE_FILE = EC(351002, "require curvebs kind client configure file")
 	ERR_REQUIRE_CURVEFS_KIND_CLIENT_CONFIGURE_FILE = EC(351003, "require curvefs kind client configure file")
 	ERR_INVALID_CLUSTER_LISTEN_MDS_ADDRESS         = EC(351004, "invalid cluster MDS listen address")
+	ERR_UNSUPPORT_CLIENT_CONFIGURE_OVERRIDE        = EC(351005, "unsupport to override client configure item")
+
+	// 352: configure (clients.yaml: parse failed)
+	ERR_CLIENT_MANIFEST_FILE_NOT_EXIST = EC(352000, "client manifest file not exist")
+	ERR_PARSE_CLIENT_MANIFEST_FAILED   = EC(352001, "parse client manifest failed")
+	// 353: configure (clients.yaml: invalid configure value)
+	ERR_DUPLICATE_HOST_IN_CLIENT_MANIFEST   = EC(353000, "host is duplicate in client manifest")
+	ERR_DUPLICATE_CLIENT_IN_CLIENT_MANIFEST = EC(353001, "volume or mount point is duplicate in client manifest")
+	ERR_CLIENT_MANIFEST_REQUIRES_CURVEBS    = EC(353002, "client manifest with maps requires curvebs client configure, like 'curvebs: client.yaml'")
+	ERR_CLIENT_MANIFEST_REQUIRES_CURVEFS    = EC(353003, "client manifest with mounts requires curvefs client configure, like 'curvefs: client.yaml'")
 
 	// 400: common (hosts)
 	ERR_HOST_NOT_FOUND = EC(400000, "host not found")
@@ -399,6 +465,12 @@ var (
 	ERR_ENCRYPT_FILE_FAILED                  = EC(410021, "encrypt file failed")
 	ERR_CLIENT_ID_NOT_FOUND                  = EC(410022, "client id not found")
 	ERR_ENABLE_ETCD_AUTH_FAILED              = EC(410023, "enable etcd auth failed")
+	ERR_DECODE_CLIENT_AUX_INFO_FAILED        = EC(410024, "decode client aux info failed")
+	ERR_REPAIR_CLIENT_FAILED                 = EC(410025, "repair client failed")
+	ERR_CLIENT_CONFIGURE_NOT_FOUND           = EC(410026, "client configure not found")
+	ERR_UPGRADE_CLIENT_FAILED                = EC(410027, "upgrade client failed")
+	ERR_ENCODE_CLUSTER_HEALTH_TO_JSON_FAILED = EC(410028, "encode cluster health to json failed")
+	ERR_CLUSTER_UNHEALTHY                    = EC(410029, "cluster is unhealthy")
 
 	// 420: common (curvebs client)
 	ERR_VOLUME_ALREADY_MAPPED             = EC(420000, "volume already mapped")
@@ -410,12 +482,50 @@ var (
 	ERR_UNMAP_VOLUME_FAILED               = EC(420006, "unmap volume failed")
 	ERR_OLD_TARGET_DAEMON_IS_ABNORMAL     = EC(420007, "old target daemon is abnormal")
 	ERR_TARGET_DAEMON_IS_ABNORMAL         = EC(420008, "target daemon is abnormal")
+	ERR_VOLUME_IS_MAPPED                  = EC(420009, "volume is mapped, please unmap it first")
+	ERR_VOLUME_IS_SERVED_BY_TARGET        = EC(420010, "volume is served by target, please delete the target first")
+	ERR_LIST_VOLUMES_FAILED               = EC(420011, "list volumes failed")
+	ERR_GET_VOLUME_INFO_FAILED            = EC(420012, "get volume info failed")
+	ERR_EXTEND_VOLUME_FAILED              = EC(420013, "extend volume failed")
+	ERR_DELETE_VOLUME_FAILED              = EC(420014, "delete volume failed")
+	ERR_RENAME_VOLUME_FAILED              = EC(420015, "rename volume failed")
+	ERR_DECODE_VOLUME_INFO_FAILED         = EC(420016, "decode volume info failed")
+	ERR_NO_SNAPSHOTCLONE_SERVICE          = EC(420017, "no snapshotclone service in cluster")
+	ERR_REQUEST_SNAPSHOTCLONE_FAILED      = EC(420018, "request snapshotclone service failed")
+	ERR_DECODE_SNAPSHOTCLONE_RESPONSE     = EC(420019, "decode response of snapshotclone service failed")
+	ERR_CREATE_SNAPSHOT_FAILED            = EC(420020, "create snapshot failed")
+	ERR_LIST_SNAPSHOTS_FAILED             = EC(420021, "list snapshots failed")
+	ERR_DELETE_SNAPSHOT_FAILED            = EC(420022, "delete snapshot failed")
+	ERR_CANCEL_SNAPSHOT_FAILED            = EC(420023, "cancel snapshot failed")
+	ERR_CLONE_VOLUME_FAILED               = EC(420024, "clone volume failed")
+	ERR_FLATTEN_VOLUME_FAILED             = EC(420025, "flatten volume failed")
+	ERR_LIST_CLONE_TASKS_FAILED           = EC(420026, "list clone tasks failed")
+	ERR_SNAPSHOT_TASK_FAILED              = EC(420027, "snapshot task failed")
+	ERR_CLONE_TASK_FAILED                 = EC(420028, "clone task failed")
+	ERR_SNAPSHOT_TASK_NOT_FOUND           = EC(420029, "snapshot or clone task not found")
+	ERR_ADD_TARGET_FAILED                 = EC(420030, "add target failed")
+	ERR_ENCODE_TARGET_INFO_TO_JSON_FAILED = EC(420031, "encode target info to json failed")
+	ERR_DECODE_TARGET_INFO_FAILED         = EC(420032, "decode target info failed")
+	ERR_UPDATE_VOLUME_THROTTLE_FAILED     = EC(420033, "update volume throttle failed")
+	ERR_WAIT_SNAPSHOT_TASK_TIMEOUT        = EC(420034, "wait snapshot or clone task timeout")
+	ERR_ENCODE_SNAPSHOT_TO_JSON_FAILED    = EC(420035, "encode snapshot info to json failed")
+	ERR_RESTART_NEBD_SERVER_FAILED        = EC(420036, "restart nebd-server in place failed")
 
 	// 430: common (curvefs client)
-	ERR_FS_PATH_ALREADY_MOUNTED  = EC(430000, "path already mounted")
-	ERR_CREATE_FILESYSTEM_FAILED = EC(430001, "create filesystem failed")
-	ERR_MOUNT_FILESYSTEM_FAILED  = EC(430002, "mount filesystem failed")
-	ERR_UMOUNT_FILESYSTEM_FAILED = EC(430003, "umount filesystem failed")
+	ERR_FS_PATH_ALREADY_MOUNTED       = EC(430000, "path already mounted")
+	ERR_CREATE_FILESYSTEM_FAILED      = EC(430001, "create filesystem failed")
+	ERR_MOUNT_FILESYSTEM_FAILED       = EC(430002, "mount filesystem failed")
+	ERR_UMOUNT_FILESYSTEM_FAILED      = EC(430003, "umount filesystem failed")
+	ERR_FS_IS_MOUNTED                 = EC(430004, "filesystem is mounted, please umount it first")
+	ERR_LIST_FILESYSTEMS_FAILED       = EC(430005, "list filesystems failed")
+	ERR_GET_FILESYSTEM_INFO_FAILED    = EC(430006, "get filesystem info failed")
+	ERR_DELETE_FILESYSTEM_FAILED      = EC(430007, "delete filesystem failed")
+	ERR_SET_FILESYSTEM_QUOTA_FAILED   = EC(430008, "set filesystem quota failed")
+	ERR_GET_FILESYSTEM_QUOTA_FAILED   = EC(430009, "get filesystem quota failed")
+	ERR_DECODE_FILESYSTEM_INFO_FAILED = EC(430010, "decode filesystem info failed")
+	ERR_FS_MOUNT_POINT_BUSY           = EC(430011, "mount point is busy")
+	ERR_ENCODE_FS_INFO_TO_JSON_FAILED = EC(430012, "encode filesystem info to json failed")
+	ERR_CHECK_MOUNT_POINT_BUSY_FAILED = EC(430013, "check whether mount point is busy failed")
 
 	// 440: common (polarfs)
 	ERR_GET_OS_REELASE_FAILED       = EC(440000, "get os release failed")
@@ -423,7 +533,8 @@ var (
 	ERR_INSTALL_PFSD_PACKAGE_FAILED = EC(440002, "install pfsd package failed")
 
 	// 450: common (playground)
-	ERR_PLAYGROUND_NOT_FOUND = EC(450000, "playground not found")
+	ERR_PLAYGROUND_NOT_FOUND            = EC(450000, "playground not found")
+	ERR_INVALID_PLAYGROUND_NODE_ADDRESS = EC(450001, "invalid playground node address")
 
 	// 500: checker (topology/s3)
 	ERR_INVALID_S3_ACCESS_KEY  = EC(500000, "invalid S3 access key")
@@ -466,6 +577,9 @@ var (
 	ERR_PORT_ALREADY_IN_USE                = EC(540000, "port is already in use")
 	ERR_DESTINATION_UNREACHABLE            = EC(540001, "destination unreachable")
 	ERR_CONNET_MOCK_SERVICE_ADDRESS_FAILED = EC(540002, "try to connect mock service listen address failed")
+	ERR_INCONSISTENT_MTU                   = EC(540003, "MTU is inconsistent between service hosts")
+	ERR_JUMBO_FRAME_NOT_SUPPORTED          = EC(540004, "jumbo frame can't pass through the network path")
+	ERR_UNRECOGNIZED_NETWORK_INTERFACE     = EC(540005, "unrecognized network interface of listen address")
 
 	// 550: checker (date)
 	ERR_INVALID_DATE_FORMAT                  = EC(550000, "invalid date format")
@@ -480,6 +594,17 @@ var (
 	ERR_INVALID_CURVEFS_CLIENT_S3_ADDRESS     = EC(570002, "invalid curvefs client S3 address")
 	ERR_INVALID_CURVEFS_CLIENT_S3_BUCKET_NAME = EC(570003, "invalid curvefs client S3 bucket name")
 
+	// 580: checker (disk)
+	ERR_UNRECOGNIZED_DISK_FREE_OUTPUT      = EC(580000, "unrecognized disk free output (df)")
+	ERR_DATA_DIRECTORY_NO_ENOUGH_CAPACITY  = EC(580001, "data directory has no enough capacity for copysets")
+	ERR_NO_INODES_LEFT_ON_DEVICE           = EC(580002, "no inodes left on device")
+	ERR_FILESYSTEM_MOUNTED_READ_ONLY       = EC(580003, "filesystem is mounted read-only")
+	ERR_DISK_NOT_MOUNTED_ON_DATA_DIRECTORY = EC(580004, "formatted disk is not mounted on data directory")
+	ERR_CHUNKSERVER_INSTANCES_SHARE_DISK   = EC(580005, "multiple chunkserver instances share one physical disk")
+	// 581: checker (resource)
+	ERR_UNRECOGNIZED_HOST_RESOURCE = EC(581000, "unrecognized host resource information")
+	ERR_HOST_NO_ENOUGH_MEMORY      = EC(581001, "host has no enough memory for services")
+
 	// 590: checker (others)
 	ERR_CONTAINER_ENGINE_NOT_INSTALLED = EC(590000, "container engine docker/podman not installed")
 	ERR_DOCKER_DAEMON_IS_NOT_RUNNING   = EC(590001, "docker daemon is not running")
@@ -527,6 +652,7 @@ var (
 	ERR_SECURE_COPY_FILE_TO_REMOTE_FAILED          = EC(620026, "secure copy file to remote failed (scp)")
 	ERR_GET_BLOCK_DEVICE_UUID_FAILED               = EC(620027, "get block device uuid failed (blkid)")
 	ERR_RESERVE_FILESYSTEM_BLOCKS_FAILED           = EC(620028, "reserve filesystem blocks (tune2fs)")
+	ERR_CONTROL_SYSTEMD_UNIT_FAILED                = EC(620029, "control systemd unit failed (systemctl)")
 	ERR_RUN_SCRIPT_FAILED                          = EC(620998, "run script failed (bash script.sh)")
 	ERR_RUN_A_BASH_COMMAND_FAILED                  = EC(620999, "run a bash command failed (bash -c)")
 
@@ -546,9 +672,15 @@ var (
 	ERR_INSPECT_CONTAINER_FAILED         = EC(630012, "get container low-level information failed")
 	ERR_GET_CONTAINER_LOGS_FAILED        = EC(630013, "get container logs failed")
 	ERR_UPDATE_CONTAINER_FAILED          = EC(630014, "update container failed")
+	ERR_RENAME_CONTAINER_FAILED          = EC(630015, "rename container failed")
+	ERR_KILL_CONTAINER_FAILED            = EC(630016, "kill container failed")
+	ERR_GET_CONTAINER_STATS_FAILED       = EC(630017, "get container resource usage statistics failed")
+	ERR_CREATE_NETWORK_FAILED            = EC(630018, "create container network failed")
+	ERR_REMOVE_NETWORK_FAILED            = EC(630019, "remove container network failed")
 
 	// 690: execuetr task (others)
 	ERR_START_CRONTAB_IN_CONTAINER_FAILED = EC(690000, "start crontab in container failed")
+	ERR_GRAFANA_DASHBOARDS_NOT_FOUND      = EC(690001, "grafana dashboards not found")
 
 	// 900: others
 	ERR_CANCEL_OPERATION = EC(CODE_CANCEL_OPERATION, "cancel operation")
@@ -47,9 +47,14 @@ const (
 	CHECK_DESTINATION_REACHABLE
 	START_HTTP_SERVER
 	CHECK_NETWORK_FIREWALL
+	MEASURE_NETWORK_QUALITY
+	CHECK_NETWORK_QUALITY
 	GET_HOST_DATE
 	CHECK_HOST_DATE
 	CHECK_CHUNKFILE_POOL
+	CHECK_DISK
+	CHECK_SHARED_DISK
+	CHECK_HOST_RESOURCE
 	CHECK_S3
 	CLEAN_PRECHECK_ENVIRONMENT
 
@@ -71,6 +76,7 @@ const (
 	UPDATE_TOPOLOGY
 	INIT_SERVIE_STATUS
 	GET_SERVICE_STATUS
+	GET_SERVICE_STATS
 	CLEAN_SERVICE
 	INIT_SUPPORT
 	COLLECT_REPORT
@@ -81,6 +87,9 @@ const (
 	CHECK_MDS_ADDRESS
 	INIT_CLIENT_STATUS
 	GET_CLIENT_STATUS
+	REPAIR_CLIENT
+	UPGRADE_CLIENT
+	APPLY_CLIENT
 	INSTALL_CLIENT
 	UNINSTALL_CLIENT
 	ATTACH_LEADER_OR_RANDOM_CONTAINER
@@ -91,6 +100,7 @@ const (
 	GET_FORMAT_STATUS
 	STOP_FORMAT
 	BALANCE_LEADER
+	GET_CLUSTER_HEALTH
 	START_NEBD_SERVICE
 	CREATE_VOLUME
 	MAP_IMAGE
@@ -100,9 +110,11 @@ const (
 	PULL_MONITOR_IMAGE
 	CREATE_MONITOR_CONTAINER
 	SYNC_MONITOR_CONFIG
+	SYNC_MONITOR_TARGET
 	CLEAN_CONFIG_CONTAINER
 	START_MONITOR_SERVICE
 	RESTART_MONITOR_SERVICE
+	RELOAD_MONITOR_SERVICE
 	STOP_MONITOR_SERVICE
 	INIT_MONITOR_STATUS
 	GET_MONITOR_STATUS
@@ -115,10 +127,19 @@ const (
 	DELETE_TARGET
 	LIST_TARGETS
 
+	// bs/volume
+	OPERATE_VOLUME
+	CHECK_VOLUME_TARGET
+
+	// bs/snapshot
+	REQUEST_SNAPSHOTCLONE
+	WAIT_SNAPSHOTCLONE_TASK
+
 	// fs
 	CHECK_CLIENT_S3
 	MOUNT_FILESYSTEM
 	UMOUNT_FILESYSTEM
+	OPERATE_FILESYSTEM
 
 	// polarfs
 	DETECT_OS_RELEASE
@@ -131,6 +152,7 @@ const (
 	START_PLAYGROUND
 	REMOVE_PLAYGROUND
 	GET_PLAYGROUND_STATUS
+	CREATE_PLAYGROUND_NODES
 
 	// unknown
 	UNKNOWN
@@ -158,6 +180,8 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 		switch step.Type {
 		case CHECK_SSH_CONNECT,
 			GET_HOST_DATE,
+			MEASURE_NETWORK_QUALITY,
+			CHECK_HOST_RESOURCE,
 			PULL_IMAGE:
 			host := config.GetDC(i).GetHost()
 			if once[host] {
@@ -186,12 +210,22 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = checker.NewStartHTTPServerTask(curveadm, config.GetDC(i))
 		case CHECK_NETWORK_FIREWALL:
 			t, err = checker.NewCheckNetworkFirewallTask(curveadm, config.GetDC(i))
+		case MEASURE_NETWORK_QUALITY:
+			t, err = checker.NewMeasureNetworkQualityTask(curveadm, config.GetDC(i))
+		case CHECK_NETWORK_QUALITY:
+			t, err = checker.NewCheckNetworkQualityTask(curveadm, nil)
 		case GET_HOST_DATE:
 			t, err = checker.NewGetHostDate(curveadm, config.GetDC(i))
 		case CHECK_HOST_DATE:
 			t, err = checker.NewCheckDate(curveadm, nil)
 		case CHECK_CHUNKFILE_POOL:
 			t, err = checker.NewCheckChunkfilePoolTask(curveadm, config.GetDC(i))
+		case CHECK_DISK:
+			t, err = checker.NewCheckDiskTask(curveadm, config.GetDC(i))
+		case CHECK_SHARED_DISK:
+			t, err = checker.NewCheckSharedDiskTask(curveadm, nil)
+		case CHECK_HOST_RESOURCE:
+			t, err = checker.NewCheckHostResourceTask(curveadm, config.GetDC(i))
 		case CHECK_S3:
 			t, err = checker.NewCheckS3Task(curveadm, config.GetDC(i))
 		case CHECK_MDS_ADDRESS:
@@ -227,6 +261,8 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = comm.NewInitServiceStatusTask(curveadm, config.GetDC(i))
 		case GET_SERVICE_STATUS:
 			t, err = comm.NewGetServiceStatusTask(curveadm, config.GetDC(i))
+		case GET_SERVICE_STATS:
+			t, err = comm.NewGetServiceStatsTask(curveadm, config.GetDC(i))
 		case ATTACH_LEADER_OR_RANDOM_CONTAINER:
 			t, err = comm.NewAttachLeaderOrRandomContainerTask(curveadm, config.GetDC(i))
 		case CLEAN_SERVICE:
@@ -247,6 +283,12 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = comm.NewInitClientStatusTask(curveadm, config.GetAny(i))
 		case GET_CLIENT_STATUS:
 			t, err = comm.NewGetClientStatusTask(curveadm, config.GetAny(i))
+		case REPAIR_CLIENT:
+			t, err = comm.NewRepairClientTask(curveadm, config.GetAny(i))
+		case UPGRADE_CLIENT:
+			t, err = comm.NewUpgradeClientTask(curveadm, config.GetAny(i))
+		case APPLY_CLIENT:
+			t, err = comm.NewApplyClientTask(curveadm, config.GetAny(i))
 		case INSTALL_CLIENT:
 			t, err = comm.NewInstallClientTask(curveadm, config.GetCC(i))
 		case UNINSTALL_CLIENT:
@@ -262,6 +304,8 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = bs.NewStopFormatTask(curveadm, config.GetFC(i))
 		case BALANCE_LEADER:
 			t, err = bs.NewBalanceTask(curveadm, config.GetDC(i))
+		case GET_CLUSTER_HEALTH:
+			t, err = bs.NewGetClusterHealthTask(curveadm, config.GetDC(i))
 		case START_NEBD_SERVICE:
 			t, err = bs.NewStartNEBDServiceTask(curveadm, config.GetCC(i))
 		case CREATE_VOLUME:
@@ -276,11 +320,21 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 		case STOP_TARGET_DAEMON:
 			t, err = bs.NewStopTargetDaemonTask(curveadm, nil)
 		case ADD_TARGET:
-			t, err = bs.NewAddTargetTask(curveadm, config.GetCC(i))
+			t, err = bs.NewAddTargetTask(curveadm, config.GetAny(i))
 		case DELETE_TARGET:
-			t, err = bs.NewDeleteTargetTask(curveadm, nil)
+			t, err = bs.NewDeleteTargetTask(curveadm, config.GetAny(i))
 		case LIST_TARGETS:
-			t, err = bs.NewListTargetsTask(curveadm, nil)
+			t, err = bs.NewListTargetsTask(curveadm, config.GetAny(i))
+		// bs/volume
+		case OPERATE_VOLUME:
+			t, err = bs.NewVolumeTask(curveadm, config.GetDC(i))
+		case CHECK_VOLUME_TARGET:
+			t, err = bs.NewCheckVolumeTargetTask(curveadm, config.GetHC(i))
+		// bs/snapshot
+		case REQUEST_SNAPSHOTCLONE:
+			t, err = bs.NewSnapshotCloneTask(curveadm, config.GetDC(i))
+		case WAIT_SNAPSHOTCLONE_TASK:
+			t, err = bs.NewWaitSnapshotCloneTask(curveadm, config.GetDC(i))
 		// fs
 		case CHECK_CLIENT_S3:
 			t, err = checker.NewClientS3ConfigureTask(curveadm, config.GetCC(i))
@@ -288,6 +342,8 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = fs.NewMountFSTask(curveadm, config.GetCC(i))
 		case UMOUNT_FILESYSTEM:
 			t, err = fs.NewUmountFSTask(curveadm, config.GetCC(i))
+		case OPERATE_FILESYSTEM:
+			t, err = fs.NewFSTask(curveadm, config.GetDC(i))
 		// polarfs
 		case DETECT_OS_RELEASE:
 			t, err = bs.NewDetectOSReleaseTask(curveadm, nil)
@@ -306,6 +362,8 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = pg.NewRemovePlaygroundTask(curveadm, config.GetAny(i))
 		case GET_PLAYGROUND_STATUS:
 			t, err = pg.NewGetPlaygroundStatusTask(curveadm, config.GetAny(i))
+		case CREATE_PLAYGROUND_NODES:
+			t, err = pg.NewCreatePlaygroundNodesTask(curveadm, config.GetPGC(i))
 		// monitor
 		case PULL_MONITOR_IMAGE:
 			t, err = monitor.NewPullImageTask(curveadm, config.GetMC(i))
@@ -313,12 +371,16 @@ func (p *Playbook) createTasks(step *PlaybookStep) (*tasks.Tasks, error) {
 			t, err = monitor.NewCreateContainerTask(curveadm, config.GetMC(i))
 		case SYNC_MONITOR_CONFIG:
 			t, err = monitor.NewSyncConfigTask(curveadm, config.GetMC(i))
+		case SYNC_MONITOR_TARGET:
+			t, err = monitor.NewSyncTargetTask(curveadm, config.GetMC(i))
 		case CLEAN_CONFIG_CONTAINER:
 			t, err = monitor.NewCleanConfigContainerTask(curveadm, config.GetMC(i))
 		case START_MONITOR_SERVICE:
 			t, err = monitor.NewStartServiceTask(curveadm, config.GetMC(i))
 		case RESTART_MONITOR_SERVICE:
 			t, err = monitor.NewRestartServiceTask(curveadm, config.GetMC(i))
+		case RELOAD_MONITOR_SERVICE:
+			t, err = monitor.NewReloadServiceTask(curveadm, config.GetMC(i))
 		case STOP_MONITOR_SERVICE:
 			t, err = monitor.NewStopServiceTask(curveadm, config.GetMC(i))
 		case INIT_MONITOR_STATUS:
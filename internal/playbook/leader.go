@@ -0,0 +1,68 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package playbook
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/task/common"
+)
+
+// AttachLeaderOrRandom returns the deploy config of leader service in dcs,
+// or a random running one if the leader can't be determined
+func AttachLeaderOrRandom(curveadm *cli.CurveAdm,
+	dcs []*topology.DeployConfig) (*topology.DeployConfig, error) {
+	if len(dcs) == 0 {
+		return nil, errno.ERR_NO_SERVICES_MATCHED
+	}
+
+	pb := NewPlaybook(curveadm)
+	pb.AddStep(&PlaybookStep{
+		Type:    ATTACH_LEADER_OR_RANDOM_CONTAINER,
+		Configs: dcs,
+		ExecOptions: ExecOptions{
+			SilentSubBar:  true,
+			SilentMainBar: true,
+			SkipError:     true,
+		},
+	})
+	if err := pb.Run(); err != nil {
+		return nil, err
+	}
+
+	value := curveadm.MemStorage().Get(comm.LEADER_OR_RANDOM_ID)
+	if value == nil {
+		return nil, errno.ERR_NO_LEADER_OR_RANDOM_CONTAINER_FOUND
+	}
+	dcs = curveadm.FilterDeployConfig(dcs, topology.FilterOption{
+		Id:   value.(common.Leader0rRandom).Id,
+		Role: "*",
+		Host: "*",
+	})
+	if len(dcs) == 0 {
+		return nil, errno.ERR_NO_LEADER_OR_RANDOM_CONTAINER_FOUND
+	}
+	return dcs[0], nil
+}
@@ -26,7 +26,9 @@ package playbook
 
 import (
 	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/tasks"
+	"github.com/opencurve/curveadm/internal/utils"
 )
 
 /*
@@ -79,6 +81,22 @@ func (p *Playbook) AddPostStep(s *PlaybookStep) {
 	p.postSteps = append(p.postSteps, s)
 }
 
+// record the elapsed time of each step, it will be shown by '--timing'
+// and saved with the audit log
+func (p *Playbook) recordTiming(timing tasks.Timing) {
+	if len(timing.Tasks) == 0 {
+		return
+	}
+	p.curveadm.MemStorage().TX(func(m *utils.SafeMap) error {
+		timings := []tasks.Timing{}
+		if v := m.Get(comm.KEY_PLAYBOOK_TIMING); v != nil {
+			timings = v.([]tasks.Timing)
+		}
+		m.Set(comm.KEY_PLAYBOOK_TIMING, append(timings, timing))
+		return nil
+	})
+}
+
 func (p *Playbook) run(steps []*PlaybookStep) error {
 	for i, step := range steps {
 		tasks, err := p.createTasks(step)
@@ -87,6 +105,7 @@ func (p *Playbook) run(steps []*PlaybookStep) error {
 		}
 
 		err = tasks.Execute(step.ExecOptions)
+		p.recordTiming(tasks.Timing())
 		if err != nil {
 			return err
 		}
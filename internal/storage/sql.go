@@ -208,6 +208,9 @@ var (
 	// set client aux info
 	SetClientAuxInfo = `UPDATE clients SET aux_info = ? WHERE id = ?`
 
+	// set client container id
+	SetClientContainerId = `UPDATE clients SET container_id = ? WHERE id = ?`
+
 	// select clients
 	SelectClients = `SELECT * FROM clients`
 
@@ -218,6 +221,43 @@ var (
 	DeleteClient = `DELETE from clients WHERE id = ?`
 )
 
+// target
+type Target struct {
+	ClusterId int
+	Name      string
+	Host      string
+	Tid       string
+	AuxInfo   string
+}
+
+var (
+	// table: targets
+	// cluster_id: -1 if target added without cluster
+	// name: iSCSI qualified name, target exported on multiple hosts shares the same name
+	CreateTargetsTable = `
+		CREATE TABLE IF NOT EXISTS targets (
+			cluster_id INTEGER NOT NULL,
+			name TEXT NOT NULL,
+			host TEXT NOT NULL,
+			tid TEXT NOT NULL,
+			aux_info TEXT NOT NULL,
+			PRIMARY KEY (cluster_id, name, host)
+		)
+	`
+
+	// insert target
+	InsertTarget = `INSERT INTO targets(cluster_id, name, host, tid, aux_info) VALUES(?, ?, ?, ?, ?)`
+
+	// select targets
+	SelectTargets = `SELECT * FROM targets WHERE cluster_id = ?`
+
+	// select target by name
+	SelectTargetByName = `SELECT * FROM targets WHERE cluster_id = ? AND name = ?`
+
+	// delete target
+	DeleteTarget = `DELETE from targets WHERE cluster_id = ? AND name = ? AND host = ?`
+)
+
 // playground
 type Playground struct {
 	Id         int
@@ -345,6 +385,26 @@ var (
 
 	// statement: drom old clusters table
 	DropOldClustersTable = `DROP TABLE clusters_old`
+
+	// check cluster_id column of targets table
+	CheckTargetsClusterIdColumn = `
+		SELECT COUNT(*) AS total
+		FROM pragma_table_info('targets')
+		WHERE name='cluster_id'
+	`
+
+	// rename targets table
+	RenameTargetsTable = `ALTER TABLE targets RENAME TO targets_old`
+
+	// insert targets from old table, they belong to the current cluster
+	InsertTargetsFromOldTable = `
+		INSERT INTO targets(cluster_id, name, host, tid, aux_info)
+		SELECT IFNULL((SELECT id FROM clusters WHERE current = 1), -1), name, host, tid, aux_info
+		FROM targets_old
+	`
+
+	// drop old targets table
+	DropOldTargetsTable = `DROP TABLE targets_old`
 )
 
 var (
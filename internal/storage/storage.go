@@ -25,6 +25,7 @@ package storage
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -78,6 +79,7 @@ func (s *Storage) init() error {
 		CreateClustersTable,
 		CreateContainersTable,
 		CreateClientsTable,
+		CreateTargetsTable,
 		CreatePlaygroundTable,
 		CreateAuditTable,
 		CreateMonitorTable,
@@ -91,6 +93,37 @@ func (s *Storage) init() error {
 		}
 	}
 
+	return s.migrateTargetsTable()
+}
+
+// the targets table created by old version has no cluster_id column
+func (s *Storage) migrateTargetsTable() error {
+	result, err := s.db.Query(CheckTargetsClusterIdColumn)
+	if err != nil {
+		return err
+	}
+	var total int
+	for result.Next() {
+		err = result.Scan(&total)
+		break
+	}
+	result.Close()
+	if err != nil || total > 0 {
+		return err
+	}
+
+	sqls := []string{
+		RenameTargetsTable,
+		CreateTargetsTable,
+		InsertTargetsFromOldTable,
+		DropOldTargetsTable,
+	}
+	for _, sql := range sqls {
+		_, err := s.db.Write(sql)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -273,7 +306,11 @@ func (s *Storage) InsertClient(id, kind, host, containerId, auxInfo string) erro
 }
 
 func (s *Storage) SetClientAuxInfo(id, auxInfo string) error {
-	return s.write(SetClientAuxInfo, id, auxInfo)
+	return s.write(SetClientAuxInfo, auxInfo, id)
+}
+
+func (s *Storage) SetClientContainerId(id, containerId string) error {
+	return s.write(SetClientContainerId, containerId, id)
 }
 
 func (s *Storage) getClients(query string, args ...interface{}) ([]Client, error) {
@@ -317,6 +354,43 @@ func (s *Storage) DeleteClient(id string) error {
 	return s.write(DeleteClient, id)
 }
 
+// target
+func (s *Storage) InsertTarget(clusterId int, name, host, tid, auxInfo string) error {
+	return s.write(InsertTarget, clusterId, name, host, tid, auxInfo)
+}
+
+func (s *Storage) getTargets(query string, args ...interface{}) ([]Target, error) {
+	result, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	targets := []Target{}
+	var target Target
+	for result.Next() {
+		err = result.Scan(&target.ClusterId, &target.Name, &target.Host, &target.Tid, &target.AuxInfo)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+func (s *Storage) GetTarget(clusterId int, name string) ([]Target, error) {
+	return s.getTargets(SelectTargetByName, clusterId, name)
+}
+
+func (s *Storage) GetTargets(clusterId int) ([]Target, error) {
+	return s.getTargets(SelectTargets, clusterId)
+}
+
+func (s *Storage) DeleteTarget(clusterId int, name, host string) error {
+	return s.write(DeleteTarget, clusterId, name, host)
+}
+
 // playground
 func (s *Storage) InsertPlayground(name, mountPoint string) error {
 	// FIXME: remove status
@@ -413,7 +487,9 @@ func (s *Storage) GetAuditLog(id int64) ([]AuditLog, error) {
 
 // any item prefix
 const (
-	PREFIX_CLIENT_CONFIG = 0x01
+	PREFIX_CLIENT_CONFIG    = 0x01
+	PREFIX_AUDIT_TIMING     = 0x02
+	PREFIX_PLAYGROUND_HOSTS = 0x03
 )
 
 func (s *Storage) realId(prefix int, id string) string {
@@ -425,8 +501,7 @@ func (s *Storage) InsertClientConfig(id, data string) error {
 	return s.write(InsertAnyItem, id, data)
 }
 
-func (s *Storage) GetClientConfig(id string) ([]Any, error) {
-	id = s.realId(PREFIX_CLIENT_CONFIG, id)
+func (s *Storage) getAnyItems(id string) ([]Any, error) {
 	result, err := s.db.Query(SelectAnyItem, id)
 	if err != nil {
 		return nil, err
@@ -446,11 +521,42 @@ func (s *Storage) GetClientConfig(id string) ([]Any, error) {
 	return items, nil
 }
 
+func (s *Storage) GetClientConfig(id string) ([]Any, error) {
+	id = s.realId(PREFIX_CLIENT_CONFIG, id)
+	return s.getAnyItems(id)
+}
+
 func (s *Storage) DeleteClientConfig(id string) error {
 	id = s.realId(PREFIX_CLIENT_CONFIG, id)
 	return s.write(DeleteAnyItem, id)
 }
 
+func (s *Storage) InsertAuditTiming(auditId int64, data string) error {
+	id := s.realId(PREFIX_AUDIT_TIMING, strconv.FormatInt(auditId, 10))
+	return s.write(InsertAnyItem, id, data)
+}
+
+func (s *Storage) GetAuditTiming(auditId int64) ([]Any, error) {
+	id := s.realId(PREFIX_AUDIT_TIMING, strconv.FormatInt(auditId, 10))
+	return s.getAnyItems(id)
+}
+
+// hosts of multi-node playground, which named by playground
+func (s *Storage) InsertPlaygroundHosts(name, data string) error {
+	id := s.realId(PREFIX_PLAYGROUND_HOSTS, name)
+	return s.write(InsertAnyItem, id, data)
+}
+
+func (s *Storage) GetPlaygroundHosts(name string) ([]Any, error) {
+	id := s.realId(PREFIX_PLAYGROUND_HOSTS, name)
+	return s.getAnyItems(id)
+}
+
+func (s *Storage) DeletePlaygroundHosts(name string) error {
+	id := s.realId(PREFIX_PLAYGROUND_HOSTS, name)
+	return s.write(DeleteAnyItem, id)
+}
+
 func (s *Storage) GetMonitor(clusterId int) (Monitor, error) {
 	monitor := Monitor{
 		ClusterId: clusterId,
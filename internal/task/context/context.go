@@ -44,7 +44,7 @@ func NewContext(sshClient *module.SSHClient) (*Context, error) {
 
 func (ctx *Context) Close() {
 	if ctx.sshClient != nil {
-		ctx.sshClient.Client().Close()
+		ctx.sshClient.Close()
 	}
 }
 
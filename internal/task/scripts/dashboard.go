@@ -0,0 +1,34 @@
+/*
+*  Copyright (c) 2026 NetEase Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+ */
+
+/*
+* Project: Curveadm
+* Created Date: 2026-10-19
+* Author: Jingli Chen (Wine93)
+ */
+
+package scripts
+
+import (
+	"embed"
+)
+
+// grafana dashboards are organized by kind and the minimum curve version
+// they support, e.g. dashboards/curvebs/v1.2/overview.json, bump the
+// "version" field in dashboard when you change it.
+//
+//go:embed dashboards
+var DASHBOARDS embed.FS
@@ -26,6 +26,10 @@ var PROMETHEUS_YML = `
 global:
   scrape_interval: 3s
   evaluation_interval: 15s
+  external_labels:
+    replica: '%s'
+
+rule_files: ['curve_rules.yml']
 
 scrape_configs:
   - job_name: 'prometheus'
@@ -39,16 +43,206 @@ scrape_configs:
   - job_name: 'node'
     static_configs:
       - targets: %s
+%s%s`
+
+var PROMETHEUS_ALERTING_YML = `
+alerting:
+  alert_relabel_configs:
+  - action: labeldrop
+    regex: replica
+  alertmanagers:
+  - static_configs:
+    - targets: ['%s']
+`
+
+var PROMETHEUS_REMOTE_WRITE_YML = `
+remote_write:
+%s`
+
+var PROMETHEUS_REMOTE_WRITE_URL = `- url: '%s'
+`
+
+var PROMETHEUS_REMOTE_WRITE_AUTH = `  basic_auth:
+    username: '%s'
+    password: '%s'
+`
+
+// thresholds: disk usage (percent), chunkserver write latency (ms)
+var PROMETHEUS_RULES_YML = `
+groups:
+- name: curve
+  rules:
+  - alert: EtcdNoLeader
+    expr: etcd_server_has_leader{job="etcd"} == 0
+    for: 1m
+    labels:
+      severity: critical
+    annotations:
+      summary: 'etcd {{ $labels.instance }} has no leader'
+
+  - alert: MDSDown
+    expr: up{job="mds"} == 0
+    for: 1m
+    labels:
+      severity: critical
+    annotations:
+      summary: 'mds {{ $labels.instance }} is down'
+
+  - alert: ChunkserverOffline
+    expr: up{job="chunkserver"} == 0
+    for: 1m
+    labels:
+      severity: critical
+    annotations:
+      summary: 'chunkserver {{ $labels.instance }} is offline'
+
+  - alert: CopysetUnhealthy
+    expr: sum(mds_scheduler_metric_operator_num{job="mds"}) > 0
+    for: 30m
+    labels:
+      severity: warning
+    annotations:
+      summary: 'copysets are still recovering after 30 minutes'
+
+  - alert: DiskNearlyFull
+    expr: (1 - node_filesystem_avail_bytes{fstype!~"tmpfs|overlay"} / node_filesystem_size_bytes{fstype!~"tmpfs|overlay"}) * 100 > %d
+    for: 5m
+    labels:
+      severity: warning
+    annotations:
+      summary: 'disk {{ $labels.mountpoint }} on {{ $labels.instance }} is {{ $value | humanize }}%% full'
+
+  - alert: ChunkserverHighLatency
+    expr: {__name__=~"chunkserver_.*write_chunk_latency_99", job="chunkserver"} / 1000 > %d
+    for: 5m
+    labels:
+      severity: warning
+    annotations:
+      summary: 'chunkserver {{ $labels.instance }} write latency p99 is {{ $value | humanize }}ms'
+`
+
+// receivers which without any config will drop all alerts
+var ALERTMANAGER_YML = `
+global:
+  smtp_smarthost: '%s'
+  smtp_from: '%s'
+  smtp_require_tls: false
+
+route:
+  receiver: 'curve'
+  group_by: ['alertname', 'job']
+  group_wait: 30s
+  group_interval: 5m
+  repeat_interval: 4h
+
+receivers:
+- name: 'curve'
+%s`
+
+var ALERTMANAGER_WEBHOOK_CONFIG = `  webhook_configs:
+  - url: '%s'
+    send_resolved: true
+`
+
+var ALERTMANAGER_EMAIL_CONFIG = `  email_configs:
+  - to: '%s'
+    send_resolved: true
 `
 
 var GRAFANA_DATA_SOURCE = `
 datasources:
-- name: 'Prometheus'
+%s`
+
+var GRAFANA_PROMETHEUS_DATA_SOURCE = `- name: '%s'
   type: 'prometheus'
   access: 'proxy'
   org_id: 1
-  url: 'http://%s:%d'
-  is_default: true
+  url: 'http://%s'
+  is_default: %t
+  version: 1
+  editable: true
+`
+
+var GRAFANA_DASHBOARD_PROVIDER = `apiVersion: 1
+
+providers:
+- name: 'curve'
+  orgId: 1
+  folder: '%s'
+  type: file
+  disableDeletion: false
+  allowUiUpdates: true
+  updateIntervalSeconds: 30
+  options:
+    path: '%s'
+`
+
+var GRAFANA_LOKI_DATA_SOURCE = `- name: 'Loki'
+  type: 'loki'
+  access: 'proxy'
+  org_id: 1
+  url: 'http://%s'
+  is_default: false
   version: 1
   editable: true
-`
\ No newline at end of file
+`
+
+var LOKI_YML = `auth_enabled: false
+
+server:
+  http_listen_port: %d
+  grpc_listen_port: 0
+
+common:
+  instance_addr: 127.0.0.1
+  path_prefix: /loki
+  storage:
+    filesystem:
+      chunks_directory: /loki/chunks
+      rules_directory: /loki/rules
+  replication_factor: 1
+  ring:
+    kvstore:
+      store: inmemory
+
+schema_config:
+  configs:
+  - from: 2020-10-24
+    store: tsdb
+    object_store: filesystem
+    schema: v13
+    index:
+      prefix: index_
+      period: 24h
+
+limits_config:
+  retention_period: %s
+
+compactor:
+  working_directory: /loki/compactor
+  retention_enabled: true
+  delete_request_store: filesystem
+`
+
+var PROMTAIL_YML = `server:
+  http_listen_port: %d
+  grpc_listen_port: 0
+
+positions:
+  filename: /promtail/positions.yaml
+
+clients:
+- url: 'http://%s/loki/api/v1/push'
+
+scrape_configs:
+%s`
+
+var PROMTAIL_SCRAPE_CONFIG = `- job_name: '%s'
+  static_configs:
+  - targets: ['localhost']
+    labels:
+      __path__: '%s'
+%s`
+
+var PROMTAIL_LABEL = `      %s: '%s'
+`
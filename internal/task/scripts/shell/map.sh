@@ -1,19 +1,20 @@
 #!/usr/bin/env bash
 
-# Usage: map USER VOLUME CREATE SIZE
-# Example: map curve test true 10
+# Usage: map USER VOLUME [OPTIONS...]
+# Example: map curve test --no-exclusive --device /dev/nbd0 --timeout 60 --read-only
 # Created Date: 2022-01-10
 # Author: Jingli Chen (Wine93)
 
 
 g_user=$1
 g_volume=$2
-g_options=$3
+g_options=${@:3}
 g_stderr=/tmp/__curveadm_map__
+g_nbds_max=64 # keep in sync with NBDS_MAX in curveadm
 
 mkdir -p /curvebs/nebd/data/lock
 touch /etc/curve/curvetab
-curve-nbd map --nbds_max=16 ${g_options} cbd:pool/${g_volume}_${g_user}_ > ${g_stderr} 2>&1
+curve-nbd map --nbds_max=${g_nbds_max} ${g_options} cbd:pool/${g_volume}_${g_user}_ > ${g_stderr} 2>&1
 if [ $? -ne 0 ]; then
   cat ${g_stderr}
   exit 1
@@ -5,6 +5,11 @@
 
 
 g_listen="$1"
+g_root="/tmp/curveadm-precheck"
+
+# sparse file for network throughput test
+mkdir -p "$g_root"
+truncate -s 64M "$g_root/payload"
 
 cat << __EOF__ > /etc/nginx/nginx.conf
 daemon off;
@@ -14,6 +19,7 @@ events {
 http {
     server {
         $g_listen
+        root $g_root;
     }
 }
 __EOF__
@@ -1,7 +1,14 @@
 #!/usr/bin/env bash
 
-# Usage: target USER VOLUME CREATE SIZE
-# Example: target curve test true 10
+# Usage: target USER VOLUME CREATE SIZE BLOCKSIZE [OPTIONS]
+#   --lun USER:VOLUME            export another volume as next LUN, LUN starts from 1
+#   --targetname NAME            iSCSI qualified name of target, required
+#   --no-exclusive               open volumes without exclusive lock, required by multipath
+#   --initiator-address ADDRESS  allow initiator with IP address or CIDR
+#   --initiator-name NAME        allow initiator with iSCSI name
+#   --chap FILE                  CHAP credentials file, it will be removed once loaded
+# Example: target curve test true 10 4096 --targetname iqn.2022-02.com.opencurve:curve.0d7f3b --lun curve:test2
+# Output: the tid of new target
 # See Also: https://linux.die.net/man/8/tgtadm
 # Created Date: 2022-02-08
 # Author: Jingli Chen (Wine93)
@@ -13,20 +20,79 @@ g_create=$3
 g_size=$4
 g_blocksize=$5
 g_tid=1
-g_image=cbd:pool/${g_volume}_${g_user}_
-g_image_md5=$(echo -n ${g_image} | md5sum | awk '{ print $1 }')
-g_targetname=iqn.$(date +"%Y-%m").com.opencurve:curve.${g_image_md5}
+g_luns=("${g_user}:${g_volume}")
+g_no_exclusive=false
+g_initiator_addresses=()
+g_initiator_names=()
+g_chap_file=
+g_chap_user=
+g_chap_password=
+g_chap_mutual_user=
+g_chap_mutual_password=
+g_targetname=
 
-mkdir -p /curvebs/nebd/data/lock
-touch /etc/curve/curvetab
+shift 5
+while [ $# -gt 0 ]; do
+    case $1 in
+        --lun) g_luns+=("$2"); shift 2 ;;
+        --targetname) g_targetname=$2; shift 2 ;;
+        --no-exclusive) g_no_exclusive=true; shift 1 ;;
+        --initiator-address) g_initiator_addresses+=("$2"); shift 2 ;;
+        --initiator-name) g_initiator_names+=("$2"); shift 2 ;;
+        --chap) g_chap_file=$2; shift 2 ;;
+        *) echo "unknown option: $1"; exit 1 ;;
+    esac
+done
 
-if [ $g_create == "true" ]; then
-    output=$(curve_ops_tool create -userName=$g_user -fileName=$g_volume -fileLength=$g_size)
+# the name is derived from cluster and volumes by curveadm
+if [ -z "$g_targetname" ]; then
+    echo "target name is required"
+    exit 1
+fi
+
+if [ -n "$g_chap_file" ]; then
+    source $g_chap_file
+    rm -f $g_chap_file
+fi
+
+# new account if not exist and bind it to target
+function bind_account() {
+    local user=$1 password=$2 outgoing=$3
+    tgtadm --lld iscsi --mode account --op show | awk '{ print $1 }' | grep -qx "$user"
     if [ $? -ne 0 ]; then
-        if [ "$output" != "CreateFile fail with errCode: 101" ]; then
+        tgtadm --lld iscsi --mode account --op new --user "$user" --password "$password"
+        if [ $? -ne 0 ]; then
+            echo "tgtadm account new failed"
             exit 1
         fi
     fi
+
+    tgtadm --lld iscsi --mode account --op bind --tid ${g_tid} --user "$user" $outgoing
+    if [ $? -ne 0 ]; then
+        echo "tgtadm account bind failed"
+        exit 1
+    fi
+}
+
+mkdir -p /curvebs/nebd/data/lock
+touch /etc/curve/curvetab
+
+tgtadm --lld iscsi --mode target --op show | grep -q "^Target [0-9]*: ${g_targetname}$"
+if [ $? -eq 0 ]; then
+    echo "target ${g_targetname} already exists"
+    exit 1
+fi
+
+if [ $g_create == "true" ]; then
+    for lun in "${g_luns[@]}"; do
+        output=$(curve_ops_tool create -userName=${lun%%:*} -fileName=${lun#*:} -fileLength=$g_size)
+        if [ $? -ne 0 ]; then
+            if [ "$output" != "CreateFile fail with errCode: 101" ]; then
+                echo "create volume ${lun} failed"
+                exit 1
+            fi
+        fi
+    done
 fi
 for ((i=1;;i++)); do
     tgtadm --lld iscsi --mode target --op show --tid $i 1>/dev/null 2>&1
@@ -46,36 +112,74 @@ if [ $? -ne 0 ]; then
    exit 1
 fi
 
-tgtadm --lld iscsi \
-    --mode logicalunit \
-    --op new \
-    --tid ${g_tid} \
-    --lun 1 \
-    --bstype curve \
-    --backing-store ${g_image} \
-    --blocksize ${g_blocksize}
-if [ $? -ne 0 ]; then
-   echo "tgtadm logicalunit new failed"
-   exit 1
+# the volume is opened by tgtd on every gateway if multipath enabled
+g_bsopts=
+if [ $g_no_exclusive == "true" ]; then
+    g_bsopts="--bsopts no_exclusive"
 fi
+for ((i=0;i<${#g_luns[@]};i++)); do
+    lun=${g_luns[$i]}
+    image=cbd:pool/${lun#*:}_${lun%%:*}_
+    tgtadm --lld iscsi \
+        --mode logicalunit \
+        --op new \
+        --tid ${g_tid} \
+        --lun $((i+1)) \
+        --bstype curve \
+        --backing-store ${image} \
+        --blocksize ${g_blocksize} \
+        ${g_bsopts}
+    if [ $? -ne 0 ]; then
+       echo "tgtadm logicalunit new failed"
+       exit 1
+    fi
 
-tgtadm --lld iscsi \
-    --mode logicalunit \
-    --op update \
-    --tid ${g_tid} \
-    --lun 1 \
-    --params vendor_id=NetEase,product_id=CurveVolume,product_rev=2.0
-if [ $? -ne 0 ]; then
-   echo "tgtadm logicalunit update failed"
-   exit 1
+    # scsi_sn must be identical across gateways for dm-multipath
+    serial=$(echo -n ${image} | md5sum | cut -c 1-16)
+    tgtadm --lld iscsi \
+        --mode logicalunit \
+        --op update \
+        --tid ${g_tid} \
+        --lun $((i+1)) \
+        --params vendor_id=NetEase,product_id=CurveVolume,product_rev=2.0,scsi_sn=${serial}
+    if [ $? -ne 0 ]; then
+       echo "tgtadm logicalunit update failed"
+       exit 1
+    fi
+done
+
+if [ -n "$g_chap_user" ]; then
+    bind_account "$g_chap_user" "$g_chap_password"
+fi
+if [ -n "$g_chap_mutual_user" ]; then
+    bind_account "$g_chap_mutual_user" "$g_chap_mutual_password" --outgoing
 fi
 
-tgtadm --lld iscsi \
-    --mode target \
-    --op bind \
-    --tid ${g_tid} \
-    -I ALL
-if [ $? -ne 0 ]; then
-   echo "tgtadm target bind failed"
-   exit 1
+# allow all initiators if no ACL specified
+if [ ${#g_initiator_addresses[@]} -eq 0 ] && [ ${#g_initiator_names[@]} -eq 0 ]; then
+    g_initiator_addresses=(ALL)
 fi
+for address in "${g_initiator_addresses[@]}"; do
+    tgtadm --lld iscsi \
+        --mode target \
+        --op bind \
+        --tid ${g_tid} \
+        -I ${address}
+    if [ $? -ne 0 ]; then
+       echo "tgtadm target bind failed"
+       exit 1
+    fi
+done
+for name in "${g_initiator_names[@]}"; do
+    tgtadm --lld iscsi \
+        --mode target \
+        --op bind \
+        --tid ${g_tid} \
+        -Q ${name}
+    if [ $? -ne 0 ]; then
+       echo "tgtadm target bind failed"
+       exit 1
+    fi
+done
+
+echo ${g_tid}
@@ -46,6 +46,7 @@ type (
 	Volume struct { // bind mount a volume
 		HostPath      string
 		ContainerPath string
+		Options       string // e.g. ro,rslave
 	}
 
 	CreateContainer struct {
@@ -94,6 +95,13 @@ type (
 		module.ExecOptions
 	}
 
+	KillContainer struct {
+		ContainerId string
+		Signal      string // e.g. HUP, default is KILL
+		Out         *string
+		module.ExecOptions
+	}
+
 	WaitContainer struct {
 		ContainerId string
 		Out         *string
@@ -107,6 +115,13 @@ type (
 		module.ExecOptions
 	}
 
+	RenameContainer struct {
+		ContainerId string
+		Name        string
+		Out         *string
+		module.ExecOptions
+	}
+
 	ListContainers struct {
 		Format  string
 		Filter  string
@@ -155,6 +170,29 @@ type (
 		Success     *bool
 		module.ExecOptions
 	}
+
+	ContainerStats struct {
+		ContainerId string
+		Format      string
+		Out         *string
+		Success     *bool
+		module.ExecOptions
+	}
+
+	CreateNetwork struct {
+		Name    string
+		Driver  string
+		Out     *string
+		Success *bool
+		module.ExecOptions
+	}
+
+	RemoveNetwork struct {
+		Name    string
+		Out     *string
+		Success *bool
+		module.ExecOptions
+	}
 )
 
 func (s *EngineInfo) Execute(ctx *context.Context) error {
@@ -228,7 +266,11 @@ func (s *CreateContainer) Execute(ctx *context.Context) error {
 		cli.AddOption("--ulimit %s", ulimit)
 	}
 	for _, volume := range s.Volumes {
-		cli.AddOption("--volume %s:%s", volume.HostPath, volume.ContainerPath)
+		if len(volume.Options) > 0 {
+			cli.AddOption("--volume %s:%s:%s", volume.HostPath, volume.ContainerPath, volume.Options)
+		} else {
+			cli.AddOption("--volume %s:%s", volume.HostPath, volume.ContainerPath)
+		}
 	}
 
 	out, err := cli.Execute(s.ExecOptions)
@@ -257,6 +299,15 @@ func (s *RestartContainer) Execute(ctx *context.Context) error {
 	return PostHandle(nil, s.Out, out, err, errno.ERR_RESTART_CONTAINER_FAILED.FD("(%s restart CONTAINER)", s.ExecWithEngine))
 }
 
+func (s *KillContainer) Execute(ctx *context.Context) error {
+	cli := ctx.Module().DockerCli().KillContainer(s.ContainerId)
+	if len(s.Signal) > 0 {
+		cli.AddOption("--signal %s", s.Signal)
+	}
+	out, err := cli.Execute(s.ExecOptions)
+	return PostHandle(nil, s.Out, out, err, errno.ERR_KILL_CONTAINER_FAILED.FD("(%s kill CONTAINER)", s.ExecWithEngine))
+}
+
 func (s *WaitContainer) Execute(ctx *context.Context) error {
 	cli := ctx.Module().DockerCli().WaitContainer(s.ContainerId)
 	out, err := cli.Execute(s.ExecOptions)
@@ -269,6 +320,12 @@ func (s *RemoveContainer) Execute(ctx *context.Context) error {
 	return PostHandle(s.Success, s.Out, out, err, errno.ERR_REMOVE_CONTAINER_FAILED.FD("(%s rm CONTAINER)", s.ExecWithEngine))
 }
 
+func (s *RenameContainer) Execute(ctx *context.Context) error {
+	cli := ctx.Module().DockerCli().RenameContainer(s.ContainerId, s.Name)
+	out, err := cli.Execute(s.ExecOptions)
+	return PostHandle(nil, s.Out, out, err, errno.ERR_RENAME_CONTAINER_FAILED.FD("(%s rename CONTAINER NAME)", s.ExecWithEngine))
+}
+
 func (s *ListContainers) Execute(ctx *context.Context) error {
 	cli := ctx.Module().DockerCli().ListContainers()
 	if len(s.Format) > 0 {
@@ -324,3 +381,28 @@ func (s *ContainerLogs) Execute(ctx *context.Context) error {
 	out, err := cli.Execute(s.ExecOptions)
 	return PostHandle(s.Success, s.Out, out, err, errno.ERR_GET_CONTAINER_LOGS_FAILED.FD("(%s logs ID)", s.ExecWithEngine))
 }
+
+func (s *ContainerStats) Execute(ctx *context.Context) error {
+	cli := ctx.Module().DockerCli().ContainerStats(s.ContainerId)
+	cli.AddOption("--no-stream")
+	if len(s.Format) > 0 {
+		cli.AddOption("--format %s", s.Format)
+	}
+	out, err := cli.Execute(s.ExecOptions)
+	return PostHandle(s.Success, s.Out, out, err, errno.ERR_GET_CONTAINER_STATS_FAILED.FD("(%s stats ID)", s.ExecWithEngine))
+}
+
+func (s *CreateNetwork) Execute(ctx *context.Context) error {
+	cli := ctx.Module().DockerCli().CreateNetwork(s.Name)
+	if len(s.Driver) > 0 {
+		cli.AddOption("--driver %s", s.Driver)
+	}
+	out, err := cli.Execute(s.ExecOptions)
+	return PostHandle(s.Success, s.Out, out, err, errno.ERR_CREATE_NETWORK_FAILED.FD("(%s network create NAME)", s.ExecWithEngine))
+}
+
+func (s *RemoveNetwork) Execute(ctx *context.Context) error {
+	cli := ctx.Module().DockerCli().RemoveNetwork(s.Name)
+	out, err := cli.Execute(s.ExecOptions)
+	return PostHandle(s.Success, s.Out, out, err, errno.ERR_REMOVE_NETWORK_FAILED.FD("(%s network rm NAME)", s.ExecWithEngine))
+}
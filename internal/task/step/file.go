@@ -30,6 +30,7 @@ import (
 	"os"
 	"path"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/opencurve/curveadm/internal/errno"
@@ -40,8 +41,14 @@ import (
 )
 
 const (
-	TEMP_DIR       = "/tmp"
 	REGEX_KV_SPLIT = "^(([^%s]+)%s\\s*)([^\\s#]*)" // key: mu[2] value: mu[3]
+
+	DEFAULT_INSTALL_FILE_MODE = 0644
+	SECRET_FILE_MODE          = "600" // e.g. credentials
+)
+
+var (
+	TEMP_DIR = "/tmp" // changed by test
 )
 
 type (
@@ -53,11 +60,14 @@ type (
 		module.ExecOptions
 	}
 
+	// the temporary files are created with Mode, so the secret content
+	// (with Mode SECRET_FILE_MODE) never exposed to others
 	InstallFile struct {
 		Content           *string
 		HostDestPath      string
 		ContainerId       *string
 		ContainerDestPath string
+		Mode              string // octal, e.g. 755, default DEFAULT_INSTALL_FILE_MODE
 		module.ExecOptions
 	}
 
@@ -132,7 +142,7 @@ func (s *ReadFile) Execute(ctx *context.Context) error {
 	localPath := utils.RandFilename(TEMP_DIR)
 	defer os.Remove(localPath)
 	if !s.ExecInLocal {
-		err := ctx.Module().File().Download(remotePath, localPath)
+		err := ctx.Module().File().Download(remotePath, localPath, s.ExecOptions)
 		if err != nil {
 			return errno.ERR_DOWNLOAD_FILE_FROM_REMOTE_BY_SSH_FAILED.E(err)
 		}
@@ -153,17 +163,31 @@ func (s *ReadFile) Execute(ctx *context.Context) error {
 }
 
 func (s *InstallFile) Execute(ctx *context.Context) error {
+	mode := uint64(DEFAULT_INSTALL_FILE_MODE)
+	if len(s.Mode) > 0 {
+		m, err := strconv.ParseUint(s.Mode, 8, 32)
+		if err != nil {
+			return errno.ERR_CHANGE_FILE_MODE_FAILED.E(err)
+		}
+		mode = m
+	}
+
 	localPath := utils.RandFilename(TEMP_DIR)
 	defer os.Remove(localPath)
-	err := utils.WriteFile(localPath, *s.Content, 0644)
+	err := utils.WriteFile(localPath, *s.Content, int(mode))
 	if err != nil {
 		return errno.ERR_WRITE_FILE_FAILED.E(err)
+	} else if len(s.Mode) > 0 { // the mode of created file is masked by umask
+		err = os.Chmod(localPath, os.FileMode(mode))
+		if err != nil {
+			return errno.ERR_CHANGE_FILE_MODE_FAILED.E(err)
+		}
 	}
 
 	remotePath := utils.RandFilename(TEMP_DIR)
 	if !s.ExecInLocal {
-		// defer ctx.Module().Shell().Remove(remotePath).Execute(module.ExecOptions{})
-		err = ctx.Module().File().Upload(localPath, remotePath)
+		// NOTE: the uploaded file keeps the mode of local file
+		err = ctx.Module().File().Upload(localPath, remotePath, s.ExecOptions)
 		if err != nil {
 			return errno.ERR_UPLOAD_FILE_TO_REMOTE_BY_SSH_FAILED.E(err)
 		}
@@ -185,12 +209,15 @@ func (s *InstallFile) Execute(ctx *context.Context) error {
 		if err != nil {
 			return errno.ERR_RENAME_FILE_OR_DIRECTORY_FAILED.E(err)
 		}
-	} else {
-		cli := ctx.Module().DockerCli().CopyIntoContainer(remotePath, *s.ContainerId, s.ContainerDestPath)
-		_, err = cli.Execute(s.ExecOptions)
-		if err != nil {
-			return errno.ERR_COPY_INTO_CONTAINER_FAILED.FD(" (%scp SRC_PATH CONTAINER:DEST_PATH)", s.ExecWithEngine).E(err)
-		}
+		return nil
+	}
+
+	// no copy left on host after it copied into container
+	defer ctx.Module().Shell().Remove(remotePath).Execute(s.ExecOptions)
+	cli := ctx.Module().DockerCli().CopyIntoContainer(remotePath, *s.ContainerId, s.ContainerDestPath)
+	_, err = cli.Execute(s.ExecOptions)
+	if err != nil {
+		return errno.ERR_COPY_INTO_CONTAINER_FAILED.FD(" (%scp SRC_PATH CONTAINER:DEST_PATH)", s.ExecWithEngine).E(err)
 	}
 	return nil
 }
@@ -298,7 +325,7 @@ func (s *SyncFileDirectly) Execute(ctx *context.Context) error {
 }
 
 func (s *DownloadFile) Execute(ctx *context.Context) error {
-	return ctx.Module().File().Download(s.RemotePath, s.LocalPath)
+	return ctx.Module().File().Download(s.RemotePath, s.LocalPath, s.ExecOptions)
 }
 
 func (s *TrySyncFile) Execute(ctx *context.Context) error {
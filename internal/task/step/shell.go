@@ -130,9 +130,10 @@ type (
 
 	// see also: https://linuxize.com/post/how-to-check-disk-space-in-linux-using-the-df-command/#output-format
 	ShowDiskFree struct {
-		Files  []string
-		Format string
-		Out    *string
+		Files   []string
+		Format  string
+		Success *bool
+		Out     *string
 		module.ExecOptions
 	}
 
@@ -165,11 +166,14 @@ type (
 	}
 
 	Ping struct {
-		Destination *string
-		Count       int
-		Timeout     int
-		Success     *bool
-		Out         *string
+		Destination  *string
+		Count        int
+		Timeout      int
+		Interval     float64 // seconds
+		PacketSize   int
+		DontFragment bool // prohibit fragmentation, for path MTU discovery
+		Success      *bool
+		Out          *string
 		module.ExecOptions
 	}
 
@@ -265,6 +269,15 @@ type (
 		module.ExecOptions
 	}
 
+	Systemctl struct {
+		Action  string // e.g. enable, disable, daemon-reload
+		Units   []string
+		Now     bool // --now
+		Success *bool
+		Out     *string
+		module.ExecOptions
+	}
+
 	Command struct {
 		Command string
 		Success *bool
@@ -416,7 +429,7 @@ func (s *ShowDiskFree) Execute(ctx *context.Context) error {
 	}
 
 	out, err := cmd.Execute(s.ExecOptions)
-	return PostHandle(nil, s.Out, out, err, errno.ERR_GET_DISK_SPACE_USAGE_FAILED)
+	return PostHandle(s.Success, s.Out, out, err, errno.ERR_GET_DISK_SPACE_USAGE_FAILED)
 }
 
 func (s *ListBlockDevice) Execute(ctx *context.Context) error {
@@ -467,6 +480,15 @@ func (s *Ping) Execute(ctx *context.Context) error {
 	if s.Timeout > 0 {
 		cmd.AddOption("-W %d", s.Timeout)
 	}
+	if s.Interval > 0 {
+		cmd.AddOption("-i %g", s.Interval)
+	}
+	if s.PacketSize > 0 {
+		cmd.AddOption("-s %d", s.PacketSize)
+	}
+	if s.DontFragment {
+		cmd.AddOption("-M do")
+	}
 
 	out, err := cmd.Execute(s.ExecOptions)
 	return PostHandle(s.Success, s.Out, out, err, errno.ERR_SEND_ICMP_ECHO_REQUEST_TO_HOST_FAILED)
@@ -621,6 +643,15 @@ func (s *Scp) Execute(ctx *context.Context) error {
 	return PostHandle(nil, nil, out, err, errno.ERR_SECURE_COPY_FILE_TO_REMOTE_FAILED)
 }
 
+func (s *Systemctl) Execute(ctx *context.Context) error {
+	cmd := ctx.Module().Shell().Systemctl(s.Action, s.Units...)
+	if s.Now {
+		cmd.AddOption("--now")
+	}
+	out, err := cmd.Execute(s.ExecOptions)
+	return PostHandle(s.Success, s.Out, out, err, errno.ERR_CONTROL_SYSTEMD_UNIT_FAILED)
+}
+
 func (s *Command) Execute(ctx *context.Context) error {
 	cmd := ctx.Module().Shell().Command(s.Command)
 	out, err := cmd.Execute(s.ExecOptions)
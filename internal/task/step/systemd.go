@@ -0,0 +1,105 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package step
+
+import (
+	"path"
+
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	SYSTEMD_UNIT_DIR = "/etc/systemd/system"
+)
+
+type (
+	// install unit file into systemd unit directory and enable it
+	InstallSystemdUnit struct {
+		Name    string // e.g. curveadm-client-xxx.service
+		Content *string
+		module.ExecOptions
+	}
+
+	// disable the unit and remove its unit file, nonexistent unit is ignored
+	RemoveSystemdUnit struct {
+		Name string
+		module.ExecOptions
+	}
+)
+
+func SystemdUnitPath(name string) string {
+	return path.Join(SYSTEMD_UNIT_DIR, name)
+}
+
+func (s *InstallSystemdUnit) Execute(ctx *context.Context) error {
+	steps := []task.Step{
+		&InstallFile{
+			Content:      s.Content,
+			HostDestPath: SystemdUnitPath(s.Name),
+			ExecOptions:  s.ExecOptions,
+		},
+		&Systemctl{
+			Action:      "daemon-reload",
+			ExecOptions: s.ExecOptions,
+		},
+		&Systemctl{
+			Action:      "enable",
+			Units:       []string{s.Name},
+			ExecOptions: s.ExecOptions,
+		},
+	}
+	for _, step := range steps {
+		if err := step.Execute(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *RemoveSystemdUnit) Execute(ctx *context.Context) error {
+	var success bool
+	steps := []task.Step{
+		&Systemctl{
+			Action:      "disable",
+			Units:       []string{s.Name},
+			Success:     &success, // unit maybe not exist
+			ExecOptions: s.ExecOptions,
+		},
+		&RemoveFile{
+			Files:       []string{SystemdUnitPath(s.Name)},
+			ExecOptions: s.ExecOptions,
+		},
+		&Systemctl{
+			Action:      "daemon-reload",
+			ExecOptions: s.ExecOptions,
+		},
+	}
+	for _, step := range steps {
+		if err := step.Execute(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
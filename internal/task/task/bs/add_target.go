@@ -23,35 +23,193 @@
 package bs
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/task/context"
 	"github.com/opencurve/curveadm/internal/task/scripts"
 	"github.com/opencurve/curveadm/internal/task/step"
 	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
 )
 
-type TargetOption struct {
-	Host      string
-	User      string
-	Volume    string
-	Create    bool
-	Size      int
-	Tid       string
-	Blocksize uint64
+const (
+	TARGET_NAME_PREFIX = "iqn.2022-02.com.opencurve:curve."
+)
+
+type (
+	TargetOption struct {
+		Host        string
+		User        string
+		Volume      string
+		Luns        []TargetLun // volumes exported as LUN 2, 3, ...
+		Name        string      // iSCSI qualified name
+		Create      bool
+		Size        int
+		Tid         string
+		Blocksize   uint64
+		NoExclusive bool
+		Initiators  []string // IP address, CIDR or iSCSI name of initiator
+		Chap        *TargetChap
+	}
+
+	TargetLun struct {
+		User   string
+		Volume string
+	}
+
+	// TargetGateway is one of hosts which the target exported on
+	TargetGateway struct {
+		Host         string
+		ClientConfig *configure.ClientConfig
+	}
+
+	// TargetInfo is the aux info of target which stored in database
+	TargetInfo struct {
+		Luns        []string `json:"luns"` // backing store of LUN 1, 2, ...
+		NoExclusive bool     `json:"no_exclusive,omitempty"`
+		Initiators  []string `json:"initiators,omitempty"`
+	}
+
+	// TargetChap is the CHAP credentials of target, the mutual one
+	// is used by initiator to authenticate target
+	TargetChap struct {
+		User           string `mapstructure:"user"`
+		Password       string `mapstructure:"password"`
+		MutualUser     string `mapstructure:"mutual_user"`
+		MutualPassword string `mapstructure:"mutual_password"`
+	}
+
+	step2RecordTarget struct {
+		curveadm *cli.CurveAdm
+		host     string
+		options  TargetOption
+		output   *string
+	}
+)
+
+// GetTargetLuns returns all volumes which the target exported, in LUN order
+func (options TargetOption) GetTargetLuns() []TargetLun {
+	luns := []TargetLun{{User: options.User, Volume: options.Volume}}
+	return append(luns, options.Luns...)
+}
+
+// GetTargetName returns the iSCSI qualified name which derived from cluster
+// and volumes, so the target exported on different gateways shares the same
+// name and initiator can access it with dm-multipath, while the same volume
+// of different clusters is exported with different names
+func GetTargetName(clusterUUId string, luns []TargetLun) string {
+	images := []string{}
+	for _, lun := range luns {
+		images = append(images, formatImage(lun.User, lun.Volume))
+	}
+	key := strings.Join(images, ",")
+	if len(clusterUUId) > 0 {
+		key = clusterUUId + ":" + key
+	}
+	return TARGET_NAME_PREFIX + utils.MD5Sum(key)
+}
+
+func DecodeTargetInfo(target storage.Target) (*TargetInfo, error) {
+	info := &TargetInfo{}
+	err := json.Unmarshal([]byte(target.AuxInfo), info)
+	if err != nil {
+		return nil, errno.ERR_DECODE_TARGET_INFO_FAILED.E(err)
+	}
+	return info, nil
+}
+
+// IsInitiatorName returns true if initiator is specified by iSCSI name,
+// e.g. iqn.1994-05.com.redhat:client1, eui.02004567A425678D
+func IsInitiatorName(initiator string) bool {
+	return strings.HasPrefix(initiator, "iqn.") ||
+		strings.HasPrefix(initiator, "eui.") ||
+		strings.HasPrefix(initiator, "naa.")
 }
 
-func NewAddTargetTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.Task, error) {
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// credentials are passed by file which sourced by target.sh,
+// so they never appear in the command line
+func (chap *TargetChap) render() string {
+	lines := []string{
+		"g_chap_user=" + shellQuote(chap.User),
+		"g_chap_password=" + shellQuote(chap.Password),
+		"g_chap_mutual_user=" + shellQuote(chap.MutualUser),
+		"g_chap_mutual_password=" + shellQuote(chap.MutualPassword),
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func getTargetArgs(options TargetOption, chapPath string) string {
+	args := []string{}
+	for _, lun := range options.Luns {
+		args = append(args, fmt.Sprintf("--lun %s:%s", lun.User, lun.Volume))
+	}
+	args = append(args, "--targetname "+options.Name)
+	if options.NoExclusive {
+		args = append(args, "--no-exclusive")
+	}
+	for _, initiator := range options.Initiators {
+		if IsInitiatorName(initiator) {
+			args = append(args, "--initiator-name "+initiator)
+		} else {
+			args = append(args, "--initiator-address "+initiator)
+		}
+	}
+	if options.Chap != nil {
+		args = append(args, "--chap "+chapPath)
+	}
+	return strings.Join(args, " ")
+}
+
+// target.sh prints the tid of new target at last line
+func (s *step2RecordTarget) Execute(ctx *context.Context) error {
+	lines := strings.Split(strings.TrimSpace(*s.output), "\n")
+	tid := strings.TrimSpace(lines[len(lines)-1])
+	if len(tid) == 0 {
+		return errno.ERR_ADD_TARGET_FAILED.F("no tid returned: %s", *s.output)
+	}
+
+	options := s.options
+	info := TargetInfo{
+		Luns:        []string{},
+		NoExclusive: options.NoExclusive,
+		Initiators:  options.Initiators,
+	}
+	for _, lun := range options.GetTargetLuns() {
+		info.Luns = append(info.Luns, formatImage(lun.User, lun.Volume))
+	}
+	bytes, err := json.Marshal(info)
+	if err != nil {
+		return errno.ERR_ENCODE_TARGET_INFO_TO_JSON_FAILED.E(err)
+	}
+	err = s.curveadm.Storage().InsertTarget(s.curveadm.ClusterId(), options.Name, s.host, tid, string(bytes))
+	if err != nil {
+		return errno.ERR_INSERT_TARGET_FAILED.E(err)
+	}
+	return nil
+}
+
+func NewAddTargetTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
 	options := curveadm.MemStorage().Get(comm.KEY_TARGET_OPTIONS).(TargetOption)
+	gateway := v.(TargetGateway)
+	cc := gateway.ClientConfig
 	user, volume := options.User, options.Volume
-	hc, err := curveadm.GetHost(options.Host)
+	hc, err := curveadm.GetHost(gateway.Host)
 	if err != nil {
 		return nil, err
 	}
 
-	subname := fmt.Sprintf("host=%s volume=%s", options.Host, volume)
+	subname := fmt.Sprintf("host=%s target=%s", gateway.Host, options.Name)
 	t := task.NewTask("Add Target", subname, hc.GetSSHConfig())
 
 	// add step
@@ -59,7 +217,9 @@ func NewAddTargetTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task
 	containerId := DEFAULT_TGTD_CONTAINER_NAME
 	targetScriptPath := "/curvebs/tools/sbin/target.sh"
 	targetScript := scripts.TARGET
-	cmd := fmt.Sprintf("/bin/bash %s %s %s %v %d %d", targetScriptPath, user, volume, options.Create, options.Size, options.Blocksize)
+	chapPath := utils.RandFilename("/tmp")
+	cmd := fmt.Sprintf("/bin/bash %s %s %s %v %d %d %s", targetScriptPath, user, volume,
+		options.Create, options.Size, options.Blocksize, getTargetArgs(options, chapPath))
 	toolsConf := fmt.Sprintf(FORMAT_TOOLS_CONF, cc.GetClusterMDSAddr())
 
 	t.AddStep(&step.ListContainers{
@@ -84,11 +244,28 @@ func NewAddTargetTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task
 		ContainerDestPath: targetScriptPath,
 		ExecOptions:       curveadm.ExecOptions(),
 	})
+	if options.Chap != nil {
+		chap := options.Chap.render()
+		t.AddStep(&step.InstallFile{ // install CHAP credentials
+			Content:           &chap,
+			ContainerId:       &containerId,
+			ContainerDestPath: chapPath,
+			Mode:              step.SECRET_FILE_MODE,
+			ExecOptions:       curveadm.ExecOptions(),
+		})
+	}
 	t.AddStep(&step.ContainerExec{
 		ContainerId: &containerId,
 		Command:     cmd,
+		Out:         &output,
 		ExecOptions: curveadm.ExecOptions(),
 	})
+	t.AddStep(&step2RecordTarget{
+		curveadm: curveadm,
+		host:     gateway.Host,
+		options:  options,
+		output:   &output,
+	})
 
 	return t, nil
 }
@@ -0,0 +1,315 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package bs
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	HEALTH_VERDICT_HEALTHY   = "healthy"
+	HEALTH_VERDICT_WARNING   = "warning"
+	HEALTH_VERDICT_UNHEALTHY = "unhealthy"
+
+	SPACE_USAGE_WARNING_PERCENT = 80
+
+	HEALTH_CHECK_COPYSET       = "copysets"
+	HEALTH_CHECK_CHUNKSERVER   = "chunkservers"
+	HEALTH_CHECK_SPACE         = "space"
+	HEALTH_CHECK_ETCD          = "etcd"
+	HEALTH_CHECK_MDS           = "mds"
+	HEALTH_CHECK_SNAPSHOTCLONE = "snapshotclone"
+)
+
+type (
+	CopysetHealth struct {
+		Total     int `json:"total"`
+		Unhealthy int `json:"unhealthy"`
+	}
+
+	ChunkserverHealth struct {
+		Total   int `json:"total"`
+		Online  int `json:"online"`
+		Offline int `json:"offline"`
+	}
+
+	// capacity of logical pools, in GB which printed by curve_ops_tool
+	SpaceHealth struct {
+		Total       uint64  `json:"total"`
+		Used        uint64  `json:"used"`
+		UsedPercent float64 `json:"used_percent"`
+	}
+
+	ServiceHealth struct {
+		Leader  string   `json:"leader"`
+		Online  []string `json:"online"`
+		Offline []string `json:"offline"`
+	}
+
+	ClusterHealth struct {
+		Verdict       string            `json:"verdict"`
+		Problems      []string          `json:"problems"`
+		Copysets      CopysetHealth     `json:"copysets"`
+		Chunkservers  ChunkserverHealth `json:"chunkservers"`
+		Space         SpaceHealth       `json:"space"`
+		Etcd          ServiceHealth     `json:"etcd"`
+		MDS           ServiceHealth     `json:"mds"`
+		SnapshotClone *ServiceHealth    `json:"snapshotclone,omitempty"`
+	}
+
+	healthCheck struct {
+		name    string
+		command string
+		success bool
+		output  string
+	}
+)
+
+var (
+	HEALTH_CHECK_COMMANDS = map[string]string{
+		HEALTH_CHECK_COPYSET:       "curve_ops_tool copysets-status",
+		HEALTH_CHECK_CHUNKSERVER:   "curve_ops_tool chunkserver-status",
+		HEALTH_CHECK_SPACE:         "curve_ops_tool space",
+		HEALTH_CHECK_ETCD:          "curve_ops_tool etcd-status",
+		HEALTH_CHECK_MDS:           "curve_ops_tool mds-status",
+		HEALTH_CHECK_SNAPSHOTCLONE: "curve_ops_tool snapshot-clone-status",
+	}
+
+	// e.g. total copysets: 100, unhealthy copysets: 0, unhealthy_ratio: 0%
+	regexCopysets = regexp.MustCompile(`total copysets: (\d+), unhealthy copysets: (\d+)`)
+	// e.g. chunkserver: total num = 3, online = 3, offline = 0(recoveringout = 0, chunkserverlist: [])
+	regexChunkservers = regexp.MustCompile(`total num = (\d+), online = (\d+), offline = (\d+)`)
+	// e.g. logical: total = 100GB, used = 10GB(10.00%, can be recycled = 0GB(0.00%)), left = ...
+	regexLogicalSpace = regexp.MustCompile(`logical: total = (\d+)GB, used = (\d+)GB\(([\d.]+)%`)
+	// e.g. leader: 10.0.1.1:2379 / current MDS: 10.0.1.1:6700
+	regexLeader  = regexp.MustCompile(`(?m)^[ \t]*(?:leader|current [\w-]+):[ \t]*(.*)$`)
+	regexOnline  = regexp.MustCompile(`(?m)^[ \t]*online[\w -]*:[ \t]*(.*)$`)
+	regexOffline = regexp.MustCompile(`(?m)^[ \t]*offline[\w -]*:[ \t]*(.*)$`)
+)
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func splitAddrs(s string) []string {
+	addrs := []string{}
+	for _, addr := range strings.Split(strings.Trim(s, "[] "), ",") {
+		if addr = strings.TrimSpace(addr); len(addr) > 0 {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+func firstLine(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) == 0 {
+		return "no output"
+	}
+	return strings.SplitN(output, "\n", 2)[0]
+}
+
+func parseCopysetHealth(output string) (CopysetHealth, bool) {
+	mu := regexCopysets.FindStringSubmatch(output)
+	if mu == nil {
+		return CopysetHealth{}, false
+	}
+	return CopysetHealth{Total: atoi(mu[1]), Unhealthy: atoi(mu[2])}, true
+}
+
+func parseChunkserverHealth(output string) (ChunkserverHealth, bool) {
+	mu := regexChunkservers.FindStringSubmatch(output)
+	if mu == nil {
+		return ChunkserverHealth{}, false
+	}
+	return ChunkserverHealth{Total: atoi(mu[1]), Online: atoi(mu[2]), Offline: atoi(mu[3])}, true
+}
+
+func parseSpaceHealth(output string) (SpaceHealth, bool) {
+	mu := regexLogicalSpace.FindStringSubmatch(output)
+	if mu == nil {
+		return SpaceHealth{}, false
+	}
+	total, _ := strconv.ParseUint(mu[1], 10, 64)
+	used, _ := strconv.ParseUint(mu[2], 10, 64)
+	percent, _ := strconv.ParseFloat(mu[3], 64)
+	return SpaceHealth{Total: total, Used: used, UsedPercent: percent}, true
+}
+
+func parseServiceHealth(output string) (ServiceHealth, bool) {
+	health := ServiceHealth{Online: []string{}, Offline: []string{}}
+	mu := regexOnline.FindStringSubmatch(output)
+	if mu == nil {
+		return health, false
+	}
+	health.Online = splitAddrs(mu[1])
+	if mu = regexOffline.FindStringSubmatch(output); mu != nil {
+		health.Offline = splitAddrs(mu[1])
+	}
+	if mu = regexLeader.FindStringSubmatch(output); mu != nil {
+		health.Leader = strings.TrimSpace(mu[1])
+	}
+	return health, true
+}
+
+/*
+ * verdict:
+ *   unhealthy: any check failed, unhealthy copysets, etcd/mds without leader
+ *   warning: any service offline, snapshotclone without leader or logical pool usage over 80%
+ *   healthy: otherwise
+ */
+func (h *ClusterHealth) report(verdict, format string, a ...interface{}) {
+	h.Problems = append(h.Problems, fmt.Sprintf(format, a...))
+	if verdict == HEALTH_VERDICT_UNHEALTHY || h.Verdict == HEALTH_VERDICT_HEALTHY {
+		h.Verdict = verdict
+	}
+}
+
+func (h *ClusterHealth) checkService(name string, health ServiceHealth, leaderVerdict string) {
+	if len(health.Leader) == 0 {
+		h.report(leaderVerdict, "%s has no leader", name)
+	}
+	if len(health.Offline) > 0 {
+		h.report(HEALTH_VERDICT_WARNING, "%s offline: %s", name, strings.Join(health.Offline, ", "))
+	}
+}
+
+func summaryClusterHealth(checks []*healthCheck) ClusterHealth {
+	h := ClusterHealth{
+		Verdict:  HEALTH_VERDICT_HEALTHY,
+		Problems: []string{},
+		Etcd:     ServiceHealth{Online: []string{}, Offline: []string{}},
+		MDS:      ServiceHealth{Online: []string{}, Offline: []string{}},
+	}
+	for _, check := range checks {
+		ok := true
+		switch check.name {
+		case HEALTH_CHECK_COPYSET:
+			if h.Copysets, ok = parseCopysetHealth(check.output); ok && h.Copysets.Unhealthy > 0 {
+				h.report(HEALTH_VERDICT_UNHEALTHY, "%d/%d copysets are unhealthy",
+					h.Copysets.Unhealthy, h.Copysets.Total)
+			}
+		case HEALTH_CHECK_CHUNKSERVER:
+			if h.Chunkservers, ok = parseChunkserverHealth(check.output); ok && h.Chunkservers.Offline > 0 {
+				h.report(HEALTH_VERDICT_WARNING, "%d/%d chunkservers are offline",
+					h.Chunkservers.Offline, h.Chunkservers.Total)
+			}
+		case HEALTH_CHECK_SPACE:
+			if h.Space, ok = parseSpaceHealth(check.output); ok && h.Space.UsedPercent >= SPACE_USAGE_WARNING_PERCENT {
+				h.report(HEALTH_VERDICT_WARNING, "logical pool usage %.2f%% exceeds %d%%",
+					h.Space.UsedPercent, SPACE_USAGE_WARNING_PERCENT)
+			}
+		case HEALTH_CHECK_ETCD:
+			if h.Etcd, ok = parseServiceHealth(check.output); ok {
+				h.checkService(check.name, h.Etcd, HEALTH_VERDICT_UNHEALTHY)
+			}
+		case HEALTH_CHECK_MDS:
+			if h.MDS, ok = parseServiceHealth(check.output); ok {
+				h.checkService(check.name, h.MDS, HEALTH_VERDICT_UNHEALTHY)
+			}
+		case HEALTH_CHECK_SNAPSHOTCLONE:
+			var health ServiceHealth
+			if health, ok = parseServiceHealth(check.output); ok {
+				h.SnapshotClone = &health
+				h.checkService(check.name, health, HEALTH_VERDICT_WARNING)
+			}
+		}
+
+		if !ok {
+			h.report(HEALTH_VERDICT_UNHEALTHY, "get %s status failed: %s",
+				check.name, firstLine(check.output))
+		}
+	}
+	return h
+}
+
+func checkClusterHealth(checks []*healthCheck, memStorage *utils.SafeMap) step.LambdaType {
+	return func(ctx *context.Context) error {
+		memStorage.Set(comm.KEY_CLUSTER_HEALTH, summaryClusterHealth(checks))
+		return nil
+	}
+}
+
+func NewGetClusterHealthTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+	dcs, err := curveadm.ParseTopology()
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Get Cluster Health", subname, hc.GetSSHConfig())
+
+	// add step to task
+	names := []string{
+		HEALTH_CHECK_COPYSET,
+		HEALTH_CHECK_CHUNKSERVER,
+		HEALTH_CHECK_SPACE,
+		HEALTH_CHECK_ETCD,
+		HEALTH_CHECK_MDS,
+	}
+	if len(curveadm.FilterDeployConfigByRole(dcs, topology.ROLE_SNAPSHOTCLONE)) > 0 {
+		names = append(names, HEALTH_CHECK_SNAPSHOTCLONE)
+	}
+	checks := []*healthCheck{}
+	for _, name := range names {
+		check := &healthCheck{name: name, command: HEALTH_CHECK_COMMANDS[name]}
+		checks = append(checks, check)
+		// curve_ops_tool exits with non-zero code if something unhealthy
+		t.AddStep(&step.ContainerExec{
+			ContainerId: &containerId,
+			Command:     check.command,
+			Success:     &check.success,
+			Out:         &check.output,
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
+	t.AddStep(&step.Lambda{
+		Lambda: checkClusterHealth(checks, curveadm.MemStorage()),
+	})
+
+	return t, nil
+}
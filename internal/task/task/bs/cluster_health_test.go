@@ -0,0 +1,110 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package bs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	COPYSETS_HEALTHY_OUTPUT = `Copysets are healthy!
+total copysets: 100, unhealthy copysets: 0, unhealthy_ratio: 0%`
+	CHUNKSERVER_STATUS_OUTPUT = `chunkserver: total num = 3, online = 3, offline = 0(recoveringout = 0, chunkserverlist: [])`
+	SPACE_OUTPUT              = `Space info:
+physical: total = 300GB, used = 30GB(10.00%), left = 270GB(90.00%)
+logical: total = 100GB, used = 10GB(10.00%, can be recycled = 0GB(0.00%)), left = 90GB(90.00%), allocated = 10GB(10.00%)`
+	ETCD_STATUS_OUTPUT = `Etcd status:
+version: 3.4.10
+leader: 10.0.1.1:2379
+online: 10.0.1.1:2379, 10.0.1.2:2379, 10.0.1.3:2379
+offline: `
+	MDS_STATUS_OUTPUT = `MDS status:
+version: 1.2.5
+current MDS: 10.0.1.2:6700
+online mds list: 10.0.1.1:6700, 10.0.1.2:6700
+offline mds list: 10.0.1.3:6700`
+)
+
+func newHealthChecks(outputs map[string]string) []*healthCheck {
+	checks := []*healthCheck{}
+	for _, name := range []string{
+		HEALTH_CHECK_COPYSET,
+		HEALTH_CHECK_CHUNKSERVER,
+		HEALTH_CHECK_SPACE,
+		HEALTH_CHECK_ETCD,
+		HEALTH_CHECK_MDS,
+	} {
+		checks = append(checks, &healthCheck{name: name, success: true, output: outputs[name]})
+	}
+	return checks
+}
+
+func TestSummaryClusterHealth(t *testing.T) {
+	assert := assert.New(t)
+	outputs := map[string]string{
+		HEALTH_CHECK_COPYSET:     COPYSETS_HEALTHY_OUTPUT,
+		HEALTH_CHECK_CHUNKSERVER: CHUNKSERVER_STATUS_OUTPUT,
+		HEALTH_CHECK_SPACE:       SPACE_OUTPUT,
+		HEALTH_CHECK_ETCD:        ETCD_STATUS_OUTPUT,
+		HEALTH_CHECK_MDS:         MDS_STATUS_OUTPUT,
+	}
+
+	// (1) one mds offline
+	h := summaryClusterHealth(newHealthChecks(outputs))
+	assert.Equal(HEALTH_VERDICT_WARNING, h.Verdict)
+	assert.Equal([]string{"mds offline: 10.0.1.3:6700"}, h.Problems)
+	assert.Equal(CopysetHealth{Total: 100, Unhealthy: 0}, h.Copysets)
+	assert.Equal(ChunkserverHealth{Total: 3, Online: 3, Offline: 0}, h.Chunkservers)
+	assert.Equal(SpaceHealth{Total: 100, Used: 10, UsedPercent: 10}, h.Space)
+	assert.Equal("10.0.1.1:2379", h.Etcd.Leader)
+	assert.Len(h.Etcd.Online, 3)
+	assert.Empty(h.Etcd.Offline)
+	assert.Equal("10.0.1.2:6700", h.MDS.Leader)
+	assert.Nil(h.SnapshotClone)
+
+	// (2) all healthy
+	outputs[HEALTH_CHECK_MDS] = "current MDS: 10.0.1.2:6700\nonline mds list: 10.0.1.2:6700\noffline mds list:"
+	h = summaryClusterHealth(newHealthChecks(outputs))
+	assert.Equal(HEALTH_VERDICT_HEALTHY, h.Verdict)
+	assert.Empty(h.Problems)
+
+	// (3) unhealthy copysets and unrecognized output
+	outputs[HEALTH_CHECK_COPYSET] = "Copysets not healthy!\ntotal copysets: 100, unhealthy copysets: 3, unhealthy_ratio: 3%"
+	outputs[HEALTH_CHECK_ETCD] = "connect etcd failed"
+	h = summaryClusterHealth(newHealthChecks(outputs))
+	assert.Equal(HEALTH_VERDICT_UNHEALTHY, h.Verdict)
+	assert.Equal([]string{
+		"3/100 copysets are unhealthy",
+		"get etcd status failed: connect etcd failed",
+	}, h.Problems)
+
+	// (4) space usage over threshold only warns
+	outputs[HEALTH_CHECK_COPYSET] = COPYSETS_HEALTHY_OUTPUT
+	outputs[HEALTH_CHECK_ETCD] = ETCD_STATUS_OUTPUT
+	outputs[HEALTH_CHECK_SPACE] = "logical: total = 100GB, used = 85GB(85.00%, can be recycled = 0GB(0.00%))"
+	h = summaryClusterHealth(newHealthChecks(outputs))
+	assert.Equal(HEALTH_VERDICT_WARNING, h.Verdict)
+	assert.Equal([]string{"logical pool usage 85.00% exceeds 80%"}, h.Problems)
+}
@@ -76,16 +76,21 @@ func checkCreateStatus(out *string) step.LambdaType {
 	}
 }
 
-func setClientAuxInfo(curveadm *cli.CurveAdm, options MapOptions) step.LambdaType {
+// the volume which already exist is fine for mapping
+func checkVolumeCreated(out *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if *out == "SUCCESS" || *out == "EXIST" {
+			return nil
+		}
+		return errno.ERR_CREATE_VOLUME_FAILED
+	}
+}
+
+func setClientAuxInfo(curveadm *cli.CurveAdm, options MapOptions, cc *configure.ClientConfig) step.LambdaType {
 	return func(ctx *context.Context) error {
 		volumeId := curveadm.GetVolumeId(options.Host, options.User, options.Volume)
 
-		auxInfo := &AuxInfo{
-			User:    options.User,
-			Volume:  options.Volume,
-			Poolset: options.Poolset,
-		}
-		bytes, err := json.Marshal(auxInfo)
+		bytes, err := json.Marshal(newAuxInfo(options, cc))
 		if err != nil {
 			return errno.ERR_ENCODE_VOLUME_INFO_TO_JSON_FAILED.E(err)
 		}
@@ -98,6 +103,36 @@ func setClientAuxInfo(curveadm *cli.CurveAdm, options MapOptions) step.LambdaTyp
 	}
 }
 
+// steps to create volume in the running NEBD container, the result
+// (SUCCESS or EXIST) of create.sh will be stored in out
+func newCreateVolumeSteps(curveadm *cli.CurveAdm, cc *configure.ClientConfig,
+	options MapOptions, containerId, out *string) []task.Step {
+	toolsConf := fmt.Sprintf(FORMAT_TOOLS_CONF, cc.GetClusterMDSAddr())
+	script := scripts.CREATE_VOLUME
+	scriptPath := "/curvebs/nebd/sbin/create.sh"
+	command := fmt.Sprintf("/bin/bash %s %s %s %d %s", scriptPath, options.User, options.Volume, options.Size, options.Poolset)
+	return []task.Step{
+		&step.InstallFile{ // install tools.conf
+			Content:           &toolsConf,
+			ContainerId:       containerId,
+			ContainerDestPath: "/etc/curve/tools.conf",
+			ExecOptions:       curveadm.ExecOptions(),
+		},
+		&step.InstallFile{ // install create_volume.sh
+			Content:           &script,
+			ContainerId:       containerId,
+			ContainerDestPath: scriptPath,
+			ExecOptions:       curveadm.ExecOptions(),
+		},
+		&step.ContainerExec{
+			ContainerId: containerId,
+			Command:     command,
+			Out:         out,
+			ExecOptions: curveadm.ExecOptions(),
+		},
+	}
+}
+
 func NewCreateVolumeTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.Task, error) {
 	options := curveadm.MemStorage().Get(comm.KEY_MAP_OPTIONS).(MapOptions)
 	hc, err := curveadm.GetHost(options.Host)
@@ -112,10 +147,6 @@ func NewCreateVolumeTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*t
 	var out string
 	containerName := volume2ContainerName(options.User, options.Volume)
 	containerId := containerName
-	toolsConf := fmt.Sprintf(FORMAT_TOOLS_CONF, cc.GetClusterMDSAddr())
-	script := scripts.CREATE_VOLUME
-	scriptPath := "/curvebs/nebd/sbin/create.sh"
-	command := fmt.Sprintf("/bin/bash %s %s %s %d %s", scriptPath, options.User, options.Volume, options.Size, options.Poolset)
 	t.AddStep(&step.Lambda{
 		Lambda: checkCreateOption(options.Create),
 	})
@@ -129,29 +160,14 @@ func NewCreateVolumeTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*t
 	t.AddStep(&step.Lambda{
 		Lambda: checkVolumeStatus(&out),
 	})
-	t.AddStep(&step.InstallFile{ // install tools.conf
-		Content:           &toolsConf,
-		ContainerId:       &containerName,
-		ContainerDestPath: "/etc/curve/tools.conf",
-		ExecOptions:       curveadm.ExecOptions(),
-	})
-	t.AddStep(&step.InstallFile{ // install create_volume.sh
-		Content:           &script,
-		ContainerId:       &containerId,
-		ContainerDestPath: scriptPath,
-		ExecOptions:       curveadm.ExecOptions(),
-	})
-	t.AddStep(&step.ContainerExec{
-		ContainerId: &containerId,
-		Command:     command,
-		Out:         &out,
-		ExecOptions: curveadm.ExecOptions(),
-	})
+	for _, step := range newCreateVolumeSteps(curveadm, cc, options, &containerId, &out) {
+		t.AddStep(step)
+	}
 	t.AddStep(&step.Lambda{
 		Lambda: checkCreateStatus(&out),
 	})
 	t.AddStep(&step.Lambda{
-		Lambda: setClientAuxInfo(curveadm, options),
+		Lambda: setClientAuxInfo(curveadm, options, cc),
 	})
 
 	return t, nil
@@ -27,9 +27,8 @@ import (
 	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
-	"github.com/opencurve/curveadm/internal/common"
-	client "github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
 	"github.com/opencurve/curveadm/internal/task/context"
 	"github.com/opencurve/curveadm/internal/task/step"
 	"github.com/opencurve/curveadm/internal/task/task"
@@ -37,6 +36,12 @@ import (
 
 type (
 	step2CheckTgtdStatus struct{ output *string }
+
+	step2DeleteTarget struct {
+		curveadm *cli.CurveAdm
+		target   storage.Target
+		output   *string
+	}
 )
 
 // check target daemon status
@@ -50,20 +55,56 @@ func (s *step2CheckTgtdStatus) Execute(ctx *context.Context) error {
 	return nil
 }
 
-func NewDeleteTargetTask(curveadm *cli.CurveAdm, cc *client.ClientConfig) (*task.Task, error) {
-	options := curveadm.MemStorage().Get(common.KEY_TARGET_OPTIONS).(TargetOption)
-	hc, err := curveadm.GetHost(options.Host)
+// the tid is reallocated once target daemon restarted, so we find
+// the recorded target by its name rather than the tid in database
+func (s *step2DeleteTarget) Execute(ctx *context.Context) error {
+	tid := s.target.Tid
+	if len(s.target.Name) > 0 {
+		tid = ""
+		for _, target := range parseTargets(s.target.Host, "", *s.output) {
+			if target.Name == s.target.Name {
+				tid = target.Tid
+			}
+		}
+	}
+
+	curveadm := s.curveadm
+	if len(tid) > 0 {
+		command := fmt.Sprintf("tgtadm --lld iscsi --mode target --op delete --tid %s", tid)
+		dockerCli := ctx.Module().DockerCli().ContainerExec(DEFAULT_TGTD_CONTAINER_NAME, command)
+		out, err := dockerCli.Execute(curveadm.ExecOptions())
+		if err != nil {
+			return errno.ERR_RUN_COMMAND_IN_CONTAINER_FAILED.S(out)
+		}
+	}
+
+	if len(s.target.Name) > 0 {
+		err := curveadm.Storage().DeleteTarget(s.target.ClusterId, s.target.Name, s.target.Host)
+		if err != nil {
+			return errno.ERR_DELETE_TARGET_FAILED.E(err)
+		}
+	}
+	return nil
+}
+
+// NewDeleteTargetTask deletes target on one gateway, the target which not
+// recorded in database (e.g. added by old version) is specified by tid.
+func NewDeleteTargetTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
+	target := v.(storage.Target)
+	hc, err := curveadm.GetHost(target.Host)
 	if err != nil {
 		return nil, err
 	}
 
-	subname := fmt.Sprintf("hostname=%s tid=%s", hc.GetHostname(), options.Tid)
+	subname := fmt.Sprintf("hostname=%s tid=%s", hc.GetHostname(), target.Tid)
+	if len(target.Name) > 0 {
+		subname = fmt.Sprintf("hostname=%s target=%s", hc.GetHostname(), target.Name)
+	}
 	t := task.NewTask("Delete Target", subname, hc.GetSSHConfig())
 
 	// add step
 	var output string
 	containerId := DEFAULT_TGTD_CONTAINER_NAME
-	tid := options.Tid
 	t.AddStep(&step.ListContainers{
 		ShowAll:     true,
 		Format:      "'{{.ID}} {{.Status}}'",
@@ -76,9 +117,15 @@ func NewDeleteTargetTask(curveadm *cli.CurveAdm, cc *client.ClientConfig) (*task
 	})
 	t.AddStep(&step.ContainerExec{
 		ContainerId: &containerId,
-		Command:     fmt.Sprintf("tgtadm --lld iscsi --mode target --op delete --tid %s", tid),
+		Command:     CMD_SHOW_TARGETS,
+		Out:         &output,
 		ExecOptions: curveadm.ExecOptions(),
 	})
+	t.AddStep(&step2DeleteTarget{
+		curveadm: curveadm,
+		target:   target,
+		output:   &output,
+	})
 
 	return t, nil
 }
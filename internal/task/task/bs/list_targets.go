@@ -37,6 +37,7 @@ import (
 
 const (
 	DEFAULT_TGTD_LISTEN_PORT = 3260
+	CMD_SHOW_TARGETS         = "tgtadm --lld iscsi --mode target --op show"
 )
 
 type (
@@ -48,14 +49,24 @@ type (
 	}
 
 	Target struct {
-		Host   string
-		Tid    string
-		Name   string
-		Store  string
-		Portal string
+		Host     string
+		Tid      string
+		Name     string
+		Stores   []string // backing store of LUN 1, 2, ...
+		Portal   string
+		Status   string
+		ACLs     []string
+		Accounts []string
+		Sessions []string // initiator@address
 	}
 )
 
+// FormatTargetId returns the key of target in KEY_ALL_TARGETS,
+// the target name is unique on one host
+func FormatTargetId(host, name string) string {
+	return fmt.Sprintf("%s/%s", host, name)
+}
+
 func addTarget(memStorage *utils.SafeMap, id string, target *Target) {
 	memStorage.TX(func(kv *utils.SafeMap) error {
 		m := map[string]*Target{}
@@ -73,47 +84,101 @@ func addTarget(memStorage *utils.SafeMap, id string, target *Target) {
 Output Example:
 Target 3: iqn.2022-02.com.opencurve:curve.wine93/test03
 
-	...
+	System information:
+	    ...
+	I_T nexus information:
+	    I_T nexus: 1
+	        Initiator: iqn.1994-05.com.redhat:client1 alias: client1
+	        Connection: 0
+	            IP Address: 10.0.0.5
 	LUN information:
 	    LUN: 0
 	        ...
 	    LUN: 1
 	        ...
 	        Backing store path: cbd:pool//test03_wine93_
+	Account information:
+	    user1
+	    user2 (outgoing)
+	ACL information:
+	    10.0.0.0/24
+	    iqn.1994-05.com.redhat:client1
 */
-func (s *step2FormatTarget) Execute(ctx *context.Context) error {
-	output := *s.output
+func parseTargets(host, hostname, output string) []*Target {
+	targets := []*Target{}
 	lines := strings.Split(output, "\n")
 
 	var target *Target
+	var section, initiator string
 	titlePattern := regexp.MustCompile("^Target ([0-9]+): (.+)$")
+	sectionPattern := regexp.MustCompile(`^\s+([A-Za-z_ ]+) information:$`)
 	storePattern := regexp.MustCompile("Backing store path: (cbd:pool//.+)$")
+	initiatorPattern := regexp.MustCompile(`^\s+Initiator: (\S+)`)
+	addressPattern := regexp.MustCompile(`^\s+IP Address: (\S+)`)
 	for _, line := range lines {
 		mu := titlePattern.FindStringSubmatch(line)
 		if len(mu) > 0 {
 			target = &Target{
-				Host:   s.host,
-				Tid:    mu[1],
-				Name:   mu[2],
-				Store:  "-",
-				Portal: fmt.Sprintf("%s:%d", s.hostname, DEFAULT_TGTD_LISTEN_PORT),
+				Host:     host,
+				Tid:      mu[1],
+				Name:     mu[2],
+				Stores:   []string{},
+				Portal:   fmt.Sprintf("%s:%d", hostname, DEFAULT_TGTD_LISTEN_PORT),
+				ACLs:     []string{},
+				Accounts: []string{},
+				Sessions: []string{},
 			}
-			addTarget(s.memStorage, mu[1], target)
+			targets = append(targets, target)
+			section = ""
+			continue
+		} else if target == nil {
+			continue
+		}
+
+		mu = sectionPattern.FindStringSubmatch(line)
+		if len(mu) > 0 {
+			section = mu[1]
 			continue
 		}
 
 		mu = storePattern.FindStringSubmatch(line)
 		if len(mu) > 0 {
-			target.Store = mu[1]
+			target.Stores = append(target.Stores, mu[1])
+			continue
+		}
+
+		switch section {
+		case "I_T nexus":
+			if mu = initiatorPattern.FindStringSubmatch(line); len(mu) > 0 {
+				initiator = mu[1]
+			} else if mu = addressPattern.FindStringSubmatch(line); len(mu) > 0 {
+				target.Sessions = append(target.Sessions, initiator+"@"+mu[1])
+			}
+		case "Account":
+			if item := strings.TrimSpace(line); len(item) > 0 {
+				target.Accounts = append(target.Accounts, item)
+			}
+		case "ACL":
+			if item := strings.TrimSpace(line); len(item) > 0 {
+				target.ACLs = append(target.ACLs, item)
+			}
 		}
 	}
+	return targets
+}
 
+func (s *step2FormatTarget) Execute(ctx *context.Context) error {
+	for _, target := range parseTargets(s.host, s.hostname, *s.output) {
+		addTarget(s.memStorage, FormatTargetId(target.Host, target.Name), target)
+	}
 	return nil
 }
 
+// NewListTargetsTask lists targets which actually exported on host,
+// the task will be skipped if target daemon is not running.
 func NewListTargetsTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
-	options := curveadm.MemStorage().Get(comm.KEY_TARGET_OPTIONS).(TargetOption)
-	hc, err := curveadm.GetHost(options.Host)
+	host := v.(string)
+	hc, err := curveadm.GetHost(host)
 	if err != nil {
 		return nil, err
 	}
@@ -132,17 +197,17 @@ func NewListTargetsTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, erro
 		Out:         &output,
 		ExecOptions: curveadm.ExecOptions(),
 	})
-	t.AddStep(&step2CheckTgtdStatus{
-		output: &output,
+	t.AddStep(&step.Lambda{
+		Lambda: checkTgtdRunning(&output),
 	})
 	t.AddStep(&step.ContainerExec{
 		ContainerId: &containerId,
-		Command:     fmt.Sprintf("tgtadm --lld iscsi --mode target --op show"),
+		Command:     CMD_SHOW_TARGETS,
 		Out:         &output,
 		ExecOptions: curveadm.ExecOptions(),
 	})
 	t.AddStep(&step2FormatTarget{
-		host:       options.Host,
+		host:       host,
 		hostname:   hc.GetHostname(),
 		output:     &output,
 		memStorage: curveadm.MemStorage(),
@@ -51,6 +51,38 @@ type (
 		Size        int
 		NoExclusive bool
 		Poolset     string
+		Persist     bool
+		Device      string // map to specified nbd device, e.g. /dev/nbd0
+		Timeout     int    // seconds of NBD request timeout, 0 means default
+		ReadOnly    bool
+		Throttles   []VolumeThrottle
+	}
+
+	// VolumeThrottle limits IOPS or bandwidth (bytes per second) of volume,
+	// it applied in MDS, so all clients of the volume share the limit and
+	// it is kept after the volume unmapped
+	VolumeThrottle struct {
+		Type  string `json:"type"` // e.g. iops_total, bps_write
+		Limit uint64 `json:"limit"`
+	}
+
+	step2UpdateThrottle struct {
+		curveadm    *cli.CurveAdm
+		options     MapOptions
+		containerId *string
+	}
+)
+
+const (
+	MAP_SCRIPT_PATH      = "/curvebs/nebd/sbin/map.sh"
+	TOOLS_V2_BINARY_PATH = "/curvebs/tools-v2/sbin/curve"
+)
+
+var (
+	// see also: curve bs update throttle --help
+	THROTTLE_TYPES = []string{
+		"iops_total", "iops_read", "iops_write",
+		"bps_total", "bps_read", "bps_write",
 	}
 )
 
@@ -68,9 +100,120 @@ func getMapOptions(options MapOptions) string {
 	if options.NoExclusive {
 		mapOptions = append(mapOptions, "--no-exclusive")
 	}
+	if len(options.Device) > 0 {
+		mapOptions = append(mapOptions, "--device "+options.Device)
+	}
+	if options.Timeout > 0 {
+		mapOptions = append(mapOptions, fmt.Sprintf("--timeout %d", options.Timeout))
+	}
+	if options.ReadOnly {
+		mapOptions = append(mapOptions, "--read-only")
+	}
 	return strings.Join(mapOptions, " ")
 }
 
+// e.g. curve bs update throttle --path /test --user curve --type iops_total --limit 1000
+func getThrottleCommand(options MapOptions, throttle VolumeThrottle) string {
+	return fmt.Sprintf("%s bs update throttle --path %s --user %s --type %s --limit %d",
+		TOOLS_V2_BINARY_PATH, options.Volume, options.User, throttle.Type, throttle.Limit)
+}
+
+func (s *step2UpdateThrottle) Execute(ctx *context.Context) error {
+	for _, throttle := range s.options.Throttles {
+		command := getThrottleCommand(s.options, throttle)
+		dockerCli := ctx.Module().DockerCli().ContainerExec(*s.containerId, command)
+		out, err := dockerCli.Execute(s.curveadm.ExecOptions())
+		if err != nil {
+			return errno.ERR_UPDATE_VOLUME_THROTTLE_FAILED.
+				F("%s=%d: %s", throttle.Type, throttle.Limit, out)
+		}
+	}
+	return nil
+}
+
+func getMapCommand(options MapOptions) string {
+	return fmt.Sprintf("/bin/bash %s %s %s %s", MAP_SCRIPT_PATH,
+		options.User, options.Volume, getMapOptions(options))
+}
+
+// the unit starts the NEBD container and maps the volume again after host reboot
+func newMapUnit(curveadm *cli.CurveAdm, options MapOptions) string {
+	unit := task.ClientUnit{
+		Description: fmt.Sprintf("%s:%s", options.User, options.Volume),
+		Engine:      curveadm.ExecOptions().ExecWithEngine,
+		Container:   volume2ContainerName(options.User, options.Volume),
+		PreCommands: []string{
+			fmt.Sprintf("modprobe %s nbds_max=%d", comm.KERNERL_MODULE_NBD, comm.NBDS_MAX),
+		},
+		PostCommands: []string{getMapCommand(options)},
+	}
+	return unit.Render()
+}
+
+func newInstallMapUnitStep(curveadm *cli.CurveAdm, options MapOptions) task.Step {
+	volumeId := curveadm.GetVolumeId(options.Host, options.User, options.Volume)
+	unit := newMapUnit(curveadm, options)
+	return &step.InstallSystemdUnit{
+		Name:        task.ClientUnitName(volumeId),
+		Content:     &unit,
+		ExecOptions: curveadm.ExecOptions(),
+	}
+}
+
+// steps to map volume in the running NEBD container
+func newMapSteps(curveadm *cli.CurveAdm, cc *configure.ClientConfig,
+	options MapOptions, containerId *string) []task.Step {
+	var out string
+	var success bool
+	script := scripts.MAP
+	return []task.Step{
+		&step.ModProbe{
+			Name:        comm.KERNERL_MODULE_NBD,
+			Args:        []string{fmt.Sprintf("nbds_max=%d", comm.NBDS_MAX)},
+			ExecOptions: curveadm.ExecOptions(),
+		},
+		&step.SyncFile{ // sync nebd-client config
+			ContainerSrcId:    containerId,
+			ContainerSrcPath:  "/curvebs/conf/nebd-client.conf",
+			ContainerDestId:   containerId,
+			ContainerDestPath: "/etc/nebd/nebd-client.conf",
+			KVFieldSplit:      CLIENT_CONFIG_DELIMITER,
+			Mutate:            newMutate(cc, CLIENT_CONFIG_DELIMITER),
+			ExecOptions:       curveadm.ExecOptions(),
+		},
+		&step.InstallFile{ // install map.sh
+			Content:           &script,
+			ContainerId:       containerId,
+			ContainerDestPath: MAP_SCRIPT_PATH,
+			ExecOptions:       curveadm.ExecOptions(),
+		},
+		&step.TrySyncFile{ // sync tools-v2 config
+			ContainerSrcId:    containerId,
+			ContainerSrcPath:  TOOLS_V2_CONFIG_SRC_PATH,
+			ContainerDestId:   containerId,
+			ContainerDestPath: TOOLS_V2_CONFIG_DEST_PATH,
+			KVFieldSplit:      TOOLS_V2_CONFIG_DELIMITER,
+			Mutate:            newMutate(cc, TOOLS_V2_CONFIG_DELIMITER),
+			ExecOptions:       curveadm.ExecOptions(),
+		},
+		&step2UpdateThrottle{
+			curveadm:    curveadm,
+			options:     options,
+			containerId: containerId,
+		},
+		&step.ContainerExec{
+			ContainerId: containerId,
+			Command:     getMapCommand(options),
+			Success:     &success,
+			Out:         &out,
+			ExecOptions: curveadm.ExecOptions(),
+		},
+		&step.Lambda{
+			Lambda: checkMapStatus(&success, &out),
+		},
+	}
+}
+
 func NewMapTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.Task, error) {
 	options := curveadm.MemStorage().Get(comm.KEY_MAP_OPTIONS).(MapOptions)
 	hc, err := curveadm.GetHost(options.Host)
@@ -83,13 +226,8 @@ func NewMapTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.Task,
 
 	// add step
 	var out string
-	var success bool
 	containerName := volume2ContainerName(options.User, options.Volume)
 	containerId := containerName
-	script := scripts.MAP
-	scriptPath := "/curvebs/nebd/sbin/map.sh"
-	mapOptions := getMapOptions(options)
-	command := fmt.Sprintf("/bin/bash %s %s %s %s", scriptPath, options.User, options.Volume, mapOptions)
 
 	t.AddStep(&step.ListContainers{
 		ShowAll:     true,
@@ -101,45 +239,60 @@ func NewMapTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.Task,
 	t.AddStep(&step.Lambda{
 		Lambda: checkVolumeStatus(&out),
 	})
-	t.AddStep(&step.ModProbe{
-		Name:        comm.KERNERL_MODULE_NBD,
-		Args:        []string{"nbds_max=64"},
-		ExecOptions: curveadm.ExecOptions(),
-	})
-	t.AddStep(&step.SyncFile{ // sync nebd-client config
-		ContainerSrcId:    &containerId,
-		ContainerSrcPath:  "/curvebs/conf/nebd-client.conf",
-		ContainerDestId:   &containerId,
-		ContainerDestPath: "/etc/nebd/nebd-client.conf",
-		KVFieldSplit:      CLIENT_CONFIG_DELIMITER,
-		Mutate:            newMutate(cc, CLIENT_CONFIG_DELIMITER),
-		ExecOptions:       curveadm.ExecOptions(),
-	})
-	t.AddStep(&step.InstallFile{ // install map.sh
-		Content:           &script,
-		ContainerId:       &containerId,
-		ContainerDestPath: scriptPath,
-		ExecOptions:       curveadm.ExecOptions(),
-	})
-	t.AddStep(&step.TrySyncFile{ // sync tools-v2 config
-		ContainerSrcId:    &containerId,
-		ContainerSrcPath:  TOOLS_V2_CONFIG_SRC_PATH,
-		ContainerDestId:   &containerId,
-		ContainerDestPath: TOOLS_V2_CONFIG_DEST_PATH,
-		KVFieldSplit:      TOOLS_V2_CONFIG_DELIMITER,
-		Mutate:            newMutate(cc, TOOLS_V2_CONFIG_DELIMITER),
-		ExecOptions:       curveadm.ExecOptions(),
-	})
-	t.AddStep(&step.ContainerExec{
-		ContainerId: &containerId,
-		Command:     command,
-		Success:     &success,
-		Out:         &out,
-		ExecOptions: curveadm.ExecOptions(),
+	for _, step := range newMapSteps(curveadm, cc, options, &containerId) {
+		t.AddStep(step)
+	}
+	t.AddStep(&step2RecordMetricPort{
+		curveadm:    curveadm,
+		clientId:    curveadm.GetVolumeId(options.Host, options.User, options.Volume),
+		startPort:   cc.GetMetricPort(),
+		containerId: &containerId,
 	})
-	t.AddStep(&step.Lambda{
-		Lambda: checkMapStatus(&success, &out),
+	if options.Persist {
+		t.AddStep(newInstallMapUnitStep(curveadm, options))
+	}
+
+	return t, nil
+}
+
+// NewMapVolumeTask starts the NEBD service, creates the volume if required
+// and maps it in one task, the options are specified by caller instead of
+// memory storage, so that many volumes can be mapped concurrently
+func NewMapVolumeTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig,
+	options MapOptions) (*task.Task, error) {
+	hc, err := curveadm.GetHost(options.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	subname := fmt.Sprintf("hostname=%s volume=%s:%s", hc.GetHostname(), options.User, options.Volume)
+	t := task.NewTask("Map Volume", subname, hc.GetSSHConfig())
+
+	// add step
+	var containerId, out string
+	for _, step := range newNEBDServiceSteps(curveadm, cc, hc.GetHostname(), options, &containerId) {
+		t.AddStep(step)
+	}
+	if options.Create {
+		for _, step := range newCreateVolumeSteps(curveadm, cc, options, &containerId, &out) {
+			t.AddStep(step)
+		}
+		t.AddStep(&step.Lambda{
+			Lambda: checkVolumeCreated(&out),
+		})
+	}
+	for _, step := range newMapSteps(curveadm, cc, options, &containerId) {
+		t.AddStep(step)
+	}
+	t.AddStep(&step2RecordMetricPort{
+		curveadm:    curveadm,
+		clientId:    curveadm.GetVolumeId(options.Host, options.User, options.Volume),
+		startPort:   cc.GetMetricPort(),
+		containerId: &containerId,
 	})
+	if options.Persist {
+		t.AddStep(newInstallMapUnitStep(curveadm, options))
+	}
 
 	return t, nil
 }
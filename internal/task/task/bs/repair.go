@@ -0,0 +1,159 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package bs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	CMD_LIST_MAPPED = "curve-nbd list-mapped"
+)
+
+type step2RepairMap struct {
+	curveadm    *cli.CurveAdm
+	status      *string
+	containerId string
+	options     MapOptions
+}
+
+// IsVolumeMapped checks whether the volume is in the output of `curve-nbd list-mapped`
+func IsVolumeMapped(ctx *context.Context, containerId, user, volume string,
+	execOptions module.ExecOptions) (bool, error) {
+	dockerCli := ctx.Module().DockerCli().ContainerExec(containerId, CMD_LIST_MAPPED)
+	out, err := dockerCli.Execute(execOptions)
+	if err != nil {
+		return false, errno.ERR_RUN_COMMAND_IN_CONTAINER_FAILED.S(out)
+	}
+	return strings.Contains(out, formatImage(user, volume)), nil
+}
+
+func DecodeAuxInfo(client storage.Client) (*AuxInfo, error) {
+	auxInfo := &AuxInfo{}
+	err := json.Unmarshal([]byte(client.AuxInfo), auxInfo)
+	if err != nil {
+		return nil, errno.ERR_DECODE_CLIENT_AUX_INFO_FAILED.E(err)
+	}
+	return auxInfo, nil
+}
+
+func (s *step2RepairMap) Execute(ctx *context.Context) error {
+	status := *s.status
+	curveadm := s.curveadm
+	options := s.options
+	if len(status) == 0 {
+		return errno.ERR_REPAIR_CLIENT_FAILED.
+			F("volume %s:%s: %s, please unmap and map it again",
+				options.User, options.Volume, comm.CLIENT_DRIFT_CONTAINER_LOSED)
+	}
+
+	steps := []task.Step{}
+	if !strings.HasPrefix(status, "Up") {
+		steps = append(steps, &step.StartContainer{
+			ContainerId: &s.containerId,
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
+	steps = append(steps, &step.ModProbe{
+		Name:        comm.KERNERL_MODULE_NBD,
+		Args:        []string{fmt.Sprintf("nbds_max=%d", comm.NBDS_MAX)},
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	for _, step := range steps {
+		err := step.Execute(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	mapped, err := IsVolumeMapped(ctx, s.containerId, options.User, options.Volume, curveadm.ExecOptions())
+	if err != nil {
+		return err
+	} else if mapped {
+		return nil
+	}
+
+	dockerCli := ctx.Module().DockerCli().ContainerExec(s.containerId, getMapCommand(options))
+	out, err := dockerCli.Execute(curveadm.ExecOptions())
+	if err != nil {
+		return errno.ERR_MAP_VOLUME_FAILED.S(out)
+	}
+	return nil
+}
+
+// NewRepairMapTask starts the stopped NEBD container and maps the volume again,
+// and the systemd unit will be reinstalled if the client is persisted
+func NewRepairMapTask(curveadm *cli.CurveAdm, client storage.Client) (*task.Task, error) {
+	auxInfo, err := DecodeAuxInfo(client)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(client.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	options := auxInfo.mapOptions(client.Host)
+	containerId := client.ContainerId
+	subname := fmt.Sprintf("hostname=%s volume=%s:%s containerId=%s",
+		hc.GetHostname(), options.User, options.Volume, tui.TrimContainerId(containerId))
+	t := task.NewTask("Repair Volume", subname, hc.GetSSHConfig())
+
+	// add step
+	var status string
+	t.AddStep(&step.ListContainers{
+		ShowAll:     true,
+		Format:      "'{{.Status}}'",
+		Filter:      fmt.Sprintf("id=%s", containerId),
+		Out:         &status,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step2RepairMap{
+		curveadm:    curveadm,
+		status:      &status,
+		containerId: containerId,
+		options:     options,
+	})
+	if options.Persist {
+		unit := newMapUnit(curveadm, options)
+		t.AddStep(&step.InstallSystemdUnit{
+			Name:        task.ClientUnitName(client.Id),
+			Content:     &unit,
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
+
+	return t, nil
+}
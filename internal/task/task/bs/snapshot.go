@@ -0,0 +1,518 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package bs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+// actions of snapshotclone http api
+const (
+	SNAPSHOT_ACTION_CREATE      = "CreateSnapshot"
+	SNAPSHOT_ACTION_DELETE      = "DeleteSnapshot"
+	SNAPSHOT_ACTION_CANCEL      = "CancelSnapshot"
+	SNAPSHOT_ACTION_LIST        = "GetFileSnapshotList"
+	SNAPSHOT_ACTION_CLONE       = "Clone"
+	SNAPSHOT_ACTION_FLATTEN     = "Flatten"
+	SNAPSHOT_ACTION_LIST_CLONES = "GetCloneTaskList"
+
+	SNAPSHOTCLONE_API_VERSION   = "0.0.6"
+	SNAPSHOTCLONE_SUCCESS_CODE  = "0"
+	SNAPSHOTCLONE_LIST_LIMIT    = 100
+	SNAPSHOTCLONE_POLL_INTERVAL = 3 * time.Second
+	URL_SNAPSHOTCLONE_SERVICE   = "http://%s/SnapshotCloneService?%s"
+	COMMAND_CURL_SNAPSHOTCLONE  = "curl -s --connect-timeout 3 --max-time 10 '%s'"
+)
+
+// status of snapshot
+const (
+	SNAPSHOT_STATUS_DONE = iota
+	SNAPSHOT_STATUS_PENDING
+	SNAPSHOT_STATUS_DELETING
+	SNAPSHOT_STATUS_ERROR_DELETING
+	SNAPSHOT_STATUS_CANCELING
+	SNAPSHOT_STATUS_ERROR
+)
+
+// status of clone task
+const (
+	CLONE_STATUS_DONE = iota
+	CLONE_STATUS_CLONING
+	CLONE_STATUS_RECOVERING
+	CLONE_STATUS_CLEANING
+	CLONE_STATUS_ERROR_CLEANING
+	CLONE_STATUS_ERROR
+	CLONE_STATUS_RETRYING
+	CLONE_STATUS_META_INSTALLED
+)
+
+type (
+	SnapshotOptions struct {
+		Action  string
+		User    string
+		Volume  string // volume which snapshot taken from, or clone destination
+		Name    string // snapshot name
+		UUID    string // snapshot or clone task
+		Source  string // snapshot uuid or volume which clone from
+		Lazy    bool
+		Timeout time.Duration // wait snapshot or clone task done, 0 means no limit
+	}
+
+	Snapshot struct {
+		UUID     string `json:"uuid"`
+		Name     string `json:"name"`
+		User     string `json:"user"`
+		Volume   string `json:"volume"`
+		Size     uint64 `json:"size"` // bytes
+		Status   string `json:"status"`
+		Progress int    `json:"progress"`
+		Ctime    uint64 `json:"ctime"` // microseconds
+	}
+
+	CloneTask struct {
+		UUID        string `json:"uuid"`
+		User        string `json:"user"`
+		Source      string `json:"source"`
+		Destination string `json:"destination"`
+		Type        string `json:"type"`
+		Lazy        bool   `json:"lazy"`
+		Status      string `json:"status"`
+		Progress    int    `json:"progress"`
+		Ctime       uint64 `json:"ctime"` // microseconds
+	}
+
+	snapshotInfo struct {
+		UUID       string `json:"UUID"`
+		Name       string `json:"Name"`
+		User       string `json:"User"`
+		File       string `json:"File"`
+		FileLength uint64 `json:"FileLength"`
+		Status     int    `json:"Status"`
+		Progress   int    `json:"Progress"`
+		Time       uint64 `json:"Time"`
+	}
+
+	cloneTaskInfo struct {
+		UUID       string `json:"UUID"`
+		User       string `json:"User"`
+		File       string `json:"File"`
+		Src        string `json:"Src"`
+		IsLazy     bool   `json:"IsLazy"`
+		TaskType   int    `json:"TaskType"`
+		TaskStatus int    `json:"TaskStatus"`
+		Progress   int    `json:"Progress"`
+		Time       uint64 `json:"Time"`
+	}
+
+	snapshotCloneResponse struct {
+		Code       string          `json:"Code"`
+		Message    string          `json:"Message"`
+		RequestId  string          `json:"RequestId"`
+		UUID       string          `json:"UUID"`
+		TotalCount int             `json:"TotalCount"`
+		Snapshots  []snapshotInfo  `json:"Snapshots"`
+		TaskInfos  []cloneTaskInfo `json:"TaskInfos"`
+	}
+
+	snapshotCloneClient struct {
+		ctx         *context.Context
+		containerId string
+		addr        string // proxy address of snapshotclone
+		execOptions module.ExecOptions
+	}
+
+	step2RequestSnapshotClone struct {
+		client     *snapshotCloneClient
+		options    SnapshotOptions
+		memStorage *utils.SafeMap
+	}
+
+	step2WaitSnapshotClone struct {
+		client  *snapshotCloneClient
+		options SnapshotOptions
+		uuid    string
+		task    *task.Task // report progress
+	}
+)
+
+var (
+	SNAPSHOT_ACTION_TITLE = map[string]string{
+		SNAPSHOT_ACTION_CREATE:      "Create Snapshot",
+		SNAPSHOT_ACTION_DELETE:      "Delete Snapshot",
+		SNAPSHOT_ACTION_CANCEL:      "Cancel Snapshot",
+		SNAPSHOT_ACTION_LIST:        "List Snapshots",
+		SNAPSHOT_ACTION_CLONE:       "Clone Volume",
+		SNAPSHOT_ACTION_FLATTEN:     "Flatten Volume",
+		SNAPSHOT_ACTION_LIST_CLONES: "List Clone Tasks",
+	}
+
+	SNAPSHOT_ACTION_ERRNO = map[string]*errno.ErrorCode{
+		SNAPSHOT_ACTION_CREATE:      errno.ERR_CREATE_SNAPSHOT_FAILED,
+		SNAPSHOT_ACTION_DELETE:      errno.ERR_DELETE_SNAPSHOT_FAILED,
+		SNAPSHOT_ACTION_CANCEL:      errno.ERR_CANCEL_SNAPSHOT_FAILED,
+		SNAPSHOT_ACTION_LIST:        errno.ERR_LIST_SNAPSHOTS_FAILED,
+		SNAPSHOT_ACTION_CLONE:       errno.ERR_CLONE_VOLUME_FAILED,
+		SNAPSHOT_ACTION_FLATTEN:     errno.ERR_FLATTEN_VOLUME_FAILED,
+		SNAPSHOT_ACTION_LIST_CLONES: errno.ERR_LIST_CLONE_TASKS_FAILED,
+	}
+
+	SNAPSHOT_STATUS = map[int]string{
+		SNAPSHOT_STATUS_DONE:           "done",
+		SNAPSHOT_STATUS_PENDING:        "pending",
+		SNAPSHOT_STATUS_DELETING:       "deleting",
+		SNAPSHOT_STATUS_ERROR_DELETING: "errorDeleting",
+		SNAPSHOT_STATUS_CANCELING:      "canceling",
+		SNAPSHOT_STATUS_ERROR:          "error",
+	}
+
+	CLONE_STATUS = map[int]string{
+		CLONE_STATUS_DONE:           "done",
+		CLONE_STATUS_CLONING:        "cloning",
+		CLONE_STATUS_RECOVERING:     "recovering",
+		CLONE_STATUS_CLEANING:       "cleaning",
+		CLONE_STATUS_ERROR_CLEANING: "errorCleaning",
+		CLONE_STATUS_ERROR:          "error",
+		CLONE_STATUS_RETRYING:       "retrying",
+		CLONE_STATUS_META_INSTALLED: "metaInstalled",
+	}
+
+	CLONE_TYPE = map[int]string{
+		0: "clone",
+		1: "recover",
+	}
+)
+
+func (info snapshotInfo) snapshot() Snapshot {
+	return Snapshot{
+		UUID:     info.UUID,
+		Name:     info.Name,
+		User:     info.User,
+		Volume:   info.File,
+		Size:     info.FileLength,
+		Status:   utils.Choose(len(SNAPSHOT_STATUS[info.Status]) > 0, SNAPSHOT_STATUS[info.Status], "unknown"),
+		Progress: info.Progress,
+		Ctime:    info.Time,
+	}
+}
+
+func (info cloneTaskInfo) cloneTask() CloneTask {
+	return CloneTask{
+		UUID:        info.UUID,
+		User:        info.User,
+		Source:      info.Src,
+		Destination: info.File,
+		Type:        utils.Choose(len(CLONE_TYPE[info.TaskType]) > 0, CLONE_TYPE[info.TaskType], "unknown"),
+		Lazy:        info.IsLazy,
+		Status:      utils.Choose(len(CLONE_STATUS[info.TaskStatus]) > 0, CLONE_STATUS[info.TaskStatus], "unknown"),
+		Progress:    info.Progress,
+		Ctime:       info.Time,
+	}
+}
+
+// e.g. Action=CreateSnapshot&File=%2Ftest&Name=snap1&User=curve&Version=0.0.6
+func snapshotParams(options SnapshotOptions) url.Values {
+	params := url.Values{}
+	params.Set("Action", options.Action)
+	params.Set("Version", SNAPSHOTCLONE_API_VERSION)
+	params.Set("User", options.User)
+	setIfNotEmpty := func(key, value string) {
+		if len(value) > 0 {
+			params.Set(key, value)
+		}
+	}
+
+	switch options.Action {
+	case SNAPSHOT_ACTION_CREATE:
+		params.Set("File", options.Volume)
+		params.Set("Name", options.Name)
+	case SNAPSHOT_ACTION_DELETE, SNAPSHOT_ACTION_CANCEL:
+		params.Set("File", options.Volume)
+		params.Set("UUID", options.UUID)
+	case SNAPSHOT_ACTION_CLONE:
+		params.Set("Source", options.Source)
+		params.Set("Destination", options.Volume)
+		params.Set("Lazy", strconv.FormatBool(options.Lazy))
+	case SNAPSHOT_ACTION_FLATTEN:
+		params.Set("UUID", options.UUID)
+	case SNAPSHOT_ACTION_LIST, SNAPSHOT_ACTION_LIST_CLONES:
+		setIfNotEmpty("File", options.Volume)
+		setIfNotEmpty("UUID", options.UUID)
+	}
+	return params
+}
+
+/*
+ * Response Example:
+ * {
+ *   "Code": "0",
+ *   "Message": "Exec success.",
+ *   "RequestId": "8d5ea5a8-6e2f-4b2a-a1a8-2e3b1e1c1c8f",
+ *   "UUID": "f2d8b8a6-0c9e-4d2c-9a7e-0e4d8c9b6a1d"
+ * }
+ */
+func parseSnapshotCloneResponse(output string) (*snapshotCloneResponse, error) {
+	resp := &snapshotCloneResponse{}
+	err := json.Unmarshal([]byte(output), resp)
+	if err != nil {
+		return nil, errno.ERR_DECODE_SNAPSHOTCLONE_RESPONSE.
+			F("response: %s", output)
+	}
+	return resp, nil
+}
+
+func (c *snapshotCloneClient) request(options SnapshotOptions, params url.Values) (*snapshotCloneResponse, error) {
+	link := fmt.Sprintf(URL_SNAPSHOTCLONE_SERVICE, c.addr, params.Encode())
+	command := fmt.Sprintf(COMMAND_CURL_SNAPSHOTCLONE, link)
+	cmd := c.ctx.Module().DockerCli().ContainerExec(c.containerId, command)
+	out, err := cmd.Execute(c.execOptions)
+	if err != nil {
+		return nil, errno.ERR_REQUEST_SNAPSHOTCLONE_FAILED.E(err)
+	}
+
+	resp, err := parseSnapshotCloneResponse(out)
+	if err != nil {
+		return nil, err
+	} else if resp.Code != SNAPSHOTCLONE_SUCCESS_CODE {
+		return nil, SNAPSHOT_ACTION_ERRNO[options.Action].
+			F("code=%s message=%s", resp.Code, resp.Message)
+	}
+	return resp, nil
+}
+
+// list all snapshots or clone tasks page by page
+func (c *snapshotCloneClient) list(options SnapshotOptions) ([]snapshotInfo, []cloneTaskInfo, error) {
+	snapshots, tasks := []snapshotInfo{}, []cloneTaskInfo{}
+	params := snapshotParams(options)
+	params.Set("Limit", strconv.Itoa(SNAPSHOTCLONE_LIST_LIMIT))
+	for offset := 0; ; offset += SNAPSHOTCLONE_LIST_LIMIT {
+		params.Set("Offset", strconv.Itoa(offset))
+		resp, err := c.request(options, params)
+		if err != nil {
+			return nil, nil, err
+		}
+		snapshots = append(snapshots, resp.Snapshots...)
+		tasks = append(tasks, resp.TaskInfos...)
+		n := len(resp.Snapshots) + len(resp.TaskInfos)
+		if n < SNAPSHOTCLONE_LIST_LIMIT || offset+n >= resp.TotalCount {
+			break
+		}
+	}
+	return snapshots, tasks, nil
+}
+
+// the clone task of volume which need to flatten
+func (c *snapshotCloneClient) getCloneTaskUUID(options SnapshotOptions) (string, error) {
+	_, tasks, err := c.list(SnapshotOptions{
+		Action: SNAPSHOT_ACTION_LIST_CLONES,
+		User:   options.User,
+		Volume: options.Volume,
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, t := range tasks {
+		if t.File == options.Volume && t.IsLazy {
+			return t.UUID, nil
+		}
+	}
+	return "", errno.ERR_SNAPSHOT_TASK_NOT_FOUND.
+		F("no lazy clone task for volume %s:%s", options.User, options.Volume)
+}
+
+func (s *step2RequestSnapshotClone) Execute(ctx *context.Context) error {
+	s.client.ctx = ctx
+	options := s.options
+	switch options.Action {
+	case SNAPSHOT_ACTION_LIST, SNAPSHOT_ACTION_LIST_CLONES:
+		infos, tasks, err := s.client.list(options)
+		if err != nil {
+			return err
+		}
+		snapshots, cloneTasks := []Snapshot{}, []CloneTask{}
+		for _, info := range infos {
+			snapshots = append(snapshots, info.snapshot())
+		}
+		for _, info := range tasks {
+			cloneTasks = append(cloneTasks, info.cloneTask())
+		}
+		s.memStorage.Set(comm.KEY_ALL_SNAPSHOTS, snapshots)
+		s.memStorage.Set(comm.KEY_ALL_CLONE_TASKS, cloneTasks)
+		return nil
+
+	case SNAPSHOT_ACTION_FLATTEN:
+		if len(options.UUID) == 0 {
+			uuid, err := s.client.getCloneTaskUUID(options)
+			if err != nil {
+				return err
+			}
+			options.UUID = uuid
+		}
+	}
+
+	resp, err := s.client.request(options, snapshotParams(options))
+	if err != nil {
+		return err
+	}
+	// the uuid of snapshot or clone task which we can wait for
+	uuid := utils.Choose(len(resp.UUID) > 0, resp.UUID, options.UUID)
+	s.memStorage.Set(comm.KEY_SNAPSHOT_TASK_UUID, uuid)
+	return nil
+}
+
+// returns true and the progress if the snapshot or clone task finished
+func (s *step2WaitSnapshotClone) poll() (bool, int, error) {
+	options := s.options
+	action := utils.Choose(options.Action == SNAPSHOT_ACTION_CREATE,
+		SNAPSHOT_ACTION_LIST, SNAPSHOT_ACTION_LIST_CLONES)
+	snapshots, tasks, err := s.client.list(SnapshotOptions{
+		Action: action,
+		User:   options.User,
+		Volume: utils.Choose(action == SNAPSHOT_ACTION_LIST, options.Volume, ""),
+		UUID:   s.uuid,
+	})
+	if err != nil {
+		return false, 0, err
+	}
+
+	for _, info := range snapshots {
+		if info.UUID != s.uuid {
+			continue
+		} else if info.Status == SNAPSHOT_STATUS_DONE {
+			return true, 100, nil
+		} else if info.Status == SNAPSHOT_STATUS_PENDING {
+			return false, info.Progress, nil
+		}
+		return false, 0, errno.ERR_SNAPSHOT_TASK_FAILED.
+			F("uuid=%s status=%s", s.uuid, SNAPSHOT_STATUS[info.Status])
+	}
+	for _, info := range tasks {
+		if info.UUID != s.uuid {
+			continue
+		}
+		switch info.TaskStatus {
+		case CLONE_STATUS_DONE:
+			return true, 100, nil
+		case CLONE_STATUS_META_INSTALLED: // lazy clone is available now
+			return options.Action == SNAPSHOT_ACTION_CLONE, info.Progress, nil
+		case CLONE_STATUS_CLONING, CLONE_STATUS_RECOVERING, CLONE_STATUS_RETRYING:
+			return false, info.Progress, nil
+		}
+		return false, 0, errno.ERR_CLONE_TASK_FAILED.
+			F("uuid=%s status=%s", s.uuid, CLONE_STATUS[info.TaskStatus])
+	}
+	return false, 0, errno.ERR_SNAPSHOT_TASK_NOT_FOUND.F("uuid=%s", s.uuid)
+}
+
+func (s *step2WaitSnapshotClone) Execute(ctx *context.Context) error {
+	s.client.ctx = ctx
+	start := time.Now()
+	for {
+		done, progress, err := s.poll()
+		if err != nil {
+			return err
+		}
+		s.task.SetProgress(progress)
+		if done {
+			return nil
+		} else if s.options.Timeout > 0 && time.Since(start) >= s.options.Timeout {
+			return errno.ERR_WAIT_SNAPSHOT_TASK_TIMEOUT.
+				F("uuid=%s progress=%d%% timeout=%s", s.uuid, progress, s.options.Timeout)
+		}
+		time.Sleep(SNAPSHOTCLONE_POLL_INTERVAL)
+	}
+}
+
+func newSnapshotCloneTask(curveadm *cli.CurveAdm,
+	dc *topology.DeployConfig, name string) (*task.Task, *snapshotCloneClient, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if err != nil {
+		return nil, nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask(name, subname, hc.GetSSHConfig())
+	client := &snapshotCloneClient{
+		containerId: containerId,
+		addr:        fmt.Sprintf("%s:%d", dc.GetListenIp(), dc.GetListenProxyPort()),
+		execOptions: curveadm.ExecOptions(),
+	}
+	return t, client, nil
+}
+
+func NewSnapshotCloneTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	options := curveadm.MemStorage().Get(comm.KEY_SNAPSHOT_OPTIONS).(SnapshotOptions)
+	t, client, err := newSnapshotCloneTask(curveadm, dc, SNAPSHOT_ACTION_TITLE[options.Action])
+	if err != nil {
+		return nil, err
+	}
+
+	// add step to task
+	t.AddStep(&step2RequestSnapshotClone{
+		client:     client,
+		options:    options,
+		memStorage: curveadm.MemStorage(),
+	})
+
+	return t, nil
+}
+
+func NewWaitSnapshotCloneTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	options := curveadm.MemStorage().Get(comm.KEY_SNAPSHOT_OPTIONS).(SnapshotOptions)
+	uuid := curveadm.MemStorage().Get(comm.KEY_SNAPSHOT_TASK_UUID).(string)
+	name := fmt.Sprintf("Wait %s Done", SNAPSHOT_ACTION_TITLE[options.Action])
+	t, client, err := newSnapshotCloneTask(curveadm, dc, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// add step to task
+	t.SetSubname(fmt.Sprintf("host=%s uuid=%s", dc.GetHost(), uuid))
+	t.AddStep(&step2WaitSnapshotClone{
+		client:  client,
+		options: options,
+		uuid:    uuid,
+		task:    t,
+	})
+
+	return t, nil
+}
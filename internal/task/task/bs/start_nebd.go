@@ -40,6 +40,8 @@ import (
 
 const (
 	CLIENT_CONFIG_DELIMITER = "="
+
+	PROCESS_NEBD_SERVER = "nebd-server"
 )
 
 type (
@@ -50,11 +52,29 @@ type (
 		containerId *string
 	}
 
+	// the client finds an available port from the configured one for
+	// exposing metrics, we record the port it actually listens on
+	step2RecordMetricPort struct {
+		curveadm    *cli.CurveAdm
+		clientId    string
+		startPort   int
+		containerId *string
+	}
+
 	AuxInfo struct {
-		User    string `json:"user"`
-		Volume  string `json:"volume"`
-		Poolset string `json:"poolset"`
-		Config  string `json:"config,omitempty"` // TODO(P1)
+		User        string           `json:"user"`
+		Volume      string           `json:"volume"`
+		Poolset     string           `json:"poolset"`
+		Image       string           `json:"image,omitempty"`
+		NEBDImage   string           `json:"nebd_image,omitempty"` // nebd-server upgraded in place
+		NoExclusive bool             `json:"no_exclusive,omitempty"`
+		Persist     bool             `json:"persist,omitempty"`
+		Device      string           `json:"device,omitempty"`
+		Timeout     int              `json:"timeout,omitempty"`
+		ReadOnly    bool             `json:"read_only,omitempty"`
+		Throttles   []VolumeThrottle `json:"throttles,omitempty"`
+		MetricPort  int              `json:"metric_port,omitempty"`
+		Config      string           `json:"config,omitempty"` // TODO(P1)
 	}
 )
 
@@ -66,6 +86,38 @@ func volume2ContainerName(user, volume string) string {
 	return fmt.Sprintf("curvebs-volume-%s", utils.MD5Sum(formatImage(user, volume)))
 }
 
+func newAuxInfo(options MapOptions, cc *configure.ClientConfig) *AuxInfo {
+	return &AuxInfo{
+		User:        options.User,
+		Volume:      options.Volume,
+		Poolset:     options.Poolset,
+		Image:       cc.GetContainerImage(),
+		NoExclusive: options.NoExclusive,
+		Persist:     options.Persist,
+		Device:      options.Device,
+		Timeout:     options.Timeout,
+		ReadOnly:    options.ReadOnly,
+		Throttles:   options.Throttles,
+		MetricPort:  cc.GetMetricPort(),
+	}
+}
+
+// options to map the volume again, e.g. repair or upgrade client
+func (auxInfo *AuxInfo) mapOptions(host string) MapOptions {
+	return MapOptions{
+		Host:        host,
+		User:        auxInfo.User,
+		Volume:      auxInfo.Volume,
+		Poolset:     auxInfo.Poolset,
+		NoExclusive: auxInfo.NoExclusive,
+		Persist:     auxInfo.Persist,
+		Device:      auxInfo.Device,
+		Timeout:     auxInfo.Timeout,
+		ReadOnly:    auxInfo.ReadOnly,
+		Throttles:   auxInfo.Throttles,
+	}
+}
+
 func checkVolumeExist(volume, containerName string, out *string) step.LambdaType {
 	return func(ctx *context.Context) error {
 		if len(*out) > 0 && *out == containerName {
@@ -122,12 +174,7 @@ func (s *step2InsertClient) Execute(ctx *context.Context) error {
 	options := s.options
 	volumeId := curveadm.GetVolumeId(options.Host, options.User, options.Volume)
 
-	auxInfo := &AuxInfo{
-		User:    options.User,
-		Volume:  options.Volume,
-		Poolset: options.Poolset,
-	}
-	bytes, err := json.Marshal(auxInfo)
+	bytes, err := json.Marshal(newAuxInfo(options, config))
 	if err != nil {
 		return errno.ERR_ENCODE_VOLUME_INFO_TO_JSON_FAILED.E(err)
 	}
@@ -146,85 +193,139 @@ func (s *step2InsertClient) Execute(ctx *context.Context) error {
 	return nil
 }
 
-func NewStartNEBDServiceTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.Task, error) {
-	options := curveadm.MemStorage().Get(comm.KEY_MAP_OPTIONS).(MapOptions)
-	hc, err := curveadm.GetHost(options.Host)
-	if err != nil {
-		return nil, err
+func (s *step2RecordMetricPort) Execute(ctx *context.Context) error {
+	curveadm := s.curveadm
+	port, err := task.GetListenPort(ctx, *s.containerId, PROCESS_NEBD_SERVER,
+		s.startPort, curveadm.ExecOptions())
+	if err != nil || port == 0 {
+		return nil // keep the configured port
 	}
 
-	subname := fmt.Sprintf("hostname=%s image=%s", hc.GetHostname(), cc.GetContainerImage())
-	t := task.NewTask("Start NEBD Service", subname, hc.GetSSHConfig())
+	clients, err := curveadm.Storage().GetClient(s.clientId)
+	if err != nil {
+		return errno.ERR_GET_CLIENT_BY_ID_FAILED.E(err)
+	} else if len(clients) == 0 {
+		return nil
+	}
+	auxInfo := &AuxInfo{}
+	err = json.Unmarshal([]byte(clients[0].AuxInfo), auxInfo)
+	if err != nil {
+		return errno.ERR_DECODE_CLIENT_AUX_INFO_FAILED.E(err)
+	}
+	auxInfo.MetricPort = port
+	bytes, err := json.Marshal(auxInfo)
+	if err != nil {
+		return errno.ERR_ENCODE_VOLUME_INFO_TO_JSON_FAILED.E(err)
+	}
+	err = curveadm.Storage().SetClientAuxInfo(s.clientId, string(bytes))
+	if err != nil {
+		return errno.ERR_SET_CLIENT_AUX_INFO_FAILED.E(err)
+	}
+	return nil
+}
 
-	// add step
-	var containerId, out string
-	var success bool
-	volume := fmt.Sprintf("%s:%s", options.User, options.Volume)
+func newCreateNEBDContainerStep(curveadm *cli.CurveAdm, cc *configure.ClientConfig,
+	hostname string, options MapOptions, containerId *string) task.Step {
 	containerName := volume2ContainerName(options.User, options.Volume)
-	hostname := containerName
-	host2addr := fmt.Sprintf("%s:%s", hostname, hc.GetHostname())
-
-	t.AddStep(&step.EngineInfo{
-		Success:     &success,
-		Out:         &out,
-		ExecOptions: curveadm.ExecOptions(),
-	})
-	t.AddStep(&step.Lambda{
-		Lambda: checker.CheckEngineInfo(options.Host, curveadm.ExecOptions().ExecWithEngine, &success, &out),
-	})
-	t.AddStep(&step.ListContainers{
-		ShowAll:     true,
-		Format:      "'{{.Names}}'",
-		Filter:      fmt.Sprintf("name=%s", containerName),
-		Out:         &out,
-		ExecOptions: curveadm.ExecOptions(),
-	})
-	t.AddStep(&step.Lambda{
-		Lambda: checkVolumeExist(volume, containerName, &out),
-	})
-	t.AddStep(&step.CreateDirectory{
-		Paths:       []string{cc.GetLogDir(), cc.GetDataDir()},
-		ExecOptions: curveadm.ExecOptions(),
-	})
-	t.AddStep(&step.PullImage{
-		Image:       cc.GetContainerImage(),
-		ExecOptions: curveadm.ExecOptions(),
-	})
-	t.AddStep(&step.CreateContainer{
+	host2addr := fmt.Sprintf("%s:%s", containerName, hostname)
+	return &step.CreateContainer{
 		Image:       cc.GetContainerImage(),
 		AddHost:     []string{host2addr},
 		Envs:        []string{"LD_PRELOAD=/usr/local/lib/libjemalloc.so"},
-		Hostname:    hostname,
+		Hostname:    containerName,
 		Command:     fmt.Sprintf("--role nebd"),
 		Name:        containerName,
 		Pid:         "host",
 		Privileged:  true,
 		Volumes:     getVolumes(cc),
-		Out:         &containerId,
+		Out:         containerId,
 		ExecOptions: curveadm.ExecOptions(),
-	})
-	t.AddStep(&step2InsertClient{
-		curveadm:    curveadm,
-		options:     options,
-		config:      cc,
-		containerId: &containerId,
-	})
+	}
+}
+
+// sync configs into the created NEBD container and start it
+func newStartNEBDServiceSteps(curveadm *cli.CurveAdm, cc *configure.ClientConfig,
+	containerId *string) []task.Step {
+	steps := []task.Step{}
 	for _, filename := range []string{"client.conf", "nebd-server.conf"} {
-		t.AddStep(&step.SyncFile{
-			ContainerSrcId:    &containerId,
+		steps = append(steps, &step.SyncFile{
+			ContainerSrcId:    containerId,
 			ContainerSrcPath:  "/curvebs/conf/" + filename,
-			ContainerDestId:   &containerId,
+			ContainerDestId:   containerId,
 			ContainerDestPath: "/curvebs/nebd/conf/" + filename,
 			KVFieldSplit:      CLIENT_CONFIG_DELIMITER,
 			Mutate:            newMutate(cc, CLIENT_CONFIG_DELIMITER),
 			ExecOptions:       curveadm.ExecOptions(),
 		})
 	}
-	t.AddStep(&step.StartContainer{
-		ContainerId: &containerId,
-		Out:         &out,
+	steps = append(steps, &step.StartContainer{
+		ContainerId: containerId,
 		ExecOptions: curveadm.ExecOptions(),
 	})
+	return steps
+}
+
+// steps to create and start the NEBD container for the volume
+func newNEBDServiceSteps(curveadm *cli.CurveAdm, cc *configure.ClientConfig,
+	hostname string, options MapOptions, containerId *string) []task.Step {
+	var out string
+	var success bool
+	volume := fmt.Sprintf("%s:%s", options.User, options.Volume)
+	containerName := volume2ContainerName(options.User, options.Volume)
+
+	steps := []task.Step{
+		&step.EngineInfo{
+			Success:     &success,
+			Out:         &out,
+			ExecOptions: curveadm.ExecOptions(),
+		},
+		&step.Lambda{
+			Lambda: checker.CheckEngineInfo(options.Host, curveadm.ExecOptions().ExecWithEngine, &success, &out),
+		},
+		&step.ListContainers{
+			ShowAll:     true,
+			Format:      "'{{.Names}}'",
+			Filter:      fmt.Sprintf("name=%s", containerName),
+			Out:         &out,
+			ExecOptions: curveadm.ExecOptions(),
+		},
+		&step.Lambda{
+			Lambda: checkVolumeExist(volume, containerName, &out),
+		},
+		&step.CreateDirectory{
+			Paths:       []string{cc.GetLogDir(), cc.GetDataDir()},
+			ExecOptions: curveadm.ExecOptions(),
+		},
+		&step.PullImage{
+			Image:       cc.GetContainerImage(),
+			ExecOptions: curveadm.ExecOptions(),
+		},
+		newCreateNEBDContainerStep(curveadm, cc, hostname, options, containerId),
+		&step2InsertClient{
+			curveadm:    curveadm,
+			options:     options,
+			config:      cc,
+			containerId: containerId,
+		},
+	}
+	return append(steps, newStartNEBDServiceSteps(curveadm, cc, containerId)...)
+}
+
+func NewStartNEBDServiceTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.Task, error) {
+	options := curveadm.MemStorage().Get(comm.KEY_MAP_OPTIONS).(MapOptions)
+	hc, err := curveadm.GetHost(options.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	subname := fmt.Sprintf("hostname=%s image=%s", hc.GetHostname(), cc.GetContainerImage())
+	t := task.NewTask("Start NEBD Service", subname, hc.GetSSHConfig())
+
+	// add step
+	var containerId string
+	for _, step := range newNEBDServiceSteps(curveadm, cc, hc.GetHostname(), options, &containerId) {
+		t.AddStep(step)
+	}
 
 	return t, nil
 }
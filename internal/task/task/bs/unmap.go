@@ -130,13 +130,36 @@ func (s *step2DeleteClient) Execute(ctx *context.Context) error {
 	return nil
 }
 
+func isPersisted(curveadm *cli.CurveAdm, volumeId string) (bool, error) {
+	clients, err := curveadm.Storage().GetClient(volumeId)
+	if err != nil {
+		return false, errno.ERR_GET_CLIENT_BY_ID_FAILED.E(err)
+	} else if len(clients) == 0 {
+		return false, nil
+	}
+
+	auxInfo, err := DecodeAuxInfo(clients[0])
+	if err != nil {
+		return false, err
+	}
+	return auxInfo.Persist, nil
+}
+
 func NewUnmapTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
 	options := curveadm.MemStorage().Get(comm.KEY_MAP_OPTIONS).(MapOptions)
+	return NewUnmapVolumeTask(curveadm, options)
+}
+
+func NewUnmapVolumeTask(curveadm *cli.CurveAdm, options MapOptions) (*task.Task, error) {
 	volumeId := curveadm.GetVolumeId(options.Host, options.User, options.Volume)
 	containerId, err := curveadm.Storage().GetClientContainerId(volumeId)
 	if err != nil {
 		return nil, errno.ERR_GET_CLIENT_CONTAINER_ID_FAILED.E(err)
 	}
+	persist, err := isPersisted(curveadm, volumeId)
+	if err != nil {
+		return nil, err
+	}
 	hc, err := curveadm.GetHost(options.Host)
 	if err != nil {
 		return nil, err
@@ -158,12 +181,19 @@ func NewUnmapTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
 		Out:         &output,
 		ExecOptions: curveadm.ExecOptions(),
 	})
+	// NOTE: the volume throttle is left in MDS, it is shared by all clients of the volume
 	t.AddStep(&step2UnmapImage{
 		output:      &output,
 		user:        options.User,
 		volume:      options.Volume,
 		execOptions: curveadm.ExecOptions(),
 	})
+	if persist {
+		t.AddStep(&step.RemoveSystemdUnit{
+			Name:        task.ClientUnitName(volumeId),
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
 	t.AddStep(&step2RemoveContainer{
 		curveadm:    curveadm,
 		status:      &output,
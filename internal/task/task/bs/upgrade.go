@@ -0,0 +1,514 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package bs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
+	log "github.com/opencurve/curveadm/pkg/log/glg"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	FORMAT_BACKUP_CONTAINER_NAME = "%s-backup"
+	FORMAT_NEBD_SERVER_STAGING   = "curvebs-nebd-staging-%s"
+
+	NEBD_SERVER_BINARY_PATH = "/curvebs/nebd/sbin/nebd-server"
+	CMD_GET_NEBD_SERVER_PID = "pgrep -x nebd-server"
+)
+
+var (
+	WAIT_NEBD_SERVER_RESPAWN_SECONDS = 30
+)
+
+type (
+	// upgradeStage records how far the upgrade went, rollback depends on it
+	upgradeStage struct {
+		restarted bool // nebd-server restarted in place, the device kept open
+		unmapped  bool
+		detached  bool // old container stopped and renamed to backup
+		created   bool
+		committed bool
+	}
+
+	step2RestartNEBDServer struct {
+		curveadm       *cli.CurveAdm
+		image          string
+		output         *string
+		options        *MapOptions
+		newContainerId *string
+		stage          *upgradeStage
+	}
+
+	// the step is skipped once nebd-server restarted in place
+	step2UnlessRestarted struct {
+		stage *upgradeStage
+		step  task.Step
+	}
+
+	step2GetMappedDevice struct {
+		curveadm *cli.CurveAdm
+		output   *string
+		options  *MapOptions
+	}
+
+	step2MapVolume struct {
+		curveadm    *cli.CurveAdm
+		cc          *configure.ClientConfig
+		options     *MapOptions
+		containerId *string
+	}
+
+	step2CommitUpgrade struct {
+		curveadm       *cli.CurveAdm
+		client         storage.Client
+		auxInfo        *AuxInfo
+		image          string
+		oldContainerId string
+		newContainerId *string
+		stage          *upgradeStage
+	}
+
+	step2RollbackUpgrade struct {
+		curveadm       *cli.CurveAdm
+		cc             *configure.ClientConfig
+		options        *MapOptions
+		oldContainerId string
+		newContainerId *string
+		stage          *upgradeStage
+	}
+)
+
+func mark(flag *bool) step.LambdaType {
+	return func(ctx *context.Context) error {
+		*flag = true
+		return nil
+	}
+}
+
+func checkUpgradeContainer(output *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if len(*output) == 0 {
+			return errno.ERR_VOLUME_CONTAINER_LOSED
+		}
+		return nil
+	}
+}
+
+// e.g.
+// id      image                   device
+// 1234    cbd:pool/volume_user_   /dev/nbd0
+func parseMappedDevice(out, user, volume string) string {
+	image := formatImage(user, volume)
+	for _, line := range strings.Split(out, "\n") {
+		if !strings.Contains(line, image) {
+			continue
+		}
+		for _, field := range strings.Fields(line) {
+			if strings.HasPrefix(field, "/dev/nbd") {
+				return field
+			}
+		}
+	}
+	return ""
+}
+
+func getNEBDServerPid(ctx *context.Context, containerId string, options module.ExecOptions) string {
+	out, err := ctx.Module().DockerCli().ContainerExec(containerId, CMD_GET_NEBD_SERVER_PID).Execute(options)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+// copy nebd-server from the new image to the running container, the running
+// process is not affected because the binary is replaced by rename, and the
+// old binary is kept for restore
+func (s *step2RestartNEBDServer) replaceBinary(ctx *context.Context, containerId string) error {
+	options := s.curveadm.ExecOptions()
+	staging := fmt.Sprintf(FORMAT_NEBD_SERVER_STAGING, utils.RandString(8))
+	hostPath := utils.RandFilename(step.TEMP_DIR)
+	newPath := NEBD_SERVER_BINARY_PATH + ".new"
+	oldPath := NEBD_SERVER_BINARY_PATH + ".old"
+	defer ctx.Module().DockerCli().RemoveContainer(staging).Execute(options)
+	defer ctx.Module().Shell().Remove(hostPath).Execute(options)
+
+	command := fmt.Sprintf("cp -fp %s %s", NEBD_SERVER_BINARY_PATH, oldPath)
+	dockerCli := ctx.Module().DockerCli().ContainerExec(containerId, command)
+	if out, err := dockerCli.Execute(options); err != nil {
+		return errno.ERR_RUN_COMMAND_IN_CONTAINER_FAILED.S(out)
+	}
+	dockerCli = ctx.Module().DockerCli().CreateContainer(s.image, "").AddOption("--name %s", staging)
+	if out, err := dockerCli.Execute(options); err != nil {
+		return errno.ERR_CREATE_CONTAINER_FAILED.S(out)
+	}
+	dockerCli = ctx.Module().DockerCli().CopyFromContainer(staging, NEBD_SERVER_BINARY_PATH, hostPath)
+	if out, err := dockerCli.Execute(options); err != nil {
+		return errno.ERR_COPY_FROM_CONTAINER_FAILED.S(out)
+	}
+	dockerCli = ctx.Module().DockerCli().CopyIntoContainer(hostPath, containerId, newPath)
+	if out, err := dockerCli.Execute(options); err != nil {
+		return errno.ERR_COPY_INTO_CONTAINER_FAILED.S(out)
+	}
+	command = fmt.Sprintf("mv -f %s %s", newPath, NEBD_SERVER_BINARY_PATH)
+	dockerCli = ctx.Module().DockerCli().ContainerExec(containerId, command)
+	if out, err := dockerCli.Execute(options); err != nil {
+		return errno.ERR_RUN_COMMAND_IN_CONTAINER_FAILED.S(out)
+	}
+	return nil
+}
+
+// put the old binary back, so the old container still works if we
+// fall back to remap and it's rolled back
+func (s *step2RestartNEBDServer) restoreBinary(ctx *context.Context, containerId string) {
+	command := fmt.Sprintf("mv -f %s.old %s", NEBD_SERVER_BINARY_PATH, NEBD_SERVER_BINARY_PATH)
+	ctx.Module().DockerCli().ContainerExec(containerId, command).Execute(s.curveadm.ExecOptions())
+}
+
+func (s *step2RestartNEBDServer) restart(ctx *context.Context, containerId, pid string) error {
+	user, volume := s.options.User, s.options.Volume
+	options := s.curveadm.ExecOptions()
+	if err := s.replaceBinary(ctx, containerId); err != nil {
+		return err
+	}
+
+	command := fmt.Sprintf("kill %s", pid)
+	out, err := ctx.Module().DockerCli().ContainerExec(containerId, command).Execute(options)
+	if err != nil {
+		return errno.ERR_RESTART_NEBD_SERVER_FAILED.S(out)
+	}
+	deadline := time.Now().Add(time.Duration(WAIT_NEBD_SERVER_RESPAWN_SECONDS) * time.Second)
+	for {
+		newPid := getNEBDServerPid(ctx, containerId, options)
+		if len(newPid) > 0 && newPid != pid {
+			break
+		} else if time.Now().After(deadline) {
+			return errno.ERR_RESTART_NEBD_SERVER_FAILED.
+				F("nebd-server not respawned in %d seconds", WAIT_NEBD_SERVER_RESPAWN_SECONDS)
+		}
+		time.Sleep(time.Second)
+	}
+	mapped, err := IsVolumeMapped(ctx, containerId, user, volume, options)
+	if err != nil {
+		return err
+	} else if !mapped {
+		return errno.ERR_RESTART_NEBD_SERVER_FAILED.
+			F("volume %s:%s not mapped after nebd-server restarted", user, volume)
+	}
+	return nil
+}
+
+// NEBD splits the client into two parts: nebd-client in curve-nbd which
+// holds the NBD device, and nebd-server which actually accesses the cluster,
+// the nebd-client reconnects once nebd-server restarted. So we upgrade
+// nebd-server in the running container and restart it, which requires that
+// it's respawned by the main process of container, otherwise (the volume
+// not mapped or the restart failed) we unmap and map it again with the
+// new container.
+func (s *step2RestartNEBDServer) Execute(ctx *context.Context) error {
+	items := strings.Split(*s.output, " ")
+	if len(items) < 2 || !strings.HasPrefix(items[1], "Up") {
+		return nil
+	}
+
+	containerId := items[0]
+	options := s.curveadm.ExecOptions()
+	mapped, err := IsVolumeMapped(ctx, containerId, s.options.User, s.options.Volume, options)
+	if err != nil || !mapped {
+		return nil
+	}
+	pid := getNEBDServerPid(ctx, containerId, options)
+	if len(pid) == 0 {
+		return nil
+	}
+	dockerCli := ctx.Module().DockerCli().InspectContainer(containerId).AddOption("--format '{{.State.Pid}}'")
+	out, err := dockerCli.Execute(options)
+	if err != nil || strings.TrimSpace(out) == pid {
+		return nil // nebd-server is the main process of container
+	}
+
+	err = s.restart(ctx, containerId, pid)
+	if err != nil {
+		s.restoreBinary(ctx, containerId)
+		log.Warn("Restart nebd-server in place failed, fall back to remap",
+			log.Field("ContainerId", containerId),
+			log.Field("Error", err))
+		return nil
+	}
+
+	command := fmt.Sprintf("rm -f %s.old", NEBD_SERVER_BINARY_PATH)
+	ctx.Module().DockerCli().ContainerExec(containerId, command).Execute(options)
+	*s.newContainerId = containerId
+	s.stage.restarted = true
+	return nil
+}
+
+func (s *step2UnlessRestarted) Execute(ctx *context.Context) error {
+	if s.stage.restarted {
+		return nil
+	}
+	return s.step.Execute(ctx)
+}
+
+// we remember the NBD device before unmap, so the volume can be mapped to
+// the same device path with the new NEBD container
+func (s *step2GetMappedDevice) Execute(ctx *context.Context) error {
+	items := strings.Split(*s.output, " ")
+	if len(items) < 2 || !strings.HasPrefix(items[1], "Up") {
+		return nil
+	}
+
+	containerId := items[0]
+	dockerCli := ctx.Module().DockerCli().ContainerExec(containerId, CMD_LIST_MAPPED)
+	out, err := dockerCli.Execute(s.curveadm.ExecOptions())
+	if err != nil {
+		return errno.ERR_RUN_COMMAND_IN_CONTAINER_FAILED.S(out)
+	}
+	s.options.Device = parseMappedDevice(out, s.options.User, s.options.Volume)
+	return nil
+}
+
+// map command depends on the device which we got at runtime,
+// so the map steps are generated on execute
+func (s *step2MapVolume) Execute(ctx *context.Context) error {
+	for _, step := range newMapSteps(s.curveadm, s.cc, *s.options, s.containerId) {
+		err := step.Execute(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// the container is kept if nebd-server restarted in place, the container
+// still runs with the old image, so the new one is recorded for nebd-server
+func (s *step2CommitUpgrade) Execute(ctx *context.Context) error {
+	curveadm := s.curveadm
+	auxInfo := *s.auxInfo
+	if s.stage.restarted {
+		auxInfo.NEBDImage = s.image
+	} else {
+		err := (&step.RemoveContainer{
+			ContainerId: s.oldContainerId,
+			ExecOptions: curveadm.ExecOptions(),
+		}).Execute(ctx)
+		if err != nil {
+			return err
+		}
+		err = curveadm.Storage().SetClientContainerId(s.client.Id, *s.newContainerId)
+		if err != nil {
+			return errno.ERR_SET_CLIENT_CONTAINER_ID_FAILED.E(err)
+		}
+		auxInfo.Image = s.image
+		auxInfo.NEBDImage = ""
+	}
+
+	bytes, err := json.Marshal(auxInfo)
+	if err != nil {
+		return errno.ERR_ENCODE_VOLUME_INFO_TO_JSON_FAILED.E(err)
+	}
+	err = curveadm.Storage().SetClientAuxInfo(s.client.Id, string(bytes))
+	if err != nil {
+		return errno.ERR_SET_CLIENT_AUX_INFO_FAILED.E(err)
+	}
+
+	s.stage.committed = true
+	return nil
+}
+
+// rollback removes the new container and brings the old one back,
+// NOTE: all errors are ignored because it's a post step
+func (s *step2RollbackUpgrade) Execute(ctx *context.Context) error {
+	stage := s.stage
+	if stage.committed || !stage.unmapped {
+		return nil
+	}
+
+	curveadm := s.curveadm
+	containerName := volume2ContainerName(s.options.User, s.options.Volume)
+	steps := []task.Step{}
+	if stage.created {
+		steps = append(steps, &step.StopContainer{
+			ContainerId: *s.newContainerId,
+			ExecOptions: curveadm.ExecOptions(),
+		})
+		steps = append(steps, &step.RemoveContainer{
+			ContainerId: *s.newContainerId,
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
+	if stage.detached {
+		steps = append(steps, &step.RenameContainer{
+			ContainerId: s.oldContainerId,
+			Name:        containerName,
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
+	containerId := s.oldContainerId
+	steps = append(steps, &step.StartContainer{
+		ContainerId: &containerId,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	steps = append(steps, &step2MapVolume{
+		curveadm:    curveadm,
+		cc:          s.cc,
+		options:     s.options,
+		containerId: &containerId,
+	})
+	for _, step := range steps {
+		err := step.Execute(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewUpgradeMapTask upgrades nebd-server in place if possible, otherwise
+// replaces the NEBD container with the new image, the volume will be mapped
+// to the same NBD device which it used before.
+func NewUpgradeMapTask(curveadm *cli.CurveAdm, client storage.Client,
+	cc *configure.ClientConfig) (*task.Task, error) {
+	auxInfo, err := DecodeAuxInfo(client)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(client.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	mapOptions := auxInfo.mapOptions(client.Host)
+	options := &mapOptions
+	oldContainerId := client.ContainerId
+	subname := fmt.Sprintf("hostname=%s volume=%s:%s image=%s",
+		hc.GetHostname(), options.User, options.Volume, cc.GetContainerImage())
+	t := task.NewTask("Upgrade Volume Client", subname, hc.GetSSHConfig())
+
+	// add step
+	var output, newContainerId string
+	stage := &upgradeStage{}
+	containerName := volume2ContainerName(options.User, options.Volume)
+	t.AddStep(&step.ListContainers{
+		ShowAll:     true,
+		Format:      "'{{.ID}} {{.Status}}'",
+		Filter:      fmt.Sprintf("id=%s", oldContainerId),
+		Out:         &output,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checkUpgradeContainer(&output),
+	})
+	t.AddStep(&step.PullImage{
+		Image:       cc.GetContainerImage(),
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step2RestartNEBDServer{
+		curveadm:       curveadm,
+		image:          cc.GetContainerImage(),
+		output:         &output,
+		options:        options,
+		newContainerId: &newContainerId,
+		stage:          stage,
+	})
+
+	// fall back to unmap and map again with new container
+	steps := []task.Step{
+		&step2GetMappedDevice{
+			curveadm: curveadm,
+			output:   &output,
+			options:  options,
+		},
+		&step2UnmapImage{
+			output:      &output,
+			user:        options.User,
+			volume:      options.Volume,
+			execOptions: curveadm.ExecOptions(),
+		},
+		&step.Lambda{
+			Lambda: mark(&stage.unmapped),
+		},
+		&step.StopContainer{
+			ContainerId: oldContainerId,
+			ExecOptions: curveadm.ExecOptions(),
+		},
+		&step.RenameContainer{
+			ContainerId: oldContainerId,
+			Name:        fmt.Sprintf(FORMAT_BACKUP_CONTAINER_NAME, containerName),
+			ExecOptions: curveadm.ExecOptions(),
+		},
+		&step.Lambda{
+			Lambda: mark(&stage.detached),
+		},
+		newCreateNEBDContainerStep(curveadm, cc, hc.GetHostname(), *options, &newContainerId),
+		&step.Lambda{
+			Lambda: mark(&stage.created),
+		},
+	}
+	steps = append(steps, newStartNEBDServiceSteps(curveadm, cc, &newContainerId)...)
+	steps = append(steps, &step2MapVolume{
+		curveadm:    curveadm,
+		cc:          cc,
+		options:     options,
+		containerId: &newContainerId,
+	})
+	for _, step := range steps {
+		t.AddStep(&step2UnlessRestarted{stage: stage, step: step})
+	}
+	t.AddStep(&step2CommitUpgrade{
+		curveadm:       curveadm,
+		client:         client,
+		auxInfo:        auxInfo,
+		image:          cc.GetContainerImage(),
+		oldContainerId: oldContainerId,
+		newContainerId: &newContainerId,
+		stage:          stage,
+	})
+	t.AddStep(&step2RecordMetricPort{
+		curveadm:    curveadm,
+		clientId:    client.Id,
+		startPort:   cc.GetMetricPort(),
+		containerId: &newContainerId,
+	})
+	t.AddPostStep(&step2RollbackUpgrade{
+		curveadm:       curveadm,
+		cc:             cc,
+		options:        options,
+		oldContainerId: oldContainerId,
+		newContainerId: &newContainerId,
+		stage:          stage,
+	})
+
+	return t, nil
+}
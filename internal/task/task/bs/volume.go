@@ -0,0 +1,338 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package bs
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/hosts"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	VOLUME_OP_LIST   = "list"
+	VOLUME_OP_INFO   = "info"
+	VOLUME_OP_CREATE = "create"
+	VOLUME_OP_EXTEND = "extend"
+	VOLUME_OP_DELETE = "delete"
+	VOLUME_OP_RENAME = "rename"
+
+	VOLUME_ROOT_DIR = "/"
+)
+
+type (
+	VolumeOptions struct {
+		Op          string
+		User        string
+		Volume      string
+		NewVolume   string // rename
+		Size        int    // GiB
+		Poolset     string
+		StripeUnit  uint64 // bytes
+		StripeCount uint64
+	}
+
+	Volume struct {
+		Name        string   `json:"name"`
+		User        string   `json:"user"`
+		Size        uint64   `json:"size"` // bytes
+		Status      string   `json:"status"`
+		Poolset     string   `json:"poolset,omitempty"`
+		StripeUnit  uint64   `json:"stripe_unit,omitempty"`
+		StripeCount uint64   `json:"stripe_count,omitempty"`
+		Ctime       uint64   `json:"ctime"` // microseconds
+		MappedBy    []string `json:"mapped_by,omitempty"`
+	}
+
+	// FileInfo of curvebs which printed by tools-v2
+	fileInfo struct {
+		FileName    string           `json:"fileName"`
+		FileType    string           `json:"fileType"`
+		Owner       string           `json:"owner"`
+		Length      task.ProtoUint64 `json:"length"`
+		Ctime       task.ProtoUint64 `json:"ctime"`
+		FileStatus  string           `json:"fileStatus"`
+		StripeUnit  task.ProtoUint64 `json:"stripeUnit"`
+		StripeCount task.ProtoUint64 `json:"stripeCount"`
+		Poolset     string           `json:"poolset"`
+	}
+
+	step2HandleVolumeOutput struct {
+		options    VolumeOptions
+		success    *bool
+		output     *string
+		memStorage *utils.SafeMap
+	}
+
+	step2CheckVolumeTarget struct {
+		host     string
+		hostname string
+		options  VolumeOptions
+		output   *string
+	}
+)
+
+var (
+	VOLUME_OP_TITLE = map[string]string{
+		VOLUME_OP_LIST:   "List Volumes",
+		VOLUME_OP_INFO:   "Get Volume Info",
+		VOLUME_OP_CREATE: "Create Volume",
+		VOLUME_OP_EXTEND: "Extend Volume",
+		VOLUME_OP_DELETE: "Delete Volume",
+		VOLUME_OP_RENAME: "Rename Volume",
+	}
+
+	VOLUME_OP_ERRNO = map[string]*errno.ErrorCode{
+		VOLUME_OP_LIST:   errno.ERR_LIST_VOLUMES_FAILED,
+		VOLUME_OP_INFO:   errno.ERR_GET_VOLUME_INFO_FAILED,
+		VOLUME_OP_CREATE: errno.ERR_CREATE_VOLUME_FAILED,
+		VOLUME_OP_EXTEND: errno.ERR_EXTEND_VOLUME_FAILED,
+		VOLUME_OP_DELETE: errno.ERR_DELETE_VOLUME_FAILED,
+		VOLUME_OP_RENAME: errno.ERR_RENAME_VOLUME_FAILED,
+	}
+)
+
+func (f fileInfo) volume() Volume {
+	return Volume{
+		Name:        f.FileName,
+		User:        f.Owner,
+		Size:        uint64(f.Length),
+		Status:      f.FileStatus,
+		Poolset:     f.Poolset,
+		StripeUnit:  uint64(f.StripeUnit),
+		StripeCount: uint64(f.StripeCount),
+		Ctime:       uint64(f.Ctime),
+	}
+}
+
+// e.g. bs create file --path /test --user curve --size 10GiB --format json
+func volumeArgs(options VolumeOptions) string {
+	var args []string
+	switch options.Op {
+	case VOLUME_OP_LIST:
+		args = []string{"list dir", "--path", VOLUME_ROOT_DIR}
+	case VOLUME_OP_INFO:
+		args = []string{"query file", "--path", options.Volume}
+	case VOLUME_OP_CREATE:
+		args = []string{"create file", "--path", options.Volume, "--user", options.User,
+			"--size", fmt.Sprintf("%dGiB", options.Size)}
+		if len(options.Poolset) > 0 {
+			args = append(args, "--poolset", options.Poolset)
+		}
+		if options.StripeCount > 0 {
+			args = append(args, "--stripeunit", fmt.Sprintf("%dB", options.StripeUnit),
+				"--stripecount", strconv.FormatUint(options.StripeCount, 10))
+		}
+	case VOLUME_OP_EXTEND:
+		args = []string{"update file", "--path", options.Volume, "--user", options.User,
+			"--size", fmt.Sprintf("%dGiB", options.Size)}
+	case VOLUME_OP_DELETE:
+		args = []string{"delete file", "--path", options.Volume, "--user", options.User, "--force"}
+	case VOLUME_OP_RENAME:
+		args = []string{"rename file", "--path", options.Volume, "--newpath", options.NewVolume,
+			"--user", options.User}
+	}
+	return fmt.Sprintf("bs %s --format json", strings.Join(args, " "))
+}
+
+// result of tools-v2 can be a file info, a list of file info or wrapped by "fileInfo"
+func decodeFileInfos(data json.RawMessage) ([]fileInfo, error) {
+	infos := []fileInfo{}
+	data = json.RawMessage(strings.TrimSpace(string(data)))
+	if len(data) == 0 || string(data) == "null" {
+		return infos, nil
+	} else if data[0] == '[' {
+		err := json.Unmarshal(data, &infos)
+		return infos, err
+	}
+
+	wrapper := struct {
+		FileInfo json.RawMessage `json:"fileInfo"`
+	}{}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, err
+	} else if len(wrapper.FileInfo) > 0 {
+		return decodeFileInfos(wrapper.FileInfo)
+	}
+
+	info := fileInfo{}
+	err := json.Unmarshal(data, &info)
+	return append(infos, info), err
+}
+
+/*
+ * Output Example:
+ * {
+ *   "error": [{"code": 0, "message": "success"}],
+ *   "result": {"fileInfo": {"fileName": "test", "owner": "curve", "length": "10737418240", ...}}
+ * }
+ */
+func parseVolumeOutput(output string, decode bool) ([]Volume, string, error) {
+	result, message, err := task.ParseToolsV2Output(output)
+	if err != nil || len(message) > 0 {
+		return nil, message, err
+	} else if !decode {
+		return nil, "", nil
+	}
+
+	infos, err := decodeFileInfos(result)
+	if err != nil {
+		return nil, "", err
+	}
+	volumes := []Volume{}
+	for _, info := range infos {
+		if len(info.FileType) > 0 && info.FileType != "INODE_PAGEFILE" {
+			continue // directory, snapshot...
+		}
+		volumes = append(volumes, info.volume())
+	}
+	return volumes, "", nil
+}
+
+func (s *step2HandleVolumeOutput) Execute(ctx *context.Context) error {
+	ec := VOLUME_OP_ERRNO[s.options.Op]
+	decode := s.options.Op == VOLUME_OP_LIST || s.options.Op == VOLUME_OP_INFO
+	volumes, message, err := parseVolumeOutput(*s.output, decode)
+	if len(message) > 0 {
+		return ec.S(message)
+	} else if !*s.success {
+		return ec.S(*s.output)
+	} else if err != nil && decode {
+		return errno.ERR_DECODE_VOLUME_INFO_FAILED.E(err)
+	}
+
+	for i := range volumes { // file name without parent directory
+		if s.options.Op == VOLUME_OP_INFO {
+			volumes[i].Name = s.options.Volume
+		} else {
+			volumes[i].Name = path.Join(VOLUME_ROOT_DIR, volumes[i].Name)
+		}
+	}
+	s.memStorage.Set(comm.KEY_ALL_VOLUMES, volumes)
+	return nil
+}
+
+func NewVolumeTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	options := curveadm.MemStorage().Get(comm.KEY_VOLUME_OPTIONS).(VolumeOptions)
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask(VOLUME_OP_TITLE[options.Op], subname, hc.GetSSHConfig())
+
+	// add step to task
+	var output string
+	var success bool
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     fmt.Sprintf("%s %s", dc.GetProjectLayout().ToolsV2BinaryPath, volumeArgs(options)),
+		Success:     &success,
+		Out:         &output,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step2HandleVolumeOutput{
+		options:    options,
+		success:    &success,
+		output:     &output,
+		memStorage: curveadm.MemStorage(),
+	})
+
+	return t, nil
+}
+
+// TASK: check whether volume served by target
+func checkTgtdRunning(output *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		items := strings.Split(*output, " ")
+		if len(items) < 2 || !strings.HasPrefix(items[1], "Up") {
+			return task.ERR_SKIP_TASK
+		}
+		return nil
+	}
+}
+
+func (s *step2CheckVolumeTarget) Execute(ctx *context.Context) error {
+	store := formatImage(s.options.User, s.options.Volume)
+	for _, target := range parseTargets(s.host, s.hostname, *s.output) {
+		if utils.Slice2Map(target.Stores)[store] {
+			return errno.ERR_VOLUME_IS_SERVED_BY_TARGET.
+				F("host=%s tid=%s target=%s", target.Host, target.Tid, target.Name)
+		}
+	}
+	return nil
+}
+
+func NewCheckVolumeTargetTask(curveadm *cli.CurveAdm, hc *hosts.HostConfig) (*task.Task, error) {
+	options := curveadm.MemStorage().Get(comm.KEY_VOLUME_OPTIONS).(VolumeOptions)
+
+	subname := fmt.Sprintf("host=%s volume=%s", hc.GetName(), formatImage(options.User, options.Volume))
+	t := task.NewTask("Check Volume Target", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var output string
+	containerId := DEFAULT_TGTD_CONTAINER_NAME
+	t.AddStep(&step.ListContainers{
+		Format:      "'{{.ID}} {{.Status}}'",
+		Filter:      fmt.Sprintf("name=%s", DEFAULT_TGTD_CONTAINER_NAME),
+		Out:         &output,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checkTgtdRunning(&output),
+	})
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     CMD_SHOW_TARGETS,
+		Out:         &output,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step2CheckVolumeTarget{
+		host:     hc.GetName(),
+		hostname: hc.GetHostname(),
+		options:  options,
+		output:   &output,
+	})
+
+	return t, nil
+}
@@ -0,0 +1,87 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package bs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVolumeOutput(t *testing.T) {
+	assert := assert.New(t)
+
+	// list dir
+	volumes, message, err := parseVolumeOutput(`{
+  "error": [{"code": 0, "message": "success"}],
+  "result": [
+    {"fileName": "vol1", "fileType": "INODE_PAGEFILE", "owner": "curve", "length": "10737418240",
+     "ctime": "1700000000000000", "fileStatus": "kFileCreated", "stripeUnit": 65536, "stripeCount": 32},
+    {"fileName": "RecycleBin", "fileType": "INODE_DIRECTORY", "owner": "root"}
+  ]
+}`, true)
+	assert.Nil(err)
+	assert.Empty(message)
+	assert.Len(volumes, 1)
+	assert.Equal(Volume{
+		Name:        "vol1",
+		User:        "curve",
+		Size:        10737418240,
+		Status:      "kFileCreated",
+		StripeUnit:  65536,
+		StripeCount: 32,
+		Ctime:       1700000000000000,
+	}, volumes[0])
+
+	// query file
+	volumes, _, err = parseVolumeOutput(`{"error": [], "result": {"fileInfo": {"fileName": "vol1", "owner": "curve", "length": 21474836480}}}`, true)
+	assert.Nil(err)
+	assert.Len(volumes, 1)
+	assert.Equal(uint64(21474836480), volumes[0].Size)
+
+	// failed
+	_, message, err = parseVolumeOutput(`{"error": [{"code": 101, "message": "file not exist"}], "result": null}`, true)
+	assert.Nil(err)
+	assert.Equal("file not exist", message)
+	_, _, err = parseVolumeOutput("Error: unknown command", true)
+	assert.NotNil(err)
+}
+
+func TestVolumeCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	options := VolumeOptions{
+		Op:          VOLUME_OP_CREATE,
+		User:        "curve",
+		Volume:      "/vol1",
+		Size:        10,
+		Poolset:     "ssd",
+		StripeUnit:  65536,
+		StripeCount: 32,
+	}
+	assert.Equal("bs create file --path /vol1 --user curve --size 10GiB --poolset ssd"+
+		" --stripeunit 65536B --stripecount 32 --format json", volumeArgs(options))
+
+	options = VolumeOptions{Op: VOLUME_OP_RENAME, User: "curve", Volume: "/vol1", NewVolume: "/vol2"}
+	assert.Equal("bs rename file --path /vol1 --newpath /vol2 --user curve --format json", volumeArgs(options))
+}
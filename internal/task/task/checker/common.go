@@ -25,7 +25,10 @@
 package checker
 
 import (
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/utils"
 )
 
 type (
@@ -39,6 +42,16 @@ type (
 		Type string
 		Path string
 	}
+
+	// Warning is an item which not block the deploy but deserves attention,
+	// all warnings will be shown after precheck finished, role is empty if
+	// it's a host level warning
+	Warning struct {
+		Host    string
+		Role    string
+		Message string
+		Hint    string
+	}
 )
 
 const (
@@ -187,3 +200,28 @@ func getServiceDirectorys(dc *topology.DeployConfig) []Directory {
 
 	return dirs
 }
+
+func addWarning(curveadm *cli.CurveAdm, host, role, message, hint string) {
+	curveadm.MemStorage().TX(func(kv *utils.SafeMap) error {
+		warnings := []Warning{}
+		if v := kv.Get(comm.KEY_CHECK_WARNINGS); v != nil {
+			warnings = v.([]Warning)
+		}
+		warnings = append(warnings, Warning{
+			Host:    host,
+			Role:    role,
+			Message: message,
+			Hint:    hint,
+		})
+		kv.Set(comm.KEY_CHECK_WARNINGS, warnings)
+		return nil
+	})
+}
+
+func GetWarnings(curveadm *cli.CurveAdm) []Warning {
+	v := curveadm.MemStorage().Get(comm.KEY_CHECK_WARNINGS)
+	if v == nil {
+		return []Warning{}
+	}
+	return v.([]Warning)
+}
@@ -0,0 +1,460 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package checker
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	// size and avail are in 1K-blocks
+	DISK_FREE_FORMAT    = "source,fstype,size,avail,itotal,iavail,target"
+	BLOCK_DEVICE_FORMAT = "PKNAME,TYPE"
+	PROC_MOUNTS         = "/proc/mounts"
+
+	// every copyset should hold 64 chunks at least,
+	// it's 1GiB for the default 16MiB chunk size
+	CHUNKSERVER_MIN_CHUNKS_PER_COPYSET = 64
+	// warning if free inodes less than 10%
+	MIN_FREE_INODES_PERCENT = 10
+	// warning if free space of log/core directory less than 1GiB
+	MIN_FREE_SPACE_KB = 1024 * 1024
+)
+
+var (
+	RECOMMENDED_FILESYSTEMS = map[string]bool{
+		"ext4": true,
+		"xfs":  true,
+	}
+)
+
+type (
+	DiskFree struct {
+		Source string
+		FSType string
+		Size   uint64 // KB
+		Avail  uint64 // KB
+		ITotal uint64
+		IAvail uint64
+		Target string
+	}
+
+	// physical disk which the service data directory located in
+	ServiceDisk struct {
+		Host      string
+		ServiceId string
+		DataDir   string
+		Disk      string
+	}
+
+	step2CheckDisk struct {
+		curveadm    *cli.CurveAdm
+		dc          *topology.DeployConfig
+		execOptions module.ExecOptions
+		nwarning    int
+	}
+)
+
+func parseDiskFree(out string) (*DiskFree, error) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) < 2 {
+		return nil, errno.ERR_UNRECOGNIZED_DISK_FREE_OUTPUT.S(out)
+	}
+
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) < 7 {
+		return nil, errno.ERR_UNRECOGNIZED_DISK_FREE_OUTPUT.S(out)
+	}
+	numbers := []uint64{}
+	for _, field := range fields[2:6] {
+		n, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			if field != "-" { // e.g. inodes of btrfs
+				return nil, errno.ERR_UNRECOGNIZED_DISK_FREE_OUTPUT.S(out)
+			}
+			n = 0
+		}
+		numbers = append(numbers, n)
+	}
+
+	return &DiskFree{
+		Source: fields[0],
+		FSType: fields[1],
+		Size:   numbers[0],
+		Avail:  numbers[1],
+		ITotal: numbers[2],
+		IAvail: numbers[3],
+		Target: strings.Join(fields[6:], " "),
+	}, nil
+}
+
+// return mount options of the last filesystem which mounted on target
+func parseMountOptions(out, target string) []string {
+	options := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 4 && fields[1] == target {
+			options = strings.Split(fields[3], ",")
+		}
+	}
+	return options
+}
+
+/*
+ * lsblk --output=PKNAME,TYPE --noheadings /dev/sdb1
+ *   sdb part
+ * lsblk --output=PKNAME,TYPE --noheadings /dev/sdb
+ *   disk
+ */
+func parseBlockDevice(out, source string) string {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	fields := strings.Fields(lines[0])
+	if len(fields) == 2 && fields[1] != "disk" {
+		return fields[0]
+	}
+	return filepath.Base(source)
+}
+
+func formatKB(kb uint64) string {
+	return humanize.IBytes(kb * 1024)
+}
+
+func checkInodes(dir Directory, disk *DiskFree) error {
+	if disk.ITotal == 0 { // filesystem allocates inodes dynamically
+		return nil
+	} else if disk.IAvail == 0 {
+		return errno.ERR_NO_INODES_LEFT_ON_DEVICE.
+			F("%s: %s (%s)", dir.Type, dir.Path, disk.Target)
+	}
+	return nil
+}
+
+func checkMountOptions(dir Directory, disk *DiskFree, options []string) error {
+	for _, option := range options {
+		if option == "ro" {
+			return errno.ERR_FILESYSTEM_MOUNTED_READ_ONLY.
+				F("%s: %s (%s on %s)", dir.Type, dir.Path, disk.Source, disk.Target)
+		}
+	}
+	return nil
+}
+
+/*
+ * required capacity = copysets * 64 * chunk_size
+ *
+ * if the chunkfile pool is formatted, its capacity is disk size * format percent,
+ * which specified in format.yaml, otherwise we take the available space.
+ */
+func checkCapacity(dc *topology.DeployConfig, disk *DiskFree, fc *configure.FormatConfig) error {
+	chunkSize := uint64(configure.DEFAULT_CHUNK_SIZE)
+	capacity := disk.Avail * 1024
+	if fc != nil {
+		if disk.Target != fc.GetMountPoint() {
+			return errno.ERR_DISK_NOT_MOUNTED_ON_DATA_DIRECTORY.
+				F("%s: %s (data directory on %s)", fc.GetDevice(), fc.GetMountPoint(), disk.Target)
+		}
+		if fc.GetChunkSize() > 0 {
+			chunkSize = uint64(fc.GetChunkSize())
+		}
+		capacity = disk.Size * 1024 * uint64(fc.GetFormatPercent()) / 100
+	} else if dc.GetEnableChunkfilePool() {
+		capacity = disk.Size * 1024
+	}
+
+	required := uint64(dc.GetCopysets()) * CHUNKSERVER_MIN_CHUNKS_PER_COPYSET * chunkSize
+	if capacity < required {
+		return errno.ERR_DATA_DIRECTORY_NO_ENOUGH_CAPACITY.
+			F("copysets=%d capacity=%s required=%s",
+				dc.GetCopysets(), humanize.IBytes(capacity), humanize.IBytes(required))
+	}
+	return nil
+}
+
+func (s *step2CheckDisk) warning(format string, a ...interface{}) func(hint string) {
+	return func(hint string) {
+		s.nwarning++
+		addWarning(s.curveadm, s.dc.GetHost(), s.dc.GetRole(), fmt.Sprintf(format, a...), hint)
+	}
+}
+
+// data directory may not exist before deploy, so we check its nearest existing parent
+func (s *step2CheckDisk) diskFree(ctx *context.Context, path string) (*DiskFree, error) {
+	for {
+		var out string
+		var success bool
+		step := step.ShowDiskFree{
+			Files:       []string{path},
+			Format:      DISK_FREE_FORMAT,
+			Success:     &success,
+			Out:         &out,
+			ExecOptions: s.execOptions,
+		}
+		if path == "/" {
+			step.Success = nil
+		}
+		err := step.Execute(ctx)
+		if err != nil {
+			return nil, err
+		} else if success || path == "/" {
+			return parseDiskFree(out)
+		}
+		path = filepath.Dir(path)
+	}
+}
+
+func (s *step2CheckDisk) physicalDisk(ctx *context.Context, source string) string {
+	var out string
+	var success bool
+	step := step.ListBlockDevice{
+		Device:      []string{source},
+		Format:      BLOCK_DEVICE_FORMAT,
+		NoHeadings:  true,
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}
+	err := step.Execute(ctx)
+	if err != nil || !success {
+		return source // e.g. overlay, tmpfs
+	}
+	return parseBlockDevice(out, source)
+}
+
+// the device path shown by df may differ from the one in format.yaml
+// (e.g. /dev/mapper/*), so we compare the filesystem UUID, and treat
+// it as the same device if the UUID can't be determined
+func (s *step2CheckDisk) sameDevice(ctx *context.Context, source, device string) bool {
+	if source == device {
+		return true
+	}
+
+	uuids := []string{}
+	for _, dev := range []string{source, device} {
+		var out string
+		var success bool
+		step := step.BlockId{
+			Device:      dev,
+			Format:      "value",
+			MatchTag:    "UUID",
+			Success:     &success,
+			Out:         &out,
+			ExecOptions: s.execOptions,
+		}
+		err := step.Execute(ctx)
+		if err != nil || !success || len(out) == 0 {
+			return true
+		}
+		uuids = append(uuids, out)
+	}
+	return uuids[0] == uuids[1]
+}
+
+func (s *step2CheckDisk) formatConfig() *configure.FormatConfig {
+	v := s.curveadm.MemStorage().Get(comm.KEY_CHECK_FORMAT_CONFIGS)
+	if v == nil {
+		return nil
+	}
+	for _, fc := range v.([]*configure.FormatConfig) {
+		if fc.GetHost() == s.dc.GetHost() && fc.GetMountPoint() == s.dc.GetDataDir() {
+			return fc
+		}
+	}
+	return nil
+}
+
+func (s *step2CheckDisk) addServiceDisk(disk string) {
+	dc := s.dc
+	s.curveadm.MemStorage().TX(func(kv *utils.SafeMap) error {
+		disks := []ServiceDisk{}
+		if v := kv.Get(comm.KEY_ALL_SERVICE_DISKS); v != nil {
+			disks = v.([]ServiceDisk)
+		}
+		disks = append(disks, ServiceDisk{
+			Host:      dc.GetHost(),
+			ServiceId: dc.GetId(),
+			DataDir:   dc.GetDataDir(),
+			Disk:      disk,
+		})
+		kv.Set(comm.KEY_ALL_SERVICE_DISKS, disks)
+		return nil
+	})
+}
+
+func (s *step2CheckDisk) Execute(ctx *context.Context) error {
+	dc := s.dc
+	var mounts string
+	var success bool
+	err := (&step.Cat{
+		Files:       []string{PROC_MOUNTS},
+		Success:     &success,
+		Out:         &mounts,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range getServiceDirectorys(dc) {
+		disk, err := s.diskFree(ctx, dir.Path)
+		if err != nil {
+			return err
+		}
+
+		// (1) space and inodes
+		if disk.Avail == 0 {
+			return errno.ERR_NO_SPACE_LEFT_ON_DEVICE.
+				F("%s: %s (%s)", dir.Type, dir.Path, disk.Target)
+		} else if err := checkInodes(dir, disk); err != nil {
+			return err
+		} else if disk.ITotal > 0 && disk.IAvail*100/disk.ITotal < MIN_FREE_INODES_PERCENT {
+			s.warning("%s %s has only %d free inodes (%d%%)",
+				dir.Type, dir.Path, disk.IAvail, disk.IAvail*100/disk.ITotal)(
+				"remove useless small files or use a filesystem with more inodes")
+		}
+		if dir.Type != DATA_DIR && disk.Avail < MIN_FREE_SPACE_KB {
+			s.warning("%s %s has only %s free space", dir.Type, dir.Path, formatKB(disk.Avail))(
+				"clean up the directory or move it to a larger disk")
+		}
+
+		// (2) mount options
+		if success {
+			err = checkMountOptions(dir, disk, parseMountOptions(mounts, disk.Target))
+			if err != nil {
+				return err
+			}
+		}
+
+		if dir.Type != DATA_DIR {
+			continue
+		}
+
+		// (3) filesystem type
+		if !RECOMMENDED_FILESYSTEMS[disk.FSType] {
+			s.warning("data_dir %s is on %s filesystem", dir.Path, disk.FSType)(
+				"ext4 or xfs is recommended for data directory")
+		}
+
+		// (4) capacity for copysets
+		if dc.GetRole() != ROLE_CHUNKSERVER {
+			continue
+		}
+		fc := s.formatConfig()
+		if fc != nil && disk.Target == fc.GetMountPoint() &&
+			!s.sameDevice(ctx, disk.Source, fc.GetDevice()) {
+			return errno.ERR_DISK_NOT_MOUNTED_ON_DATA_DIRECTORY.
+				F("%s: %s (%s mounted)", fc.GetDevice(), fc.GetMountPoint(), disk.Source)
+		}
+		err = checkCapacity(dc, disk, fc)
+		if err != nil {
+			return err
+		}
+		// the data directory which not mounted (or not exist yet) resolves
+		// to its parent mount (e.g. /), we only compare the dedicated ones
+		if disk.Target == filepath.Clean(dir.Path) {
+			s.addServiceDisk(s.physicalDisk(ctx, disk.Source))
+		}
+	}
+
+	if s.nwarning > 0 {
+		return task.ERR_WARN_TASK
+	}
+	return nil
+}
+
+func checkSharedDisk(curveadm *cli.CurveAdm) step.LambdaType {
+	return func(ctx *context.Context) error {
+		v := curveadm.MemStorage().Get(comm.KEY_ALL_SERVICE_DISKS)
+		if v == nil {
+			return nil
+		}
+
+		m := map[string][]ServiceDisk{}
+		for _, disk := range v.([]ServiceDisk) {
+			key := fmt.Sprintf("%s:%s", disk.Host, disk.Disk)
+			m[key] = append(m[key], disk)
+		}
+
+		keys := []string{}
+		for key, disks := range m {
+			if len(disks) > 1 {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+
+		sort.Strings(keys)
+		disks := m[keys[0]]
+		dirs := []string{}
+		for _, disk := range disks {
+			dirs = append(dirs, disk.DataDir)
+		}
+		sort.Strings(dirs)
+		return errno.ERR_CHUNKSERVER_INSTANCES_SHARE_DISK.
+			F("host=%s disk=%s data_dir=(%s)",
+				disks[0].Host, disks[0].Disk, strings.Join(dirs, ", "))
+	}
+}
+
+func NewCheckDiskTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	subname := fmt.Sprintf("host=%s role=%s", dc.GetHost(), dc.GetRole())
+	t := task.NewTask("Check Disk <disk>", subname, hc.GetSSHConfig())
+
+	t.AddStep(&step2CheckDisk{
+		curveadm:    curveadm,
+		dc:          dc,
+		execOptions: curveadm.ExecOptions(),
+	})
+
+	return t, nil
+}
+
+func NewCheckSharedDiskTask(curveadm *cli.CurveAdm, c interface{}) (*task.Task, error) {
+	t := task.NewTask("Check Shared Disk <disk>", "", nil)
+	t.AddStep(&step.Lambda{
+		Lambda: checkSharedDisk(curveadm),
+	})
+	return t, nil
+}
@@ -0,0 +1,127 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDiskFree(t *testing.T) {
+	assert := assert.New(t)
+
+	out := `Filesystem     Type  1K-blocks     Avail   Inodes    IFree Mounted on
+/dev/sdb       ext4 3844640564 3649149660 244195328 244195317 /data/chunkserver0`
+	disk, err := parseDiskFree(out)
+	assert.Nil(err)
+	assert.Equal(DiskFree{
+		Source: "/dev/sdb",
+		FSType: "ext4",
+		Size:   3844640564,
+		Avail:  3649149660,
+		ITotal: 244195328,
+		IAvail: 244195317,
+		Target: "/data/chunkserver0",
+	}, *disk)
+
+	// inodes of btrfs
+	out = `Filesystem     Type  1K-blocks     Avail Inodes IFree Mounted on
+/dev/sdc       btrfs 1048576000 1048000000      -     - /data`
+	disk, err = parseDiskFree(out)
+	assert.Nil(err)
+	assert.Equal(uint64(0), disk.ITotal)
+	assert.Equal("btrfs", disk.FSType)
+
+	_, err = parseDiskFree("df: /data: No such file or directory")
+	assert.Equal(errno.ERR_UNRECOGNIZED_DISK_FREE_OUTPUT.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
+
+func TestParseMountOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	out := `/dev/sda1 / ext4 rw,relatime 0 0
+/dev/sdb /data/chunkserver0 xfs rw,noatime,attr2 0 0
+/dev/sdc /data/chunkserver0 xfs ro,noatime 0 0`
+	assert.Equal([]string{"rw", "relatime"}, parseMountOptions(out, "/"))
+	assert.Equal([]string{"ro", "noatime"}, parseMountOptions(out, "/data/chunkserver0"))
+	assert.Equal([]string{}, parseMountOptions(out, "/data"))
+
+	dir := Directory{DATA_DIR, "/data/chunkserver0"}
+	disk := &DiskFree{Source: "/dev/sdc", Target: "/data/chunkserver0"}
+	err := checkMountOptions(dir, disk, parseMountOptions(out, "/data/chunkserver0"))
+	assert.Equal(errno.ERR_FILESYSTEM_MOUNTED_READ_ONLY.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
+
+func TestParseBlockDevice(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("sdb", parseBlockDevice("sdb  part", "/dev/sdb1"))
+	assert.Equal("sdb", parseBlockDevice("     disk", "/dev/sdb"))
+	assert.Equal("nvme0n1", parseBlockDevice("nvme0n1 part\n", "/dev/nvme0n1p1"))
+}
+
+func TestCheckCapacity(t *testing.T) {
+	assert := assert.New(t)
+
+	dcs, err := topology.ParseTopology(`
+kind: curvebs
+chunkserver_services:
+  config:
+    copysets: 100
+  deploy:
+    - host: host1
+`, nil)
+	assert.Nil(err)
+	dc := dcs[0]
+	fc := &configure.FormatConfig{
+		Device:       "/dev/sdb",
+		MountPoint:   "/data/chunkserver0",
+		FormtPercent: 90,
+	}
+
+	tests := []struct {
+		size   uint64 // KB
+		target string
+		fc     *configure.FormatConfig
+		code   int
+	}{
+		{200 * 1024 * 1024, "/data/chunkserver0", fc, 0},
+		{100 * 1024 * 1024, "/data/chunkserver0", fc, errno.ERR_DATA_DIRECTORY_NO_ENOUGH_CAPACITY.GetCode()},
+		{200 * 1024 * 1024, "/", fc, errno.ERR_DISK_NOT_MOUNTED_ON_DATA_DIRECTORY.GetCode()},
+		{100 * 1024 * 1024, "/", nil, 0},
+		{99 * 1024 * 1024, "/", nil, errno.ERR_DATA_DIRECTORY_NO_ENOUGH_CAPACITY.GetCode()},
+	}
+	for _, t := range tests {
+		disk := &DiskFree{Size: t.size, Avail: t.size, Target: t.target}
+		err := checkCapacity(dc, disk, t.fc)
+		if t.code == 0 {
+			assert.Nil(err)
+		} else {
+			assert.Equal(t.code, err.(*errno.ErrorCode).GetCode())
+		}
+	}
+}
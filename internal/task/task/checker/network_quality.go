@@ -0,0 +1,484 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package checker
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	NETWORK_INTERNAL = "internal"
+	NETWORK_EXTERNAL = "external" // chunkserver external server
+
+	PING_COUNT    = 5
+	PING_INTERVAL = 0.2
+	STANDARD_MTU  = 1500
+	ICMP_OVERHEAD = 28 // IPv4 header (20 bytes) + ICMP header (8 bytes)
+
+	// sparse file served by mock http server, see start_nginx.sh
+	THROUGHPUT_PAYLOAD     = "payload"
+	THROUGHPUT_MAX_TIME    = 10 // seconds
+	FORMAT_SPEED_DOWNLOAD  = "%{speed_download}"
+	FORMAT_SHOW_ADDRESS_TO = "ip -o addr show to %s"
+	FORMAT_INTERFACE_MTU   = "/sys/class/net/%s/mtu"
+
+	// thresholds, path which exceeds them will be reported as warning
+	MAX_LATENCY_MS     = 1.0
+	MAX_JITTER_MS      = 0.5
+	MIN_BANDWIDTH_MBPS = 1000
+	OUTLIER_FACTOR     = 3 // compare with the median of all paths
+
+	REGEX_PACKET_LOSS = `([\d.]+)% packet loss`
+	REGEX_PING_RTT    = `= ([\d.]+)/([\d.]+)/([\d.]+)(?:/([\d.]+))? ms`
+)
+
+type (
+	// address of host which the quality measured with
+	Endpoint struct {
+		Host       string
+		IP         string
+		Port       int
+		ExternalIP string
+	}
+
+	NetworkPath struct {
+		Src       string
+		Dst       string
+		Loss      float64 // percent
+		Latency   float64 // average RTT in ms
+		Jitter    float64 // RTT mdev in ms
+		Bandwidth float64 // Mbit/s, 0 means throughput test failed
+	}
+
+	HostMTU struct {
+		Host      string
+		Network   string
+		IP        string
+		Interface string
+		MTU       int
+	}
+
+	step2MeasureNetwork struct {
+		curveadm    *cli.CurveAdm
+		containerId string // mock http server, which runs the curve image
+		self        Endpoint
+		peers       []Endpoint
+		execOptions module.ExecOptions
+	}
+)
+
+// first service on each host decides the endpoint
+func getEndpoints(dcs []*topology.DeployConfig) []Endpoint {
+	endpoints := []Endpoint{}
+	index := map[string]int{}
+	for _, dc := range dcs {
+		host := dc.GetHost()
+		i, ok := index[host]
+		if !ok {
+			addresses := getServiceListenAddresses(dc)
+			if len(addresses) == 0 {
+				continue
+			}
+			i = len(endpoints)
+			index[host] = i
+			endpoints = append(endpoints, Endpoint{
+				Host: host,
+				IP:   addresses[0].IP,
+				Port: addresses[0].Port,
+			})
+		}
+
+		if dc.GetRole() == ROLE_CHUNKSERVER && dc.GetEnableExternalServer() &&
+			len(endpoints[i].ExternalIP) == 0 {
+			endpoints[i].ExternalIP = dc.GetListenExternalIp()
+		}
+	}
+	return endpoints
+}
+
+/*
+ * 5 packets transmitted, 5 received, 0% packet loss, time 803ms
+ * rtt min/avg/max/mdev = 0.045/0.060/0.081/0.013 ms
+ */
+func parsePing(out string) (loss, latency, jitter float64) {
+	loss = 100
+	mu := regexp.MustCompile(REGEX_PACKET_LOSS).FindStringSubmatch(out)
+	if len(mu) > 0 {
+		loss, _ = strconv.ParseFloat(mu[1], 64)
+	}
+	mu = regexp.MustCompile(REGEX_PING_RTT).FindStringSubmatch(out)
+	if len(mu) > 0 {
+		latency, _ = strconv.ParseFloat(mu[2], 64)
+		jitter, _ = strconv.ParseFloat(mu[4], 64) // busybox has no mdev
+	}
+	return
+}
+
+// 2: eth0    inet 10.0.1.1/24 brd 10.0.1.255 scope global eth0\ ...
+func parseInterface(out string) string {
+	fields := strings.Fields(out)
+	if len(fields) < 2 {
+		return ""
+	}
+	return strings.Split(fields[1], "@")[0] // e.g. eth0@if5
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	return sorted[len(sorted)/2]
+}
+
+func (s *step2MeasureNetwork) ping(ctx *context.Context, ip string, size int) (string, bool, error) {
+	var out string
+	var success bool
+	cmd := &step.Ping{
+		Destination: &ip,
+		Count:       PING_COUNT,
+		Interval:    PING_INTERVAL,
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}
+	if size > 0 { // path MTU discovery
+		cmd.Count = 1
+		cmd.Interval = 0
+		cmd.Timeout = 1
+		cmd.PacketSize = size
+		cmd.DontFragment = true
+	}
+	err := cmd.Execute(ctx)
+	return out, success, err
+}
+
+// download payload within the container, so curl shipped in curve image is used
+func (s *step2MeasureNetwork) throughput(ctx *context.Context, peer Endpoint) (float64, error) {
+	cli := ctx.Module().Shell().Curl(fmt.Sprintf("http://%s:%d/%s", peer.IP, peer.Port, THROUGHPUT_PAYLOAD))
+	cli.AddOption("--output /dev/null")
+	cli.AddOption("--silent")
+	cli.AddOption("--max-time %d", THROUGHPUT_MAX_TIME)
+	cli.AddOption("--write-out '%s'", FORMAT_SPEED_DOWNLOAD)
+	command, err := cli.String()
+	if err != nil {
+		return 0, err
+	}
+
+	var out string
+	var success bool
+	err = (&step.ContainerExec{
+		ContainerId: &s.containerId,
+		Command:     command,
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	if err != nil || !success {
+		return 0, err
+	}
+	bytes, _ := strconv.ParseFloat(strings.TrimSpace(out), 64) // bytes per second
+	return bytes * 8 / 1000 / 1000, nil
+}
+
+func (s *step2MeasureNetwork) mtu(ctx *context.Context, ip string) (string, int, error) {
+	var out string
+	err := (&step.Command{
+		Command:     fmt.Sprintf(FORMAT_SHOW_ADDRESS_TO, ip),
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	if err != nil {
+		return "", 0, err
+	}
+	iface := parseInterface(out)
+	if len(iface) == 0 {
+		return "", 0, errno.ERR_UNRECOGNIZED_NETWORK_INTERFACE.
+			F("host=%s ip=%s", s.self.Host, ip)
+	}
+
+	err = (&step.Cat{
+		Files:       []string{fmt.Sprintf(FORMAT_INTERFACE_MTU, iface)},
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	if err != nil {
+		return "", 0, err
+	}
+	mtu, ok := utils.Str2Int(strings.TrimSpace(out))
+	if !ok {
+		return "", 0, errno.ERR_UNRECOGNIZED_NETWORK_INTERFACE.
+			F("host=%s interface=%s mtu=%s", s.self.Host, iface, out)
+	}
+	return iface, mtu, nil
+}
+
+// jumbo frame must pass through every path, otherwise the packet will be dropped
+func (s *step2MeasureNetwork) checkJumboFrame(ctx *context.Context, mtu int, dests map[string]string) error {
+	if mtu <= STANDARD_MTU {
+		return nil
+	}
+	for host, ip := range dests {
+		_, success, err := s.ping(ctx, ip, mtu-ICMP_OVERHEAD)
+		if err != nil {
+			return err
+		} else if !success {
+			return errno.ERR_JUMBO_FRAME_NOT_SUPPORTED.
+				F("src=%s dest=%s(%s) mtu=%d", s.self.Host, host, ip, mtu)
+		}
+	}
+	return nil
+}
+
+func (s *step2MeasureNetwork) measureMTU(ctx *context.Context) error {
+	networks := []struct {
+		name  string
+		ip    string
+		dests map[string]string
+	}{
+		{NETWORK_INTERNAL, s.self.IP, map[string]string{}},
+		{NETWORK_EXTERNAL, s.self.ExternalIP, map[string]string{}},
+	}
+	for _, peer := range s.peers {
+		networks[0].dests[peer.Host] = peer.IP
+		if len(peer.ExternalIP) > 0 {
+			networks[1].dests[peer.Host] = peer.ExternalIP
+		}
+	}
+
+	for _, network := range networks {
+		if len(network.ip) == 0 {
+			continue
+		}
+		iface, mtu, err := s.mtu(ctx, network.ip)
+		if err != nil {
+			return err
+		}
+		s.curveadm.MemStorage().TX(func(kv *utils.SafeMap) error {
+			mtus := []HostMTU{}
+			if v := kv.Get(comm.KEY_ALL_HOST_MTU); v != nil {
+				mtus = v.([]HostMTU)
+			}
+			mtus = append(mtus, HostMTU{s.self.Host, network.name, network.ip, iface, mtu})
+			kv.Set(comm.KEY_ALL_HOST_MTU, mtus)
+			return nil
+		})
+
+		err = s.checkJumboFrame(ctx, mtu, network.dests)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *step2MeasureNetwork) Execute(ctx *context.Context) error {
+	err := s.measureMTU(ctx)
+	if err != nil {
+		return err
+	}
+
+	paths := []NetworkPath{}
+	for _, peer := range s.peers {
+		out, _, err := s.ping(ctx, peer.IP, 0)
+		if err != nil {
+			return err
+		}
+		loss, latency, jitter := parsePing(out)
+		if loss >= 100 {
+			return errno.ERR_DESTINATION_UNREACHABLE.
+				F("src=%s dest=%s(%s)", s.self.Host, peer.Host, peer.IP)
+		}
+
+		bandwidth, err := s.throughput(ctx, peer)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, NetworkPath{
+			Src:       s.self.Host,
+			Dst:       peer.Host,
+			Loss:      loss,
+			Latency:   latency,
+			Jitter:    jitter,
+			Bandwidth: bandwidth,
+		})
+	}
+
+	s.curveadm.MemStorage().TX(func(kv *utils.SafeMap) error {
+		if v := kv.Get(comm.KEY_ALL_NETWORK_PATHS); v != nil {
+			paths = append(v.([]NetworkPath), paths...)
+		}
+		kv.Set(comm.KEY_ALL_NETWORK_PATHS, paths)
+		return nil
+	})
+	return nil
+}
+
+func checkMTU(mtus []HostMTU) error {
+	for _, network := range []string{NETWORK_INTERNAL, NETWORK_EXTERNAL} {
+		values := map[int]bool{}
+		items := []string{}
+		for _, m := range mtus {
+			if m.Network == network {
+				values[m.MTU] = true
+				items = append(items, fmt.Sprintf("%s(%s)=%d", m.Host, m.Interface, m.MTU))
+			}
+		}
+		if len(values) > 1 {
+			sort.Strings(items)
+			return errno.ERR_INCONSISTENT_MTU.
+				F("network=%s %s", network, strings.Join(items, " "))
+		}
+	}
+	return nil
+}
+
+// report the path which exceeds thresholds or is an outlier among all paths
+func analyzeNetworkPaths(paths []NetworkPath) []Warning {
+	latencies, bandwidths := []float64{}, []float64{}
+	for _, p := range paths {
+		latencies = append(latencies, p.Latency)
+		if p.Bandwidth > 0 {
+			bandwidths = append(bandwidths, p.Bandwidth)
+		}
+	}
+	medianLatency, medianBandwidth := median(latencies), median(bandwidths)
+
+	sort.Slice(paths, func(i, j int) bool {
+		if paths[i].Src == paths[j].Src {
+			return paths[i].Dst < paths[j].Dst
+		}
+		return paths[i].Src < paths[j].Src
+	})
+
+	warnings := []Warning{}
+	warn := func(p NetworkPath, hint, format string, a ...interface{}) {
+		warnings = append(warnings, Warning{
+			Host:    p.Src,
+			Message: fmt.Sprintf("%s -> %s: ", p.Src, p.Dst) + fmt.Sprintf(format, a...),
+			Hint:    hint,
+		})
+	}
+	for _, p := range paths {
+		if p.Loss > 0 {
+			warn(p, "check the NIC, cable and switch port between the hosts",
+				"%.1f%% packet loss", p.Loss)
+		}
+		if p.Latency > MAX_LATENCY_MS || p.Latency > medianLatency*OUTLIER_FACTOR {
+			warn(p, "check the network path between the hosts, they should be in the same data center",
+				"latency %.3fms (threshold=%.3fms median=%.3fms)", p.Latency, MAX_LATENCY_MS, medianLatency)
+		}
+		if p.Jitter > MAX_JITTER_MS {
+			warn(p, "check whether the network or host is overloaded",
+				"jitter %.3fms (threshold=%.3fms)", p.Jitter, MAX_JITTER_MS)
+		}
+		if p.Bandwidth == 0 {
+			warn(p, "check whether the mock http server is reachable",
+				"throughput test failed")
+		} else if p.Bandwidth < MIN_BANDWIDTH_MBPS || p.Bandwidth < medianBandwidth/OUTLIER_FACTOR {
+			warn(p, "check the speed and duplex of NIC by ethtool",
+				"throughput %.0fMbit/s (threshold=%dMbit/s median=%.0fMbit/s)",
+				p.Bandwidth, MIN_BANDWIDTH_MBPS, medianBandwidth)
+		}
+	}
+	return warnings
+}
+
+func checkNetworkQuality(curveadm *cli.CurveAdm) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if v := curveadm.MemStorage().Get(comm.KEY_ALL_HOST_MTU); v != nil {
+			err := checkMTU(v.([]HostMTU))
+			if err != nil {
+				return err
+			}
+		}
+
+		v := curveadm.MemStorage().Get(comm.KEY_ALL_NETWORK_PATHS)
+		if v == nil {
+			return nil
+		}
+		warnings := analyzeNetworkPaths(v.([]NetworkPath))
+		for _, w := range warnings {
+			addWarning(curveadm, w.Host, "", w.Message, w.Hint)
+		}
+		if len(warnings) > 0 {
+			return task.ERR_WARN_TASK
+		}
+		return nil
+	}
+}
+
+func NewMeasureNetworkQualityTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	var self Endpoint
+	peers := []Endpoint{}
+	hosts := []string{}
+	dcs := curveadm.MemStorage().Get(comm.KEY_ALL_DEPLOY_CONFIGS).([]*topology.DeployConfig)
+	for _, endpoint := range getEndpoints(dcs) {
+		if endpoint.Host == dc.GetHost() {
+			self = endpoint
+		} else {
+			peers = append(peers, endpoint)
+			hosts = append(hosts, endpoint.Host)
+		}
+	}
+
+	subname := fmt.Sprintf("host=%s peers={%s}", dc.GetHost(), strings.Join(hosts, ","))
+	t := task.NewTask("Measure Network Quality <network>", subname, hc.GetSSHConfig())
+	t.AddStep(&step2MeasureNetwork{
+		curveadm:    curveadm,
+		containerId: getHTTPServerContainerName(curveadm, dc),
+		self:        self,
+		peers:       peers,
+		execOptions: curveadm.ExecOptions(),
+	})
+
+	return t, nil
+}
+
+func NewCheckNetworkQualityTask(curveadm *cli.CurveAdm, c interface{}) (*task.Task, error) {
+	t := task.NewTask("Check Network Quality <network>", "", nil)
+	t.AddStep(&step.Lambda{
+		Lambda: checkNetworkQuality(curveadm),
+	})
+	return t, nil
+}
@@ -0,0 +1,105 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNetworkQuality(t *testing.T) {
+	assert := assert.New(t)
+
+	loss, latency, jitter := parsePing(`PING 10.0.1.2 (10.0.1.2) 56(84) bytes of data.
+64 bytes from 10.0.1.2: icmp_seq=1 ttl=64 time=0.045 ms
+
+--- 10.0.1.2 ping statistics ---
+5 packets transmitted, 4 received, 20% packet loss, time 803ms
+rtt min/avg/max/mdev = 0.045/0.060/0.081/0.013 ms`)
+	assert.Equal(20.0, loss)
+	assert.Equal(0.060, latency)
+	assert.Equal(0.013, jitter)
+
+	loss, _, _ = parsePing("5 packets transmitted, 0 received, 100% packet loss, time 4096ms")
+	assert.Equal(100.0, loss)
+	loss, _, _ = parsePing("")
+	assert.Equal(100.0, loss)
+
+	assert.Equal("eth0", parseInterface(
+		"2: eth0    inet 10.0.1.1/24 brd 10.0.1.255 scope global eth0\\       valid_lft forever"))
+	assert.Equal("eth0", parseInterface("3: eth0@if5    inet 172.17.0.2/16 scope global eth0"))
+	assert.Equal("", parseInterface(""))
+}
+
+func TestCheckMTU(t *testing.T) {
+	assert := assert.New(t)
+
+	mtus := []HostMTU{
+		{Host: "host1", Network: NETWORK_INTERNAL, Interface: "eth0", MTU: 1500},
+		{Host: "host2", Network: NETWORK_INTERNAL, Interface: "eth0", MTU: 1500},
+		{Host: "host1", Network: NETWORK_EXTERNAL, Interface: "eth1", MTU: 9000},
+		{Host: "host2", Network: NETWORK_EXTERNAL, Interface: "eth1", MTU: 9000},
+	}
+	assert.Nil(checkMTU(mtus))
+
+	mtus[3].MTU = 1500
+	err := checkMTU(mtus)
+	assert.NotNil(err)
+	assert.Equal(errno.ERR_INCONSISTENT_MTU.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
+
+func TestAnalyzeNetworkPaths(t *testing.T) {
+	assert := assert.New(t)
+
+	good := func(src, dst string) NetworkPath {
+		return NetworkPath{Src: src, Dst: dst, Latency: 0.1, Jitter: 0.02, Bandwidth: 9400}
+	}
+	paths := []NetworkPath{
+		good("host1", "host2"), good("host1", "host3"),
+		good("host2", "host1"), good("host2", "host3"),
+		good("host3", "host1"), good("host3", "host2"),
+	}
+	assert.Len(analyzeNetworkPaths(paths), 0)
+
+	// outliers compared with other paths
+	paths[2].Latency = 0.5
+	paths[3].Bandwidth = 2000
+	warnings := analyzeNetworkPaths(paths)
+	assert.Len(warnings, 2)
+	assert.Equal("host2", warnings[0].Host)
+	assert.Contains(warnings[0].Message, "host2 -> host1: latency")
+	assert.Contains(warnings[1].Message, "host2 -> host3: throughput")
+
+	// exceed thresholds
+	paths = []NetworkPath{good("host1", "host2"), good("host2", "host1")}
+	paths[0].Loss = 20
+	paths[0].Jitter = 1
+	paths[1].Bandwidth = 0
+	warnings = analyzeNetworkPaths(paths)
+	assert.Len(warnings, 3)
+	for _, w := range warnings {
+		assert.NotEmpty(w.Hint)
+	}
+}
@@ -0,0 +1,312 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package checker
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	PROC_MEMINFO    = "/proc/meminfo"
+	PROC_SWAPPINESS = "/proc/sys/vm/swappiness"
+	PROC_FILE_MAX   = "/proc/sys/fs/file-max"
+	SYS_THP_ENABLED = "/sys/kernel/mm/transparent_hugepage/enabled"
+
+	CMD_NPROC  = "nproc"
+	CMD_NOFILE = "bash -c 'ulimit -n'"
+
+	REGEX_MEMINFO_ITEM = `^(\w+):\s+(\d+) kB$`
+	REGEX_THP_SELECTED = `\[(\w+)\]`
+	UNLIMITED          = "unlimited"
+
+	// MemTotal excludes the memory reserved by kernel,
+	// so a 8GiB host satisfies the requirement of 8GiB
+	MEMORY_TOLERANCE_PERCENT = 90
+)
+
+type (
+	// requirement of all services which deployed on the same host
+	Requirement struct {
+		CPU    int
+		Memory uint64 // bytes
+		Nofile int
+		Roles  map[string]int // role: number of services
+	}
+
+	HostResource struct {
+		CPU        int
+		Memory     uint64 // bytes
+		Swap       uint64 // bytes
+		Swappiness int
+		THP        string // always, madvise, never
+		Nofile     int    // -1 means unlimited
+		FileMax    int
+	}
+
+	step2CheckHostResource struct {
+		curveadm    *cli.CurveAdm
+		dc          *topology.DeployConfig
+		requirement Requirement
+		execOptions module.ExecOptions
+	}
+)
+
+func getRequirement(curveadm *cli.CurveAdm, host string) Requirement {
+	cfg := curveadm.Config()
+	r := Requirement{
+		Nofile: cfg.GetMinNofile(),
+		Roles:  map[string]int{},
+	}
+	dcs := curveadm.MemStorage().Get(comm.KEY_ALL_DEPLOY_CONFIGS).([]*topology.DeployConfig)
+	for _, dc := range dcs {
+		if dc.GetHost() != host {
+			continue
+		}
+		role := dc.GetRole()
+		r.CPU += cfg.GetMinCPU(role)
+		r.Memory += uint64(cfg.GetMinMemory(role)) * humanize.GiByte
+		r.Roles[role]++
+	}
+	return r
+}
+
+func parseMeminfo(out string) (uint64, uint64, error) {
+	regex := regexp.MustCompile(REGEX_MEMINFO_ITEM)
+	m := map[string]uint64{}
+	for _, line := range strings.Split(out, "\n") {
+		mu := regex.FindStringSubmatch(strings.TrimSpace(line))
+		if len(mu) == 0 {
+			continue
+		}
+		n, _ := strconv.ParseUint(mu[2], 10, 64)
+		m[mu[1]] = n * 1024
+	}
+
+	memory, ok := m["MemTotal"]
+	if !ok {
+		return 0, 0, errno.ERR_UNRECOGNIZED_HOST_RESOURCE.
+			F("%s: %s", PROC_MEMINFO, out)
+	}
+	return memory, m["SwapTotal"], nil
+}
+
+// always [madvise] never => madvise
+func parseTHP(out string) string {
+	mu := regexp.MustCompile(REGEX_THP_SELECTED).FindStringSubmatch(out)
+	if len(mu) == 0 {
+		return ""
+	}
+	return mu[1]
+}
+
+func parseNofile(out string) (int, error) {
+	out = strings.TrimSpace(out)
+	if out == UNLIMITED {
+		return -1, nil
+	}
+	n, err := strconv.Atoi(out)
+	if err != nil {
+		return 0, errno.ERR_UNRECOGNIZED_HOST_RESOURCE.
+			F("ulimit -n: %s", out)
+	}
+	return n, nil
+}
+
+/*
+ * memory: failed if less than requirement
+ * cpu, nofile, swap, transparent hugepage: warning
+ */
+func checkHostResource(r Requirement, hr HostResource) ([]Warning, error) {
+	warnings := []Warning{}
+	warn := func(hint, format string, a ...interface{}) {
+		warnings = append(warnings, Warning{
+			Message: fmt.Sprintf(format, a...),
+			Hint:    hint,
+		})
+	}
+
+	if hr.Memory < r.Memory*MEMORY_TOLERANCE_PERCENT/100 {
+		return nil, errno.ERR_HOST_NO_ENOUGH_MEMORY.
+			F("memory=%s required=%s (%s), add memory or reduce services on this host",
+				humanize.IBytes(hr.Memory), humanize.IBytes(r.Memory), formatRoles(r.Roles))
+	}
+
+	if hr.CPU < r.CPU {
+		warn("add CPU cores or reduce services on this host",
+			"%d CPU cores is less than the required %d (%s)", hr.CPU, r.CPU, formatRoles(r.Roles))
+	}
+	if hr.Nofile >= 0 && hr.Nofile < r.Nofile {
+		warn("raise 'nofile' in /etc/security/limits.conf and LimitNOFILE of container engine service",
+			"open files limit %d is less than %d", hr.Nofile, r.Nofile)
+	}
+	if hr.FileMax > 0 && hr.FileMax < r.Nofile {
+		warn(fmt.Sprintf("sysctl -w fs.file-max=%d", r.Nofile),
+			"fs.file-max %d is less than %d", hr.FileMax, r.Nofile)
+	}
+	if hr.Swap > 0 && hr.Swappiness > 0 {
+		warn("run 'swapoff -a' and remove swap entries from /etc/fstab, or 'sysctl -w vm.swappiness=0'",
+			"swap is enabled (size=%s, vm.swappiness=%d)", humanize.IBytes(hr.Swap), hr.Swappiness)
+	}
+	if hr.THP == "always" {
+		warn(fmt.Sprintf("echo never > %s", SYS_THP_ENABLED),
+			"transparent hugepage is enabled (%s)", hr.THP)
+	}
+	return warnings, nil
+}
+
+func formatRoles(roles map[string]int) string {
+	items := []string{}
+	for _, role := range comm.ROLES {
+		if roles[role] > 0 {
+			items = append(items, fmt.Sprintf("%s*%d", role, roles[role]))
+		}
+	}
+	return strings.Join(items, " ")
+}
+
+func (s *step2CheckHostResource) cat(ctx *context.Context, file string) (string, bool, error) {
+	var out string
+	var success bool
+	err := (&step.Cat{
+		Files:       []string{file},
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	return out, success, err
+}
+
+func (s *step2CheckHostResource) command(ctx *context.Context, command string) (string, error) {
+	var out string
+	err := (&step.Command{
+		Command:     command,
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	return out, err
+}
+
+func (s *step2CheckHostResource) gather(ctx *context.Context) (HostResource, error) {
+	hr := HostResource{}
+
+	// cpu
+	out, err := s.command(ctx, CMD_NPROC)
+	if err != nil {
+		return hr, err
+	} else if hr.CPU, err = strconv.Atoi(strings.TrimSpace(out)); err != nil {
+		return hr, errno.ERR_UNRECOGNIZED_HOST_RESOURCE.F("nproc: %s", out)
+	}
+
+	// memory and swap
+	out, _, err = s.cat(ctx, PROC_MEMINFO)
+	if err != nil {
+		return hr, err
+	} else if hr.Memory, hr.Swap, err = parseMeminfo(out); err != nil {
+		return hr, err
+	}
+	out, success, err := s.cat(ctx, PROC_SWAPPINESS)
+	if err != nil {
+		return hr, err
+	} else if success {
+		hr.Swappiness, _ = strconv.Atoi(strings.TrimSpace(out))
+	}
+
+	// transparent hugepage (not exist if kernel built without it)
+	out, success, err = s.cat(ctx, SYS_THP_ENABLED)
+	if err != nil {
+		return hr, err
+	} else if success {
+		hr.THP = parseTHP(out)
+	}
+
+	// open files
+	out, err = s.command(ctx, CMD_NOFILE)
+	if err != nil {
+		return hr, err
+	} else if hr.Nofile, err = parseNofile(out); err != nil {
+		return hr, err
+	}
+	out, success, err = s.cat(ctx, PROC_FILE_MAX)
+	if err != nil {
+		return hr, err
+	} else if success {
+		hr.FileMax, _ = strconv.Atoi(strings.TrimSpace(out))
+	}
+
+	return hr, nil
+}
+
+func (s *step2CheckHostResource) Execute(ctx *context.Context) error {
+	hr, err := s.gather(ctx)
+	if err != nil {
+		return err
+	}
+
+	warnings, err := checkHostResource(s.requirement, hr)
+	if err != nil {
+		return err
+	}
+	for _, w := range warnings {
+		addWarning(s.curveadm, s.dc.GetHost(), "", w.Message, w.Hint)
+	}
+	if len(warnings) > 0 {
+		return task.ERR_WARN_TASK
+	}
+	return nil
+}
+
+func NewCheckHostResourceTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	r := getRequirement(curveadm, dc.GetHost())
+	subname := fmt.Sprintf("host=%s require=(cpu>=%d,memory>=%dGiB,nofile>=%d)",
+		dc.GetHost(), r.CPU, r.Memory/humanize.GiByte, r.Nofile)
+	t := task.NewTask("Check Host Resource <resource>", subname, hc.GetSSHConfig())
+
+	// add step to task
+	t.AddStep(&step2CheckHostResource{
+		curveadm:    curveadm,
+		dc:          dc,
+		requirement: r,
+		execOptions: curveadm.ExecOptions(),
+	})
+
+	return t, nil
+}
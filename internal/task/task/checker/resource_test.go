@@ -0,0 +1,104 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/dustin/go-humanize"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHostResource(t *testing.T) {
+	assert := assert.New(t)
+
+	memory, swap, err := parseMeminfo(`MemTotal:        8009104 kB
+MemFree:          215560 kB
+SwapTotal:       2097148 kB
+SwapFree:        2097148 kB`)
+	assert.Nil(err)
+	assert.Equal(uint64(8009104*1024), memory)
+	assert.Equal(uint64(2097148*1024), swap)
+	_, _, err = parseMeminfo("")
+	assert.NotNil(err)
+
+	assert.Equal("madvise", parseTHP("always [madvise] never\n"))
+	assert.Equal("always", parseTHP("[always] madvise never"))
+	assert.Equal("", parseTHP(""))
+
+	nofile, err := parseNofile("65535\n")
+	assert.Nil(err)
+	assert.Equal(65535, nofile)
+	nofile, err = parseNofile("unlimited")
+	assert.Nil(err)
+	assert.Equal(-1, nofile)
+}
+
+func TestCheckHostResource(t *testing.T) {
+	assert := assert.New(t)
+
+	r := Requirement{
+		CPU:    4,
+		Memory: 8 * humanize.GiByte,
+		Nofile: 65535,
+		Roles:  map[string]int{ROLE_CHUNKSERVER: 2},
+	}
+	good := HostResource{
+		CPU:        8,
+		Memory:     7800 * humanize.MiByte, // MemTotal of 8GiB host
+		Swappiness: 60,
+		THP:        "madvise",
+		Nofile:     1048576,
+		FileMax:    9223372036854775807,
+	}
+	warnings, err := checkHostResource(r, good)
+	assert.Nil(err)
+	assert.Len(warnings, 0)
+
+	// warnings
+	hr := good
+	hr.CPU = 2
+	hr.Swap = humanize.GiByte
+	hr.THP = "always"
+	hr.Nofile = 1024
+	warnings, err = checkHostResource(r, hr)
+	assert.Nil(err)
+	assert.Len(warnings, 4)
+	for _, w := range warnings {
+		assert.NotEmpty(w.Hint)
+	}
+
+	// swap is enabled but never used
+	hr = good
+	hr.Swap = humanize.GiByte
+	hr.Swappiness = 0
+	warnings, _ = checkHostResource(r, hr)
+	assert.Len(warnings, 0)
+
+	// failed
+	hr = good
+	hr.Memory = 4 * humanize.GiByte
+	_, err = checkHostResource(r, hr)
+	assert.Equal(errno.ERR_HOST_NO_ENOUGH_MEMORY.GetCode(), err.(*errno.ErrorCode).GetCode())
+}
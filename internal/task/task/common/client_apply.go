@@ -0,0 +1,160 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package common
+
+import (
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/internal/task/task/fs"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	CLIENT_APPLY_ACTION_MAP    = "map"
+	CLIENT_APPLY_ACTION_UNMAP  = "unmap"
+	CLIENT_APPLY_ACTION_MOUNT  = "mount"
+	CLIENT_APPLY_ACTION_UMOUNT = "umount"
+)
+
+// ClientApplyItem is one map/unmap/mount/umount which generated by
+// diffing the client manifest against the clients table
+type ClientApplyItem struct {
+	Id           string
+	Action       string
+	Config       *configure.ClientConfig // only for map and mount
+	MapOptions   bs.MapOptions
+	MountOptions fs.MountOptions
+}
+
+// the result will be absent if the task failed
+func setApplyResult(curveadm *cli.CurveAdm, id string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		curveadm.MemStorage().TX(func(kv *utils.SafeMap) error {
+			m := map[string]bool{}
+			v := kv.Get(comm.KEY_CLIENT_APPLY_RESULTS)
+			if v != nil {
+				m = v.(map[string]bool)
+			}
+			m[id] = true
+			kv.Set(comm.KEY_CLIENT_APPLY_RESULTS, m)
+			return nil
+		})
+		return nil
+	}
+}
+
+/*
+ * the client is recorded before the volume mapped or filesystem mounted,
+ * so we remove the record and its container if the item failed, otherwise
+ * the next apply will regard it as applied
+ */
+func cleanFailedApply(curveadm *cli.CurveAdm, id string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if GetApplyResults(curveadm)[id] {
+			return nil
+		}
+
+		clients, err := curveadm.Storage().GetClient(id)
+		if err != nil {
+			return errno.ERR_GET_CLIENT_BY_ID_FAILED.E(err)
+		} else if len(clients) == 0 {
+			return nil
+		}
+
+		containerId := clients[0].ContainerId
+		if len(containerId) > 0 {
+			steps := []task.Step{
+				&step.StopContainer{
+					ContainerId: containerId,
+					ExecOptions: curveadm.ExecOptions(),
+				},
+				&step.RemoveContainer{
+					ContainerId: containerId,
+					ExecOptions: curveadm.ExecOptions(),
+				},
+			}
+			for _, step := range steps {
+				if err := step.Execute(ctx); err != nil {
+					return err
+				}
+			}
+		}
+
+		err = curveadm.Storage().DeleteClient(id)
+		if err != nil {
+			return errno.ERR_DELETE_CLIENT_FAILED.E(err)
+		}
+		err = curveadm.Storage().DeleteClientConfig(id)
+		if err != nil {
+			return errno.ERR_DELETE_CLIENT_CONFIG_FAILED.E(err)
+		}
+		return nil
+	}
+}
+
+// GetApplyResults returns ids of the items which applied successfully
+func GetApplyResults(curveadm *cli.CurveAdm) map[string]bool {
+	v := curveadm.MemStorage().Get(comm.KEY_CLIENT_APPLY_RESULTS)
+	if v == nil {
+		return map[string]bool{}
+	}
+	return v.(map[string]bool)
+}
+
+func NewApplyClientTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
+	item := v.(ClientApplyItem)
+	var t *task.Task
+	var err error
+	switch item.Action {
+	case CLIENT_APPLY_ACTION_MAP:
+		t, err = bs.NewMapVolumeTask(curveadm, item.Config, item.MapOptions)
+	case CLIENT_APPLY_ACTION_UNMAP:
+		t, err = bs.NewUnmapVolumeTask(curveadm, item.MapOptions)
+	case CLIENT_APPLY_ACTION_MOUNT:
+		t, err = fs.NewMountFilesystemTask(curveadm, item.Config, item.MountOptions)
+	case CLIENT_APPLY_ACTION_UMOUNT:
+		t, err = fs.NewUmountFilesystemTask(curveadm, item.MountOptions)
+	default:
+		return nil, errno.ERR_UNKNOWN_TASK_TYPE.F("action: %s", item.Action)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	t.AddStep(&step.Lambda{
+		Lambda: setApplyResult(curveadm, item.Id),
+	})
+	if item.Action == CLIENT_APPLY_ACTION_MAP ||
+		item.Action == CLIENT_APPLY_ACTION_MOUNT {
+		t.AddPostStep(&step.Lambda{
+			Lambda: cleanFailedApply(curveadm, item.Id),
+		})
+	}
+	return t, nil
+}
@@ -24,16 +24,21 @@ package common
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
 	"github.com/opencurve/curveadm/internal/storage"
 	"github.com/opencurve/curveadm/internal/task/context"
 	"github.com/opencurve/curveadm/internal/task/step"
 	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/internal/task/task/fs"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
 	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
 )
 
 const (
@@ -53,12 +58,20 @@ type (
 		memStorage *utils.SafeMap
 	}
 
+	step2CheckClientDrift struct {
+		client      storage.Client
+		status      *string
+		memStorage  *utils.SafeMap
+		execOptions module.ExecOptions
+	}
+
 	ClientStatus struct {
 		Id          string
 		Host        string
 		Kind        string
 		ContainerId string
 		Status      string
+		Drift       string
 		AuxInfo     string
 		CfgPath     string
 	}
@@ -109,6 +122,7 @@ func (s *step2InitClientStatus) Execute(ctx *context.Context) error {
 		Kind:        client.Kind,
 		ContainerId: client.ContainerId,
 		Status:      comm.CLIENT_STATUS_UNKNOWN,
+		Drift:       comm.CLIENT_DRIFT_UNKNOWN,
 		AuxInfo:     client.AuxInfo,
 		CfgPath:     *s.cfgPath,
 	})
@@ -136,6 +150,86 @@ func (s *step2FormatClientStatus) Execute(ctx *context.Context) error {
 	return nil
 }
 
+func (s *step2CheckClientDrift) check(ctx *context.Context) ([]string, error) {
+	client := s.client
+	status := *s.status
+	if len(status) == 0 {
+		return []string{comm.CLIENT_DRIFT_CONTAINER_LOSED}, nil
+	} else if !strings.HasPrefix(status, "Up") {
+		return []string{comm.CLIENT_DRIFT_CONTAINER_DOWN}, nil
+	}
+
+	drifts := []string{}
+	var persist bool
+	switch client.Kind {
+	case topology.KIND_CURVEBS:
+		auxInfo, err := bs.DecodeAuxInfo(client)
+		if err != nil {
+			return nil, err
+		}
+		mapped, err := bs.IsVolumeMapped(ctx, client.ContainerId,
+			auxInfo.User, auxInfo.Volume, s.execOptions)
+		if err != nil {
+			return nil, err
+		} else if !mapped {
+			drifts = append(drifts, comm.CLIENT_DRIFT_NOT_MAPPED)
+		}
+		persist = auxInfo.Persist
+	case topology.KIND_CURVEFS:
+		auxInfo, err := fs.DecodeAuxInfo(client)
+		if err != nil {
+			return nil, err
+		}
+		mounted, err := fs.IsMounted(ctx, auxInfo.MountPoint, s.execOptions)
+		if err != nil {
+			return nil, err
+		} else if !mounted {
+			drifts = append(drifts, comm.CLIENT_DRIFT_NOT_MOUNTED)
+		}
+		persist = auxInfo.Persist
+	default:
+		return nil, errno.ERR_UNSUPPORT_CLIENT_KIND.F("kind: %s", client.Kind)
+	}
+
+	if persist {
+		var enabled bool
+		(&step.Systemctl{
+			Action:      "is-enabled",
+			Units:       []string{task.ClientUnitName(client.Id)},
+			Success:     &enabled,
+			ExecOptions: s.execOptions,
+		}).Execute(ctx)
+		if !enabled {
+			drifts = append(drifts, comm.CLIENT_DRIFT_UNIT_DISABLED)
+		}
+	}
+	return drifts, nil
+}
+
+func (s *step2CheckClientDrift) Execute(ctx *context.Context) error {
+	drift := comm.CLIENT_DRIFT_NONE
+	drifts, err := s.check(ctx)
+	if err != nil {
+		drift = comm.CLIENT_DRIFT_UNKNOWN
+	} else if len(drifts) > 0 {
+		drift = strings.Join(drifts, ", ")
+	}
+
+	id := s.client.Id
+	s.memStorage.TX(func(kv *utils.SafeMap) error {
+		v := kv.Get(comm.KEY_ALL_CLIENT_STATUS)
+		m := v.(map[string]ClientStatus)
+
+		// update the drift
+		s := m[id]
+		s.Drift = drift
+		m[id] = s
+		kv.Set(comm.KEY_ALL_CLIENT_STATUS, m)
+		return nil
+	})
+	return nil
+}
+
 func NewInitClientStatusTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
 	client := v.(storage.Client)
 
@@ -180,6 +274,23 @@ func NewGetClientStatusTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task,
 		status:     &status,
 		memStorage: curveadm.MemStorage(),
 	})
+	t.AddStep(&step2CheckClientDrift{
+		client:      client,
+		status:      &status,
+		memStorage:  curveadm.MemStorage(),
+		execOptions: curveadm.ExecOptions(),
+	})
 
 	return t, nil
 }
+
+func NewRepairClientTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
+	client := v.(storage.Client)
+	switch client.Kind {
+	case topology.KIND_CURVEBS:
+		return bs.NewRepairMapTask(curveadm, client)
+	case topology.KIND_CURVEFS:
+		return fs.NewRepairMountTask(curveadm, client)
+	}
+	return nil, errno.ERR_UNSUPPORT_CLIENT_KIND.F("kind: %s", client.Kind)
+}
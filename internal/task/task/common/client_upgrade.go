@@ -0,0 +1,91 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package common
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/task/task/bs"
+	"github.com/opencurve/curveadm/internal/task/task/fs"
+)
+
+// the client configure which used to map or mount is stored in database
+func getClientConfig(curveadm *cli.CurveAdm, client storage.Client) (*configure.ClientConfig, error) {
+	cfgs, err := curveadm.Storage().GetClientConfig(client.Id)
+	if err != nil {
+		return nil, errno.ERR_SELECT_CLIENT_CONFIG_FAILED.E(err)
+	} else if len(cfgs) == 0 {
+		return nil, errno.ERR_CLIENT_CONFIGURE_NOT_FOUND.F("id: %s", client.Id)
+	}
+
+	return configure.ParseClientCfg(cfgs[0].Data)
+}
+
+// GetClientImage returns the image which client is running with, the image
+// recorded in aux info takes precedence over the one in configure, and the
+// nebd-server upgraded in place takes precedence over its container
+func GetClientImage(curveadm *cli.CurveAdm, client storage.Client) (string, error) {
+	auxInfo := struct {
+		Image     string `json:"image"`
+		NEBDImage string `json:"nebd_image"`
+	}{}
+	err := json.Unmarshal([]byte(client.AuxInfo), &auxInfo)
+	if err == nil && len(auxInfo.NEBDImage) > 0 {
+		return auxInfo.NEBDImage, nil
+	} else if err == nil && len(auxInfo.Image) > 0 {
+		return auxInfo.Image, nil
+	}
+
+	cc, err := getClientConfig(curveadm, client)
+	if err != nil {
+		return "", err
+	}
+	return cc.GetContainerImage(), nil
+}
+
+func NewUpgradeClientTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
+	client := v.(storage.Client)
+	cc, err := getClientConfig(curveadm, client)
+	if err != nil {
+		return nil, err
+	}
+	image := curveadm.MemStorage().Get(comm.KEY_CLIENT_UPGRADE_IMAGE).(string)
+	cc.SetContainerImage(image)
+
+	switch client.Kind {
+	case topology.KIND_CURVEBS:
+		return bs.NewUpgradeMapTask(curveadm, client, cc)
+	case topology.KIND_CURVEFS:
+		drainWait := curveadm.MemStorage().Get(comm.KEY_CLIENT_DRAIN_WAIT).(time.Duration)
+		return fs.NewUpgradeMountTask(curveadm, client, cc, drainWait)
+	}
+	return nil, errno.ERR_UNSUPPORT_CLIENT_KIND.F("kind: %s", client.Kind)
+}
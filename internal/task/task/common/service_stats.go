@@ -0,0 +1,150 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	FORMAT_CONTAINER_STATS = `"{{.CPUPerc}}|{{.MemUsage}}|{{.MemPerc}}"`
+	// glog prefix of error/fatal line, e.g: E1019 10:00:00.123456 12 mds.cpp:100] ...
+	// only the newest ERROR log is scanned, which includes the fatal lines
+	CMD_RECENT_ERRORS    = `bash -c 'f=$(ls -t %s/*.ERROR* 2>/dev/null | head -n 1); test -z "$f" || grep -hsE "^[EF][0-9]{4} " "$f" | tail -n %d'`
+	NUMBER_RECENT_ERRORS = 3
+)
+
+type (
+	step2FormatServiceStats struct {
+		serviceId  string
+		stats      *string
+		statsOk    *bool
+		errors     *string
+		errorsOk   *bool
+		memStorage *utils.SafeMap
+	}
+
+	ServiceStats struct {
+		Id         string
+		CPU        string   // e.g. 1.25%
+		Memory     string   // e.g. 120.5MiB / 15.5GiB
+		MemPercent string   // e.g. 0.76%
+		Errors     []string // recent error lines in service log
+	}
+)
+
+func setServiceStats(memStorage *utils.SafeMap, id string, stats ServiceStats) {
+	memStorage.TX(func(kv *utils.SafeMap) error {
+		m := map[string]ServiceStats{}
+		v := kv.Get(comm.KEY_ALL_SERVICE_STATS)
+		if v != nil {
+			m = v.(map[string]ServiceStats)
+		}
+		m[id] = stats
+		kv.Set(comm.KEY_ALL_SERVICE_STATS, m)
+		return nil
+	})
+}
+
+// e.g. 1.25%|120.5MiB / 15.5GiB|0.76%
+func parseContainerStats(out string) (cpu, memory, memPercent string) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	items := strings.Split(lines[len(lines)-1], "|")
+	if len(items) != 3 {
+		return "-", "-", "-"
+	}
+	return strings.TrimSpace(items[0]), strings.TrimSpace(items[1]), strings.TrimSpace(items[2])
+}
+
+func (s *step2FormatServiceStats) Execute(ctx *context.Context) error {
+	stats := ServiceStats{Id: s.serviceId, CPU: "-", Memory: "-", MemPercent: "-", Errors: []string{}}
+	if *s.statsOk {
+		stats.CPU, stats.Memory, stats.MemPercent = parseContainerStats(*s.stats)
+	}
+	for _, line := range strings.Split(*s.errors, "\n") {
+		if *s.errorsOk && len(strings.TrimSpace(line)) > 0 {
+			stats.Errors = append(stats.Errors, line)
+		}
+	}
+	setServiceStats(s.memStorage, s.serviceId, stats)
+	return nil
+}
+
+func NewGetServiceStatsTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if curveadm.IsSkip(dc) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else if containerId == comm.CLEANED_CONTAINER_ID {
+		return nil, nil
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Get Service Stats", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var stats, errors string
+	var statsOk, errorsOk bool
+	t.AddStep(&step.ContainerStats{
+		ContainerId: containerId,
+		Format:      FORMAT_CONTAINER_STATS,
+		Out:         &stats,
+		Success:     &statsOk,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     fmt.Sprintf(CMD_RECENT_ERRORS, dc.GetProjectLayout().ServiceLogDir, NUMBER_RECENT_ERRORS),
+		Out:         &errors,
+		Success:     &errorsOk, // container maybe stopped
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step2FormatServiceStats{
+		serviceId:  serviceId,
+		stats:      &stats,
+		statsOk:    &statsOk,
+		errors:     &errors,
+		errorsOk:   &errorsOk,
+		memStorage: curveadm.MemStorage(),
+	})
+
+	return t, nil
+}
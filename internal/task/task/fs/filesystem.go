@@ -0,0 +1,297 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package fs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	FS_OP_LIST      = "list"
+	FS_OP_INFO      = "info"
+	FS_OP_CREATE    = "create"
+	FS_OP_DELETE    = "delete"
+	FS_OP_SET_QUOTA = "set_quota"
+	FS_OP_GET_QUOTA = "get_quota"
+
+	FSTYPE_S3     = "s3"
+	FSTYPE_VOLUME = "volume"
+	FSTYPE_HYBRID = "hybrid"
+)
+
+type (
+	FSOptions struct {
+		Op         string
+		FSName     string
+		FSType     string
+		VolumeUser string // volume, hybrid
+		VolumeName string
+		VolumeSize uint64 // bytes
+		QuotaPath  string // empty means the whole filesystem
+		Capacity   uint64 // GiB
+		Inodes     uint64
+	}
+
+	Filesystem struct {
+		Id        uint64   `json:"id"`
+		Name      string   `json:"name"`
+		Type      string   `json:"type"`
+		Status    string   `json:"status"`
+		BlockSize uint64   `json:"block_size"`
+		Capacity  uint64   `json:"capacity"`
+		MountNum  uint64   `json:"mount_num"` // reported by mds
+		MountedBy []string `json:"mounted_by,omitempty"`
+	}
+
+	Quota struct {
+		FSName     string `json:"fsname"`
+		Path       string `json:"path"`
+		Capacity   uint64 `json:"capacity"` // bytes, 0 means unlimited
+		Inodes     uint64 `json:"inodes"`   // 0 means unlimited
+		UsedBytes  uint64 `json:"used_bytes"`
+		UsedInodes uint64 `json:"used_inodes"`
+	}
+
+	// FsInfo of curvefs which printed by tools-v2
+	fsInfo struct {
+		FsId      task.ProtoUint64 `json:"fsId"`
+		FsName    string           `json:"fsName"`
+		FsType    string           `json:"fsType"`
+		Status    string           `json:"status"`
+		BlockSize task.ProtoUint64 `json:"blockSize"`
+		Capacity  task.ProtoUint64 `json:"capacity"`
+		MountNum  task.ProtoUint64 `json:"mountNum"`
+	}
+
+	quotaInfo struct {
+		MaxBytes   task.ProtoUint64 `json:"maxBytes"`
+		MaxInodes  task.ProtoUint64 `json:"maxInodes"`
+		UsedBytes  task.ProtoUint64 `json:"usedBytes"`
+		UsedInodes task.ProtoUint64 `json:"usedInodes"`
+	}
+
+	step2HandleFSOutput struct {
+		options    FSOptions
+		success    *bool
+		output     *string
+		memStorage *utils.SafeMap
+	}
+)
+
+var (
+	FS_OP_TITLE = map[string]string{
+		FS_OP_LIST:      "List FileSystems",
+		FS_OP_INFO:      "Get FileSystem Info",
+		FS_OP_CREATE:    "Create FileSystem",
+		FS_OP_DELETE:    "Delete FileSystem",
+		FS_OP_SET_QUOTA: "Set FileSystem Quota",
+		FS_OP_GET_QUOTA: "Get FileSystem Quota",
+	}
+
+	FS_OP_ERRNO = map[string]*errno.ErrorCode{
+		FS_OP_LIST:      errno.ERR_LIST_FILESYSTEMS_FAILED,
+		FS_OP_INFO:      errno.ERR_GET_FILESYSTEM_INFO_FAILED,
+		FS_OP_CREATE:    errno.ERR_CREATE_FILESYSTEM_FAILED,
+		FS_OP_DELETE:    errno.ERR_DELETE_FILESYSTEM_FAILED,
+		FS_OP_SET_QUOTA: errno.ERR_SET_FILESYSTEM_QUOTA_FAILED,
+		FS_OP_GET_QUOTA: errno.ERR_GET_FILESYSTEM_QUOTA_FAILED,
+	}
+)
+
+func (f fsInfo) filesystem() Filesystem {
+	return Filesystem{
+		Id:        uint64(f.FsId),
+		Name:      f.FsName,
+		Type:      strings.ToLower(strings.TrimPrefix(f.FsType, "TYPE_")),
+		Status:    f.Status,
+		BlockSize: uint64(f.BlockSize),
+		Capacity:  uint64(f.Capacity),
+		MountNum:  uint64(f.MountNum),
+	}
+}
+
+// e.g. fs create fs --fsname test --fstype s3 --format json
+func fsArgs(options FSOptions) string {
+	var args []string
+	switch options.Op {
+	case FS_OP_LIST:
+		args = []string{"list fs"}
+	case FS_OP_INFO:
+		args = []string{"query fs", "--fsname", options.FSName}
+	case FS_OP_CREATE:
+		args = []string{"create fs", "--fsname", options.FSName, "--fstype", options.FSType}
+		if options.FSType != FSTYPE_S3 {
+			args = append(args, "--volume.user", options.VolumeUser, "--volume.name", options.VolumeName)
+			if options.VolumeSize > 0 {
+				args = append(args, "--volume.size", strconv.FormatUint(options.VolumeSize, 10))
+			}
+		}
+	case FS_OP_DELETE:
+		args = []string{"delete fs", "--fsname", options.FSName, "--noconfirm"}
+	case FS_OP_SET_QUOTA, FS_OP_GET_QUOTA:
+		op := utils.Choose(options.Op == FS_OP_SET_QUOTA, "set", "get")
+		if len(options.QuotaPath) == 0 {
+			args = []string{"quota", op + "fs", "--fsname", options.FSName}
+		} else {
+			args = []string{"quota", op, "--fsname", options.FSName, "--path", options.QuotaPath}
+		}
+		if options.Op == FS_OP_SET_QUOTA && options.Capacity > 0 {
+			args = append(args, "--capacity", strconv.FormatUint(options.Capacity, 10))
+		}
+		if options.Op == FS_OP_SET_QUOTA && options.Inodes > 0 {
+			args = append(args, "--inodes", strconv.FormatUint(options.Inodes, 10))
+		}
+	}
+	return fmt.Sprintf("fs %s --format json", strings.Join(args, " "))
+}
+
+// unwrap {"key": ...} if the key exists
+func unwrap(data json.RawMessage, key string) json.RawMessage {
+	wrapper := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return data
+	} else if v, ok := wrapper[key]; ok {
+		return v
+	}
+	return data
+}
+
+// result of tools-v2 can be a fs info, a list of fs info or wrapped by "fsInfo"
+func decodeFSInfos(data json.RawMessage) ([]fsInfo, error) {
+	infos := []fsInfo{}
+	data = json.RawMessage(strings.TrimSpace(string(data)))
+	if len(data) == 0 || string(data) == "null" {
+		return infos, nil
+	} else if data[0] == '[' {
+		err := json.Unmarshal(data, &infos)
+		return infos, err
+	}
+
+	if inner := unwrap(data, "fsInfo"); string(inner) != string(data) {
+		return decodeFSInfos(inner)
+	}
+	info := fsInfo{}
+	err := json.Unmarshal(data, &info)
+	return append(infos, info), err
+}
+
+func decodeQuota(data json.RawMessage) (quotaInfo, error) {
+	info := quotaInfo{}
+	err := json.Unmarshal(unwrap(data, "quota"), &info)
+	return info, err
+}
+
+func (s *step2HandleFSOutput) Execute(ctx *context.Context) error {
+	options := s.options
+	ec := FS_OP_ERRNO[options.Op]
+	result, message, err := task.ParseToolsV2Output(*s.output)
+	if len(message) > 0 {
+		return ec.S(message)
+	} else if !*s.success {
+		return ec.S(*s.output)
+	}
+
+	switch options.Op {
+	case FS_OP_LIST, FS_OP_INFO:
+		var infos []fsInfo
+		if err == nil {
+			infos, err = decodeFSInfos(result)
+		}
+		if err != nil {
+			return errno.ERR_DECODE_FILESYSTEM_INFO_FAILED.E(err)
+		}
+		filesystems := []Filesystem{}
+		for _, info := range infos {
+			filesystems = append(filesystems, info.filesystem())
+		}
+		s.memStorage.Set(comm.KEY_ALL_FILESYSTEMS, filesystems)
+
+	case FS_OP_GET_QUOTA:
+		var info quotaInfo
+		if err == nil {
+			info, err = decodeQuota(result)
+		}
+		if err != nil {
+			return errno.ERR_DECODE_FILESYSTEM_INFO_FAILED.E(err)
+		}
+		s.memStorage.Set(comm.KEY_FS_QUOTA, Quota{
+			FSName:     options.FSName,
+			Path:       utils.Choose(len(options.QuotaPath) > 0, options.QuotaPath, "/"),
+			Capacity:   uint64(info.MaxBytes),
+			Inodes:     uint64(info.MaxInodes),
+			UsedBytes:  uint64(info.UsedBytes),
+			UsedInodes: uint64(info.UsedInodes),
+		})
+	}
+	return nil
+}
+
+func NewFSTask(curveadm *cli.CurveAdm, dc *topology.DeployConfig) (*task.Task, error) {
+	options := curveadm.MemStorage().Get(comm.KEY_FS_OPTIONS).(FSOptions)
+	serviceId := curveadm.GetServiceId(dc.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(dc.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		dc.GetHost(), dc.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask(FS_OP_TITLE[options.Op], subname, hc.GetSSHConfig())
+
+	// add step to task
+	var output string
+	var success bool
+	t.AddStep(&step.ContainerExec{
+		ContainerId: &containerId,
+		Command:     fmt.Sprintf("%s %s", dc.GetProjectLayout().ToolsV2BinaryPath, fsArgs(options)),
+		Success:     &success,
+		Out:         &output,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step2HandleFSOutput{
+		options:    options,
+		success:    &success,
+		output:     &output,
+		memStorage: curveadm.MemStorage(),
+	})
+
+	return t, nil
+}
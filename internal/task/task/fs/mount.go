@@ -23,10 +23,12 @@
 package fs
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"text/template"
 
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
@@ -44,6 +46,8 @@ import (
 const (
 	FORMAT_MOUNT_OPTION = "type=bind,source=%s,target=%s,bind-propagation=rshared"
 
+	PROCESS_CURVE_FUSE = "curve-fuse"
+
 	CLIENT_CONFIG_DELIMITER   = "="
 	TOOLS_V2_CONFIG_DELIMITER = ": "
 
@@ -58,6 +62,16 @@ type (
 		MountFSName string
 		MountFSType string
 		MountPoint  string
+		Persist     bool
+		Config      MountConfig
+	}
+
+	// MountConfig is the per-mount configure, it will be stored in
+	// client's aux info, so remount (e.g. upgrade) can reuse it
+	MountConfig struct {
+		FuseOptions []string          `json:"fuse_options,omitempty"` // key or key=value
+		Overrides   map[string]string `json:"overrides,omitempty"`    // override client.yaml items
+		ReadOnly    bool              `json:"read_only,omitempty"`
 	}
 
 	step2InsertClient struct {
@@ -67,32 +81,84 @@ type (
 		containerId *string
 	}
 
+	// the client finds an available port from the configured one for
+	// exposing metrics, we record the port it actually listens on
+	step2RecordMetricPort struct {
+		curveadm    *cli.CurveAdm
+		clientId    string
+		startPort   int
+		containerId *string
+	}
+
 	AuxInfo struct {
-		FSName     string `json:"fsname"`
-		MountPoint string `json:"mount_point,"`
-		Config     string `json:"config,omitempty"` // TODO(P1)
+		FSName     string       `json:"fsname"`
+		MountPoint string       `json:"mount_point,"`
+		FSType     string       `json:"fstype,omitempty"`
+		Image      string       `json:"image,omitempty"`
+		Persist    bool         `json:"persist,omitempty"`
+		MetricPort int          `json:"metric_port,omitempty"`
+		Config     *MountConfig `json:"config,omitempty"`
 	}
 )
 
+const (
+	TEMPLATE_FUSE_ARGS = "-f{{range .options}} -o {{.}}{{end}} {{.path}}"
+)
+
 var (
-	// TODO(P1): use template
-	FORMAT_FUSE_ARGS = []string{
-		"-f",
-		"-o default_permissions",
-		"-o allow_other",
-		"-o fsname=%s", // fsname
-		"-o fstype=%s", // fstype, `s3` or `volume`
-		"-o user=curvefs",
-		"-o conf=%s", // config path
-		"%s",         // mount path
+	// fuse options which user can't override
+	RESERVED_FUSE_OPTIONS = map[string]bool{
+		"fsname": true,
+		"fstype": true,
+		"conf":   true,
 	}
 )
 
-func getMountCommand(cc *configure.ClientConfig, mountFSName string, mountFSType string, mountPoint string) string {
-	format := strings.Join(FORMAT_FUSE_ARGS, " ")
-	fuseArgs := fmt.Sprintf(format, mountFSName, mountFSType,
-		configure.GetFSClientConfPath(), configure.GetFSClientMountPath(mountPoint))
-	return fmt.Sprintf("/client.sh %s %s --role=client --args='%s'", mountFSName, mountFSType, fuseArgs)
+func fuseOptionKey(option string) string {
+	return strings.SplitN(option, "=", 2)[0]
+}
+
+// default fuse options, and then the user specified options,
+// the option with the same key will be replaced
+func getFuseOptions(options MountOptions) []string {
+	fuseOptions := []string{
+		"default_permissions",
+		"allow_other",
+		"fsname=" + options.MountFSName,
+		"fstype=" + options.MountFSType, // `s3` or `volume`
+		"user=curvefs",
+		"conf=" + configure.GetFSClientConfPath(),
+	}
+	config := options.Config
+	if config.ReadOnly {
+		fuseOptions = append(fuseOptions, "ro")
+	}
+
+	for _, option := range config.FuseOptions {
+		replaced := false
+		for i, o := range fuseOptions {
+			if fuseOptionKey(o) == fuseOptionKey(option) {
+				fuseOptions[i] = option
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			fuseOptions = append(fuseOptions, option)
+		}
+	}
+	return fuseOptions
+}
+
+func getMountCommand(options MountOptions) string {
+	var buffer bytes.Buffer
+	tmpl := template.Must(template.New("fuse").Parse(TEMPLATE_FUSE_ARGS))
+	tmpl.Execute(&buffer, map[string]interface{}{
+		"options": getFuseOptions(options),
+		"path":    configure.GetFSClientMountPath(options.MountPoint),
+	})
+	return fmt.Sprintf("/client.sh %s %s --role=client --args='%s'",
+		options.MountFSName, options.MountFSType, buffer.String())
 }
 
 func getMountVolumes(cc *configure.ClientConfig) []step.Volume {
@@ -213,10 +279,24 @@ func newToolsMutate(cc *configure.ClientConfig, delimiter string) step.Mutate {
 	}
 }
 
+func (c MountConfig) IsEmpty() bool {
+	return len(c.FuseOptions) == 0 && len(c.Overrides) == 0 && !c.ReadOnly
+}
+
 func mountPoint2ContainerName(mountPoint string) string {
 	return fmt.Sprintf("curvefs-filesystem-%s", utils.MD5Sum(mountPoint))
 }
 
+// the container mounts filesystem once it started, so the unit only starts it
+func newMountUnit(curveadm *cli.CurveAdm, options MountOptions) string {
+	unit := task.ClientUnit{
+		Description: fmt.Sprintf("%s (%s)", options.MountFSName, options.MountPoint),
+		Engine:      curveadm.ExecOptions().ExecWithEngine,
+		Container:   mountPoint2ContainerName(options.MountPoint),
+	}
+	return unit.Render()
+}
+
 func checkMountStatus(mountPoint, name string, out *string) step.LambdaType {
 	return func(ctx *context.Context) error {
 		if *out == name {
@@ -246,6 +326,13 @@ func (s *step2InsertClient) Execute(ctx *context.Context) error {
 	auxInfo := &AuxInfo{
 		FSName:     options.MountFSName,
 		MountPoint: options.MountPoint,
+		FSType:     options.MountFSType,
+		Image:      config.GetContainerImage(),
+		Persist:    options.Persist,
+		MetricPort: config.GetMetricPort(),
+	}
+	if !options.Config.IsEmpty() {
+		auxInfo.Config = &options.Config
 	}
 	bytes, err := json.Marshal(auxInfo)
 	if err != nil {
@@ -266,6 +353,37 @@ func (s *step2InsertClient) Execute(ctx *context.Context) error {
 	return nil
 }
 
+func (s *step2RecordMetricPort) Execute(ctx *context.Context) error {
+	curveadm := s.curveadm
+	port, err := task.GetListenPort(ctx, *s.containerId, PROCESS_CURVE_FUSE,
+		s.startPort, curveadm.ExecOptions())
+	if err != nil || port == 0 {
+		return nil // keep the configured port
+	}
+
+	clients, err := curveadm.Storage().GetClient(s.clientId)
+	if err != nil {
+		return errno.ERR_GET_CLIENT_BY_ID_FAILED.E(err)
+	} else if len(clients) == 0 {
+		return nil
+	}
+	auxInfo := &AuxInfo{}
+	err = json.Unmarshal([]byte(clients[0].AuxInfo), auxInfo)
+	if err != nil {
+		return errno.ERR_DECODE_CLIENT_AUX_INFO_FAILED.E(err)
+	}
+	auxInfo.MetricPort = port
+	bytes, err := json.Marshal(auxInfo)
+	if err != nil {
+		return errno.ERR_ENCODE_VOLUME_INFO_TO_JSON_FAILED.E(err)
+	}
+	err = curveadm.Storage().SetClientAuxInfo(s.clientId, string(bytes))
+	if err != nil {
+		return errno.ERR_SET_CLIENT_AUX_INFO_FAILED.E(err)
+	}
+	return nil
+}
+
 func checkStartContainerStatus(success *bool, out *string) step.LambdaType {
 	return func(ctx *context.Context) error {
 		if *success {
@@ -277,55 +395,13 @@ func checkStartContainerStatus(success *bool, out *string) step.LambdaType {
 	}
 }
 
-func NewMountFSTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.Task, error) {
-	options := curveadm.MemStorage().Get(comm.KEY_MOUNT_OPTIONS).(MountOptions)
-	hc, err := curveadm.GetHost(options.Host)
-	if err != nil {
-		return nil, err
-	}
-
-	// new task
+func newCreateMountContainerStep(curveadm *cli.CurveAdm, cc *configure.ClientConfig,
+	options MountOptions, containerId *string) task.Step {
 	mountPoint := options.MountPoint
-	mountFSName := options.MountFSName
-	mountFSType := options.MountFSType
-	subname := fmt.Sprintf("mountFSName=%s mountFSType=%s mountPoint=%s", mountFSName, mountFSType, mountPoint)
-	t := task.NewTask("Mount FileSystem", subname, hc.GetSSHConfig())
-
-	// add step to task
-	var containerId, out string
-	var success bool
-	root := configure.GetFSProjectRoot()
-	prefix := configure.GetFSClientPrefix()
 	containerMountPath := configure.GetFSClientMountPath(mountPoint)
-	containerName := mountPoint2ContainerName(mountPoint)
-	createfsScript := scripts.CREATE_FS
-	createfsScriptPath := "/client.sh"
-
-	t.AddStep(&step.EngineInfo{
-		Success:     &success,
-		Out:         &out,
-		ExecOptions: curveadm.ExecOptions(),
-	})
-	t.AddStep(&step.Lambda{
-		Lambda: checker.CheckEngineInfo(options.Host, curveadm.ExecOptions().ExecWithEngine, &success, &out),
-	})
-	t.AddStep(&step.ListContainers{
-		ShowAll:     true,
-		Format:      "'{{.Names}}'",
-		Filter:      fmt.Sprintf("name=%s", containerName),
-		Out:         &out,
-		ExecOptions: curveadm.ExecOptions(),
-	})
-	t.AddStep(&step.Lambda{
-		Lambda: checkMountStatus(mountPoint, containerName, &out),
-	})
-	t.AddStep(&step.PullImage{
-		Image:       cc.GetContainerImage(),
-		ExecOptions: curveadm.ExecOptions(),
-	})
-	t.AddStep(&step.CreateContainer{
+	return &step.CreateContainer{
 		Image:             cc.GetContainerImage(),
-		Command:           getMountCommand(cc, mountFSName, mountFSType, mountPoint),
+		Command:           getMountCommand(options),
 		Entrypoint:        "/bin/bash",
 		Envs:              getEnvironments(cc),
 		Init:              true,
@@ -338,21 +414,28 @@ func NewMountFSTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.T
 		Ulimits:           []string{"core=-1"},
 		Pid:               cc.GetContainerPid(),
 		Privileged:        true,
-		Out:               &containerId,
+		Out:               containerId,
 		ExecOptions:       curveadm.ExecOptions(),
-	})
-	t.AddStep(&step2InsertClient{
-		curveadm:    curveadm,
-		options:     options,
-		config:      cc,
-		containerId: &containerId,
-	})
+	}
+}
 
-	if mountFSType == "volume" {
-		t.AddStep(&step.SyncFile{ // sync volume client config
-			ContainerSrcId:    &containerId,
+// sync configs into the created container and start it, the filesystem
+// will be mounted by client.sh once the container started
+func newStartMountContainerSteps(curveadm *cli.CurveAdm, cc *configure.ClientConfig,
+	options MountOptions, containerId *string) []task.Step {
+	var out string
+	var success bool
+	root := configure.GetFSProjectRoot()
+	prefix := configure.GetFSClientPrefix()
+	createfsScript := scripts.CREATE_FS
+	createfsScriptPath := "/client.sh"
+
+	steps := []task.Step{}
+	if options.MountFSType == "volume" {
+		steps = append(steps, &step.SyncFile{ // sync volume client config
+			ContainerSrcId:    containerId,
 			ContainerSrcPath:  fmt.Sprintf("%s/conf/curvebs-client.conf", root),
-			ContainerDestId:   &containerId,
+			ContainerDestId:   containerId,
 			ContainerDestPath: CURVEBS_CONF_PATH,
 			KVFieldSplit:      CLIENT_CONFIG_DELIMITER,
 			Mutate:            newCurveBSMutate(cc, CLIENT_CONFIG_DELIMITER),
@@ -360,50 +443,123 @@ func NewMountFSTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.T
 		})
 	}
 
-	t.AddStep(&step.SyncFile{ // sync service config
-		ContainerSrcId:    &containerId,
+	steps = append(steps, &step.SyncFile{ // sync service config
+		ContainerSrcId:    containerId,
 		ContainerSrcPath:  fmt.Sprintf("%s/conf/client.conf", root),
-		ContainerDestId:   &containerId,
+		ContainerDestId:   containerId,
 		ContainerDestPath: fmt.Sprintf("%s/conf/client.conf", prefix),
 		KVFieldSplit:      CLIENT_CONFIG_DELIMITER,
 		Mutate:            newMutate(cc, CLIENT_CONFIG_DELIMITER),
 		ExecOptions:       curveadm.ExecOptions(),
 	})
-	t.AddStep(&step.SyncFile{ // sync tools config
-		ContainerSrcId:    &containerId,
+	steps = append(steps, &step.SyncFile{ // sync tools config
+		ContainerSrcId:    containerId,
 		ContainerSrcPath:  fmt.Sprintf("%s/conf/tools.conf", root),
-		ContainerDestId:   &containerId,
+		ContainerDestId:   containerId,
 		ContainerDestPath: topology.GetCurveFSProjectLayout().ToolsConfSystemPath,
 		KVFieldSplit:      CLIENT_CONFIG_DELIMITER,
 		Mutate:            newToolsMutate(cc, CLIENT_CONFIG_DELIMITER),
 		ExecOptions:       curveadm.ExecOptions(),
 	})
-	t.AddStep(&step.TrySyncFile{ // sync tools-v2 config
-		ContainerSrcId:    &containerId,
+	steps = append(steps, &step.TrySyncFile{ // sync tools-v2 config
+		ContainerSrcId:    containerId,
 		ContainerSrcPath:  fmt.Sprintf("%s/conf/curve.yaml", root),
-		ContainerDestId:   &containerId,
+		ContainerDestId:   containerId,
 		ContainerDestPath: topology.GetCurveFSProjectLayout().ToolsV2ConfSystemPath,
 		KVFieldSplit:      TOOLS_V2_CONFIG_DELIMITER,
 		Mutate:            newToolsMutate(cc, TOOLS_V2_CONFIG_DELIMITER),
 		ExecOptions:       curveadm.ExecOptions(),
 	})
-	t.AddStep(&step.InstallFile{ // install client.sh shell
-		ContainerId:       &containerId,
+	steps = append(steps, &step.InstallFile{ // install client.sh shell
+		ContainerId:       containerId,
 		ContainerDestPath: createfsScriptPath,
 		Content:           &createfsScript,
 		ExecOptions:       curveadm.ExecOptions(),
 	})
-	t.AddStep(&step.StartContainer{
-		ContainerId: &containerId,
+	steps = append(steps, &step.StartContainer{
+		ContainerId: containerId,
 		Success:     &success,
 		Out:         &out,
 		ExecOptions: curveadm.ExecOptions(),
 	})
-	t.AddStep(&step.Lambda{
+	steps = append(steps, &step.Lambda{
 		Lambda: checkStartContainerStatus(&success, &out),
 	})
+	return steps
+}
+
+func NewMountFSTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig) (*task.Task, error) {
+	options := curveadm.MemStorage().Get(comm.KEY_MOUNT_OPTIONS).(MountOptions)
+	return NewMountFilesystemTask(curveadm, cc, options)
+}
+
+func NewMountFilesystemTask(curveadm *cli.CurveAdm, cc *configure.ClientConfig,
+	options MountOptions) (*task.Task, error) {
+	hc, err := curveadm.GetHost(options.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	mountPoint := options.MountPoint
+	mountFSName := options.MountFSName
+	mountFSType := options.MountFSType
+	subname := fmt.Sprintf("mountFSName=%s mountFSType=%s mountPoint=%s", mountFSName, mountFSType, mountPoint)
+	t := task.NewTask("Mount FileSystem", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var containerId, out string
+	var success bool
+	containerName := mountPoint2ContainerName(mountPoint)
+
+	t.AddStep(&step.EngineInfo{
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checker.CheckEngineInfo(options.Host, curveadm.ExecOptions().ExecWithEngine, &success, &out),
+	})
+	t.AddStep(&step.ListContainers{
+		ShowAll:     true,
+		Format:      "'{{.Names}}'",
+		Filter:      fmt.Sprintf("name=%s", containerName),
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checkMountStatus(mountPoint, containerName, &out),
+	})
+	t.AddStep(&step.PullImage{
+		Image:       cc.GetContainerImage(),
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(newCreateMountContainerStep(curveadm, cc, options, &containerId))
+	t.AddStep(&step2InsertClient{
+		curveadm:    curveadm,
+		options:     options,
+		config:      cc,
+		containerId: &containerId,
+	})
+	for _, step := range newStartMountContainerSteps(curveadm, cc, options, &containerId) {
+		t.AddStep(step)
+	}
+	t.AddStep(&step2RecordMetricPort{
+		curveadm:    curveadm,
+		clientId:    curveadm.GetFilesystemId(options.Host, mountPoint),
+		startPort:   cc.GetMetricPort(),
+		containerId: &containerId,
+	})
 	// TODO(P0): wait mount done
+	if options.Persist {
+		fsId := curveadm.GetFilesystemId(options.Host, mountPoint)
+		unit := newMountUnit(curveadm, options)
+		t.AddStep(&step.InstallSystemdUnit{
+			Name:        task.ClientUnitName(fsId),
+			Content:     &unit,
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
 
 	return t, nil
-
 }
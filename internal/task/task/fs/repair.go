@@ -0,0 +1,165 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package fs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	PROC_MOUNTS = "/proc/mounts"
+)
+
+type step2RepairMount struct {
+	curveadm    *cli.CurveAdm
+	status      *string
+	containerId string
+	options     MountOptions
+}
+
+// IsMounted checks whether there is a fuse filesystem mounted on mount point
+// of host, see also: /proc/mounts
+func IsMounted(ctx *context.Context, mountPoint string, execOptions module.ExecOptions) (bool, error) {
+	var out string
+	err := (&step.Cat{
+		Files:       []string{PROC_MOUNTS},
+		Out:         &out,
+		ExecOptions: execOptions,
+	}).Execute(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	// e.g. curvefs /mnt/fs1 fuse rw,nosuid,nodev,relatime,user_id=0,group_id=0 0 0
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && fields[1] == mountPoint &&
+			strings.HasPrefix(fields[2], "fuse") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func DecodeAuxInfo(client storage.Client) (*AuxInfo, error) {
+	auxInfo := &AuxInfo{}
+	err := json.Unmarshal([]byte(client.AuxInfo), auxInfo)
+	if err != nil {
+		return nil, errno.ERR_DECODE_CLIENT_AUX_INFO_FAILED.E(err)
+	}
+	return auxInfo, nil
+}
+
+func (s *step2RepairMount) Execute(ctx *context.Context) error {
+	status := *s.status
+	curveadm := s.curveadm
+	options := s.options
+	if len(status) == 0 {
+		return errno.ERR_REPAIR_CLIENT_FAILED.
+			F("mount point %s: %s, please umount and mount it again",
+				options.MountPoint, comm.CLIENT_DRIFT_CONTAINER_LOSED)
+	}
+
+	// the container mounts filesystem once it started
+	var repair task.Step
+	if !strings.HasPrefix(status, "Up") {
+		repair = &step.StartContainer{
+			ContainerId: &s.containerId,
+			ExecOptions: curveadm.ExecOptions(),
+		}
+	} else {
+		mounted, err := IsMounted(ctx, options.MountPoint, curveadm.ExecOptions())
+		if err != nil {
+			return err
+		} else if mounted {
+			return nil
+		}
+		repair = &step.RestartContainer{
+			ContainerId: s.containerId,
+			ExecOptions: curveadm.ExecOptions(),
+		}
+	}
+	return repair.Execute(ctx)
+}
+
+// NewRepairMountTask starts (or restarts) the container to mount filesystem again,
+// and the systemd unit will be reinstalled if the client is persisted
+func NewRepairMountTask(curveadm *cli.CurveAdm, client storage.Client) (*task.Task, error) {
+	auxInfo, err := DecodeAuxInfo(client)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(client.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	options := MountOptions{
+		Host:        client.Host,
+		MountFSName: auxInfo.FSName,
+		MountPoint:  auxInfo.MountPoint,
+		Persist:     auxInfo.Persist,
+	}
+	containerId := client.ContainerId
+	subname := fmt.Sprintf("host=%s mountPoint=%s containerId=%s",
+		client.Host, options.MountPoint, tui.TrimContainerId(containerId))
+	t := task.NewTask("Repair FileSystem", subname, hc.GetSSHConfig())
+
+	// add step
+	var status string
+	t.AddStep(&step.ListContainers{
+		ShowAll:     true,
+		Format:      "'{{.Status}}'",
+		Filter:      fmt.Sprintf("id=%s", containerId),
+		Out:         &status,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step2RepairMount{
+		curveadm:    curveadm,
+		status:      &status,
+		containerId: containerId,
+		options:     options,
+	})
+	if options.Persist {
+		unit := newMountUnit(curveadm, options)
+		t.AddStep(&step.InstallSystemdUnit{
+			Name:        task.ClientUnitName(client.Id),
+			Content:     &unit,
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
+
+	return t, nil
+}
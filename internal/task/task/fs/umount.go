@@ -120,9 +120,32 @@ func (s *step2RemoveContainer) Execute(ctx *context.Context) error {
 	return nil
 }
 
+func isPersisted(curveadm *cli.CurveAdm, fsId string) (bool, error) {
+	clients, err := curveadm.Storage().GetClient(fsId)
+	if err != nil {
+		return false, errno.ERR_GET_CLIENT_BY_ID_FAILED.E(err)
+	} else if len(clients) == 0 {
+		return false, nil
+	}
+
+	auxInfo, err := DecodeAuxInfo(clients[0])
+	if err != nil {
+		return false, err
+	}
+	return auxInfo.Persist, nil
+}
+
 func NewUmountFSTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error) {
 	options := curveadm.MemStorage().Get(comm.KEY_MOUNT_OPTIONS).(MountOptions)
+	return NewUmountFilesystemTask(curveadm, options)
+}
+
+func NewUmountFilesystemTask(curveadm *cli.CurveAdm, options MountOptions) (*task.Task, error) {
 	fsId := curveadm.GetFilesystemId(options.Host, options.MountPoint)
+	persist, err := isPersisted(curveadm, fsId)
+	if err != nil {
+		return nil, err
+	}
 	hc, err := curveadm.GetHost(options.Host)
 	if err != nil {
 		return nil, err
@@ -144,6 +167,12 @@ func NewUmountFSTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task, error)
 		Out:         &status,
 		ExecOptions: curveadm.ExecOptions(),
 	})
+	if persist {
+		t.AddStep(&step.RemoveSystemdUnit{
+			Name:        task.ClientUnitName(fsId),
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
 	t.AddStep(&step2UmountFS{
 		containerId: containerId,
 		status:      &status,
@@ -0,0 +1,322 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package fs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	FORMAT_BACKUP_CONTAINER_NAME = "%s-backup"
+	DRAIN_POLL_INTERVAL          = time.Second
+)
+
+type (
+	// upgradeStage records how far the upgrade went, rollback depends on it
+	upgradeStage struct {
+		umounted  bool
+		detached  bool // old container exited and renamed to backup
+		created   bool
+		committed bool
+	}
+
+	step2DrainMountPoint struct {
+		curveadm   *cli.CurveAdm
+		status     *string
+		mountPoint string
+		drainWait  time.Duration
+	}
+
+	step2CommitUpgrade struct {
+		curveadm       *cli.CurveAdm
+		client         storage.Client
+		auxInfo        *AuxInfo
+		image          string
+		oldContainerId string
+		newContainerId *string
+		stage          *upgradeStage
+	}
+
+	step2RollbackUpgrade struct {
+		curveadm       *cli.CurveAdm
+		mountPoint     string
+		oldContainerId string
+		newContainerId *string
+		stage          *upgradeStage
+	}
+)
+
+func mark(flag *bool) step.LambdaType {
+	return func(ctx *context.Context) error {
+		*flag = true
+		return nil
+	}
+}
+
+func checkUpgradeContainer(mountPoint string, status *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if len(*status) == 0 {
+			return errno.ERR_UPGRADE_CLIENT_FAILED.
+				F("mount point %s: container losed", mountPoint)
+		}
+		return nil
+	}
+}
+
+// e.g. /mnt/fs1:  1234c  5678
+func isMountPointBusy(ctx *context.Context, mountPoint string, curveadm *cli.CurveAdm) (bool, error) {
+	cmd := ctx.Module().Shell().Fuser(mountPoint)
+	cmd.AddOption("-m")
+	out, err := cmd.Execute(curveadm.ExecOptions())
+	if err != nil && module.ExitStatus(err) == 1 { // no process accessing
+		return false, nil
+	} else if err != nil { // e.g. fuser not found, permission denied
+		return false, errno.ERR_CHECK_MOUNT_POINT_BUSY_FAILED.S(out)
+	}
+	pids := out
+	if idx := strings.Index(out, ":"); idx >= 0 {
+		pids = out[idx+1:]
+	}
+	return len(strings.Fields(pids)) > 0, nil
+}
+
+// wait until no process accessing the mount point, we give up upgrading
+// before anything changed if it is still busy after drain wait
+func (s *step2DrainMountPoint) Execute(ctx *context.Context) error {
+	if !strings.HasPrefix(*s.status, "Up") {
+		return nil
+	}
+
+	deadline := time.Now().Add(s.drainWait)
+	for {
+		busy, err := isMountPointBusy(ctx, s.mountPoint, s.curveadm)
+		if err != nil {
+			return err
+		} else if !busy {
+			return nil
+		} else if time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(DRAIN_POLL_INTERVAL)
+	}
+	return errno.ERR_FS_MOUNT_POINT_BUSY.
+		F("mount point %s is still busy after %s", s.mountPoint, s.drainWait)
+}
+
+func (s *step2CommitUpgrade) Execute(ctx *context.Context) error {
+	curveadm := s.curveadm
+	err := (&step.RemoveContainer{
+		ContainerId: s.oldContainerId,
+		ExecOptions: curveadm.ExecOptions(),
+	}).Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	auxInfo := *s.auxInfo
+	auxInfo.Image = s.image
+	bytes, err := json.Marshal(auxInfo)
+	if err != nil {
+		return errno.ERR_ENCODE_VOLUME_INFO_TO_JSON_FAILED.E(err)
+	}
+	err = curveadm.Storage().SetClientContainerId(s.client.Id, *s.newContainerId)
+	if err != nil {
+		return errno.ERR_SET_CLIENT_CONTAINER_ID_FAILED.E(err)
+	}
+	err = curveadm.Storage().SetClientAuxInfo(s.client.Id, string(bytes))
+	if err != nil {
+		return errno.ERR_SET_CLIENT_AUX_INFO_FAILED.E(err)
+	}
+
+	s.stage.committed = true
+	return nil
+}
+
+// rollback removes the new container and starts the old one,
+// which mounts filesystem again once it started.
+// NOTE: all errors are ignored because it's a post step
+func (s *step2RollbackUpgrade) Execute(ctx *context.Context) error {
+	stage := s.stage
+	if stage.committed || !stage.umounted {
+		return nil
+	}
+
+	curveadm := s.curveadm
+	steps := []task.Step{}
+	if stage.created {
+		steps = append(steps, &step.StopContainer{
+			ContainerId: *s.newContainerId,
+			ExecOptions: curveadm.ExecOptions(),
+		})
+		steps = append(steps, &step.RemoveContainer{
+			ContainerId: *s.newContainerId,
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
+	if stage.detached {
+		steps = append(steps, &step.RenameContainer{
+			ContainerId: s.oldContainerId,
+			Name:        mountPoint2ContainerName(s.mountPoint),
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
+	containerId := s.oldContainerId
+	steps = append(steps, &step.StartContainer{
+		ContainerId: &containerId,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	for _, step := range steps {
+		err := step.Execute(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewUpgradeMountTask remounts filesystem with the new image: wait the mount
+// point to be idle, umount it, and then mount it in a new container.
+func NewUpgradeMountTask(curveadm *cli.CurveAdm, client storage.Client,
+	cc *configure.ClientConfig, drainWait time.Duration) (*task.Task, error) {
+	auxInfo, err := DecodeAuxInfo(client)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(client.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	options := MountOptions{
+		Host:        client.Host,
+		MountFSName: auxInfo.FSName,
+		MountFSType: auxInfo.FSType,
+		MountPoint:  auxInfo.MountPoint,
+		Persist:     auxInfo.Persist,
+	}
+	if len(options.MountFSType) == 0 {
+		options.MountFSType = "s3"
+	}
+	if auxInfo.Config != nil { // remount with the same per-mount configure
+		options.Config = *auxInfo.Config
+		err = cc.Override(options.Config.Overrides)
+		if err != nil {
+			return nil, err
+		}
+	}
+	oldContainerId := client.ContainerId
+	subname := fmt.Sprintf("host=%s mountPoint=%s image=%s",
+		client.Host, options.MountPoint, cc.GetContainerImage())
+	t := task.NewTask("Upgrade FileSystem Client", subname, hc.GetSSHConfig())
+
+	// add step
+	var status, newContainerId string
+	stage := &upgradeStage{}
+	containerName := mountPoint2ContainerName(options.MountPoint)
+	waitOptions := curveadm.ExecOptions()
+	waitOptions.ExecTimeoutSec = ONE_DAY_SECONDS // wait all data flushed to S3
+	t.AddStep(&step.ListContainers{
+		ShowAll:     true,
+		Format:      "'{{.Status}}'",
+		Filter:      fmt.Sprintf("id=%s", oldContainerId),
+		Out:         &status,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: checkUpgradeContainer(options.MountPoint, &status),
+	})
+	t.AddStep(&step.PullImage{
+		Image:       cc.GetContainerImage(),
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step2DrainMountPoint{
+		curveadm:   curveadm,
+		status:     &status,
+		mountPoint: options.MountPoint,
+		drainWait:  drainWait,
+	})
+	t.AddStep(&step2UmountFS{
+		containerId: oldContainerId,
+		status:      &status,
+		mountPoint:  options.MountPoint,
+		curveadm:    curveadm,
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: mark(&stage.umounted),
+	})
+	t.AddStep(&step.WaitContainer{
+		ContainerId: oldContainerId,
+		ExecOptions: waitOptions,
+	})
+	t.AddStep(&step.RenameContainer{
+		ContainerId: oldContainerId,
+		Name:        fmt.Sprintf(FORMAT_BACKUP_CONTAINER_NAME, containerName),
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: mark(&stage.detached),
+	})
+	t.AddStep(newCreateMountContainerStep(curveadm, cc, options, &newContainerId))
+	t.AddStep(&step.Lambda{
+		Lambda: mark(&stage.created),
+	})
+	for _, step := range newStartMountContainerSteps(curveadm, cc, options, &newContainerId) {
+		t.AddStep(step)
+	}
+	t.AddStep(&step2CommitUpgrade{
+		curveadm:       curveadm,
+		client:         client,
+		auxInfo:        auxInfo,
+		image:          cc.GetContainerImage(),
+		oldContainerId: oldContainerId,
+		newContainerId: &newContainerId,
+		stage:          stage,
+	})
+	t.AddStep(&step2RecordMetricPort{
+		curveadm:    curveadm,
+		clientId:    client.Id,
+		startPort:   cc.GetMetricPort(),
+		containerId: &newContainerId,
+	})
+	t.AddPostStep(&step2RollbackUpgrade{
+		curveadm:       curveadm,
+		mountPoint:     options.MountPoint,
+		oldContainerId: oldContainerId,
+		newContainerId: &newContainerId,
+		stage:          stage,
+	})
+
+	return t, nil
+}
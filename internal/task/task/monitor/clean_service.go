@@ -40,6 +40,9 @@ var (
 	ROLE_NODE_EXPORTER = configure.ROLE_NODE_EXPORTER
 	ROLE_PROMETHEUS    = configure.ROLE_PROMETHEUS
 	ROLE_GRAFANA       = configure.ROLE_GRAFANA
+	ROLE_ALERTMANAGER  = configure.ROLE_ALERTMANAGER
+	ROLE_LOKI          = configure.ROLE_LOKI
+	ROLE_PROMTAIL      = configure.ROLE_PROMTAIL
 	ROLE_MONITOR_CONF  = configure.ROLE_MONITOR_CONF
 )
 
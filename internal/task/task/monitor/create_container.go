@@ -53,6 +53,20 @@ func getArguments(cfg *configure.MonitorConfig) string {
 			"web.console.templates":       "/usr/share/prometheus/consoles",
 			"web.listen-address":          fmt.Sprintf(":%d", cfg.GetListenPort()),
 		}
+	case ROLE_ALERTMANAGER:
+		argsMap = map[string]interface{}{
+			"config.file":        "/etc/alertmanager/alertmanager.yml",
+			"storage.path":       "/alertmanager",
+			"web.listen-address": fmt.Sprintf(":%d", cfg.GetListenPort()),
+		}
+	case ROLE_LOKI:
+		argsMap = map[string]interface{}{
+			"config.file": LOKI_CONF_PATH,
+		}
+	case ROLE_PROMTAIL:
+		argsMap = map[string]interface{}{
+			"config.file": PROMTAIL_CONF_PATH,
+		}
 	}
 	args := []string{}
 	for k, v := range argsMap {
@@ -94,6 +108,30 @@ func getMountVolumes(cfg *configure.MonitorConfig) []step.Volume {
 			HostPath:      cfg.GetDataDir(),
 			ContainerPath: "/var/lib/grafana",
 		})
+	case ROLE_ALERTMANAGER:
+		volumes = append(volumes, step.Volume{
+			HostPath:      cfg.GetDataDir(),
+			ContainerPath: "/alertmanager",
+		})
+	case ROLE_LOKI:
+		volumes = append(volumes, step.Volume{
+			HostPath:      cfg.GetDataDir(),
+			ContainerPath: "/loki",
+		})
+	case ROLE_PROMTAIL:
+		// log directories of services are read via the rootfs, so the
+		// services added by scale-out need no extra volume
+		volumes = append(volumes, step.Volume{
+			HostPath:      "/",
+			ContainerPath: PROMTAIL_ROOTFS,
+			Options:       "ro,rslave",
+		})
+		if len(cfg.GetDataDir()) > 0 {
+			volumes = append(volumes, step.Volume{
+				HostPath:      cfg.GetDataDir(),
+				ContainerPath: "/promtail",
+			})
+		}
 	}
 	return volumes
 }
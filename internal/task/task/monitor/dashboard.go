@@ -0,0 +1,157 @@
+/*
+*  Copyright (c) 2026 NetEase Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+ */
+
+/*
+* Project: Curveadm
+* Created Date: 2026-10-19
+* Author: Jingli Chen (Wine93)
+ */
+
+package monitor
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/scripts"
+)
+
+const (
+	DASHBOARD_ROOT_DIR = "dashboards"
+)
+
+type dashboard struct {
+	filename string
+	content  string
+}
+
+type dashboardVersion struct {
+	name string // e.g. v1.2
+	num  int
+}
+
+// calcVersion returns -1 if the version is not like v1.2 or 1.2.6-rc1
+func calcVersion(version string) int {
+	version = strings.TrimPrefix(version, "v")
+	version = strings.SplitN(version, "-", 2)[0]
+	items := strings.Split(version, ".")
+	if len(items) > 3 {
+		return -1
+	}
+
+	num := 0
+	for i := 0; i < 3; i++ {
+		n := 0
+		if i < len(items) {
+			v, err := strconv.Atoi(items[i])
+			if err != nil {
+				return -1
+			}
+			n = v
+		}
+		num = num*1000 + n
+	}
+	return num
+}
+
+// e.g. opencurvedocker/curvebs:v1.2 => v1.2
+func getImageVersion(image string) string {
+	items := strings.Split(image, "/")
+	name := items[len(items)-1]
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		return name[i+1:]
+	}
+	return "latest"
+}
+
+// selectDashboardVersion returns the newest dashboards which not newer than
+// image version, and the newest one is used if the version unknown (e.g. latest)
+func selectDashboardVersion(kind, image string) (string, error) {
+	entries, err := scripts.DASHBOARDS.ReadDir(path.Join(DASHBOARD_ROOT_DIR, kind))
+	if err != nil {
+		return "", errno.ERR_GRAFANA_DASHBOARDS_NOT_FOUND.F("kind: %s", kind)
+	}
+
+	versions := []dashboardVersion{}
+	for _, entry := range entries {
+		if num := calcVersion(entry.Name()); entry.IsDir() && num >= 0 {
+			versions = append(versions, dashboardVersion{name: entry.Name(), num: num})
+		}
+	}
+	if len(versions) == 0 {
+		return "", errno.ERR_GRAFANA_DASHBOARDS_NOT_FOUND.F("kind: %s", kind)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].num < versions[j].num
+	})
+
+	num := calcVersion(getImageVersion(image))
+	if num < 0 {
+		return versions[len(versions)-1].name, nil
+	}
+	selected := versions[0] // the oldest dashboards is better than nothing
+	for _, v := range versions {
+		if v.num <= num {
+			selected = v
+		}
+	}
+	return selected.name, nil
+}
+
+// getDashboards returns the selected version and its dashboards
+func getDashboards(kind, image string) (string, []dashboard, error) {
+	version, err := selectDashboardVersion(kind, image)
+	if err != nil {
+		return "", nil, err
+	}
+
+	dir := path.Join(DASHBOARD_ROOT_DIR, kind, version)
+	entries, err := scripts.DASHBOARDS.ReadDir(dir)
+	if err != nil {
+		return "", nil, errno.ERR_READ_FILE_FAILED.E(err)
+	}
+	dashboards := []dashboard{}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := scripts.DASHBOARDS.ReadFile(path.Join(dir, entry.Name()))
+		if err != nil {
+			return "", nil, errno.ERR_READ_FILE_FAILED.E(err)
+		}
+		dashboards = append(dashboards, dashboard{
+			filename: entry.Name(),
+			content:  string(data),
+		})
+	}
+	return version, dashboards, nil
+}
+
+// e.g. CurveBS v1.2
+func getDashboardFolder(kind, version string) string {
+	switch kind {
+	case topology.KIND_CURVEBS:
+		kind = "CurveBS"
+	case topology.KIND_CURVEFS:
+		kind = "CurveFS"
+	}
+	return fmt.Sprintf("%s %s", kind, version)
+}
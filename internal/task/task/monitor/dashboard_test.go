@@ -0,0 +1,87 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package monitor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalcVersion(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(1002000, calcVersion("v1.2"))
+	assert.Equal(1002000, calcVersion("1.2.0"))
+	assert.Equal(1002006, calcVersion("v1.2.6-rc1"))
+	assert.True(calcVersion("v2.4") > calcVersion("v1.2.6"))
+	assert.Equal(-1, calcVersion("latest"))
+	assert.Equal(-1, calcVersion("v1.2.3.4"))
+}
+
+func TestSelectDashboardVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		kind    string
+		image   string
+		version string
+	}{
+		{"curvebs", "opencurvedocker/curvebs:v1.2", "v1.2"},
+		{"curvebs", "opencurvedocker/curvebs:v1.2.6", "v1.2"},
+		{"curvebs", "opencurvedocker/curvebs:v1.1", "v1.2"}, // the oldest one
+		{"curvebs", "opencurvedocker/curvebs:latest", "v1.2"},
+		{"curvebs", "127.0.0.1:5000/curvebs", "v1.2"},
+		{"curvefs", "opencurvedocker/curvefs:v2.4", "v2.4"},
+	}
+	for _, tt := range tests {
+		version, err := selectDashboardVersion(tt.kind, tt.image)
+		assert.Nil(err)
+		assert.Equal(tt.version, version, tt.image)
+	}
+
+	_, err := selectDashboardVersion("unknown", "unknown:v1.0")
+	assert.ErrorIs(err, errno.ERR_GRAFANA_DASHBOARDS_NOT_FOUND)
+}
+
+func TestDashboardsValid(t *testing.T) {
+	assert := assert.New(t)
+	for _, kind := range []string{"curvebs", "curvefs"} {
+		_, dashboards, err := getDashboards(kind, "latest")
+		assert.Nil(err)
+		assert.NotEmpty(dashboards)
+		uids := map[string]bool{}
+		for _, d := range dashboards {
+			v := struct {
+				Uid     string `json:"uid"`
+				Version int    `json:"version"`
+			}{}
+			assert.Nil(json.Unmarshal([]byte(d.content), &v), d.filename)
+			assert.NotEmpty(v.Uid, d.filename)
+			assert.False(uids[v.Uid], "duplicate uid %s", v.Uid)
+			assert.True(v.Version > 0, d.filename)
+			uids[v.Uid] = true
+		}
+	}
+}
@@ -0,0 +1,118 @@
+/*
+*  Copyright (c) 2026 NetEase Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+ */
+
+/*
+* Project: Curveadm
+* Created Date: 2026-10-19
+* Author: Jingli Chen (Wine93)
+ */
+
+package monitor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/task/task/common"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	SIGNAL_RELOAD = "HUP"
+)
+
+// prometheus and alertmanager reload their configure (and rules) on SIGHUP,
+// the signal will be forwarded by the init process in container
+func isHotReload(role string) bool {
+	return role == ROLE_PROMETHEUS || role == ROLE_ALERTMANAGER
+}
+
+func reloadContainer(role, containerId string, status *string,
+	options module.ExecOptions) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if !strings.HasPrefix(*status, "Up") { // e.g. new role added by reload
+			return (&step.StartContainer{
+				ContainerId: &containerId,
+				ExecOptions: options,
+			}).Execute(ctx)
+		} else if isHotReload(role) {
+			return (&step.KillContainer{
+				ContainerId: containerId,
+				Signal:      SIGNAL_RELOAD,
+				ExecOptions: options,
+			}).Execute(ctx)
+		}
+		return (&step.RestartContainer{
+			ContainerId: containerId,
+			ExecOptions: options,
+		}).Execute(ctx)
+	}
+}
+
+func NewReloadServiceTask(curveadm *cli.CurveAdm, cfg *configure.MonitorConfig) (*task.Task, error) {
+	serviceId := curveadm.GetServiceId(cfg.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if IsSkip(cfg, []string{ROLE_MONITOR_CONF}) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(cfg.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		cfg.GetHost(), cfg.GetRole(), tui.TrimContainerId(containerId))
+	t := task.NewTask("Reload Monitor Service", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out string
+	var success bool
+	host, role := cfg.GetHost(), cfg.GetRole()
+	t.AddStep(&step.ListContainers{
+		ShowAll:     true,
+		Format:      `"{{.Status}}"`,
+		Filter:      fmt.Sprintf("id=%s", containerId),
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: common.CheckContainerExist(host, role, containerId, &out),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: reloadContainer(role, containerId, &out, curveadm.ExecOptions()),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: common.WaitContainerStart(3),
+	})
+	t.AddStep(&common.Step2CheckPostStart{
+		Host:        host,
+		ContainerId: containerId,
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+
+	return t, nil
+}
@@ -23,8 +23,10 @@
 package monitor
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
@@ -35,6 +37,11 @@ import (
 	"github.com/opencurve/curveadm/internal/task/task/common"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
 	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	SCRAPE_HEALTH_UP = "up"
 )
 
 type step2InitMonitorStatus struct {
@@ -50,9 +57,26 @@ type step2FormatMonitorStatus struct {
 	containerId string
 	ports       *string
 	status      *string
+	scrape      *string
 	memStorage  *utils.SafeMap
 }
 
+type step2GetScrapeHealth struct {
+	containerId string
+	port        int
+	status      *string
+	scrape      *string
+	execOptions module.ExecOptions
+}
+
+type scrapeTargets struct {
+	Data struct {
+		ActiveTargets []struct {
+			Health string `json:"health"`
+		} `json:"activeTargets"`
+	} `json:"data"`
+}
+
 type MonitorStatus struct {
 	Id          string
 	Role        string
@@ -60,6 +84,7 @@ type MonitorStatus struct {
 	ContainerId string
 	Ports       string
 	Status      string
+	Scrape      string // e.g. 10/12 up, only for prometheus
 	DataDir     string
 	Config      *configure.MonitorConfig
 }
@@ -109,12 +134,44 @@ func (s *step2FormatMonitorStatus) Execute(ctx *context.Context) error {
 		ContainerId: tui.TrimContainerId(s.containerId),
 		Ports:       *s.ports,
 		Status:      status,
+		Scrape:      *s.scrape,
 		DataDir:     mc.GetDataDir(),
 		Config:      mc,
 	})
 	return nil
 }
 
+// scrape health of all targets, which queried by prometheus HTTP API
+func (s *step2GetScrapeHealth) Execute(ctx *context.Context) error {
+	if !strings.HasPrefix(*s.status, "Up") {
+		return nil
+	}
+
+	var out string
+	var success bool
+	err := (&step.ContainerExec{
+		ContainerId: &s.containerId,
+		Command:     fmt.Sprintf("wget -qO- 'http://127.0.0.1:%d/api/v1/targets?state=active'", s.port),
+		Success:     &success,
+		Out:         &out,
+		ExecOptions: s.execOptions,
+	}).Execute(ctx)
+	targets := scrapeTargets{}
+	if err != nil || !success || json.Unmarshal([]byte(out), &targets) != nil {
+		*s.scrape = comm.SERVICE_STATUS_UNKNOWN
+		return nil
+	}
+
+	up := 0
+	for _, target := range targets.Data.ActiveTargets {
+		if target.Health == SCRAPE_HEALTH_UP {
+			up++
+		}
+	}
+	*s.scrape = fmt.Sprintf("%d/%d up", up, len(targets.Data.ActiveTargets))
+	return nil
+}
+
 func NewInitMonitorStatusTask(curveadm *cli.CurveAdm, cfg *configure.MonitorConfig) (*task.Task, error) {
 	serviceId := curveadm.GetServiceId(cfg.GetId())
 	containerId, err := curveadm.GetContainerId(serviceId)
@@ -157,7 +214,7 @@ func NewGetMonitorStatusTask(curveadm *cli.CurveAdm, cfg *configure.MonitorConfi
 	t := task.NewTask("Get Monitor Status", subname, hc.GetSSHConfig())
 
 	// add step to task
-	var status string
+	var status, scrape string
 	ports := strconv.Itoa(cfg.GetListenPort())
 	t.AddStep(&step.ListContainers{
 		ShowAll:     true,
@@ -169,12 +226,22 @@ func NewGetMonitorStatusTask(curveadm *cli.CurveAdm, cfg *configure.MonitorConfi
 	t.AddStep(&step.Lambda{
 		Lambda: common.TrimContainerStatus(&status),
 	})
+	if cfg.GetRole() == ROLE_PROMETHEUS {
+		t.AddStep(&step2GetScrapeHealth{
+			containerId: containerId,
+			port:        cfg.GetListenPort(),
+			status:      &status,
+			scrape:      &scrape,
+			execOptions: curveadm.ExecOptions(),
+		})
+	}
 	t.AddStep(&step2FormatMonitorStatus{
 		mc:          cfg,
 		serviceId:   serviceId,
 		containerId: containerId,
 		ports:       &ports,
 		status:      &status,
+		scrape:      &scrape,
 		memStorage:  curveadm.MemStorage(),
 	})
 	return t, nil
@@ -25,6 +25,7 @@ package monitor
 import (
 	"fmt"
 	"path"
+	"sort"
 	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
@@ -34,26 +35,107 @@ import (
 	"github.com/opencurve/curveadm/internal/task/task"
 	"github.com/opencurve/curveadm/internal/task/task/common"
 	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
 )
 
 const (
 	TOOL_SYS_PATH             = "/usr/bin/curve_ops_tool"
 	MONITOR_CONF_PATH         = "monitor"
 	PROMETHEUS_CONTAINER_PATH = "/etc/prometheus"
+	ALERTMANAGER_CONF_PATH    = "/etc/alertmanager/alertmanager.yml"
 	GRAFANA_CONTAINER_PATH    = "/etc/grafana/grafana.ini"
 	DASHBOARD_CONTAINER_PATH  = "/etc/grafana/provisioning/dashboards"
+	DASHBOARD_DIR_NAME        = "curve"
 	GRAFANA_DATA_SOURCE_PATH  = "/etc/grafana/provisioning/datasources/all.yml"
 	CURVE_MANAGER_CONF_PATH   = "/curve-manager/conf/pigeon.yaml"
+	LOKI_CONF_PATH            = "/etc/loki/loki.yml"
+	PROMTAIL_CONF_PATH        = "/etc/promtail/promtail.yml"
+	PROMTAIL_ROOTFS           = "/host"
 )
 
-func getNodeExporterAddrs(hosts []string, port int) string {
+func getNodeExporterAddrs(addrs []string) string {
 	endpoint := []string{}
-	for _, item := range hosts {
-		endpoint = append(endpoint, fmt.Sprintf("'%s:%d'", item, port))
+	for _, addr := range addrs {
+		endpoint = append(endpoint, fmt.Sprintf("'%s'", addr))
 	}
 	return fmt.Sprintf("[%s]", strings.Join(endpoint, ","))
 }
 
+func getPrometheusRemoteWrite(cfg *configure.MonitorConfig) string {
+	rws := cfg.GetRemoteWrite()
+	if len(rws) == 0 {
+		return ""
+	}
+	content := ""
+	for _, rw := range rws {
+		content += fmt.Sprintf(scripts.PROMETHEUS_REMOTE_WRITE_URL, rw.Url)
+		if len(rw.Username) > 0 {
+			content += fmt.Sprintf(scripts.PROMETHEUS_REMOTE_WRITE_AUTH, rw.Username, rw.Password)
+		}
+	}
+	return fmt.Sprintf(scripts.PROMETHEUS_REMOTE_WRITE_YML, content)
+}
+
+// the first prometheus is the default datasource
+func getGrafanaDataSource(cfg *configure.MonitorConfig) string {
+	content := ""
+	for i, addr := range cfg.GetPrometheusAddrs() {
+		name := "Prometheus"
+		if i > 0 {
+			name = fmt.Sprintf("Prometheus-%d", i+1)
+		}
+		content += fmt.Sprintf(scripts.GRAFANA_PROMETHEUS_DATA_SOURCE, name, addr, i == 0)
+	}
+	if addr := cfg.GetLokiAddr(); len(addr) > 0 {
+		content += fmt.Sprintf(scripts.GRAFANA_LOKI_DATA_SOURCE, addr)
+	}
+	return fmt.Sprintf(scripts.GRAFANA_DATA_SOURCE, content)
+}
+
+func getPrometheusAlerting(cfg *configure.MonitorConfig) string {
+	addr := cfg.GetAlertmanagerAddr()
+	if len(addr) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(scripts.PROMETHEUS_ALERTING_YML, addr)
+}
+
+func getAlertmanagerReceivers(cfg *configure.MonitorConfig) string {
+	receivers := ""
+	if url := cfg.GetWebhookUrl(); len(url) > 0 {
+		receivers += fmt.Sprintf(scripts.ALERTMANAGER_WEBHOOK_CONFIG, url)
+	}
+	if to := cfg.GetEmailTo(); len(to) > 0 {
+		receivers += fmt.Sprintf(scripts.ALERTMANAGER_EMAIL_CONFIG, to)
+	}
+	return receivers
+}
+
+// each service is a job, e.g. job_name: 'etcd_host1_0' with labels role, host...
+func getPromtailScrapeConfigs(targets []configure.LogTarget) string {
+	content := ""
+	for _, target := range targets {
+		labels := ""
+		keys := []string{}
+		for k := range target.Labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			labels += fmt.Sprintf(scripts.PROMTAIL_LABEL, k, target.Labels[k])
+		}
+		logPath := path.Join(PROMTAIL_ROOTFS, target.Path, "**/*.log*") // skip glog's symlinks
+		content += fmt.Sprintf(scripts.PROMTAIL_SCRAPE_CONFIG,
+			target.Labels[configure.TARGET_LABEL_INSTANCE_ID], logPath, labels)
+	}
+	return content
+}
+
+func getPromtailConfig(cfg *configure.MonitorConfig, targets []configure.LogTarget) string {
+	return fmt.Sprintf(scripts.PROMTAIL_YML, cfg.GetListenPort(), cfg.GetLokiAddr(),
+		getPromtailScrapeConfigs(targets))
+}
+
 func NewSyncConfigTask(curveadm *cli.CurveAdm, cfg *configure.MonitorConfig) (*task.Task, error) {
 	serviceId := curveadm.GetServiceId(cfg.GetId())
 	containerId, err := curveadm.GetContainerId(serviceId)
@@ -92,8 +174,9 @@ func NewSyncConfigTask(curveadm *cli.CurveAdm, cfg *configure.MonitorConfig) (*t
 			ContainerDestPath: "/etc",
 			ExecOptions:       curveadm.ExecOptions(),
 		})
-		content := fmt.Sprintf(scripts.PROMETHEUS_YML, cfg.GetListenPort(),
-			getNodeExporterAddrs(cfg.GetNodeIps(), cfg.GetNodeListenPort()))
+		content := fmt.Sprintf(scripts.PROMETHEUS_YML, cfg.GetHost(), cfg.GetListenPort(),
+			getNodeExporterAddrs(cfg.GetNodeAddrs()),
+			getPrometheusAlerting(cfg), getPrometheusRemoteWrite(cfg))
 		t.AddStep(&step.InstallFile{ // install prometheus.yml file
 			ContainerId:       &containerId,
 			ContainerDestPath: path.Join(PROMETHEUS_CONTAINER_PATH, "prometheus.yml"),
@@ -107,6 +190,47 @@ func NewSyncConfigTask(curveadm *cli.CurveAdm, cfg *configure.MonitorConfig) (*t
 			Content:           &target,
 			ExecOptions:       curveadm.ExecOptions(),
 		})
+		rules := fmt.Sprintf(scripts.PROMETHEUS_RULES_YML, cfg.GetAlertDiskUsage(), cfg.GetAlertLatency())
+		t.AddStep(&step.InstallFile{ // install curve_rules.yml file
+			ContainerId:       &containerId,
+			ContainerDestPath: path.Join(PROMETHEUS_CONTAINER_PATH, "curve_rules.yml"),
+			Content:           &rules,
+			ExecOptions:       curveadm.ExecOptions(),
+		})
+	} else if role == ROLE_ALERTMANAGER {
+		t.AddStep(&step.CreateAndUploadDir{ // prepare alertmanager conf upath
+			HostDirName:       "alertmanager",
+			ContainerDestId:   &containerId,
+			ContainerDestPath: "/etc",
+			ExecOptions:       curveadm.ExecOptions(),
+		})
+		content := fmt.Sprintf(scripts.ALERTMANAGER_YML, cfg.GetSMTPSmarthost(), cfg.GetSMTPFrom(),
+			getAlertmanagerReceivers(cfg))
+		t.AddStep(&step.InstallFile{ // install alertmanager.yml file
+			ContainerId:       &containerId,
+			ContainerDestPath: ALERTMANAGER_CONF_PATH,
+			Content:           &content,
+			ExecOptions:       curveadm.ExecOptions(),
+		})
+	} else if role == ROLE_LOKI || role == ROLE_PROMTAIL {
+		var content string
+		if role == ROLE_LOKI {
+			content = fmt.Sprintf(scripts.LOKI_YML, cfg.GetListenPort(), cfg.GetLokiRetentionTime())
+		} else {
+			content = getPromtailConfig(cfg, cfg.GetLogTargets())
+		}
+		t.AddStep(&step.CreateAndUploadDir{ // prepare loki/promtail conf upath
+			HostDirName:       role,
+			ContainerDestId:   &containerId,
+			ContainerDestPath: "/etc",
+			ExecOptions:       curveadm.ExecOptions(),
+		})
+		t.AddStep(&step.InstallFile{ // install loki.yml/promtail.yml file
+			ContainerId:       &containerId,
+			ContainerDestPath: utils.Choose(role == ROLE_LOKI, LOKI_CONF_PATH, PROMTAIL_CONF_PATH),
+			Content:           &content,
+			ExecOptions:       curveadm.ExecOptions(),
+		})
 	} else if role == ROLE_GRAFANA {
 		serviceId = curveadm.GetServiceId(fmt.Sprintf("%s_%s", ROLE_MONITOR_CONF, cfg.GetHost()))
 		confContainerId, err := curveadm.GetContainerId(serviceId)
@@ -121,15 +245,35 @@ func NewSyncConfigTask(curveadm *cli.CurveAdm, cfg *configure.MonitorConfig) (*t
 			IsDir:             false,
 			ExecOptions:       curveadm.ExecOptions(),
 		})
-		t.AddStep(&step.SyncFileDirectly{ // sync dashboard dir
-			ContainerSrcId:    &confContainerId,
-			ContainerSrcPath:  path.Join("/", cfg.GetKind(), MONITOR_CONF_PATH, "grafana/provisioning/dashboards"),
+		t.AddStep(&step.CreateAndUploadDir{ // prepare dashboard dir
+			HostDirName:       DASHBOARD_DIR_NAME,
 			ContainerDestId:   &containerId,
 			ContainerDestPath: DASHBOARD_CONTAINER_PATH,
-			IsDir:             true,
 			ExecOptions:       curveadm.ExecOptions(),
 		})
-		content := fmt.Sprintf(scripts.GRAFANA_DATA_SOURCE, cfg.GetPrometheusIp(), cfg.GetPrometheusListenPort())
+		version, dashboards, err := getDashboards(cfg.GetKind(), cfg.GetClusterImage())
+		if err != nil {
+			return nil, err
+		}
+		dashboardDir := path.Join(DASHBOARD_CONTAINER_PATH, DASHBOARD_DIR_NAME)
+		provider := fmt.Sprintf(scripts.GRAFANA_DASHBOARD_PROVIDER,
+			getDashboardFolder(cfg.GetKind(), version), dashboardDir)
+		t.AddStep(&step.InstallFile{ // install dashboard provider file
+			ContainerId:       &containerId,
+			ContainerDestPath: path.Join(DASHBOARD_CONTAINER_PATH, DASHBOARD_DIR_NAME+".yml"),
+			Content:           &provider,
+			ExecOptions:       curveadm.ExecOptions(),
+		})
+		for _, dashboard := range dashboards {
+			content := dashboard.content
+			t.AddStep(&step.InstallFile{ // install dashboard
+				ContainerId:       &containerId,
+				ContainerDestPath: path.Join(dashboardDir, dashboard.filename),
+				Content:           &content,
+				ExecOptions:       curveadm.ExecOptions(),
+			})
+		}
+		content := getGrafanaDataSource(cfg)
 		t.AddStep(&step.InstallFile{ // install grafana datasource file
 			ContainerId:       &containerId,
 			ContainerDestPath: GRAFANA_DATA_SOURCE_PATH,
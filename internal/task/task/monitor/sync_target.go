@@ -0,0 +1,147 @@
+/*
+*  Copyright (c) 2026 NetEase Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+ */
+
+/*
+* Project: Curveadm
+* Created Date: 2026-10-19
+* Author: Jingli Chen (Wine93)
+ */
+
+package monitor
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/configure/topology"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/storage"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+func skipIfContainerNotExist(out *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		if len(*out) == 0 {
+			return task.ERR_SKIP_TASK
+		}
+		return nil
+	}
+}
+
+// the topology and pool are changed by scale-out or migrate,
+// so we read the latest one from database instead of cached
+func getLatestCluster(curveadm *cli.CurveAdm) (storage.Cluster, []*topology.DeployConfig, error) {
+	clusters, err := curveadm.Storage().GetClusters(curveadm.ClusterName())
+	if err != nil {
+		return storage.Cluster{}, nil, errno.ERR_GET_CLUSTER_BY_NAME_FAILED.E(err)
+	} else if len(clusters) == 0 {
+		return storage.Cluster{}, nil, errno.ERR_CLUSTER_NOT_FOUND.F("cluster: %s", curveadm.ClusterName())
+	}
+
+	cluster := clusters[0]
+	dcs, err := curveadm.ParseTopologyData(cluster.Topology)
+	return cluster, dcs, err
+}
+
+func genPrometheusTarget(curveadm *cli.CurveAdm, target *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		cluster, dcs, err := getLatestCluster(curveadm)
+		if err != nil {
+			return err
+		}
+		*target, err = configure.ParsePrometheusTarget(curveadm, dcs, cluster.Pool)
+		return err
+	}
+}
+
+func genPromtailConfig(curveadm *cli.CurveAdm, cfg *configure.MonitorConfig, content *string) step.LambdaType {
+	return func(ctx *context.Context) error {
+		_, dcs, err := getLatestCluster(curveadm)
+		if err != nil {
+			return err
+		}
+		*content = getPromtailConfig(cfg, configure.ParseLogTargets(curveadm, dcs, cfg.GetHost()))
+		return nil
+	}
+}
+
+// prometheus reloads target.json automatically, but promtail should be
+// restarted to load the new log targets
+func NewSyncTargetTask(curveadm *cli.CurveAdm, cfg *configure.MonitorConfig) (*task.Task, error) {
+	role := cfg.GetRole()
+	serviceId := curveadm.GetServiceId(cfg.GetId())
+	containerId, err := curveadm.GetContainerId(serviceId)
+	if (role != ROLE_PROMETHEUS && role != ROLE_PROMTAIL) ||
+		err == errno.ERR_SERVICE_CONTAINER_ID_NOT_FOUND {
+		return nil, nil // not deployed yet
+	} else if err != nil {
+		return nil, err
+	}
+	hc, err := curveadm.GetHost(cfg.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	// new task
+	subname := fmt.Sprintf("host=%s role=%s containerId=%s",
+		cfg.GetHost(), role, tui.TrimContainerId(containerId))
+	t := task.NewTask("Sync Monitor Target", subname, hc.GetSSHConfig())
+
+	// add step to task
+	var out, content string
+	t.AddStep(&step.ListContainers{
+		ShowAll:     true,
+		Format:      `"{{.ID}}"`,
+		Filter:      fmt.Sprintf("id=%s", containerId),
+		Out:         &out,
+		ExecOptions: curveadm.ExecOptions(),
+	})
+	t.AddStep(&step.Lambda{
+		Lambda: skipIfContainerNotExist(&out),
+	})
+	if role == ROLE_PROMETHEUS {
+		t.AddStep(&step.Lambda{
+			Lambda: genPrometheusTarget(curveadm, &content),
+		})
+		t.AddStep(&step.InstallFile{ // install target.json file
+			ContainerId:       &containerId,
+			ContainerDestPath: path.Join(PROMETHEUS_CONTAINER_PATH, "target.json"),
+			Content:           &content,
+			ExecOptions:       curveadm.ExecOptions(),
+		})
+	} else {
+		t.AddStep(&step.Lambda{
+			Lambda: genPromtailConfig(curveadm, cfg, &content),
+		})
+		t.AddStep(&step.InstallFile{ // install promtail.yml file
+			ContainerId:       &containerId,
+			ContainerDestPath: PROMTAIL_CONF_PATH,
+			Content:           &content,
+			ExecOptions:       curveadm.ExecOptions(),
+		})
+		t.AddStep(&step.RestartContainer{
+			ContainerId: containerId,
+			ExecOptions: curveadm.ExecOptions(),
+		})
+	}
+
+	return t, nil
+}
@@ -26,6 +26,7 @@ import (
 	"fmt"
 
 	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
 	"github.com/opencurve/curveadm/internal/configure"
 	"github.com/opencurve/curveadm/internal/configure/topology"
 	"github.com/opencurve/curveadm/internal/errno"
@@ -78,7 +79,7 @@ func execOptions(curveadm *cli.CurveAdm) module.ExecOptions {
 }
 
 func (s *step2CreateNBDDevice) Execute(ctx *context.Context) error {
-	cmd := ctx.Module().Shell().ModProbe("nbd", "nbds_max=64")
+	cmd := ctx.Module().Shell().ModProbe(comm.KERNERL_MODULE_NBD, fmt.Sprintf("nbds_max=%d", comm.NBDS_MAX))
 	_, err := cmd.Execute(s.execOptions)
 	return err
 }
@@ -43,6 +43,9 @@ import (
 const (
 	DEFAULT_CONFIG_DELIMITER = "="
 	ETCD_CONFIG_DELIMITER    = ": "
+
+	// services which started by entrypoint, see script/entrypoint.sh
+	FORMAT_SERVICES_PATH = "/%s/playground/services"
 )
 
 func newMutate(cfg interface{}, delimiter string) step.Mutate {
@@ -101,6 +104,16 @@ func prepare(dcs []*topology.DeployConfig, poolset configure.Poolset) (string, e
 	return string(bytes), err
 }
 
+// one service per line: role prefix listen.port
+func genServices(dcs []*topology.DeployConfig) string {
+	lines := []string{}
+	for _, dc := range dcs {
+		lines = append(lines, fmt.Sprintf("%s %s %d",
+			dc.GetRole(), dc.GetProjectLayout().ServiceRootDir, dc.GetListenPort()))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
 func NewInitPlaygroundTask(curveadm *cli.CurveAdm, cfg *configure.PlaygroundConfig) (*task.Task, error) {
 	// new task
 	kind := cfg.GetKind()
@@ -117,6 +130,7 @@ func NewInitPlaygroundTask(curveadm *cli.CurveAdm, cfg *configure.PlaygroundConf
 	if err != nil {
 		return nil, err
 	}
+	services := genServices(cfg.GetDeployConfigs())
 
 	t.AddStep(&step.ListContainers{ // gurantee container exist
 		ShowAll:     true,
@@ -175,6 +189,12 @@ func NewInitPlaygroundTask(curveadm *cli.CurveAdm, cfg *configure.PlaygroundConf
 			ExecOptions:       execOptions(curveadm),
 		})
 	}
+	t.AddStep(&step.InstallFile{ // install services for entrypoint
+		ContainerId:       &containerId,
+		ContainerDestPath: fmt.Sprintf(FORMAT_SERVICES_PATH, kind),
+		Content:           &services,
+		ExecOptions:       execOptions(curveadm),
+	})
 	t.AddStep(&step.InstallFile{ // install entrypoint
 		ContainerId:       &containerId,
 		ContainerDestPath: "/entrypoint.sh",
@@ -24,6 +24,7 @@ package playground
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
 	comm "github.com/opencurve/curveadm/internal/common"
@@ -62,11 +63,34 @@ func setPlaygroundStatus(memStorage *utils.SafeMap, id string, status Playground
 	})
 }
 
-func (s *step2FormatPlaygroundStatus) Execute(ctx *context.Context) error {
-	status := *s.status
-	if len(status) == 0 { // container losed
-		status = comm.PLAYGROUDN_STATUS_LOSED
+// e.g. playground-curvebs-1656035415|Up 2 hours
+func formatPlaygroundStatus(playground storage.Playground, out string) string {
+	statuses := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		items := strings.SplitN(strings.TrimSpace(line), "|", 2)
+		if len(items) == 2 && isPlaygroundContainer(playground, items[0]) {
+			statuses = append(statuses, items[1])
+		}
+	}
+
+	if len(statuses) == 0 { // container losed
+		return comm.PLAYGROUDN_STATUS_LOSED
+	} else if len(statuses) == 1 {
+		return statuses[0]
+	}
+
+	// multi-node playground: 2/3 nodes up
+	up := 0
+	for _, status := range statuses {
+		if strings.HasPrefix(status, "Up") {
+			up++
+		}
 	}
+	return fmt.Sprintf("%d/%d nodes up", up, len(statuses))
+}
+
+func (s *step2FormatPlaygroundStatus) Execute(ctx *context.Context) error {
+	status := formatPlaygroundStatus(s.playground, *s.status)
 
 	playground := s.playground
 	id := utils.Atoa(playground.Id)
@@ -89,7 +113,7 @@ func NewGetPlaygroundStatusTask(curveadm *cli.CurveAdm, v interface{}) (*task.Ta
 	var status string
 	t.AddStep(&step.ListContainers{
 		ShowAll:     true,
-		Format:      "'{{.Status}}'",
+		Format:      "'{{.Names}}|{{.Status}}'",
 		Filter:      fmt.Sprintf("name=%s", playground.Name),
 		Out:         &status,
 		ExecOptions: execOptions(curveadm),
@@ -0,0 +1,164 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package playground
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	comm "github.com/opencurve/curveadm/internal/common"
+	"github.com/opencurve/curveadm/internal/configure"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/internal/task/step"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/utils"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	FORMAT_NODE_ADDRESS = "'{{range .NetworkSettings.Networks}}{{.IPAddress}}{{end}}'"
+
+	// wait the docker daemon in node ready
+	WAIT_NODE_READY_RETRIES     = 30
+	WAIT_NODE_READY_INTERVAL_MS = 1000
+)
+
+type (
+	step2WaitNodeReady struct {
+		node        string
+		execOptions module.ExecOptions
+	}
+
+	step2SaveNodeAddress struct {
+		node       string
+		address    *string
+		memStorage *utils.SafeMap
+	}
+)
+
+func (s *step2WaitNodeReady) Execute(ctx *context.Context) error {
+	var out string
+	var success bool
+	for i := 0; i < WAIT_NODE_READY_RETRIES; i++ {
+		cmd := &step.ContainerExec{
+			ContainerId: &s.node,
+			Command:     fmt.Sprintf("%s info", s.execOptions.ExecWithEngine),
+			Success:     &success,
+			Out:         &out,
+			ExecOptions: s.execOptions,
+		}
+		if err := cmd.Execute(ctx); err == nil && success {
+			return nil
+		}
+		time.Sleep(time.Duration(WAIT_NODE_READY_INTERVAL_MS) * time.Millisecond)
+	}
+	return errno.ERR_GET_CONTAINER_ENGINE_INFO_FAILED.
+		F("node=%s: %s", s.node, out)
+}
+
+func (s *step2SaveNodeAddress) Execute(ctx *context.Context) error {
+	address := strings.TrimSpace(*s.address)
+	if !utils.IsValidAddress(address) {
+		return errno.ERR_INVALID_PLAYGROUND_NODE_ADDRESS.
+			F("node=%s address=%s", s.node, address)
+	}
+
+	s.memStorage.TX(func(kv *utils.SafeMap) error {
+		m := map[string]string{}
+		v := kv.Get(comm.KEY_PLAYGROUND_NODES)
+		if v != nil {
+			m = v.(map[string]string)
+		}
+		m[s.node] = address
+		kv.Set(comm.KEY_PLAYGROUND_NODES, m)
+		return nil
+	})
+	return nil
+}
+
+/*
+ * create nodes for multi-node playground:
+ *   each node is a privileged container which runs a docker daemon,
+ *   all nodes are attached to a private network named by playground,
+ *   the address of nodes will be saved into memory storage (name: address)
+ */
+func NewCreatePlaygroundNodesTask(curveadm *cli.CurveAdm, cfg *configure.PlaygroundConfig) (*task.Task, error) {
+	name := cfg.GetName()
+	image := cfg.GetNodeImage()
+
+	// new task
+	subname := fmt.Sprintf("name=%s nodes=%d image=%s", name, cfg.GetNodes(), image)
+	t := task.NewTask("Create Playground Nodes", subname, nil)
+
+	// add step to task
+	t.AddStep(&step.PullImage{
+		Image:       image,
+		ExecOptions: execOptions(curveadm),
+	})
+	t.AddStep(&step.CreateNetwork{
+		Name:        name,
+		Driver:      "bridge",
+		ExecOptions: execOptions(curveadm),
+	})
+	for i := 1; i <= cfg.GetNodes(); i++ {
+		node := cfg.GetNodeName(i)
+		var containerId, address string
+		t.AddStep(&step.CreateContainer{
+			Image:       image,
+			Name:        node,
+			Hostname:    node,
+			Network:     name,
+			Privileged:  true,
+			Out:         &containerId,
+			ExecOptions: execOptions(curveadm),
+		})
+		t.AddStep(&step.StartContainer{
+			ContainerId: &node,
+			ExecOptions: execOptions(curveadm),
+		})
+		t.AddStep(&step2WaitNodeReady{
+			node:        node,
+			execOptions: execOptions(curveadm),
+		})
+		t.AddStep(&step.InspectContainer{
+			ContainerId: node,
+			Format:      FORMAT_NODE_ADDRESS,
+			Out:         &address,
+			ExecOptions: execOptions(curveadm),
+		})
+		t.AddStep(&step2SaveNodeAddress{
+			node:       node,
+			address:    &address,
+			memStorage: curveadm.MemStorage(),
+		})
+	}
+	t.AddStep(&step2InsertPlayGround{
+		curveadm: curveadm,
+		cfg:      cfg,
+	})
+
+	return t, nil
+}
@@ -24,6 +24,7 @@ package playground
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/opencurve/curveadm/cli/cli"
 	"github.com/opencurve/curveadm/internal/errno"
@@ -46,22 +47,29 @@ type (
 	}
 )
 
+// playground container or its nodes, e.g. playground-curvebs-1656035415-node1
+func isPlaygroundContainer(playground storage.Playground, name string) bool {
+	return name == playground.Name ||
+		strings.HasPrefix(name, playground.Name+"-node")
+}
+
 func (s *step2RemoveContainer) Execute(ctx *context.Context) error {
-	containerId := *s.containerId
 	playground := s.plaground
-	if len(containerId) == 0 {
-		return nil
-	}
-
 	steps := []task.Step{}
-	steps = append(steps, &step.StopContainer{
-		ContainerId: playground.Name,
-		ExecOptions: execOptions(s.curveadm),
-	})
-	steps = append(steps, &step.RemoveContainer{
-		ContainerId: playground.Name,
-		ExecOptions: execOptions(s.curveadm),
-	})
+	for _, name := range strings.Split(*s.containerId, "\n") {
+		name = strings.TrimSpace(name)
+		if !isPlaygroundContainer(playground, name) {
+			continue
+		}
+		steps = append(steps, &step.StopContainer{
+			ContainerId: name,
+			ExecOptions: execOptions(s.curveadm),
+		})
+		steps = append(steps, &step.RemoveContainer{
+			ContainerId: name,
+			ExecOptions: execOptions(s.curveadm),
+		})
+	}
 	/*
 		mountPoint := playground.MountPoint
 		if len(playground.MountPoint) > 0 {
@@ -83,8 +91,21 @@ func (s *step2RemoveContainer) Execute(ctx *context.Context) error {
 	return nil
 }
 
+// multi-node playground also has a cluster and hosts named by playground
 func (s *step2DeletePlayground) Execute(ctx *context.Context) error {
-	err := s.curveadm.Storage().DeletePlayground(s.plaground.Name)
+	name := s.plaground.Name
+	items, err := s.curveadm.Storage().GetPlaygroundHosts(name)
+	if err != nil {
+		return errno.ERR_SELECT_PLAYGROUND_HOSTS_FAILED.E(err)
+	} else if len(items) > 0 {
+		if err := s.curveadm.Storage().DeleteCluster(name); err != nil {
+			return errno.ERR_DELETE_CLUSTER_FAILED.E(err)
+		} else if err := s.curveadm.Storage().DeletePlaygroundHosts(name); err != nil {
+			return errno.ERR_DELETE_PLAYGROUND_HOSTS_FAILED.E(err)
+		}
+	}
+
+	err = s.curveadm.Storage().DeletePlayground(name)
 	if err != nil {
 		return errno.ERR_DELETE_PLAYGROUND_FAILED.E(err)
 	}
@@ -99,9 +120,10 @@ func NewRemovePlaygroundTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task,
 
 	// add step to task
 	var containerId string
+	var success bool
 	t.AddStep(&step.ListContainers{
 		ShowAll:     true,
-		Format:      "'{{.ID}}'",
+		Format:      "'{{.Names}}'",
 		Filter:      fmt.Sprintf("name=%s", playground.Name),
 		Out:         &containerId,
 		ExecOptions: execOptions(curveadm),
@@ -111,6 +133,11 @@ func NewRemovePlaygroundTask(curveadm *cli.CurveAdm, v interface{}) (*task.Task,
 		plaground:   playground,
 		curveadm:    curveadm,
 	})
+	t.AddStep(&step.RemoveNetwork{
+		Name:        playground.Name,
+		Success:     &success, // only multi-node playground has network
+		ExecOptions: execOptions(curveadm),
+	})
 	t.AddStep(&step2DeletePlayground{
 		plaground: playground,
 		curveadm:  curveadm,
@@ -10,11 +10,12 @@ g_roles=("etcd" "mds" "chunkserver")
 g_user="playground"
 g_volume="/playground"
 g_topology="/curvebs/tools/conf/topology.json"
+g_services="${g_prefix}/services" # each line: role prefix listen.port
 
 function start_service() {
     local role=$1
-    local sequence=$2
-    local prefix="${g_prefix}"/"${role}${sequence}"
+    local prefix=$2
+    local port=$3
     local conf_path="${prefix}"/conf/"${role}".conf
     local log_dir="${prefix}"/logs
     local data_dir="${prefix}"/data
@@ -52,7 +53,7 @@ function start_service() {
                 -bthread_concurrency=18 \
                 -raft_sync_meta=true \
                 -chunkServerExternalIp=127.0.0.1 \
-                -chunkServerPort=820${sequence} \
+                -chunkServerPort="${port}" \
                 -walFilePoolMetaPath="${data_dir}"/walfilepool.meta \
                 -recycleUri=local://"${data_dir}"/recycler \
                 -graceful_quit_on_sigterm=true &
@@ -65,22 +66,32 @@ function start_service() {
 
 }
 
-function start_etcd() {
-    for ((i=0;i<3;i++)) do
-        start_service etcd "$i"
-    done
+function start_role() {
+    local role=$1
+    while read -r name prefix port; do
+        [ "${name}" = "${role}" ] && start_service "${name}" "${prefix}" "${port}"
+    done < "${g_services}"
+    return 0
 }
 
-function start_mds() {
-    for ((i=0;i<3;i++)) do
-        start_service mds "$i"
+# retry the command every second until it succeeded, at most $1 seconds
+function retry() {
+    local timeout=$1
+    shift
+    for ((i=0;i<timeout;i++)); do
+        "$@" && return 0
+        sleep 1
     done
+    echo "timeout: $*"
+    return 1
 }
 
-function start_chunkserver() {
-    for ((i=0;i<3;i++)) do
-        start_service chunkserver "$i"
-    done
+# e.g. chunkserver: total num = 3, online = 3, unstable = 0, offline = 0
+function check_chunkserver_online() {
+    local expect online
+    expect=$(grep -c "^chunkserver " "${g_services}")
+    online=$(curve_ops_tool chunkserver-status | sed -n 's/.*online = \([0-9]*\).*/\1/p')
+    [ "${online}" = "${expect}" ]
 }
 
 function create_physicalpool() {
@@ -107,21 +118,20 @@ function create_volume() {
 function map_volume() {
     mkdir -p /curvebs/nebd/data/lock
     touch /etc/curve/curvetab
-    curve-nbd map --nbds_max=16 cbd:pool/${g_volume}_${g_user}_
+    curve-nbd map --nbds_max=64 cbd:pool/${g_volume}_${g_user}_
     wait -n
 }
 
 function start_curvebs() {
-    start_etcd
+    start_role etcd
     sleep 3
-    start_mds
+    start_role mds
     sleep 3
     create_physicalpool
-    start_chunkserver
-    sleep 25
+    start_role chunkserver
+    retry 120 check_chunkserver_online
     create_logicalpool
-    sleep 25
-    create_volume
+    retry 120 create_volume
     start_nebd
     map_volume
 }
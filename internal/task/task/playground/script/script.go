@@ -35,4 +35,11 @@ var (
 
 	//go:embed entrypoint.sh
 	ENTRYPOINT string
+
+	// templates for multi-node playground
+	//go:embed nodes_topology.yaml
+	NODES_TOPOLOGY string
+
+	//go:embed nodes_hosts.yaml
+	NODES_HOSTS string
 )
@@ -33,6 +33,11 @@ import (
 	"github.com/opencurve/curveadm/internal/task/task"
 )
 
+var (
+	// entrypoint takes about 1 minute to start services, see script/entrypoint.sh
+	WAIT_PLAYGROUND_START_SECONDS = 60
+)
+
 func wait(seconds int) step.LambdaType {
 	return func(ctx *context.Context) error {
 		time.Sleep(time.Duration(seconds) * time.Second)
@@ -52,7 +57,7 @@ func NewStartPlaygroundTask(curveadm *cli.CurveAdm, cfg *configure.PlaygroundCon
 		ExecOptions: execOptions(curveadm),
 	})
 	t.AddStep(&step.Lambda{
-		Lambda: wait(60),
+		Lambda: wait(WAIT_PLAYGROUND_START_SECONDS),
 	})
 
 	return t, nil
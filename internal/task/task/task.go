@@ -26,6 +26,8 @@ package task
 
 import (
 	"errors"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/opencurve/curveadm/internal/errno"
@@ -36,6 +38,8 @@ import (
 var (
 	ERR_SKIP_TASK = errors.New("skip task")
 	ERR_TASK_DONE = errors.New("task done")
+	// task finished but something deserves attention, e.g. precheck warnings
+	ERR_WARN_TASK = errors.New("warn task")
 )
 
 type (
@@ -52,6 +56,8 @@ type (
 		postSteps []Step
 		sshConfig *module.SSHConfig
 		context   context.Context
+		timing    *TaskTiming
+		progress  int32 // percent reported by step, -1 means unknown
 	}
 )
 
@@ -63,6 +69,7 @@ func NewTask(name, subname string, sshConfig *module.SSHConfig) *Task {
 		name:      name,
 		subname:   subname,
 		sshConfig: sshConfig,
+		progress:  -1,
 	}
 }
 
@@ -82,6 +89,22 @@ func (t *Task) Subname() string {
 	return t.subname
 }
 
+// Timing returns nil if the task has not been executed
+func (t *Task) Timing() *TaskTiming {
+	return t.timing
+}
+
+// Progress returns the percent reported by step, -1 means unknown
+func (t *Task) Progress() int {
+	return int(atomic.LoadInt32(&t.progress))
+}
+
+// SetProgress reports the percent of long-running step, it will be
+// displayed in progress bar, e.g. wait snapshot done
+func (t *Task) SetProgress(percent int) {
+	atomic.StoreInt32(&t.progress, int32(percent))
+}
+
 func (t *Task) SetTid(tid string) {
 	t.tid = tid
 }
@@ -102,16 +125,24 @@ func (t *Task) AddPostStep(step Step) {
 	t.postSteps = append(t.postSteps, step)
 }
 
+func (t *Task) executeStep(ctx *context.Context, step Step) error {
+	t.timing.beginStep(step)
+	start := time.Now()
+	err := step.Execute(ctx)
+	t.timing.endStep(time.Since(start))
+	return err
+}
+
 func (t *Task) executePost(ctx *context.Context) {
 	for _, step := range t.postSteps {
-		err := step.Execute(ctx)
+		err := t.executeStep(ctx, step)
 		if err != nil {
 			return
 		}
 	}
 }
 
-func (t *Task) Execute() error {
+func (t *Task) execute() error {
 	var sshClient *module.SSHClient
 	if t.sshConfig != nil {
 		client, err := module.NewSSHClient(*t.sshConfig)
@@ -125,11 +156,12 @@ func (t *Task) Execute() error {
 	if err != nil {
 		return err
 	}
+	ctx.Module().SetCommandHook(t.timing.addCommand)
 	defer ctx.Close()
 	defer t.executePost(ctx)
 
 	for _, step := range t.steps {
-		err := step.Execute(ctx)
+		err := t.executeStep(ctx, step)
 		if err == ERR_TASK_DONE {
 			break
 		} else if err != nil {
@@ -138,3 +170,20 @@ func (t *Task) Execute() error {
 	}
 	return nil
 }
+
+func (t *Task) Execute() error {
+	t.timing = &TaskTiming{
+		Name:    t.name,
+		Subname: t.subname,
+		Steps:   []StepTiming{},
+	}
+	if t.sshConfig != nil {
+		t.timing.Host = t.sshConfig.Host
+	}
+
+	start := time.Now()
+	err := t.execute()
+	t.timing.Elapsed = time.Since(start)
+	t.timing.Status = timingStatus(err)
+	return err
+}
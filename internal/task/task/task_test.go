@@ -0,0 +1,27 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/stretchr/testify/assert"
+)
+
+type progressStep struct {
+	task *Task
+}
+
+func (s *progressStep) Execute(ctx *context.Context) error {
+	s.task.SetProgress(45)
+	return nil
+}
+
+func TestTaskProgress(t *testing.T) {
+	assert := assert.New(t)
+
+	task := NewTask("Wait", "", nil)
+	assert.Equal(-1, task.Progress())
+	task.AddStep(&progressStep{task: task})
+	assert.Nil(task.Execute())
+	assert.Equal(45, task.Progress())
+}
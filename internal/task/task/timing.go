@@ -0,0 +1,118 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package task
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	TIMING_STATUS_OK    = "OK"
+	TIMING_STATUS_SKIP  = "SKIP"
+	TIMING_STATUS_WARN  = "WARN"
+	TIMING_STATUS_ERROR = "ERROR"
+)
+
+type (
+	CommandTiming struct {
+		Command string        `json:"command"`
+		Elapsed time.Duration `json:"elapsed"`
+		Failed  bool          `json:"failed"`
+	}
+
+	StepTiming struct {
+		Name     string          `json:"name"`
+		Elapsed  time.Duration   `json:"elapsed"`
+		Commands []CommandTiming `json:"commands"`
+	}
+
+	TaskTiming struct {
+		Name    string        `json:"name"`
+		Subname string        `json:"subname"`
+		Host    string        `json:"host"`
+		Status  string        `json:"status"`
+		Elapsed time.Duration `json:"elapsed"`
+		Steps   []StepTiming  `json:"steps"`
+	}
+)
+
+// e.g. *step.ContainerExec => step.ContainerExec
+func stepName(step Step) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", step), "*")
+}
+
+func timingStatus(err error) string {
+	if err == nil || err == ERR_TASK_DONE {
+		return TIMING_STATUS_OK
+	} else if err == ERR_SKIP_TASK {
+		return TIMING_STATUS_SKIP
+	} else if err == ERR_WARN_TASK {
+		return TIMING_STATUS_WARN
+	}
+	return TIMING_STATUS_ERROR
+}
+
+func (t *TaskTiming) beginStep(step Step) {
+	t.Steps = append(t.Steps, StepTiming{Name: stepName(step)})
+}
+
+func (t *TaskTiming) endStep(elapsed time.Duration) {
+	t.Steps[len(t.Steps)-1].Elapsed = elapsed
+}
+
+func (t *TaskTiming) addCommand(command string, elapsed time.Duration, err error) {
+	if len(t.Steps) == 0 {
+		return
+	}
+	step := &t.Steps[len(t.Steps)-1]
+	step.Commands = append(step.Commands, CommandTiming{
+		Command: command,
+		Elapsed: elapsed,
+		Failed:  err != nil,
+	})
+}
+
+func (t *TaskTiming) SlowestStep() *StepTiming {
+	var slowest *StepTiming
+	for i := range t.Steps {
+		if slowest == nil || t.Steps[i].Elapsed > slowest.Elapsed {
+			slowest = &t.Steps[i]
+		}
+	}
+	return slowest
+}
+
+func (t *TaskTiming) SlowestCommand() *CommandTiming {
+	var slowest *CommandTiming
+	for i := range t.Steps {
+		commands := t.Steps[i].Commands
+		for j := range commands {
+			if slowest == nil || commands[j].Elapsed > slowest.Elapsed {
+				slowest = &commands[j]
+			}
+		}
+	}
+	return slowest
+}
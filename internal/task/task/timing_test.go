@@ -0,0 +1,65 @@
+package task
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/pkg/module"
+	"github.com/stretchr/testify/assert"
+)
+
+type sleepStep struct {
+	command string
+	err     error
+}
+
+func (s *sleepStep) Execute(ctx *context.Context) error {
+	_, err := ctx.Module().Shell().
+		Command(s.command).
+		Execute(module.ExecOptions{ExecInLocal: true})
+	if err != nil {
+		return err
+	}
+	return s.err
+}
+
+func TestTaskTiming_Basic(t *testing.T) {
+	assert := assert.New(t)
+
+	task := NewTask("Sleep", "host=local", nil)
+	assert.Nil(task.Timing())
+	task.AddStep(&sleepStep{command: "sleep 0.01"})
+	task.AddStep(&sleepStep{command: "sleep 0.2"})
+	task.AddPostStep(&sleepStep{command: "true"})
+	assert.Nil(task.Execute())
+
+	timing := task.Timing()
+	assert.Equal("Sleep", timing.Name)
+	assert.Equal(TIMING_STATUS_OK, timing.Status)
+	assert.Len(timing.Steps, 3)
+	assert.Equal("task.sleepStep", timing.Steps[0].Name)
+	assert.Equal("sleep 0.01", timing.Steps[0].Commands[0].Command)
+	assert.Equal("sleep 0.2", timing.SlowestCommand().Command)
+	assert.Equal(&timing.Steps[1], timing.SlowestStep())
+	assert.GreaterOrEqual(timing.Elapsed, 200*time.Millisecond)
+}
+
+func TestTaskTiming_Status(t *testing.T) {
+	assert := assert.New(t)
+
+	task := NewTask("Skip", "", nil)
+	task.AddStep(&sleepStep{command: "true", err: ERR_SKIP_TASK})
+	assert.Equal(ERR_SKIP_TASK, task.Execute())
+	assert.Equal(TIMING_STATUS_SKIP, task.Timing().Status)
+
+	task = NewTask("Error", "", nil)
+	task.AddStep(&sleepStep{command: "false"})
+	task.AddStep(&sleepStep{command: "true", err: errors.New("unreached")})
+	assert.NotNil(task.Execute())
+	timing := task.Timing()
+	assert.Equal(TIMING_STATUS_ERROR, timing.Status)
+	assert.Len(timing.Steps, 1)
+	assert.True(timing.Steps[0].Commands[0].Failed)
+}
@@ -0,0 +1,126 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/opencurve/curveadm/internal/task/context"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+type (
+	// number in protobuf json may be quoted, e.g. "length": "10737418240"
+	ProtoUint64 uint64
+
+	// json output of tools-v2 (curve bs/fs ... --format json)
+	ToolsV2Output struct {
+		Error []struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+		Result json.RawMessage `json:"result"`
+	}
+)
+
+func (n *ProtoUint64) UnmarshalJSON(data []byte) error {
+	v, err := strconv.ParseUint(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
+		return err
+	}
+	*n = ProtoUint64(v)
+	return nil
+}
+
+/*
+ * Output Example:
+ * {
+ *   "error": [{"code": 0, "message": "success"}],
+ *   "result": {...}
+ * }
+ *
+ * returns the result and the message of first error if any
+ */
+func ParseToolsV2Output(output string) (json.RawMessage, string, error) {
+	out := ToolsV2Output{}
+	err := json.Unmarshal([]byte(output), &out)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, e := range out.Error {
+		if e.Code != 0 {
+			return nil, e.Message, nil
+		}
+	}
+	return out.Result, "", nil
+}
+
+/*
+ * Output Example (ss --no-header --processes --listening --tcp --numeric):
+ * LISTEN 0 128 0.0.0.0:9000 0.0.0.0:* users:(("nebd-server",pid=12,fd=30))
+ *
+ * returns the minimum port which process listens on and not less than start,
+ * e.g. the metric port found by client from the start port, 0 if not found
+ */
+func parseListenPort(output, process string, start int) int {
+	regex := regexp.MustCompile(fmt.Sprintf(`:([0-9]+)\s+\S+\s+users:\(\("%s",`,
+		regexp.QuoteMeta(process)))
+	port := 0
+	for _, line := range strings.Split(output, "\n") {
+		mu := regex.FindStringSubmatch(line)
+		if len(mu) == 0 {
+			continue
+		}
+		n, err := strconv.Atoi(mu[1])
+		if err == nil && n >= start && (port == 0 || n < port) {
+			port = n
+		}
+	}
+	return port
+}
+
+// GetListenPort returns the port which process listens on in container,
+// see parseListenPort for details
+func GetListenPort(ctx *context.Context, containerId, process string, start int,
+	options module.ExecOptions) (int, error) {
+	cmd := ctx.Module().Shell().SocketStatistics("")
+	cmd.AddOption("--no-header")
+	cmd.AddOption("--processes")
+	cmd.AddOption("--listening")
+	cmd.AddOption("--tcp")
+	cmd.AddOption("--numeric")
+	command, err := cmd.String()
+	if err != nil {
+		return 0, err
+	}
+
+	out, err := ctx.Module().DockerCli().ContainerExec(containerId, command).Execute(options)
+	if err != nil {
+		return 0, err
+	}
+	return parseListenPort(out, process, start), nil
+}
@@ -0,0 +1,95 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package task
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	ENV_BINARY = "/usr/bin/env"
+)
+
+/*
+ * ClientUnit is a oneshot systemd unit which re-establishes the client
+ * (map/mount) after host reboot, e.g.:
+ *
+ * [Unit]
+ * Description=CurveAdm client curve:/vol1
+ * After=network-online.target docker.service
+ * Wants=network-online.target
+ *
+ * [Service]
+ * Type=oneshot
+ * RemainAfterExit=yes
+ * ExecStartPre=-/usr/bin/env modprobe nbd nbds_max=64
+ * ExecStart=/usr/bin/env docker start curvebs-volume-xxx
+ * ExecStartPost=/usr/bin/env docker exec curvebs-volume-xxx /bin/bash map.sh ...
+ *
+ * [Install]
+ * WantedBy=multi-user.target
+ */
+type ClientUnit struct {
+	Description   string
+	Engine        string   // container engine, e.g. docker, podman
+	Container     string   // container id or name
+	PreCommands   []string // commands run on host before container started, failure ignored
+	PostCommands  []string // commands run in container after container started
+	StartTimeoutS int
+}
+
+func ClientUnitName(id string) string {
+	return fmt.Sprintf("curveadm-client-%s.service", id)
+}
+
+func (u ClientUnit) Render() string {
+	lines := []string{
+		"[Unit]",
+		fmt.Sprintf("Description=CurveAdm client %s", u.Description),
+		fmt.Sprintf("After=network-online.target %s.service", u.Engine),
+		"Wants=network-online.target",
+		"",
+		"[Service]",
+		"Type=oneshot",
+		"RemainAfterExit=yes",
+	}
+	if u.StartTimeoutS > 0 {
+		lines = append(lines, fmt.Sprintf("TimeoutStartSec=%d", u.StartTimeoutS))
+	}
+	for _, command := range u.PreCommands {
+		lines = append(lines, fmt.Sprintf("ExecStartPre=-%s %s", ENV_BINARY, command))
+	}
+	lines = append(lines, fmt.Sprintf("ExecStart=%s %s start %s", ENV_BINARY, u.Engine, u.Container))
+	for _, command := range u.PostCommands {
+		lines = append(lines, fmt.Sprintf("ExecStartPost=%s %s exec %s %s",
+			ENV_BINARY, u.Engine, u.Container, command))
+	}
+	lines = append(lines,
+		"",
+		"[Install]",
+		"WantedBy=multi-user.target",
+		"",
+	)
+	return strings.Join(lines, "\n")
+}
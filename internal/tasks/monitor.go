@@ -33,6 +33,7 @@ import (
 const (
 	STATUS_OK = iota
 	STATUS_SKIP
+	STATUS_WARN
 	STATUS_ERROR
 )
 
@@ -54,37 +55,41 @@ func (m *monitor) error() error {
 	return m.err
 }
 
-// return number of {success, skip, error}
-func (m *monitor) sum(bid int) (int, int, int) {
+// return number of {success, skip, warn, error}
+func (m *monitor) sum(bid int) (int, int, int, int) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
-	nsucc, nskip, nerr := 0, 0, 0
+	nsucc, nskip, nwarn, nerr := 0, 0, 0, 0
 	for _, err := range m.result[bid] {
 		if err == nil {
 			nsucc++
 		} else if err == task.ERR_SKIP_TASK {
 			nskip++
+		} else if err == task.ERR_WARN_TASK {
+			nwarn++
 		} else {
 			nerr++
 		}
 	}
-	return nsucc, nskip, nerr
+	return nsucc, nskip, nwarn, nerr
 }
 
 func (m *monitor) set(bid int, err error) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 	m.result[bid] = append(m.result[bid], err)
-	if err != nil && err != task.ERR_SKIP_TASK {
+	if err != nil && err != task.ERR_SKIP_TASK && err != task.ERR_WARN_TASK {
 		m.err = err
 	}
 }
 
 func (m *monitor) get(bid int) int {
-	nsucc, nskip, nerr := m.sum(bid)
-	total := nsucc + nskip + nerr
+	nsucc, nskip, nwarn, nerr := m.sum(bid)
+	total := nsucc + nskip + nwarn + nerr
 	if nerr != 0 {
 		return STATUS_ERROR
+	} else if nwarn != 0 {
+		return STATUS_WARN
 	} else if nskip == total {
 		return STATUS_SKIP
 	}
@@ -28,6 +28,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/opencurve/curveadm/internal/task/task"
@@ -44,6 +45,14 @@ type (
 		SkipError     bool
 	}
 
+	// Timing records the elapsed time of tasks which share the same name,
+	// it corresponds to one step of playbook
+	Timing struct {
+		Name    string            `json:"name"`
+		Elapsed time.Duration     `json:"elapsed"`
+		Tasks   []task.TaskTiming `json:"tasks"`
+	}
+
 	Tasks struct {
 		tasks    []*task.Task
 		monitor  *monitor
@@ -51,6 +60,7 @@ type (
 		progress *mpb.Progress
 		mainBar  *mpb.Bar
 		subBar   map[string]*mpb.Bar
+		elapsed  time.Duration
 		sync.Mutex
 	}
 )
@@ -115,6 +125,8 @@ func (ts *Tasks) displayStatus() func(static decor.Statistics) string {
 				return color.GreenString("[OK]")
 			} else if status == STATUS_SKIP {
 				return color.YellowString("[SKIP]")
+			} else if status == STATUS_WARN {
+				return color.YellowString("[WARN]")
 			} else {
 				return color.RedString("[ERROR]")
 			}
@@ -126,8 +138,18 @@ func (ts *Tasks) displayStatus() func(static decor.Statistics) string {
 func (ts *Tasks) displayInstances(t *task.Task) func(static decor.Statistics) string {
 	total := ts.CountPtid(t.Ptid())
 	return func(static decor.Statistics) string {
-		nsucc, nskip, _ := ts.monitor.sum(static.ID)
-		return fmt.Sprintf("[%d/%d]", nsucc+nskip, total)
+		nsucc, nskip, nwarn, _ := ts.monitor.sum(static.ID)
+		return fmt.Sprintf("[%d/%d]", nsucc+nskip+nwarn, total)
+	}
+}
+
+// display the percent which reported by task, e.g. 45%
+func (ts *Tasks) displayProgress(t *task.Task) func(static decor.Statistics) string {
+	return func(static decor.Statistics) string {
+		if static.Completed || t.Progress() < 0 {
+			return ""
+		}
+		return fmt.Sprintf("%d%% ", t.Progress())
 	}
 }
 
@@ -153,6 +175,7 @@ func (ts *Tasks) addSubBar(t *task.Task) {
 			decor.Name(t.Subname()+" "),
 			decor.Any(ts.displayInstances(t), decor.WCSyncWidthR),
 			decor.Name(" "),
+			decor.Any(ts.displayProgress(t)),
 			decor.OnComplete(decor.Spinner([]string{}), ""),
 			decor.Any(ts.displayStatus()),
 		),
@@ -177,7 +200,7 @@ func (ts *Tasks) setMainBarStatus() {
 	defer ts.Unlock()
 	monitor := ts.monitor
 	id := ts.mainBar.ID()
-	nsucc := 0
+	nsucc, nwarn := 0, 0
 	for _, bar := range ts.subBar {
 		status := monitor.get(bar.ID())
 		if status == STATUS_ERROR {
@@ -185,10 +208,14 @@ func (ts *Tasks) setMainBarStatus() {
 			return
 		} else if status == STATUS_OK {
 			nsucc++
+		} else if status == STATUS_WARN {
+			nwarn++
 		}
 	}
 
-	if nsucc == 0 { // all task skip
+	if nwarn > 0 {
+		monitor.set(id, task.ERR_WARN_TASK)
+	} else if nsucc == 0 { // all task skip
 		monitor.set(id, task.ERR_SKIP_TASK)
 	} else {
 		monitor.set(id, nil)
@@ -209,6 +236,9 @@ func (ts *Tasks) Execute(options ExecOptions) error {
 		return nil
 	}
 
+	start := time.Now()
+	defer func() { ts.elapsed = time.Since(start) }()
+
 	ts.prettySubname()
 	options = ts.initOptions(options)
 	workers := make(chan struct{}, options.Concurrency)
@@ -258,3 +288,18 @@ func (ts *Tasks) Execute(options ExecOptions) error {
 	ts.progress.Wait()
 	return ts.monitor.error()
 }
+
+// Timing collects the elapsed time of all executed tasks,
+// it should be invoked after Execute()
+func (ts *Tasks) Timing() Timing {
+	timing := Timing{Elapsed: ts.elapsed, Tasks: []task.TaskTiming{}}
+	if len(ts.tasks) > 0 {
+		timing.Name = ts.tasks[0].Name()
+	}
+	for _, t := range ts.tasks {
+		if t.Timing() != nil {
+			timing.Tasks = append(timing.Tasks, *t.Timing())
+		}
+	}
+	return timing
+}
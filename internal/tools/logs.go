@@ -0,0 +1,222 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/opencurve/curveadm/cli/cli"
+	"github.com/opencurve/curveadm/internal/errno"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	LOG_FILE_PATTERN = "*.log*" // skip glog's symlinks, e.g. chunkserver.INFO
+	LOG_TAIL_ALL     = -1
+)
+
+type (
+	// LogSource is the container (and its log directory) which logs fetched from
+	LogSource struct {
+		Prefix      string // each line is prefixed with it, e.g. service id
+		Host        string
+		ContainerId string
+		LogDir      string // optional
+	}
+
+	LogOptions struct {
+		Follow bool
+		Since  string // only for container output
+		Tail   int
+		Grep   string
+	}
+
+	// logPrinter serializes lines from multiple streams
+	logPrinter struct {
+		mutex  sync.Mutex
+		out    io.Writer
+		grep   *regexp.Regexp
+		prefix int // the max length of prefix, for alignment
+	}
+
+	// lineWriter splits output into lines, it may be written by stdout
+	// and stderr of one session concurrently (e.g. SSH session)
+	lineWriter struct {
+		mutex   sync.Mutex
+		printer *logPrinter
+		prefix  string
+		buffer  []byte
+	}
+)
+
+func (p *logPrinter) println(prefix, line string) {
+	line = strings.TrimSuffix(line, "\r")
+	if p.grep != nil && !p.grep.MatchString(line) {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	fmt.Fprintf(p.out, "%-*s | %s\n", p.prefix, prefix, line)
+}
+
+func (w *lineWriter) Write(data []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.buffer = append(w.buffer, data...)
+	for {
+		i := bytes.IndexByte(w.buffer, '\n')
+		if i < 0 {
+			break
+		}
+		w.printer.println(w.prefix, string(w.buffer[:i]))
+		w.buffer = w.buffer[i+1:]
+	}
+	return len(data), nil
+}
+
+func (w *lineWriter) Flush() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if len(w.buffer) > 0 {
+		w.printer.println(w.prefix, string(w.buffer))
+		w.buffer = nil
+	}
+}
+
+func streamContainerLogs(m *module.Module, source LogSource, options LogOptions,
+	execOptions module.ExecOptions, out io.Writer) error {
+	cli := m.DockerCli().ContainerLogs(source.ContainerId)
+	if options.Follow {
+		cli.AddOption("--follow")
+	}
+	if len(options.Since) > 0 {
+		cli.AddOption("--since %s", options.Since)
+	}
+	if options.Tail != LOG_TAIL_ALL {
+		cli.AddOption("--tail %d", options.Tail)
+	}
+	err := cli.Stream(context.Background(), out, execOptions)
+	if err != nil {
+		return errno.ERR_GET_CONTAINER_LOGS_FAILED.
+			FD("(%s logs ID)", execOptions.ExecWithEngine).
+			F("prefix: %s", source.Prefix).E(err)
+	}
+	return nil
+}
+
+// the error of tail is ignored, e.g. no log file yet,
+// the reason has been printed with the output
+func streamLogFiles(m *module.Module, source LogSource, options LogOptions,
+	execOptions module.ExecOptions, out io.Writer) {
+	cmd := m.Shell().Tail(path.Join(source.LogDir, LOG_FILE_PATTERN))
+	cmd.AddOption("--quiet")
+	if options.Follow {
+		cmd.AddOption("--follow=name --retry")
+	}
+	if options.Tail == LOG_TAIL_ALL {
+		cmd.AddOption("--lines=+1")
+	} else {
+		cmd.AddOption("--lines=%d", options.Tail)
+	}
+	cmd.Stream(context.Background(), out, execOptions)
+}
+
+// FetchLogs fetches logs of container output and files under log directory
+// from all sources concurrently, the lines will be multiplexed with prefix
+func FetchLogs(curveadm *cli.CurveAdm, sources []LogSource, options LogOptions) error {
+	printer := &logPrinter{out: curveadm.Out()}
+	if len(options.Grep) > 0 {
+		grep, err := regexp.Compile(options.Grep)
+		if err != nil {
+			return errno.ERR_BUILD_REGEX_FAILED.E(err)
+		}
+		printer.grep = grep
+	}
+	for _, source := range sources {
+		if len(source.Prefix) > printer.prefix {
+			printer.prefix = len(source.Prefix)
+		}
+	}
+
+	// one SSH client per host, each stream is a session of it
+	modules := map[string]*module.Module{}
+	for _, source := range sources {
+		if _, ok := modules[source.Host]; ok {
+			continue
+		}
+		hc, err := curveadm.GetHost(source.Host)
+		if err != nil {
+			return err
+		}
+		client, err := module.NewSSHClient(*hc.GetSSHConfig())
+		if err != nil {
+			return errno.ERR_SSH_CONNECT_FAILED.E(err)
+		}
+		defer client.Close()
+		modules[source.Host] = module.NewModule(client)
+	}
+
+	execOptions := curveadm.ExecOptions()
+	if options.Follow {
+		execOptions.ExecTimeoutSec = 0 // never timeout
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(sources))
+	for i, source := range sources {
+		m := modules[source.Host]
+		wg.Add(1)
+		go func(i int, source LogSource) {
+			defer wg.Done()
+			w := &lineWriter{printer: printer, prefix: source.Prefix}
+			defer w.Flush()
+			errs[i] = streamContainerLogs(m, source, options, execOptions, w)
+		}(i, source)
+
+		if len(source.LogDir) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(source LogSource) {
+			defer wg.Done()
+			w := &lineWriter{printer: printer, prefix: source.Prefix}
+			defer w.Flush()
+			streamLogFiles(m, source, options, execOptions, w)
+		}(source)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
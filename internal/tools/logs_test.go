@@ -0,0 +1,72 @@
+package tools
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// reader which only returns a few bytes at once, like the pipe of SSH session
+type chunkReader struct {
+	data []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p[:3], r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func genLines(name string, n int) string {
+	lines := []string{}
+	for i := 0; i < n; i++ {
+		lines = append(lines, fmt.Sprintf("%s line %d", name, i))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// SSH session copies stdout and stderr to the same writer in two goroutines
+func TestLineWriter_ConcurrentStdoutStderr(t *testing.T) {
+	assert := assert.New(t)
+
+	out := &bytes.Buffer{}
+	printer := &logPrinter{out: out, prefix: len("mds_01")}
+	w := &lineWriter{printer: printer, prefix: "mds_01"}
+
+	var wg sync.WaitGroup
+	for _, name := range []string{"stdout", "stderr"} {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			io.Copy(w, &chunkReader{data: []byte(genLines(name, 200))})
+		}(name)
+	}
+	wg.Wait()
+	w.Flush()
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	assert.Len(lines, 400)
+	for _, line := range lines {
+		assert.Regexp(`^mds_01 \| (stdout|stderr) line \d+$`, line)
+	}
+}
+
+func TestLineWriter_FlushPartialLine(t *testing.T) {
+	assert := assert.New(t)
+
+	out := &bytes.Buffer{}
+	w := &lineWriter{printer: &logPrinter{out: out}, prefix: "etcd"}
+	w.Write([]byte("first\nsec"))
+	w.Write([]byte("ond\r\nno newline"))
+	assert.Equal("etcd | first\netcd | second\n", out.String())
+	w.Flush()
+	assert.Equal("etcd | first\netcd | second\netcd | no newline\n", out.String())
+}
@@ -0,0 +1,119 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+const (
+	APPLY_ACTION_NONE    = "none (already applied)"
+	APPLY_ACTION_CHANGED = "none (options changed: %s)"
+	APPLY_RESULT_SUCCESS = "Success"
+	APPLY_RESULT_FAILED  = "Failed"
+	APPLY_RESULT_SKIPPED = "-"
+)
+
+type ApplyPlan struct {
+	Id     string
+	Kind   string
+	Host   string
+	Item   string // e.g. curve:/vol1, /s3_001 => /mnt/s3_001
+	Action string
+	Result string
+	// options which differ from the applied client, they won't
+	// take effect until the client unmapped/umounted and applied again
+	Changes []string
+}
+
+func applyActionDecorate(action string) string {
+	if action == APPLY_ACTION_NONE {
+		return color.YellowString(action)
+	}
+	return action
+}
+
+func applyChangedDecorate(action string) string {
+	return color.RedString(action)
+}
+
+func formatApplyAction(plan ApplyPlan) tui.DecorateMessage {
+	if plan.Action == APPLY_ACTION_NONE && len(plan.Changes) > 0 {
+		action := fmt.Sprintf(APPLY_ACTION_CHANGED, strings.Join(plan.Changes, ", "))
+		return tui.DecorateMessage{Message: action, Decorate: applyChangedDecorate}
+	}
+	return tui.DecorateMessage{Message: plan.Action, Decorate: applyActionDecorate}
+}
+
+func applyResultDecorate(result string) string {
+	switch result {
+	case APPLY_RESULT_SUCCESS:
+		return color.GreenString(result)
+	case APPLY_RESULT_FAILED:
+		return color.RedString(result)
+	}
+	return result
+}
+
+// FormatApplyPlan shows the plan before apply, and the result of
+// each item will be shown after apply if result is true
+func FormatApplyPlan(plans []ApplyPlan, result bool) string {
+	lines := [][]interface{}{}
+
+	// title
+	title := []string{
+		"Id",
+		"Kind",
+		"Host",
+		"Item",
+		"Action",
+	}
+	if result {
+		title = append(title, "Result")
+	}
+	first, second := tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	// plan
+	for _, plan := range plans {
+		line := []interface{}{
+			plan.Id,
+			plan.Kind,
+			plan.Host,
+			plan.Item,
+			formatApplyAction(plan),
+		}
+		if result {
+			line = append(line, tui.DecorateMessage{Message: plan.Result, Decorate: applyResultDecorate})
+		}
+		lines = append(lines, line)
+	}
+
+	output := tui.FixedFormat(lines, 2)
+	return output
+}
@@ -39,6 +39,13 @@ func statusDecorate(status string) string {
 	return status
 }
 
+func driftDecorate(drift string) string {
+	if drift != comm.CLIENT_DRIFT_NONE {
+		return color.YellowString(drift)
+	}
+	return drift
+}
+
 func sortStatues(statuses []task.ClientStatus) {
 	sort.Slice(statuses, func(i, j int) bool {
 		s1, s2 := statuses[i], statuses[j]
@@ -59,6 +66,7 @@ func FormatStatus(statuses []task.ClientStatus, verbose bool) string {
 		"Host",
 		"Container Id",
 		"Status",
+		"Drift",
 		"Aux Info",
 	}
 	if verbose {
@@ -78,6 +86,7 @@ func FormatStatus(statuses []task.ClientStatus, verbose bool) string {
 			status.Host,
 			tui.TrimContainerId(status.ContainerId),
 			tui.DecorateMessage{Message: status.Status, Decorate: statusDecorate},
+			tui.DecorateMessage{Message: status.Drift, Decorate: driftDecorate},
 			status.AuxInfo,
 		}
 		if verbose {
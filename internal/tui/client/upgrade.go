@@ -0,0 +1,78 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package service
+
+import (
+	"github.com/fatih/color"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+const (
+	UPGRADE_ACTION_SKIP = "skip (already upgraded)"
+)
+
+type UpgradePlan struct {
+	Id       string
+	Kind     string
+	Host     string
+	OldImage string
+	NewImage string
+	Action   string
+}
+
+func actionDecorate(action string) string {
+	if action == UPGRADE_ACTION_SKIP {
+		return color.YellowString(action)
+	}
+	return action
+}
+
+func FormatUpgradePlan(plans []UpgradePlan) string {
+	lines := [][]interface{}{}
+
+	// title
+	title := []string{
+		"Id",
+		"Kind",
+		"Host",
+		"Image",
+		"Action",
+	}
+	first, second := tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	// plan
+	for _, plan := range plans {
+		lines = append(lines, []interface{}{
+			plan.Id,
+			plan.Kind,
+			plan.Host,
+			plan.OldImage + " -> " + plan.NewImage,
+			tui.DecorateMessage{Message: plan.Action, Decorate: actionDecorate},
+		})
+	}
+
+	output := tui.FixedFormat(lines, 2)
+	return output
+}
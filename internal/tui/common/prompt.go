@@ -74,6 +74,15 @@ to watch the formatting progress.
 	PROMPT_PATH_EXIST = `{{.path}} already exists.
 `
 
+	PROMPT_DELETE_VOLUME = `WARNING: volume {{.volume}} will be deleted, all data in it will be lost
+`
+
+	PROMPT_DELETE_SNAPSHOT = `WARNING: snapshot {{.uuid}} of volume {{.volume}} will be deleted
+`
+
+	PROMPT_DELETE_FILESYSTEM = `WARNING: filesystem {{.fsname}} will be deleted, all data in it will be lost
+`
+
 	DEFAULT_CONFIRM_PROMPT = "Do you want to continue?"
 )
 
@@ -234,6 +243,25 @@ func PromptAutoUpgrade(version string) string {
 	return prompt.Build()
 }
 
+func PromptDeleteVolume(volume string) string {
+	prompt := NewPrompt(color.YellowString(PROMPT_DELETE_VOLUME) + DEFAULT_CONFIRM_PROMPT)
+	prompt.data["volume"] = volume
+	return prompt.Build()
+}
+
+func PromptDeleteSnapshot(volume, uuid string) string {
+	prompt := NewPrompt(color.YellowString(PROMPT_DELETE_SNAPSHOT) + DEFAULT_CONFIRM_PROMPT)
+	prompt.data["volume"] = volume
+	prompt.data["uuid"] = uuid
+	return prompt.Build()
+}
+
+func PromptDeleteFilesystem(fsname string) string {
+	prompt := NewPrompt(color.YellowString(PROMPT_DELETE_FILESYSTEM) + DEFAULT_CONFIRM_PROMPT)
+	prompt.data["fsname"] = fsname
+	return prompt.Build()
+}
+
 func PromptPathExist(path string) string {
 	prompt := NewPrompt(color.YellowString(PROMPT_PATH_EXIST) + DEFAULT_CONFIRM_PROMPT)
 	prompt.data["path"] = path
@@ -27,6 +27,7 @@ package common
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -143,14 +144,25 @@ func TrimAddress(address string) string {
 	return address
 }
 
+// NOTE: the reader is shared by all prompts, otherwise the answers
+// piped from stdin would be swallowed by the buffer of first prompt.
+var stdin = bufio.NewReader(os.Stdin)
+
+// ReplaceStdin replaces the input of prompts, e.g. answers in tests,
+// it returns a function to restore the previous one.
+func ReplaceStdin(in io.Reader) func() {
+	prev := stdin
+	stdin = bufio.NewReader(in)
+	return func() { stdin = prev }
+}
+
 func prompt(prompt string) string {
 	if prompt != "" {
 		prompt += " "
 	}
 	fmt.Print(prompt)
 
-	reader := bufio.NewReader(os.Stdin)
-	input, err := reader.ReadString('\n')
+	input, err := stdin.ReadString('\n')
 	if err != nil {
 		return ""
 	}
@@ -0,0 +1,101 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/opencurve/curveadm/internal/task/task/fs"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+// capacity of filesystem is max uint64 if it is unlimited
+func formatCapacity(capacity uint64) string {
+	if capacity == 0 || capacity == ^uint64(0) {
+		return "unlimited"
+	}
+	return humanize.IBytes(capacity)
+}
+
+func formatPercent(used, total uint64) string {
+	if total == 0 || total == ^uint64(0) {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f%%", float64(used)*100/float64(total))
+}
+
+func FormatFilesystems(filesystems []fs.Filesystem) string {
+	lines := [][]interface{}{}
+	title := []string{"Id", "Name", "Type", "Status", "Block Size", "Capacity", "Mounts", "Mounted By"}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	sort.Slice(filesystems, func(i, j int) bool {
+		return filesystems[i].Id < filesystems[j].Id
+	})
+	for _, filesystem := range filesystems {
+		lines = append(lines, []interface{}{
+			strconv.FormatUint(filesystem.Id, 10),
+			filesystem.Name,
+			filesystem.Type,
+			filesystem.Status,
+			humanize.IBytes(filesystem.BlockSize),
+			formatCapacity(filesystem.Capacity),
+			strconv.FormatUint(filesystem.MountNum, 10),
+			utils.Choose(len(filesystem.MountedBy) > 0, strings.Join(filesystem.MountedBy, ","), "-"),
+		})
+	}
+
+	output := tuicommon.FixedFormat(lines, 2)
+	return output
+}
+
+func FormatQuotas(quotas []fs.Quota) string {
+	lines := [][]interface{}{}
+	title := []string{"Filesystem", "Path", "Used", "Capacity", "Use%", "Inodes Used", "Inodes", "Inode Use%"}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	for _, quota := range quotas {
+		lines = append(lines, []interface{}{
+			quota.FSName,
+			quota.Path,
+			humanize.IBytes(quota.UsedBytes),
+			formatCapacity(quota.Capacity),
+			formatPercent(quota.UsedBytes, quota.Capacity),
+			strconv.FormatUint(quota.UsedInodes, 10),
+			utils.Choose(quota.Inodes == 0, "unlimited", strconv.FormatUint(quota.Inodes, 10)),
+			formatPercent(quota.UsedInodes, quota.Inodes),
+		})
+	}
+
+	output := tuicommon.FixedFormat(lines, 2)
+	return output
+}
@@ -0,0 +1,104 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	task "github.com/opencurve/curveadm/internal/task/task/bs"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+func healthVerdictDecorate(verdict string) string {
+	switch verdict {
+	case task.HEALTH_VERDICT_HEALTHY:
+		return color.GreenString(verdict)
+	case task.HEALTH_VERDICT_WARNING:
+		return color.YellowString(verdict)
+	}
+	return color.RedString(verdict)
+}
+
+func formatServiceHealth(health task.ServiceHealth) string {
+	return fmt.Sprintf("leader=%s online=%d offline=%d",
+		utils.Choose(len(health.Leader) > 0, health.Leader, "-"),
+		len(health.Online), len(health.Offline))
+}
+
+/*
+ * cluster health: healthy
+ *
+ * Check          Detail
+ * -----          ------
+ * copysets       total=100 unhealthy=0
+ * chunkservers   total=3 online=3 offline=0
+ * space          total=300GB used=30GB (10.00%)
+ * etcd           leader=10.0.1.1:2379 online=3 offline=0
+ * mds            leader=10.0.1.1:6700 online=3 offline=0
+ */
+func FormatClusterHealth(health task.ClusterHealth) string {
+	lines := [][]interface{}{}
+	title := []string{"Check", "Detail"}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	lines = append(lines, []interface{}{
+		task.HEALTH_CHECK_COPYSET,
+		fmt.Sprintf("total=%d unhealthy=%d", health.Copysets.Total, health.Copysets.Unhealthy),
+	})
+	lines = append(lines, []interface{}{
+		task.HEALTH_CHECK_CHUNKSERVER,
+		fmt.Sprintf("total=%d online=%d offline=%d",
+			health.Chunkservers.Total, health.Chunkservers.Online, health.Chunkservers.Offline),
+	})
+	lines = append(lines, []interface{}{
+		task.HEALTH_CHECK_SPACE,
+		fmt.Sprintf("total=%dGB used=%dGB (%.2f%%)",
+			health.Space.Total, health.Space.Used, health.Space.UsedPercent),
+	})
+	lines = append(lines, []interface{}{task.HEALTH_CHECK_ETCD, formatServiceHealth(health.Etcd)})
+	lines = append(lines, []interface{}{task.HEALTH_CHECK_MDS, formatServiceHealth(health.MDS)})
+	if health.SnapshotClone != nil {
+		lines = append(lines, []interface{}{
+			task.HEALTH_CHECK_SNAPSHOTCLONE,
+			formatServiceHealth(*health.SnapshotClone),
+		})
+	}
+
+	output := []string{
+		fmt.Sprintf("cluster health: %s\n", healthVerdictDecorate(health.Verdict)),
+		tuicommon.FixedFormat(lines, 2),
+	}
+	if len(health.Problems) > 0 {
+		problems := []string{color.CyanString("Problems:")}
+		for _, problem := range health.Problems {
+			problems = append(problems, "  - "+problem)
+		}
+		output = append(output, strings.Join(problems, "\n")+"\n")
+	}
+	return strings.Join(output, "\n")
+}
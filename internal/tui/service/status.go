@@ -73,8 +73,11 @@ var (
 	}
 	MONITOT_ROLE_SCORE = map[string]int{
 		configure.ROLE_NODE_EXPORTER: 0,
-		configure.ROLE_PROMETHEUS:    1,
-		configure.ROLE_GRAFANA:       2,
+		configure.ROLE_PROMTAIL:      1,
+		configure.ROLE_PROMETHEUS:    2,
+		configure.ROLE_ALERTMANAGER:  3,
+		configure.ROLE_LOKI:          4,
+		configure.ROLE_GRAFANA:       5,
 	}
 )
 
@@ -259,9 +262,9 @@ func sortMonitorStatues(statuses []monitor.MonitorStatus) {
 	sort.Slice(statuses, func(i, j int) bool {
 		s1, s2 := statuses[i], statuses[j]
 		if s1.Role == s2.Role {
-			return s1.Host < s1.Host
+			return s1.Host < s2.Host
 		}
-		return MONITOT_ROLE_SCORE[s1.Role] < ROLE_SCORE[s2.Role]
+		return MONITOT_ROLE_SCORE[s1.Role] < MONITOT_ROLE_SCORE[s2.Role]
 	})
 }
 
@@ -275,6 +278,7 @@ func FormatMonitorStatus(statuses []monitor.MonitorStatus, verbose bool) string
 		"Host",
 		"Container Id",
 		"Status",
+		"Scrape",
 		"Ports",
 		"Data Dir",
 	}
@@ -291,6 +295,7 @@ func FormatMonitorStatus(statuses []monitor.MonitorStatus, verbose bool) string
 			status.Host,
 			status.ContainerId,
 			tui.DecorateMessage{Message: status.Status, Decorate: statusDecorate},
+			utils.Choose(len(status.Scrape) == 0, "-", status.Scrape),
 			utils.Choose(len(status.Ports) == 0, "-", status.Ports),
 			status.DataDir,
 		})
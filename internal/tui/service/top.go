@@ -0,0 +1,173 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	task "github.com/opencurve/curveadm/internal/task/task/common"
+	tui "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+const (
+	TOP_HOTKEYS = "[↑/k] up  [↓/j] down  [r] restart  [e] enter  [l] logs  [q] quit"
+	// lines occupied by header, title, recent errors and hotkeys
+	TOP_RESERVED_LINES = 12
+)
+
+type TopView struct {
+	Cluster  string
+	Kind     string
+	Interval time.Duration
+	Updated  time.Time
+	Selected int
+	Height   int // terminal height, 0 means unlimited
+	Message  string
+}
+
+// sort statuses in the same order as displayed
+func SortStatus(statuses []task.ServiceStatus) {
+	sortStatues(statuses)
+}
+
+// e.g. Up 2 hours => (RUNNING, 2 hours)
+func splitUptime(status string) (string, string) {
+	if strings.HasPrefix(status, "Up") {
+		return STATUS_RUNNING, strings.TrimSpace(strings.TrimPrefix(status, "Up"))
+	} else if strings.HasPrefix(status, "Exited") {
+		return STATUS_STOPPED, "-"
+	}
+	return status, "-"
+}
+
+// only display the rows around selected one if terminal is too small
+func visibleRange(n, selected, height int) (int, int) {
+	limit := height - TOP_RESERVED_LINES
+	if height <= 0 || limit >= n {
+		return 0, n
+	} else if limit <= 0 {
+		limit = 1
+	}
+	start := utils.Min(selected-limit/2, n-limit)
+	if start < 0 {
+		start = 0
+	}
+	return start, start + limit
+}
+
+func formatTopServices(statuses []task.ServiceStatus,
+	stats map[string]task.ServiceStats,
+	view TopView) string {
+	lines := [][]interface{}{}
+	title := []string{" ", "Id", "Host", "Container Id", "Status", "Uptime", "Leader", "CPU", "Memory", "Errors"}
+	first, second := tui.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	start, end := visibleRange(len(statuses), view.Selected, view.Height)
+	for i := start; i < end; i++ {
+		status := statuses[i]
+		state, uptime := splitUptime(status.Status)
+		s, ok := stats[status.Id]
+		if !ok {
+			s = task.ServiceStats{CPU: "-", Memory: "-"}
+		}
+		lines = append(lines, []interface{}{
+			utils.Choose(i == view.Selected, ">", " "),
+			status.Id,
+			status.Host,
+			status.ContainerId,
+			tui.DecorateMessage{Message: state, Decorate: statusDecorate},
+			uptime,
+			utils.Choose(status.IsLeader, "*", "-"),
+			s.CPU,
+			s.Memory,
+			tui.DecorateMessage{Message: fmt.Sprintf("%d", len(s.Errors)), Decorate: func(message string) string {
+				return utils.Choose(message == "0", message, color.RedString(message))
+			}},
+		})
+	}
+
+	// insert role as group header
+	rows := strings.Split(strings.TrimSuffix(tui.FixedFormat(lines, 2), "\n"), "\n")
+	out := append([]string{}, rows[:2]...)
+	for i := start; i < end; i++ {
+		if i == start || statuses[i].Role != statuses[i-1].Role {
+			out = append(out, color.CyanString("[%s]", statuses[i].Role))
+		}
+		row := rows[i-start+2]
+		if i == view.Selected {
+			row = color.New(color.Bold).Sprint(row)
+		}
+		out = append(out, row)
+	}
+	return strings.Join(out, "\n")
+}
+
+/*
+ * curveadm top - cluster: c1 (curvebs)  refresh: 5s  updated: 10:00:00
+ *
+ *    Id            Host          Container Id  Status   Uptime   Leader  CPU    Memory             Errors
+ *    --            ----          ------------  ------   ------   ------  ---    ------             ------
+ * [etcd]
+ * >  c9570c0d0b3c  server-host1  9f2ad8a5f0a5  RUNNING  2 hours  -       1.20%  60MiB / 15.5GiB    0
+ * [mds]
+ *    d8d7d2f4d5e3  server-host1  5a3d7e6b2c1f  RUNNING  2 hours  *       0.50%  120MiB / 15.5GiB   1
+ *
+ * Recent errors (c9570c0d0b3c):
+ *   -
+ *
+ * [↑/k] up  [↓/j] down  [r] restart  [e] enter  [l] logs  [q] quit
+ */
+func FormatTop(statuses []task.ServiceStatus, stats map[string]task.ServiceStats, view TopView) string {
+	header := fmt.Sprintf("curveadm top - cluster: %s (%s)  refresh: %s  updated: %s",
+		view.Cluster, view.Kind, view.Interval, view.Updated.Format("15:04:05"))
+	output := []string{color.New(color.Bold).Sprint(header), ""}
+	if len(statuses) == 0 {
+		output = append(output, "no services")
+	} else {
+		output = append(output, formatTopServices(statuses, stats, view))
+
+		// recent errors of selected service
+		selected := statuses[view.Selected]
+		output = append(output, "", color.CyanString("Recent errors (%s):", selected.Id))
+		errors := stats[selected.Id].Errors
+		if len(errors) == 0 {
+			output = append(output, "  -")
+		}
+		for _, line := range errors {
+			output = append(output, "  "+line)
+		}
+	}
+
+	output = append(output, "")
+	if len(view.Message) > 0 {
+		output = append(output, view.Message)
+	}
+	output = append(output, TOP_HOTKEYS)
+	return strings.Join(output, "\n") + "\n"
+}
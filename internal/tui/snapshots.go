@@ -0,0 +1,92 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package tui
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dustin/go-humanize"
+	task "github.com/opencurve/curveadm/internal/task/task/bs"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+func FormatSnapshots(snapshots []task.Snapshot) string {
+	lines := [][]interface{}{}
+	title := []string{"UUID", "User", "Volume", "Name", "Size", "Status", "Progress", "Create Time"}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		s1, s2 := snapshots[i], snapshots[j]
+		if s1.Volume == s2.Volume {
+			return s1.Ctime < s2.Ctime
+		}
+		return s1.Volume < s2.Volume
+	})
+	for _, snapshot := range snapshots {
+		lines = append(lines, []interface{}{
+			snapshot.UUID,
+			snapshot.User,
+			snapshot.Volume,
+			snapshot.Name,
+			humanize.IBytes(snapshot.Size),
+			snapshot.Status,
+			fmt.Sprintf("%d%%", snapshot.Progress),
+			formatCtime(snapshot.Ctime),
+		})
+	}
+
+	output := tuicommon.FixedFormat(lines, 2)
+	return output
+}
+
+func FormatCloneTasks(tasks []task.CloneTask) string {
+	lines := [][]interface{}{}
+	title := []string{"UUID", "User", "Source", "Destination", "Type", "Lazy", "Status", "Progress", "Create Time"}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].Ctime < tasks[j].Ctime
+	})
+	for _, t := range tasks {
+		lines = append(lines, []interface{}{
+			t.UUID,
+			t.User,
+			t.Source,
+			t.Destination,
+			t.Type,
+			utils.Choose(t.Lazy, "Y", "N"),
+			t.Status,
+			fmt.Sprintf("%d%%", t.Progress),
+			formatCtime(t.Ctime),
+		})
+	}
+
+	output := tuicommon.FixedFormat(lines, 2)
+	return output
+}
@@ -24,6 +24,7 @@ package tui
 
 import (
 	"sort"
+	"strings"
 
 	task "github.com/opencurve/curveadm/internal/task/task/bs"
 	"github.com/opencurve/curveadm/internal/tui/common"
@@ -33,13 +34,36 @@ import (
 func sortTargets(targets []task.Target) {
 	sort.Slice(targets, func(i, j int) bool {
 		t1, t2 := targets[i], targets[j]
-		return t1.Tid < t2.Tid
+		if t1.Name == t2.Name {
+			return t1.Host < t2.Host
+		}
+		return t1.Name < t2.Name
 	})
 }
 
+func joinItems(items []string) string {
+	if len(items) == 0 {
+		return "-"
+	}
+	return strings.Join(items, ",")
+}
+
+// e.g. user1, user2 (outgoing)
+func formatAuth(accounts []string) string {
+	if len(accounts) == 0 {
+		return "-"
+	}
+	for _, account := range accounts {
+		if strings.HasSuffix(account, "(outgoing)") {
+			return "mutual CHAP"
+		}
+	}
+	return "CHAP"
+}
+
 func FormatTargets(targets []task.Target) string {
 	lines := [][]interface{}{}
-	title := []string{"Tid", "Host", "Target Name", "Store", "Portal"}
+	title := []string{"Target Name", "Host", "Tid", "LUNs", "Portal", "Status", "Auth", "ACL", "Sessions"}
 	first, second := tuicommon.FormatTitle(title)
 	lines = append(lines, first)
 	lines = append(lines, second)
@@ -47,11 +71,15 @@ func FormatTargets(targets []task.Target) string {
 	sortTargets(targets)
 	for _, target := range targets {
 		lines = append(lines, []interface{}{
-			target.Tid,
-			target.Host,
 			target.Name,
-			target.Store,
+			target.Host,
+			target.Tid,
+			joinItems(target.Stores),
 			target.Portal,
+			target.Status,
+			formatAuth(target.Accounts),
+			joinItems(target.ACLs),
+			joinItems(target.Sessions),
 		})
 	}
 
@@ -0,0 +1,167 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package tui
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/opencurve/curveadm/internal/task/task"
+	"github.com/opencurve/curveadm/internal/tasks"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+)
+
+const (
+	NUMBER_OF_SLOWEST_TASKS = 5
+	MAX_COMMAND_LENGTH      = 60
+)
+
+type slowTask struct {
+	step   string
+	timing task.TaskTiming
+}
+
+func timingStatusDecorate(status string) string {
+	switch status {
+	case task.TIMING_STATUS_OK:
+		return color.GreenString(status)
+	case task.TIMING_STATUS_SKIP,
+		task.TIMING_STATUS_WARN:
+		return color.YellowString(status)
+	}
+	return color.RedString(status)
+}
+
+func formatElapsed(elapsed time.Duration) string {
+	return elapsed.Round(time.Millisecond).String()
+}
+
+// host=10.0.0.1  role=mds => host=10.0.0.1 role=mds
+func trimSubname(subname string) string {
+	return strings.Join(strings.Fields(subname), " ")
+}
+
+func trimCommand(command string) string {
+	command = strings.Join(strings.Fields(command), " ")
+	if len(command) > MAX_COMMAND_LENGTH {
+		return command[:MAX_COMMAND_LENGTH-3] + "..."
+	}
+	return command
+}
+
+/*
+ * Step                Elapsed  Tasks  Slowest Task                 Slowest Elapsed
+ * ----                -------  -----  ------------                 ---------------
+ * Pull Image          12.3s    3      host=server-host1 image=...  12.3s
+ * Create Container    1.5s     9      host=server-host2 role=mds   1.2s
+ */
+func formatTimingSteps(timings []tasks.Timing) string {
+	lines := [][]interface{}{}
+	title := []string{"Step", "Elapsed", "Tasks", "Slowest Task", "Slowest Elapsed"}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	var total time.Duration
+	for _, timing := range timings {
+		slowest, slowestElapsed := "-", "-"
+		var max time.Duration = -1
+		for _, t := range timing.Tasks {
+			if t.Elapsed > max {
+				max = t.Elapsed
+				slowest = trimSubname(t.Subname)
+				slowestElapsed = formatElapsed(t.Elapsed)
+			}
+		}
+		total += timing.Elapsed
+		lines = append(lines, []interface{}{
+			timing.Name,
+			formatElapsed(timing.Elapsed),
+			strconv.Itoa(len(timing.Tasks)),
+			slowest,
+			slowestElapsed,
+		})
+	}
+	lines = append(lines, []interface{}{"Total", formatElapsed(total), "", "", ""})
+	return tuicommon.FixedFormat(lines, 2)
+}
+
+/*
+ * Step         Task                        Status  Elapsed  Slowest Step           Slowest Command          Command Elapsed
+ * ----         ----                        ------  -------  ------------           ---------------          ---------------
+ * Pull Image   host=server-host1 image=..  OK      12.3s    step.PullImage         docker pull ...          12.3s
+ */
+func formatSlowestTasks(timings []tasks.Timing) string {
+	slowTasks := []slowTask{}
+	for _, timing := range timings {
+		for _, t := range timing.Tasks {
+			slowTasks = append(slowTasks, slowTask{step: timing.Name, timing: t})
+		}
+	}
+	sort.SliceStable(slowTasks, func(i, j int) bool {
+		return slowTasks[i].timing.Elapsed > slowTasks[j].timing.Elapsed
+	})
+	if len(slowTasks) > NUMBER_OF_SLOWEST_TASKS {
+		slowTasks = slowTasks[:NUMBER_OF_SLOWEST_TASKS]
+	}
+
+	lines := [][]interface{}{}
+	title := []string{"Step", "Task", "Status", "Elapsed",
+		"Slowest Step", "Slowest Command", "Command Elapsed"}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+	for _, st := range slowTasks {
+		t := st.timing
+		step, command, commandElapsed := "-", "-", "-"
+		if s := t.SlowestStep(); s != nil {
+			step = s.Name
+		}
+		if c := t.SlowestCommand(); c != nil {
+			command = trimCommand(c.Command)
+			commandElapsed = formatElapsed(c.Elapsed)
+		}
+		lines = append(lines, []interface{}{
+			st.step,
+			trimSubname(t.Subname),
+			tuicommon.DecorateMessage{Message: t.Status, Decorate: timingStatusDecorate},
+			formatElapsed(t.Elapsed),
+			step,
+			command,
+			commandElapsed,
+		})
+	}
+	return tuicommon.FixedFormat(lines, 2)
+}
+
+func FormatTiming(timings []tasks.Timing) string {
+	return strings.Join([]string{
+		color.CyanString("Timing (by playbook step):"),
+		formatTimingSteps(timings),
+		color.CyanString("Slowest tasks (top %d):", NUMBER_OF_SLOWEST_TASKS),
+		formatSlowestTasks(timings),
+	}, "\n")
+}
@@ -0,0 +1,84 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	task "github.com/opencurve/curveadm/internal/task/task/bs"
+	tuicommon "github.com/opencurve/curveadm/internal/tui/common"
+	"github.com/opencurve/curveadm/internal/utils"
+)
+
+func sortVolumes(volumes []task.Volume) {
+	sort.Slice(volumes, func(i, j int) bool {
+		v1, v2 := volumes[i], volumes[j]
+		if v1.User == v2.User {
+			return v1.Name < v2.Name
+		}
+		return v1.User < v2.User
+	})
+}
+
+func formatStripe(volume task.Volume) string {
+	if volume.StripeCount == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%s*%d", humanize.IBytes(volume.StripeUnit), volume.StripeCount)
+}
+
+func formatCtime(ctime uint64) string {
+	if ctime == 0 {
+		return "-"
+	}
+	return time.UnixMicro(int64(ctime)).Format("2006-01-02 15:04:05")
+}
+
+func FormatVolumes(volumes []task.Volume) string {
+	lines := [][]interface{}{}
+	title := []string{"User", "Volume", "Size", "Status", "Poolset", "Stripe", "Create Time", "Mapped By"}
+	first, second := tuicommon.FormatTitle(title)
+	lines = append(lines, first)
+	lines = append(lines, second)
+
+	sortVolumes(volumes)
+	for _, volume := range volumes {
+		lines = append(lines, []interface{}{
+			volume.User,
+			volume.Name,
+			humanize.IBytes(volume.Size),
+			utils.Choose(len(volume.Status) > 0, volume.Status, "-"),
+			utils.Choose(len(volume.Poolset) > 0, volume.Poolset, "-"),
+			formatStripe(volume),
+			formatCtime(volume.Ctime),
+			utils.Choose(len(volume.MappedBy) > 0, strings.Join(volume.MappedBy, ","), "-"),
+		})
+	}
+
+	output := tuicommon.FixedFormat(lines, 2)
+	return output
+}
@@ -25,7 +25,9 @@
 package module
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"strings"
 	"text/template"
 )
@@ -37,8 +39,10 @@ const (
 	TEMPLATE_START_CONTAINER     = "{{.engine}} start {{.options}} {{.containers}}"
 	TEMPLATE_STOP_CONTAINER      = "{{.engine}} stop {{.options}} {{.containers}}"
 	TEMPLATE_RESTART_CONTAINER   = "{{.engine}} restart {{.options}} {{.containers}}"
+	TEMPLATE_KILL_CONTAINER      = "{{.engine}} kill {{.options}} {{.containers}}"
 	TEMPLATE_WAIT_CONTAINER      = "{{.engine}} wait {{.options}} {{.containers}}"
 	TEMPLATE_REMOVE_CONTAINER    = "{{.engine}} rm {{.options}} {{.containers}}"
+	TEMPLATE_RENAME_CONTAINER    = "{{.engine}} rename {{.container}} {{.name}}"
 	TEMPLATE_LIST_CONTAINERS     = "{{.engine}} ps {{.options}}"
 	TEMPLATE_CONTAINER_EXEC      = "{{.engine}} exec {{.options}} {{.container}} {{.command}}"
 	TEMPLATE_COPY_FROM_CONTAINER = "{{.engine}} cp {{.options}} {{.container}}:{{.srcPath}} {{.destPath}}"
@@ -46,6 +50,9 @@ const (
 	TEMPLATE_INSPECT_CONTAINER   = "{{.engine}} inspect {{.options}} {{.container}}"
 	TEMPLATE_CONTAINER_LOGS      = "{{.engine}} logs {{.options}} {{.container}}"
 	TEMPLATE_UPDATE_CONTAINER    = "{{.engine}} update {{.options}} {{.container}}"
+	TEMPLATE_CONTAINER_STATS     = "{{.engine}} stats {{.options}} {{.containers}}"
+	TEMPLATE_CREATE_NETWORK      = "{{.engine}} network create {{.options}} {{.name}}"
+	TEMPLATE_REMOVE_NETWORK      = "{{.engine}} network rm {{.options}} {{.name}}"
 )
 
 type DockerCli struct {
@@ -53,6 +60,7 @@ type DockerCli struct {
 	options   []string
 	tmpl      *template.Template
 	data      map[string]interface{}
+	hook      CommandHook
 }
 
 func NewDockerCli(sshClient *SSHClient) *DockerCli {
@@ -72,7 +80,13 @@ func (s *DockerCli) AddOption(format string, args ...interface{}) *DockerCli {
 func (cli *DockerCli) Execute(options ExecOptions) (string, error) {
 	cli.data["options"] = strings.Join(cli.options, " ")
 	cli.data["engine"] = options.ExecWithEngine
-	return execCommand(cli.sshClient, cli.tmpl, cli.data, options)
+	return execCommand(cli.sshClient, cli.tmpl, cli.data, options, cli.hook)
+}
+
+func (cli *DockerCli) Stream(ctx context.Context, out io.Writer, options ExecOptions) error {
+	cli.data["options"] = strings.Join(cli.options, " ")
+	cli.data["engine"] = options.ExecWithEngine
+	return streamCommand(ctx, cli.sshClient, cli.tmpl, cli.data, options, out)
 }
 
 func (cli *DockerCli) DockerInfo() *DockerCli {
@@ -111,6 +125,12 @@ func (cli *DockerCli) RestartContainer(containerId ...string) *DockerCli {
 	return cli
 }
 
+func (cli *DockerCli) KillContainer(containerId ...string) *DockerCli {
+	cli.tmpl = template.Must(template.New("KillContainer").Parse(TEMPLATE_KILL_CONTAINER))
+	cli.data["containers"] = strings.Join(containerId, " ")
+	return cli
+}
+
 func (cli *DockerCli) WaitContainer(containerId ...string) *DockerCli {
 	cli.tmpl = template.Must(template.New("WaitContainer").Parse(TEMPLATE_WAIT_CONTAINER))
 	cli.data["containers"] = strings.Join(containerId, " ")
@@ -123,6 +143,13 @@ func (cli *DockerCli) RemoveContainer(containerId ...string) *DockerCli {
 	return cli
 }
 
+func (cli *DockerCli) RenameContainer(containerId, name string) *DockerCli {
+	cli.tmpl = template.Must(template.New("RenameContainer").Parse(TEMPLATE_RENAME_CONTAINER))
+	cli.data["container"] = containerId
+	cli.data["name"] = name
+	return cli
+}
+
 func (cli *DockerCli) ListContainers() *DockerCli {
 	cli.tmpl = template.Must(template.New("ListContainers").Parse(TEMPLATE_LIST_CONTAINERS))
 	return cli
@@ -157,6 +184,24 @@ func (cli *DockerCli) InspectContainer(containerId string) *DockerCli {
 	return cli
 }
 
+func (cli *DockerCli) ContainerStats(containerId ...string) *DockerCli {
+	cli.tmpl = template.Must(template.New("ContainerStats").Parse(TEMPLATE_CONTAINER_STATS))
+	cli.data["containers"] = strings.Join(containerId, " ")
+	return cli
+}
+
+func (cli *DockerCli) CreateNetwork(name string) *DockerCli {
+	cli.tmpl = template.Must(template.New("CreateNetwork").Parse(TEMPLATE_CREATE_NETWORK))
+	cli.data["name"] = name
+	return cli
+}
+
+func (cli *DockerCli) RemoveNetwork(name string) *DockerCli {
+	cli.tmpl = template.Must(template.New("RemoveNetwork").Parse(TEMPLATE_REMOVE_NETWORK))
+	cli.data["name"] = name
+	return cli
+}
+
 func (cli *DockerCli) ContainerLogs(containerId string) *DockerCli {
 	cli.tmpl = template.Must(template.New("ContainerLogs").Parse(TEMPLATE_CONTAINER_LOGS))
 	cli.data["container"] = containerId
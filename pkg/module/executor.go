@@ -0,0 +1,201 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package module
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/melbahja/goph"
+)
+
+type (
+	// Executor is the backend which actually connects hosts, runs the
+	// rendered commands and transfers files. The default executor is based
+	// on SSH (or local bash, local container), tests can replace it with a fake one.
+	Executor interface {
+		Connect(config SSHConfig) (*goph.Client, error)
+		Execute(ctx context.Context, client *SSHClient, command string, options ExecOptions) ([]byte, error)
+		Upload(client *SSHClient, localPath, remotePath string, options ExecOptions) error
+		Download(client *SSHClient, remotePath, localPath string, options ExecOptions) error
+	}
+
+	// StreamExecutor is implemented by the executor which can write output
+	// while the command is running, the output will be returned at once
+	// by Execute for the executor which not implement it.
+	StreamExecutor interface {
+		Stream(ctx context.Context, client *SSHClient, command string, options ExecOptions, out io.Writer) error
+	}
+
+	sshExecutor struct{}
+)
+
+var (
+	globalExecutor Executor = &sshExecutor{}
+	executorMutex  sync.RWMutex
+)
+
+func getExecutor() Executor {
+	executorMutex.RLock()
+	defer executorMutex.RUnlock()
+	return globalExecutor
+}
+
+// ReplaceGlobalExecutor replaces the executor used by all modules,
+// it returns a function to restore the previous one.
+func ReplaceGlobalExecutor(executor Executor) func() {
+	executorMutex.Lock()
+	defer executorMutex.Unlock()
+	prev := globalExecutor
+	globalExecutor = executor
+	return func() { ReplaceGlobalExecutor(prev) }
+}
+
+func isLocalContainer(client *SSHClient) bool {
+	return client != nil && len(client.Config().LocalContainer) > 0
+}
+
+// engine which manages the local containers acting as hosts, e.g. playground nodes
+func localEngine(options ExecOptions) string {
+	if len(options.ExecWithEngine) == 0 {
+		return "docker"
+	}
+	return options.ExecWithEngine
+}
+
+// localCommand returns the command which runs in local or local container,
+// it returns nil if the command should be executed by SSH
+func localCommand(ctx context.Context,
+	client *SSHClient,
+	command string,
+	options ExecOptions) *exec.Cmd {
+	var cmd *exec.Cmd
+	if options.ExecInLocal {
+		cmd = exec.CommandContext(ctx, "bash", "-c", command)
+	} else if isLocalContainer(client) {
+		cmd = exec.CommandContext(ctx, localEngine(options), "exec", "-i",
+			client.Config().LocalContainer, "bash", "-c", command)
+	} else {
+		return nil
+	}
+	cmd.Env = []string{"LANG=en_US.UTF-8"}
+	return cmd
+}
+
+func (e *sshExecutor) Connect(config SSHConfig) (*goph.Client, error) {
+	if len(config.LocalContainer) > 0 {
+		return nil, nil
+	}
+	return connect(config)
+}
+
+func (e *sshExecutor) Execute(ctx context.Context,
+	client *SSHClient,
+	command string,
+	options ExecOptions) ([]byte, error) {
+	if cmd := localCommand(ctx, client, command, options); cmd != nil {
+		return cmd.CombinedOutput()
+	}
+
+	cmd, err := client.Client().CommandContext(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+	return cmd.CombinedOutput()
+}
+
+func (e *sshExecutor) Stream(ctx context.Context,
+	client *SSHClient,
+	command string,
+	options ExecOptions,
+	out io.Writer) error {
+	if cmd := localCommand(ctx, client, command, options); cmd != nil {
+		cmd.Stdout, cmd.Stderr = out, out
+		return cmd.Run()
+	}
+
+	cmd, err := client.Client().CommandContext(ctx, command)
+	if err != nil {
+		return err
+	}
+	cmd.Stdout, cmd.Stderr = out, out
+	return cmd.Run()
+}
+
+// copy file between local and container, e.g. docker cp SRC_PATH CONTAINER:DEST_PATH
+func copyLocalContainer(engine, srcPath, destPath string) error {
+	out, err := exec.Command(engine, "cp", srcPath, destPath).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s (%s)", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// the uploaded file keeps the mode of local file like docker cp does,
+// instead of the default mode (e.g. 0644) which sftp server creates with
+func (e *sshExecutor) Upload(client *SSHClient, localPath, remotePath string, options ExecOptions) error {
+	if isLocalContainer(client) {
+		return copyLocalContainer(localEngine(options),
+			localPath, client.Config().LocalContainer+":"+remotePath)
+	}
+
+	local, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer local.Close()
+	info, err := local.Stat()
+	if err != nil {
+		return err
+	}
+
+	ftp, err := client.Client().NewSftp()
+	if err != nil {
+		return err
+	}
+	defer ftp.Close()
+	remote, err := ftp.OpenFile(remotePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return err
+	}
+	defer remote.Close()
+	err = remote.Chmod(info.Mode().Perm()) // before any content written
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(remote, local)
+	return err
+}
+
+func (e *sshExecutor) Download(client *SSHClient, remotePath, localPath string, options ExecOptions) error {
+	if isLocalContainer(client) {
+		return copyLocalContainer(localEngine(options),
+			client.Config().LocalContainer+":"+remotePath, localPath)
+	}
+	return client.Client().Download(remotePath, localPath)
+}
@@ -48,12 +48,12 @@ func NewFileManager(sshClient *SSHClient) *FileManager {
 	return &FileManager{sshClient: sshClient}
 }
 
-func (f *FileManager) Upload(localPath, remotePath string) error {
+func (f *FileManager) Upload(localPath, remotePath string, options ExecOptions) error {
 	if f.sshClient == nil {
 		return ERR_UNREACHED
 	}
 
-	err := f.sshClient.Client().Upload(localPath, remotePath)
+	err := getExecutor().Upload(f.sshClient, localPath, remotePath, options)
 	log.SwitchLevel(err)("UploadFile",
 		log.Field("remoteAddress", remoteAddr(f.sshClient)),
 		log.Field("localPath", localPath),
@@ -62,12 +62,12 @@ func (f *FileManager) Upload(localPath, remotePath string) error {
 	return err
 }
 
-func (f *FileManager) Download(remotePath, localPath string) error {
+func (f *FileManager) Download(remotePath, localPath string, options ExecOptions) error {
 	if f.sshClient == nil {
 		return ERR_UNREACHED
 	}
 
-	err := f.sshClient.Client().Download(remotePath, localPath)
+	err := getExecutor().Download(f.sshClient, remotePath, localPath, options)
 	log.SwitchLevel(err)("DownloadFile",
 		log.Field("remoteAddress", remoteAddr(f.sshClient)),
 		log.Field("remotePath", remotePath),
@@ -27,19 +27,25 @@ package module
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os/exec"
 	"strings"
 	"text/template"
 	"time"
 
-	"github.com/melbahja/goph"
 	log "github.com/opencurve/curveadm/pkg/log/glg"
 )
 
 type (
+	// CommandHook will be invoked after each command executed,
+	// it's used to collect the elapsed time of remote commands
+	CommandHook func(command string, elapsed time.Duration, err error)
+
 	Module struct {
 		sshClient *SSHClient
+		hook      CommandHook
 	}
 
 	ExecOptions struct {
@@ -60,12 +66,31 @@ func (e *TimeoutError) Error() string {
 		e.timeout)
 }
 
+// ExitStatus returns the exit status of the failed command, it returns -1
+// if the command not executed, e.g. connect failed or timed out
+func ExitStatus(err error) int {
+	var sshErr interface{ ExitStatus() int }
+	var execErr *exec.ExitError
+	if errors.As(err, &sshErr) {
+		return sshErr.ExitStatus()
+	} else if errors.As(err, &execErr) {
+		return execErr.ExitCode()
+	}
+	return -1
+}
+
 func NewModule(sshClient *SSHClient) *Module {
 	return &Module{sshClient: sshClient}
 }
 
+func (m *Module) SetCommandHook(hook CommandHook) {
+	m.hook = hook
+}
+
 func (m *Module) Shell() *Shell {
-	return NewShell(m.sshClient)
+	shell := NewShell(m.sshClient)
+	shell.hook = m.hook
+	return shell
 }
 
 func (m *Module) File() *FileManager {
@@ -73,7 +98,9 @@ func (m *Module) File() *FileManager {
 }
 
 func (m *Module) DockerCli() *DockerCli {
-	return NewDockerCli(m.sshClient)
+	cli := NewDockerCli(m.sshClient)
+	cli.hook = m.hook
+	return cli
 }
 
 // common utils
@@ -86,7 +113,8 @@ func remoteAddr(client *SSHClient) string {
 	return fmt.Sprintf("%s@%s:%d", config.User, config.Host, config.Port)
 }
 
-func execCommand(sshClient *SSHClient,
+// renderCommand renders the command template and handles 'sudo_alias' and 'become_user'
+func renderCommand(sshClient *SSHClient,
 	tmpl *template.Template,
 	data map[string]interface{},
 	options ExecOptions) (string, error) {
@@ -117,8 +145,21 @@ func execCommand(sshClient *SSHClient,
 			command = strings.Join([]string{become, command}, " ")
 		}
 	}
+	return command, nil
+}
+
+func execCommand(sshClient *SSHClient,
+	tmpl *template.Template,
+	data map[string]interface{},
+	options ExecOptions,
+	hook CommandHook) (string, error) {
+	// (1) rendering command
+	command, err := renderCommand(sshClient, tmpl, data, options)
+	if err != nil {
+		return "", err
+	}
 
-	// (4) create context for timeout
+	// (2) create context for timeout
 	ctx := context.Background()
 	if options.ExecTimeoutSec > 0 {
 		var cancel context.CancelFunc
@@ -126,24 +167,17 @@ func execCommand(sshClient *SSHClient,
 		defer cancel()
 	}
 
-	// (5) execute command
+	// (3) execute command
 	var out []byte
-	var err error
-	if options.ExecInLocal {
-		cmd := exec.CommandContext(ctx, "bash", "-c", command)
-		cmd.Env = []string{"LANG=en_US.UTF-8"}
-		out, err = cmd.CombinedOutput()
-	} else {
-		var cmd *goph.Cmd
-		cmd, err = sshClient.Client().CommandContext(ctx, command)
-		if err == nil {
-			out, err = cmd.CombinedOutput()
-		}
-	}
+	start := time.Now()
+	out, err = getExecutor().Execute(ctx, sshClient, command, options)
 
 	if ctx.Err() == context.DeadlineExceeded {
 		err = &TimeoutError{options.ExecTimeoutSec}
 	}
+	if hook != nil {
+		hook(command, time.Since(start), err)
+	}
 
 	log.SwitchLevel(err)("Execute command",
 		log.Field("remoteAddr", remoteAddr(sshClient)),
@@ -152,3 +186,41 @@ func execCommand(sshClient *SSHClient,
 		log.Field("error", err))
 	return string(out), err
 }
+
+// streamCommand writes the output to out while the command is running,
+// it's used by the long running commands, e.g. `docker logs --follow`
+func streamCommand(ctx context.Context,
+	sshClient *SSHClient,
+	tmpl *template.Template,
+	data map[string]interface{},
+	options ExecOptions,
+	out io.Writer) error {
+	command, err := renderCommand(sshClient, tmpl, data, options)
+	if err != nil {
+		return err
+	}
+
+	if options.ExecTimeoutSec > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(options.ExecTimeoutSec)*time.Second)
+		defer cancel()
+	}
+
+	executor := getExecutor()
+	if streamer, ok := executor.(StreamExecutor); ok {
+		err = streamer.Stream(ctx, sshClient, command, options, out)
+	} else {
+		var output []byte
+		output, err = executor.Execute(ctx, sshClient, command, options)
+		out.Write(output)
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		err = &TimeoutError{options.ExecTimeoutSec}
+	}
+
+	log.SwitchLevel(err)("Stream command",
+		log.Field("remoteAddr", remoteAddr(sshClient)),
+		log.Field("command", command),
+		log.Field("error", err))
+	return err
+}
@@ -0,0 +1,539 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package moduletest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	CONTAINER_STATUS_CREATED = "created"
+	CONTAINER_STATUS_RUNNING = "running"
+	CONTAINER_STATUS_EXITED  = "exited"
+
+	SHORT_CONTAINER_ID_LENGTH = 12
+)
+
+var (
+	// options which don't take a value
+	boolOptions = map[string]bool{
+		"--all":         true,
+		"-a":            true,
+		"--detach":      true,
+		"-d":            true,
+		"--force":       true,
+		"-f":            true,
+		"--init":        true,
+		"--interactive": true,
+		"-i":            true,
+		"--no-stream":   true,
+		"--privileged":  true,
+		"--quiet":       true,
+		"-q":            true,
+		"--rm":          true,
+		"--tty":         true,
+		"-t":            true,
+	}
+)
+
+type (
+	// Container is the emulated container, its files are kept in memory
+	Container struct {
+		Id      string
+		Name    string
+		Image   string
+		Status  string
+		Command string
+		Address string // address in container network, e.g. 172.20.0.2
+		Options map[string][]string
+		files   map[string]string
+	}
+
+	engine struct {
+		mutex      sync.Mutex
+		executor   *Executor
+		sequence   int
+		images     map[string]map[string]bool // host: { image: true }
+		containers map[string][]*Container    // host: containers
+	}
+
+	// parsed engine command line, e.g. ps --all --filter id=xxx
+	engineArgs struct {
+		options map[string][]string
+		args    []string
+	}
+)
+
+func newEngine(executor *Executor) *engine {
+	return &engine{
+		executor:   executor,
+		images:     map[string]map[string]bool{},
+		containers: map[string][]*Container{},
+	}
+}
+
+func parseEngineArgs(args []string, stopAtFirstArg bool) engineArgs {
+	ea := engineArgs{options: map[string][]string{}, args: []string{}}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || (stopAtFirstArg && len(ea.args) > 0) {
+			ea.args = append(ea.args, arg)
+			continue
+		}
+
+		name, value := arg, ""
+		if idx := strings.Index(arg, "="); idx > 0 {
+			name, value = arg[:idx], arg[idx+1:]
+		} else if !boolOptions[arg] && i+1 < len(args) {
+			value = args[i+1]
+			i++
+		}
+		ea.options[name] = append(ea.options[name], value)
+	}
+	return ea
+}
+
+func (ea engineArgs) get(names ...string) string {
+	for _, name := range names {
+		if values, ok := ea.options[name]; ok {
+			return values[len(values)-1]
+		}
+	}
+	return ""
+}
+
+func (ea engineArgs) has(names ...string) bool {
+	for _, name := range names {
+		if _, ok := ea.options[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+ * containers
+ */
+
+// Containers returns all containers on specified host
+func (e *Executor) Containers(host string) []Container {
+	e.engine.mutex.Lock()
+	defer e.engine.mutex.Unlock()
+	containers := []Container{}
+	for _, c := range e.engine.containers[host] {
+		containers = append(containers, *c)
+	}
+	return containers
+}
+
+// Container returns the container which match the id (prefix) or name
+func (e *Executor) Container(host, idOrName string) (Container, bool) {
+	e.engine.mutex.Lock()
+	defer e.engine.mutex.Unlock()
+	c := e.engine.lookup(host, idOrName)
+	if c == nil {
+		return Container{}, false
+	}
+	return *c, true
+}
+
+// ReadContainerFile returns the content of file which copied into container
+func (e *Executor) ReadContainerFile(host, idOrName, path string) (string, bool) {
+	e.engine.mutex.Lock()
+	defer e.engine.mutex.Unlock()
+	c := e.engine.lookup(host, idOrName)
+	if c == nil {
+		return "", false
+	}
+	content, ok := c.files[path]
+	return content, ok
+}
+
+// WriteContainerFile puts a file into container, e.g. the default configure
+func (e *Executor) WriteContainerFile(host, idOrName, path, content string) bool {
+	e.engine.mutex.Lock()
+	defer e.engine.mutex.Unlock()
+	c := e.engine.lookup(host, idOrName)
+	if c == nil {
+		return false
+	}
+	c.files[path] = content
+	return true
+}
+
+// SetContainerStatus changes the status of container, e.g. exited
+func (e *Executor) SetContainerStatus(host, idOrName, status string) bool {
+	e.engine.mutex.Lock()
+	defer e.engine.mutex.Unlock()
+	c := e.engine.lookup(host, idOrName)
+	if c == nil {
+		return false
+	}
+	c.Status = status
+	return true
+}
+
+func (e *engine) lookup(host, idOrName string) *Container {
+	if len(idOrName) == 0 {
+		return nil
+	}
+	for _, c := range e.containers[host] {
+		if c.Name == idOrName || strings.HasPrefix(c.Id, idOrName) {
+			return c
+		}
+	}
+	return nil
+}
+
+func (e *engine) newContainerAddress() string {
+	return fmt.Sprintf("172.20.%d.%d", e.sequence/250, e.sequence%250+2)
+}
+
+func (e *engine) newContainerId(host, name string) string {
+	e.sequence++
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%d", host, name, e.sequence)))
+	return hex.EncodeToString(sum[:])
+}
+
+/*
+ * engine commands
+ */
+
+func (e *engine) execute(host string, args []string) (string, error) {
+	if len(args) == 0 {
+		return failed("engine: missing command")
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	subcommand, args := args[0], args[1:]
+	switch subcommand {
+	case "info":
+		return "Server Version: 20.10.0\n", nil
+	case "pull":
+		return e.pull(host, parseEngineArgs(args, false))
+	case "create", "run":
+		return e.create(host, parseEngineArgs(args, true), subcommand == "run")
+	case "start", "restart":
+		return e.setStatus(host, parseEngineArgs(args, false), CONTAINER_STATUS_RUNNING)
+	case "stop":
+		return e.setStatus(host, parseEngineArgs(args, false), CONTAINER_STATUS_EXITED)
+	case "kill":
+		return e.kill(host, parseEngineArgs(args, false))
+	case "wait": // container exited once wait returned
+		_, err := e.setStatus(host, parseEngineArgs(args, false), CONTAINER_STATUS_EXITED)
+		if err != nil {
+			return "", err
+		}
+		return "0\n", nil
+	case "rm":
+		return e.remove(host, parseEngineArgs(args, false))
+	case "rename":
+		return e.rename(host, parseEngineArgs(args, false))
+	case "ps":
+		return e.list(host, parseEngineArgs(args, false))
+	case "inspect":
+		return e.inspect(host, parseEngineArgs(args, false))
+	case "stats":
+		return e.stats(host, parseEngineArgs(args, false))
+	case "exec":
+		return e.exec(host, parseEngineArgs(args, true))
+	case "cp":
+		return e.copy(host, parseEngineArgs(args, false))
+	}
+	// logs, update, ...
+	return "", nil
+}
+
+func (e *engine) pull(host string, ea engineArgs) (string, error) {
+	if len(ea.args) == 0 {
+		return failed("\"pull\" requires exactly 1 argument")
+	}
+	if _, ok := e.images[host]; !ok {
+		e.images[host] = map[string]bool{}
+	}
+	e.images[host][ea.args[0]] = true
+	return fmt.Sprintf("Status: Downloaded newer image for %s\n", ea.args[0]), nil
+}
+
+func (e *engine) create(host string, ea engineArgs, run bool) (string, error) {
+	if len(ea.args) == 0 {
+		return failed("\"create\" requires at least 1 argument")
+	}
+
+	name := ea.get("--name")
+	if len(name) > 0 && e.lookup(host, name) != nil {
+		return failed("Conflict. The container name \"/%s\" is already in use", name)
+	}
+	c := &Container{
+		Id:      e.newContainerId(host, name),
+		Address: e.newContainerAddress(),
+		Name:    name,
+		Image:   ea.args[0],
+		Status:  CONTAINER_STATUS_CREATED,
+		Command: strings.Join(ea.args[1:], " "),
+		Options: ea.options,
+		files:   map[string]string{},
+	}
+	if len(c.Name) == 0 {
+		c.Name = c.Id[:SHORT_CONTAINER_ID_LENGTH]
+	}
+	if run {
+		c.Status = CONTAINER_STATUS_RUNNING
+	}
+	e.containers[host] = append(e.containers[host], c)
+	return c.Id + "\n", nil
+}
+
+func (e *engine) setStatus(host string, ea engineArgs, status string) (string, error) {
+	for _, id := range ea.args {
+		c := e.lookup(host, id)
+		if c == nil {
+			return failed("Error response from daemon: No such container: %s", id)
+		}
+		c.Status = status
+	}
+	return strings.Join(ea.args, "\n") + "\n", nil
+}
+
+// only KILL signal (default) will make the container exit
+func (e *engine) kill(host string, ea engineArgs) (string, error) {
+	signal := strings.TrimPrefix(strings.ToUpper(ea.get("--signal", "-s")), "SIG")
+	if len(signal) == 0 || signal == "KILL" || signal == "9" {
+		return e.setStatus(host, ea, CONTAINER_STATUS_EXITED)
+	}
+
+	for _, id := range ea.args {
+		c := e.lookup(host, id)
+		if c == nil {
+			return failed("Error response from daemon: No such container: %s", id)
+		} else if c.Status != CONTAINER_STATUS_RUNNING {
+			return failed("Error response from daemon: Container %s is not running", id)
+		}
+	}
+	return strings.Join(ea.args, "\n") + "\n", nil
+}
+
+func (e *engine) remove(host string, ea engineArgs) (string, error) {
+	for _, id := range ea.args {
+		c := e.lookup(host, id)
+		if c == nil {
+			return failed("Error: No such container: %s", id)
+		} else if c.Status == CONTAINER_STATUS_RUNNING && !ea.has("--force", "-f") {
+			return failed("Error response from daemon: You cannot remove a running container %s", c.Id)
+		}
+
+		containers := []*Container{}
+		for _, container := range e.containers[host] {
+			if container != c {
+				containers = append(containers, container)
+			}
+		}
+		e.containers[host] = containers
+	}
+	return strings.Join(ea.args, "\n") + "\n", nil
+}
+
+func (e *engine) rename(host string, ea engineArgs) (string, error) {
+	if len(ea.args) != 2 {
+		return failed("\"rename\" requires exactly 2 arguments")
+	}
+	c := e.lookup(host, ea.args[0])
+	if c == nil {
+		return failed("Error response from daemon: No such container: %s", ea.args[0])
+	} else if e.lookup(host, ea.args[1]) != nil {
+		return failed("Error response from daemon: Conflict. The container name \"/%s\" is already in use", ea.args[1])
+	}
+	c.Name = ea.args[1]
+	return "", nil
+}
+
+func (c *Container) match(filter string) bool {
+	items := strings.SplitN(filter, "=", 2)
+	if len(items) != 2 {
+		return true
+	}
+	key, value := items[0], items[1]
+	switch key {
+	case "id":
+		return strings.HasPrefix(c.Id, value)
+	case "name":
+		return strings.Contains(c.Name, value)
+	case "status":
+		return c.Status == value
+	}
+	return true
+}
+
+func (c *Container) render(format string) string {
+	status := "Created"
+	switch c.Status {
+	case CONTAINER_STATUS_RUNNING:
+		status = "Up 1 minute"
+	case CONTAINER_STATUS_EXITED:
+		status = "Exited (0) 1 minute ago"
+	}
+	replacer := strings.NewReplacer(
+		"{{.ID}}", c.Id[:SHORT_CONTAINER_ID_LENGTH],
+		"{{.Id}}", c.Id,
+		"{{.Names}}", c.Name,
+		"{{.Name}}", "/"+c.Name,
+		"{{.Image}}", c.Image,
+		"{{.Status}}", status,
+		"{{.State.Status}}", c.Status,
+		"{{.State.Running}}", fmt.Sprintf("%t", c.Status == CONTAINER_STATUS_RUNNING),
+		"{{.Config.Image}}", c.Image,
+		"{{.CPUPerc}}", "0.00%",
+		"{{.MemUsage}}", "0B / 0B",
+		"{{.MemPerc}}", "0.00%",
+		"{{range .NetworkSettings.Networks}}{{.IPAddress}}{{end}}", c.Address,
+	)
+	return replacer.Replace(format)
+}
+
+func (e *engine) list(host string, ea engineArgs) (string, error) {
+	format := ea.get("--format")
+	if ea.has("--quiet", "-q") || len(format) == 0 {
+		format = "{{.ID}}"
+	}
+	all := ea.has("--all", "-a")
+	lines := []string{}
+	for _, c := range e.containers[host] {
+		if !all && c.Status != CONTAINER_STATUS_RUNNING {
+			continue
+		}
+		matched := true
+		for _, filter := range ea.options["--filter"] {
+			matched = matched && c.match(filter)
+		}
+		if matched {
+			lines = append(lines, c.render(format))
+		}
+	}
+	if len(lines) == 0 {
+		return "", nil
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// resource usage of emulated container is always zero
+func (e *engine) stats(host string, ea engineArgs) (string, error) {
+	format := ea.get("--format")
+	if len(format) == 0 {
+		format = "{{.ID}} {{.CPUPerc}} {{.MemUsage}} {{.MemPerc}}"
+	}
+	lines := []string{}
+	for _, id := range ea.args {
+		c := e.lookup(host, id)
+		if c == nil {
+			return failed("Error: No such container: %s", id)
+		}
+		lines = append(lines, c.render(format))
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+func (e *engine) inspect(host string, ea engineArgs) (string, error) {
+	format := ea.get("--format", "-f")
+	lines := []string{}
+	for _, id := range ea.args {
+		c := e.lookup(host, id)
+		if c == nil {
+			return failed("Error: No such object: %s", id)
+		} else if len(format) == 0 {
+			lines = append(lines, fmt.Sprintf(`[{"Id": "%s", "Name": "/%s", "State": {"Status": "%s"}}]`,
+				c.Id, c.Name, c.Status))
+		} else {
+			lines = append(lines, c.render(format))
+		}
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+func (e *engine) exec(host string, ea engineArgs) (string, error) {
+	if len(ea.args) == 0 {
+		return failed("\"exec\" requires at least 2 arguments")
+	}
+	c := e.lookup(host, ea.args[0])
+	if c == nil {
+		return failed("Error: No such container: %s", ea.args[0])
+	} else if c.Status != CONTAINER_STATUS_RUNNING {
+		return failed("Error response from daemon: Container %s is not running", c.Id)
+	}
+	return "", nil
+}
+
+// CONTAINER:SRC_PATH => (container, path)
+func (e *engine) splitPath(host, path string) (*Container, string, error) {
+	items := strings.SplitN(path, ":", 2)
+	if len(items) != 2 || strings.HasPrefix(path, "/") {
+		return nil, path, nil
+	}
+	c := e.lookup(host, items[0])
+	if c == nil {
+		return nil, "", fmt.Errorf("Error: No such container:path: %s", path)
+	}
+	return c, items[1], nil
+}
+
+/*
+ * NOTE: the file which not exist in container is treated as empty,
+ * because we don't have the files which shipped in the image, and
+ * the path which not exist on host is treated as an empty directory.
+ */
+func (e *engine) copy(host string, ea engineArgs) (string, error) {
+	if len(ea.args) != 2 {
+		return failed("\"cp\" requires exactly 2 arguments")
+	}
+
+	src, srcPath, err := e.splitPath(host, ea.args[0])
+	if err != nil {
+		return failed(err.Error())
+	}
+	dest, destPath, err := e.splitPath(host, ea.args[1])
+	if err != nil {
+		return failed(err.Error())
+	}
+
+	var content string
+	if src != nil {
+		content = src.files[srcPath]
+	} else {
+		var ok bool
+		content, ok = e.executor.ReadFile(host, srcPath)
+		if !ok {
+			return "", nil
+		}
+	}
+
+	if dest != nil {
+		dest.files[destPath] = content
+	} else {
+		e.executor.WriteFile(host, destPath, content)
+	}
+	return "", nil
+}
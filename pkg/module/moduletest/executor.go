@@ -0,0 +1,487 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+/*
+ * Package moduletest provides an in-memory executor for module, which
+ * records all rendered commands and returns scripted outputs, so the
+ * playbooks can run against a fake multi-host cluster in tests:
+ *
+ *   executor := moduletest.NewExecutor()
+ *   defer module.ReplaceGlobalExecutor(executor)()
+ *   executor.On(`curve_ops_tool status`, moduletest.Reply("cluster is healthy"))
+ *
+ * Container engine commands (create/start/ps/inspect/cp...) and a few
+ * shell commands (mv/cp/rm/cat) are emulated by default, everything else
+ * succeeds with empty output unless a rule is matched.
+ */
+package moduletest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/melbahja/goph"
+	"github.com/opencurve/curveadm/pkg/module"
+)
+
+const (
+	LOCAL_HOST = "localhost"
+)
+
+type (
+	Command struct {
+		Host    string
+		Command string
+	}
+
+	// Handler returns the output of the matched command,
+	// the command is treated as failed if error is not nil.
+	Handler func(cmd Command) (string, error)
+
+	ExitError struct {
+		Status int
+		Output string
+	}
+
+	rule struct {
+		host    string
+		pattern *regexp.Regexp
+		handler Handler
+	}
+
+	Executor struct {
+		mutex       sync.Mutex
+		commands    []Command
+		rules       []rule
+		unreachable map[string]bool
+		files       map[string]map[string]string // host: { path: content }
+		localRoot   string                       // real files on local host are under it
+		uploads     map[string]map[string]os.FileMode
+		engine      *engine
+	}
+)
+
+var (
+	_ module.Executor = (*Executor)(nil)
+)
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("Process exited with status %d", e.Status)
+}
+
+// ExitStatus is same as the one of ssh.ExitError
+func (e *ExitError) ExitStatus() int {
+	return e.Status
+}
+
+// Reply returns a handler which always succeeds with specified output
+func Reply(output string) Handler {
+	return func(cmd Command) (string, error) {
+		return output, nil
+	}
+}
+
+// Fail returns a handler which always fails with specified output
+func Fail(output string) Handler {
+	return func(cmd Command) (string, error) {
+		return output, &ExitError{Status: 1, Output: output}
+	}
+}
+
+func NewExecutor() *Executor {
+	e := &Executor{
+		commands:    []Command{},
+		rules:       []rule{},
+		unreachable: map[string]bool{},
+		files:       map[string]map[string]string{},
+		uploads:     map[string]map[string]os.FileMode{},
+	}
+	e.engine = newEngine(e)
+	return e
+}
+
+/*
+ * rules
+ */
+
+// On registers a handler for commands which match the pattern on all hosts,
+// the rule registered later has higher priority.
+func (e *Executor) On(pattern string, handler Handler) *Executor {
+	return e.OnHost("", pattern, handler)
+}
+
+// OnHost is same as On, but only for specified host
+func (e *Executor) OnHost(host, pattern string, handler Handler) *Executor {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.rules = append(e.rules, rule{
+		host:    host,
+		pattern: regexp.MustCompile(pattern),
+		handler: handler,
+	})
+	return e
+}
+
+// Unreachable makes the connection to specified host failed
+func (e *Executor) Unreachable(host string) *Executor {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.unreachable[host] = true
+	return e
+}
+
+func (e *Executor) match(cmd Command) Handler {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	for i := len(e.rules) - 1; i >= 0; i-- {
+		r := e.rules[i]
+		if len(r.host) > 0 && r.host != cmd.Host {
+			continue
+		} else if r.pattern.MatchString(cmd.Command) {
+			return r.handler
+		}
+	}
+	return nil
+}
+
+/*
+ * records
+ */
+
+// Commands returns all commands executed so far, in order
+func (e *Executor) Commands() []Command {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return append([]Command{}, e.commands...)
+}
+
+// Grep returns the commands which match the pattern, in order
+func (e *Executor) Grep(pattern string) []Command {
+	regex := regexp.MustCompile(pattern)
+	commands := []Command{}
+	for _, cmd := range e.Commands() {
+		if regex.MatchString(cmd.Command) {
+			commands = append(commands, cmd)
+		}
+	}
+	return commands
+}
+
+// Index returns the index of first command which match the pattern,
+// it returns -1 if not found. It's useful to assert the command order.
+func (e *Executor) Index(pattern string) int {
+	regex := regexp.MustCompile(pattern)
+	for i, cmd := range e.Commands() {
+		if regex.MatchString(cmd.Command) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Reset clears the recorded commands, the files and containers are kept
+func (e *Executor) Reset() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.commands = []Command{}
+}
+
+func (e *Executor) record(cmd Command) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.commands = append(e.commands, cmd)
+}
+
+/*
+ * files
+ *
+ * NOTE: files on local host under the local root are real files, because
+ * curveadm reads and writes them directly after executing commands in local,
+ * e.g. mv /tmp/xxx /tmp/yyy. Others are kept in memory like remote hosts,
+ * so the emulated commands (e.g. rm) never touch the real files outside.
+ */
+
+// SetLocalRoot sets the directory which real files on local host are under,
+// e.g. t.TempDir(), there is no real file if it's not set
+func (e *Executor) SetLocalRoot(root string) *Executor {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.localRoot = filepath.Clean(root)
+	return e
+}
+
+func (e *Executor) isRealFile(host, path string) bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return host == LOCAL_HOST && len(e.localRoot) > 0 &&
+		strings.HasPrefix(filepath.Clean(path), e.localRoot+string(filepath.Separator))
+}
+
+func (e *Executor) WriteFile(host, path, content string) {
+	if e.isRealFile(host, path) {
+		os.WriteFile(path, []byte(content), 0644)
+		return
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if _, ok := e.files[host]; !ok {
+		e.files[host] = map[string]string{}
+	}
+	e.files[host][path] = content
+}
+
+func (e *Executor) ReadFile(host, path string) (string, bool) {
+	if e.isRealFile(host, path) {
+		data, err := os.ReadFile(path)
+		return string(data), err == nil
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	content, ok := e.files[host][path]
+	return content, ok
+}
+
+func (e *Executor) RemoveFile(host, path string) {
+	if e.isRealFile(host, path) {
+		os.Remove(path)
+		return
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	delete(e.files[host], path)
+}
+
+/*
+ * implement module.Executor
+ */
+
+func hostOf(client *module.SSHClient, options module.ExecOptions) string {
+	if options.ExecInLocal || client == nil {
+		return LOCAL_HOST
+	}
+	return client.Config().Host
+}
+
+func (e *Executor) Connect(config module.SSHConfig) (*goph.Client, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.unreachable[config.Host] {
+		return nil, fmt.Errorf("dial tcp %s:%d: connect: no route to host",
+			config.Host, config.Port)
+	}
+	return nil, nil
+}
+
+func (e *Executor) Execute(ctx context.Context,
+	client *module.SSHClient,
+	command string,
+	options module.ExecOptions) ([]byte, error) {
+	out, err := e.Run(hostOf(client, options), command)
+	return []byte(out), err
+}
+
+// Stream writes the whole output at once, the command never blocks
+func (e *Executor) Stream(ctx context.Context,
+	client *module.SSHClient,
+	command string,
+	options module.ExecOptions,
+	out io.Writer) error {
+	output, err := e.Run(hostOf(client, options), command)
+	out.Write([]byte(output))
+	return err
+}
+
+func (e *Executor) Upload(client *module.SSHClient, localPath, remotePath string, options module.ExecOptions) error {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		return err
+	}
+	host := client.Config().Host
+	e.WriteFile(host, remotePath, string(data))
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if _, ok := e.uploads[host]; !ok {
+		e.uploads[host] = map[string]os.FileMode{}
+	}
+	e.uploads[host][remotePath] = info.Mode().Perm()
+	return nil
+}
+
+// UploadMode returns the mode of file which uploaded to remote path,
+// it's kept even if the file removed later
+func (e *Executor) UploadMode(host, path string) (os.FileMode, bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	mode, ok := e.uploads[host][path]
+	return mode, ok
+}
+
+func (e *Executor) Download(client *module.SSHClient, remotePath, localPath string, options module.ExecOptions) error {
+	content, ok := e.ReadFile(client.Config().Host, remotePath)
+	if !ok {
+		return fmt.Errorf("file does not exist: %s", remotePath)
+	}
+	return os.WriteFile(localPath, []byte(content), 0644)
+}
+
+// Run executes the command on specified host, it's also used by the SSH server
+func (e *Executor) Run(host, command string) (string, error) {
+	cmd := Command{Host: host, Command: command}
+	e.record(cmd)
+	if handler := e.match(cmd); handler != nil {
+		return handler(cmd)
+	}
+	return e.emulate(host, split(command))
+}
+
+/*
+ * emulate commands
+ */
+
+func failed(format string, a ...interface{}) (string, error) {
+	output := fmt.Sprintf(format, a...)
+	return output, &ExitError{Status: 1, Output: output}
+}
+
+func (e *Executor) emulate(host string, args []string) (string, error) {
+	// strip 'sudo' prefix, e.g. sudo -E docker ps
+	for len(args) > 0 && (args[0] == "sudo" || strings.HasPrefix(args[0], "-")) {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return "", nil
+	}
+
+	switch args[0] {
+	case "docker", "podman", "pouch":
+		return e.engine.execute(host, args[1:])
+	case "mv":
+		return e.move(host, trimOptions(args[1:]))
+	case "cp":
+		return e.copy(host, trimOptions(args[1:]))
+	case "rm":
+		for _, path := range trimOptions(args[1:]) {
+			e.RemoveFile(host, path)
+		}
+	case "cat":
+		return e.cat(host, trimOptions(args[1:]))
+	}
+	return "", nil
+}
+
+func (e *Executor) move(host string, args []string) (string, error) {
+	if len(args) != 2 {
+		return failed("mv: missing file operand")
+	}
+	out, err := e.copy(host, args)
+	if err == nil {
+		e.RemoveFile(host, args[0])
+	}
+	return out, err
+}
+
+func (e *Executor) copy(host string, args []string) (string, error) {
+	if len(args) != 2 {
+		return failed("cp: missing file operand")
+	}
+	content, ok := e.ReadFile(host, args[0])
+	if !ok {
+		return failed("cannot stat '%s': No such file or directory", args[0])
+	}
+	e.WriteFile(host, args[1], content)
+	return "", nil
+}
+
+func (e *Executor) cat(host string, args []string) (string, error) {
+	contents := []string{}
+	for _, path := range args {
+		content, ok := e.ReadFile(host, path)
+		if !ok {
+			return failed("cat: %s: No such file or directory", path)
+		}
+		contents = append(contents, content)
+	}
+	return strings.Join(contents, ""), nil
+}
+
+func trimOptions(args []string) []string {
+	out := []string{}
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			out = append(out, arg)
+		}
+	}
+	return out
+}
+
+// split splits the command line into arguments like shell does,
+// it only handles the quotes and backslash.
+func split(command string) []string {
+	args := []string{}
+	var arg strings.Builder
+	var quote rune
+	inArg, escaped := false, false
+	for _, c := range command {
+		switch {
+		case escaped:
+			arg.WriteRune(c)
+			escaped = false
+		case c == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				arg.WriteRune(c)
+			}
+		case c == '\'' || c == '"':
+			quote, inArg = c, true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(c)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args
+}
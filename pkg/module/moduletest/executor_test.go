@@ -0,0 +1,195 @@
+package moduletest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencurve/curveadm/pkg/module"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	execOptions = module.ExecOptions{
+		ExecWithSudo:   true,
+		ExecSudoAlias:  "sudo",
+		ExecWithEngine: "docker",
+	}
+)
+
+func newModule(t *testing.T, config module.SSHConfig) *module.Module {
+	client, err := module.NewSSHClient(config)
+	assert.Nil(t, err)
+	t.Cleanup(func() { client.Close() })
+	return module.NewModule(client)
+}
+
+func TestExecutor_Rules(t *testing.T) {
+	assert := assert.New(t)
+	executor := NewExecutor()
+	defer module.ReplaceGlobalExecutor(executor)()
+
+	executor.On("hostname", Reply("host1"))
+	executor.OnHost("10.0.0.2", "hostname", Reply("host2"))
+	executor.On("false", Fail("boom"))
+
+	m1 := newModule(t, module.SSHConfig{Host: "10.0.0.1"})
+	m2 := newModule(t, module.SSHConfig{Host: "10.0.0.2"})
+	out, err := m1.Shell().Command("hostname").Execute(execOptions)
+	assert.Nil(err)
+	assert.Equal("host1", out)
+	out, err = m2.Shell().Command("hostname").Execute(execOptions)
+	assert.Nil(err)
+	assert.Equal("host2", out)
+	_, err = m1.Shell().Command("false").Execute(execOptions)
+	assert.NotNil(err)
+
+	assert.Equal([]Command{
+		{Host: "10.0.0.1", Command: "sudo hostname"},
+		{Host: "10.0.0.2", Command: "sudo hostname"},
+		{Host: "10.0.0.1", Command: "sudo false"},
+	}, executor.Commands())
+	assert.Equal(2, executor.Index("^sudo false"))
+	assert.Len(executor.Grep("hostname"), 2)
+
+	executor.Unreachable("10.0.0.3")
+	_, err = module.NewSSHClient(module.SSHConfig{Host: "10.0.0.3"})
+	assert.NotNil(err)
+}
+
+func TestExecutor_LocalFiles(t *testing.T) {
+	assert := assert.New(t)
+	root := t.TempDir()
+	executor := NewExecutor().SetLocalRoot(root)
+
+	// (1) real files under the local root
+	src, dest := filepath.Join(root, "src"), filepath.Join(root, "dest")
+	assert.Nil(os.WriteFile(src, []byte("hello curve"), 0644))
+	_, err := executor.Run(LOCAL_HOST, "mv "+src+" "+dest)
+	assert.Nil(err)
+	data, err := os.ReadFile(dest)
+	assert.Nil(err)
+	assert.Equal("hello curve", string(data))
+	_, err = os.Stat(src)
+	assert.True(os.IsNotExist(err))
+
+	// (2) real files outside the local root are never touched
+	outside := filepath.Join(t.TempDir(), "keep")
+	assert.Nil(os.WriteFile(outside, []byte("keep"), 0644))
+	_, err = executor.Run(LOCAL_HOST, "rm -rf "+outside)
+	assert.Nil(err)
+	_, err = executor.Run(LOCAL_HOST, "cp "+dest+" "+outside)
+	assert.Nil(err)
+	data, err = os.ReadFile(outside)
+	assert.Nil(err)
+	assert.Equal("keep", string(data))
+	content, ok := executor.ReadFile(LOCAL_HOST, outside)
+	assert.True(ok)
+	assert.Equal("hello curve", content)
+}
+
+func TestExecutor_Engine(t *testing.T) {
+	assert := assert.New(t)
+	executor := NewExecutor()
+	defer module.ReplaceGlobalExecutor(executor)()
+
+	host := "10.0.0.1"
+	cli := func() *module.DockerCli {
+		return newModule(t, module.SSHConfig{Host: host}).DockerCli()
+	}
+
+	out, err := cli().CreateContainer("opencurvedocker/curvebs:v1.2", "--role mds").
+		AddOption("--name %s", "mds-1").
+		AddOption("--network host").
+		Execute(execOptions)
+	assert.Nil(err)
+	containerId := out[:len(out)-1]
+	assert.Len(containerId, 64)
+
+	_, err = cli().StartContainer(containerId).Execute(execOptions)
+	assert.Nil(err)
+	out, err = cli().ListContainers().
+		AddOption("--format '{{.ID}} {{.Status}}'").
+		AddOption("--filter id=%s", containerId).
+		AddOption("--all").
+		Execute(execOptions)
+	assert.Nil(err)
+	assert.Equal(containerId[:12]+" Up 1 minute\n", out)
+	out, err = cli().InspectContainer(containerId).
+		AddOption("--format='{{.State.Status}}'").
+		Execute(execOptions)
+	assert.Nil(err)
+	assert.Equal("running\n", out)
+
+	// copy file into container, and copy it back
+	executor.WriteFile(host, "/tmp/mds.conf", "mds.listen.addr=127.0.0.1:6700")
+	_, err = cli().CopyIntoContainer("/tmp/mds.conf", containerId, "/curvebs/mds/conf/mds.conf").
+		Execute(execOptions)
+	assert.Nil(err)
+	content, ok := executor.ReadContainerFile(host, "mds-1", "/curvebs/mds/conf/mds.conf")
+	assert.True(ok)
+	assert.Equal("mds.listen.addr=127.0.0.1:6700", content)
+	_, err = cli().CopyFromContainer(containerId, "/curvebs/mds/conf/mds.conf", "/tmp/mds.conf.1").
+		Execute(execOptions)
+	assert.Nil(err)
+	content, ok = executor.ReadFile(host, "/tmp/mds.conf.1")
+	assert.True(ok)
+	assert.Equal("mds.listen.addr=127.0.0.1:6700", content)
+
+	// running container can't be removed without force
+	_, err = cli().RemoveContainer(containerId).Execute(execOptions)
+	assert.NotNil(err)
+	_, err = cli().RemoveContainer(containerId).AddOption("--force").Execute(execOptions)
+	assert.Nil(err)
+	assert.Len(executor.Containers(host), 0)
+}
+
+func TestSSHServer(t *testing.T) {
+	assert := assert.New(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	assert.Nil(os.MkdirAll(filepath.Join(home, ".ssh"), 0700))
+	privateKeyPath := filepath.Join(home, ".ssh", "id_rsa")
+	assert.Nil(GeneratePrivateKey(privateKeyPath))
+
+	executor := NewExecutor()
+	executor.On("uname", Reply("Linux\n"))
+	server, err := NewSSHServer(executor, "host1")
+	assert.Nil(err)
+	defer server.Close()
+
+	// the default executor speaks real SSH to the stand-in
+	m := newModule(t, module.SSHConfig{
+		User:              "curve",
+		Host:              "127.0.0.1",
+		Port:              server.Port(),
+		PrivateKeyPath:    privateKeyPath,
+		ConnectRetries:    1,
+		ConnectTimeoutSec: 3,
+	})
+	out, err := m.Shell().Command("uname").Execute(module.ExecOptions{})
+	assert.Nil(err)
+	assert.Equal("Linux\n", out)
+	_, err = m.Shell().Command("cat /not/exist").Execute(module.ExecOptions{})
+	assert.NotNil(err)
+
+	// upload and download by sftp
+	localPath := filepath.Join(t.TempDir(), "hello")
+	assert.Nil(os.WriteFile(localPath, []byte("hello curve"), 0644))
+	assert.Nil(m.File().Upload(localPath, "/tmp/hello", module.ExecOptions{}))
+	content, ok := executor.ReadFile("host1", "/tmp/hello")
+	assert.True(ok)
+	assert.Equal("hello curve", content)
+
+	downloadPath := filepath.Join(t.TempDir(), "hello")
+	assert.Nil(m.File().Download("/tmp/hello", downloadPath, module.ExecOptions{}))
+	data, err := os.ReadFile(downloadPath)
+	assert.Nil(err)
+	assert.Equal("hello curve", string(data))
+
+	commands := executor.Commands()
+	assert.Len(commands, 2)
+	assert.Equal("host1", commands[0].Host)
+	assert.Equal(0, executor.Index("^uname"))
+	assert.Equal(1, executor.Index("^cat /not/exist"))
+}
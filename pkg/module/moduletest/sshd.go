@@ -0,0 +1,308 @@
+/*
+ *  Copyright (c) 2026 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveAdm
+ * Created Date: 2026-10-19
+ * Author: Jingli Chen (Wine93)
+ */
+
+package moduletest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+/*
+ * SSHServer is an in-process SSH server stand-in, it accepts any public key
+ * and serves 'exec' requests and 'sftp' subsystem by the executor, so the
+ * real SSH code path (goph) can be tested against a fake host:
+ *
+ *   server, _ := moduletest.NewSSHServer(executor, "host1")
+ *   defer server.Close()
+ *   // connect to 127.0.0.1:server.Port() with any private key
+ */
+type (
+	SSHServer struct {
+		executor *Executor
+		host     string
+		listener net.Listener
+		config   *ssh.ServerConfig
+		wg       sync.WaitGroup
+	}
+
+	// handlers for sftp request server, files are stored in executor
+	sftpHandler struct {
+		executor *Executor
+		host     string
+	}
+
+	sftpFile struct {
+		mutex   sync.Mutex
+		handler *sftpHandler
+		path    string
+		data    []byte
+	}
+
+	fileInfo struct {
+		name string
+		size int64
+	}
+
+	listerAt []os.FileInfo
+)
+
+// GeneratePrivateKey writes a new RSA private key in PEM format
+func GeneratePrivateKey(filename string) error {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+	data := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	return os.WriteFile(filename, data, 0600)
+}
+
+func NewSSHServer(executor *Executor, host string) (*SSHServer, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SSHServer{
+		executor: executor,
+		host:     host,
+		listener: listener,
+		config:   config,
+	}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+func (s *SSHServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *SSHServer) Port() uint {
+	_, port, _ := net.SplitHostPort(s.Addr())
+	n, _ := strconv.Atoi(port)
+	return uint(n)
+}
+
+func (s *SSHServer) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *SSHServer) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handleConn(conn)
+	}
+}
+
+func (s *SSHServer) handleConn(conn net.Conn) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, s.config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go s.handleSession(channel, requests)
+	}
+}
+
+// payload of 'exec' and 'subsystem' request is a ssh string
+func parseString(payload []byte) string {
+	if len(payload) < 4 {
+		return ""
+	}
+	length := binary.BigEndian.Uint32(payload)
+	if int(length) > len(payload)-4 {
+		return ""
+	}
+	return string(payload[4 : 4+length])
+}
+
+func exitStatus(err error) uint32 {
+	var exitError *ExitError
+	if err == nil {
+		return 0
+	} else if errors.As(err, &exitError) {
+		return uint32(exitError.Status)
+	}
+	return 1
+}
+
+func (s *SSHServer) handleSession(channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+	for req := range requests {
+		switch req.Type {
+		case "exec":
+			req.Reply(true, nil)
+			out, err := s.executor.Run(s.host, parseString(req.Payload))
+			io.Copy(channel, bytes.NewBufferString(out))
+			status := make([]byte, 4)
+			binary.BigEndian.PutUint32(status, exitStatus(err))
+			channel.SendRequest("exit-status", false, status)
+			return
+		case "subsystem":
+			if parseString(req.Payload) != "sftp" {
+				req.Reply(false, nil)
+				continue
+			}
+			req.Reply(true, nil)
+			handler := &sftpHandler{executor: s.executor, host: s.host}
+			server := sftp.NewRequestServer(channel, sftp.Handlers{
+				FileGet:  handler,
+				FilePut:  handler,
+				FileCmd:  handler,
+				FileList: handler,
+			})
+			server.Serve()
+			server.Close()
+			return
+		default: // env, pty-req, ...
+			req.Reply(false, nil)
+		}
+	}
+}
+
+/*
+ * sftp handlers
+ */
+
+func (h *sftpHandler) Fileread(r *sftp.Request) (io.ReaderAt, error) {
+	content, ok := h.executor.ReadFile(h.host, r.Filepath)
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return bytes.NewReader([]byte(content)), nil
+}
+
+func (h *sftpHandler) Filewrite(r *sftp.Request) (io.WriterAt, error) {
+	return &sftpFile{handler: h, path: r.Filepath}, nil
+}
+
+func (h *sftpHandler) Filecmd(r *sftp.Request) error {
+	switch r.Method {
+	case "Remove":
+		h.executor.RemoveFile(h.host, r.Filepath)
+	case "Rename", "PosixRename":
+		content, ok := h.executor.ReadFile(h.host, r.Filepath)
+		if !ok {
+			return os.ErrNotExist
+		}
+		h.executor.WriteFile(h.host, r.Target, content)
+		h.executor.RemoveFile(h.host, r.Filepath)
+	}
+	return nil
+}
+
+func (h *sftpHandler) Filelist(r *sftp.Request) (sftp.ListerAt, error) {
+	content, ok := h.executor.ReadFile(h.host, r.Filepath)
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return listerAt{&fileInfo{name: r.Filepath, size: int64(len(content))}}, nil
+}
+
+func (f *sftpFile) WriteAt(p []byte, offset int64) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	end := int(offset) + len(p)
+	if end > len(f.data) {
+		data := make([]byte, end)
+		copy(data, f.data)
+		f.data = data
+	}
+	copy(f.data[offset:], p)
+	return len(p), nil
+}
+
+func (f *sftpFile) Close() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.handler.executor.WriteFile(f.handler.host, f.path, string(f.data))
+	return nil
+}
+
+func (l listerAt) ListAt(ls []os.FileInfo, offset int64) (int, error) {
+	if offset >= int64(len(l)) {
+		return 0, io.EOF
+	}
+	n := copy(ls, l[offset:])
+	if n < len(ls) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (fi *fileInfo) Name() string       { return fi.name }
+func (fi *fileInfo) Size() int64        { return fi.size }
+func (fi *fileInfo) Mode() os.FileMode  { return 0644 }
+func (fi *fileInfo) ModTime() time.Time { return time.Now() }
+func (fi *fileInfo) IsDir() bool        { return false }
+func (fi *fileInfo) Sys() interface{}   { return nil }
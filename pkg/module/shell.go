@@ -26,7 +26,9 @@ package module
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"strings"
 	"text/template"
 )
@@ -45,6 +47,7 @@ const (
 	TEMPLATE_CHMOD    = "chmod {{.options}} {{.mode}} {{.file}}"
 	TEMPLATE_STAT     = "stat {{.options}} {{.files}}"
 	TEMPLATE_CAT      = "cat {{.options}} {{.files}}"
+	TEMPLATE_TAIL     = "tail {{.options}} {{.files}}"
 	TEMPLATE_MKFS     = "mkfs.ext4 {{.options}} {{.device}}"
 	TEMPLATE_MOUNT    = "mount {{.options}} {{.source}} {{.directory}}"
 	TEMPLATE_UMOUNT   = "umount {{.options}} {{.directory}}"
@@ -72,6 +75,9 @@ const (
 	TEMPLATE_RPM  = "rpm {{.options}}"
 	TEMPLATE_SCP  = "scp {{.options}} {{.source}} {{.user}}@{{.host}}:{{.target}}"
 
+	// systemd
+	TEMPLATE_SYSTEMCTL = "systemctl {{.options}} {{.action}} {{.units}}"
+
 	// bash
 	TEMPLATE_COMMAND     = "{{.command}}"
 	TEMPLATE_BASH_SCEIPT = "bash {{.scriptPath}} {{.arguments}}"
@@ -83,6 +89,7 @@ type Shell struct {
 	options   []string
 	tmpl      *template.Template
 	data      map[string]interface{}
+	hook      CommandHook
 }
 
 func NewShell(sshClient *SSHClient) *Shell {
@@ -111,7 +118,12 @@ func (s *Shell) String() (string, error) {
 
 func (s *Shell) Execute(options ExecOptions) (string, error) {
 	s.data["options"] = strings.Join(s.options, " ")
-	return execCommand(s.sshClient, s.tmpl, s.data, options)
+	return execCommand(s.sshClient, s.tmpl, s.data, options, s.hook)
+}
+
+func (s *Shell) Stream(ctx context.Context, out io.Writer, options ExecOptions) error {
+	s.data["options"] = strings.Join(s.options, " ")
+	return streamCommand(ctx, s.sshClient, s.tmpl, s.data, options, out)
 }
 
 // text
@@ -179,6 +191,12 @@ func (s *Shell) Cat(files ...string) *Shell {
 	return s
 }
 
+func (s *Shell) Tail(files ...string) *Shell {
+	s.tmpl = template.Must(template.New("tail").Parse(TEMPLATE_TAIL))
+	s.data["files"] = strings.Join(files, " ")
+	return s
+}
+
 func (s *Shell) Mkfs(device string) *Shell {
 	s.tmpl = template.Must(template.New("mkfs").Parse(TEMPLATE_MKFS))
 	s.data["device"] = device
@@ -303,6 +321,13 @@ func (s *Shell) Scp(source, user, host, target string) *Shell {
 	return s
 }
 
+func (s *Shell) Systemctl(action string, units ...string) *Shell {
+	s.tmpl = template.Must(template.New("systemctl").Parse(TEMPLATE_SYSTEMCTL))
+	s.data["action"] = action
+	s.data["units"] = strings.Join(units, " ")
+	return s
+}
+
 func (s *Shell) Command(command string) *Shell {
 	s.tmpl = template.Must(template.New("command").Parse(TEMPLATE_COMMAND))
 	s.data["command"] = command
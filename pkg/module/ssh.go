@@ -46,6 +46,7 @@ type (
 		PrivateKeyPath    string
 		ConnectRetries    int
 		ConnectTimeoutSec int
+		LocalContainer    string // run commands in local container instead of SSH
 	}
 
 	SSHClient struct {
@@ -89,7 +90,23 @@ func (client *SSHClient) Config() SSHConfig {
 	return client.config
 }
 
+// Close is safe to call on a client whose connection is nil
+func (client *SSHClient) Close() error {
+	if client == nil || client.client == nil {
+		return nil
+	}
+	return client.client.Close()
+}
+
 func NewSSHClient(config SSHConfig) (*SSHClient, error) {
+	client, err := getExecutor().Connect(config)
+	return &SSHClient{
+		client: client,
+		config: config,
+	}, err
+}
+
+func connect(config SSHConfig) (*goph.Client, error) {
 	user := config.User
 	host := config.Host
 	port := config.Port
@@ -146,8 +163,5 @@ connect:
 		}
 	}
 
-	return &SSHClient{
-		client: client,
-		config: config,
-	}, err
+	return client, err
 }